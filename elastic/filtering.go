@@ -0,0 +1,325 @@
+package elastic
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/partitio/atlas-app-toolkit/query"
+)
+
+// M is a JSON object of Elasticsearch query DSL.
+type M = map[string]interface{}
+
+// FilterStringToQuery is a shortcut to parse a filter string using default FilteringParser implementation
+// and call ToQuery on the returned filtering expression.
+func FilterStringToQuery(filter string, fieldMap map[string]string) (M, error) {
+	f, err := query.ParseFiltering(filter)
+	if err != nil {
+		return nil, err
+	}
+	return ToQuery(f, fieldMap)
+}
+
+// ToQuery returns Elasticsearch query DSL representation of the filtering expression f.
+// fieldMap maps dot-separated field paths of the filtering expression to document field names,
+// fields that are not in fieldMap are rejected with query.UnknownFieldError.
+// Logical operators are translated to bool queries with must and should clauses,
+// negated expressions are wrapped into bool queries with must_not clause.
+// An empty filtering expression is translated to match_all query.
+func ToQuery(f *query.Filtering, fieldMap map[string]string) (M, error) {
+	if f == nil || f.Root == nil {
+		return M{"match_all": M{}}, nil
+	}
+	switch r := f.Root.(type) {
+	case *query.Filtering_Operator:
+		return LogicalOperatorToQuery(r.Operator, fieldMap)
+	case *query.Filtering_StringCondition:
+		return StringConditionToQuery(r.StringCondition, fieldMap)
+	case *query.Filtering_NumberCondition:
+		return NumberConditionToQuery(r.NumberCondition, fieldMap)
+	case *query.Filtering_NullCondition:
+		return NullConditionToQuery(r.NullCondition, fieldMap)
+	case *query.Filtering_BoolCondition:
+		return BoolConditionToQuery(r.BoolCondition, fieldMap)
+	case *query.Filtering_StringArrayCondition:
+		return StringArrayConditionToQuery(r.StringArrayCondition, fieldMap)
+	case *query.Filtering_NumberArrayCondition:
+		return NumberArrayConditionToQuery(r.NumberArrayCondition, fieldMap)
+	default:
+		return nil, fmt.Errorf("%T type is not supported in Filtering", r)
+	}
+}
+
+// LogicalOperatorToQuery returns Elasticsearch query DSL representation of the logical operator.
+func LogicalOperatorToQuery(lop *query.LogicalOperator, fieldMap map[string]string) (M, error) {
+	var l, r M
+	var err error
+	switch left := lop.Left.(type) {
+	case *query.LogicalOperator_LeftOperator:
+		l, err = LogicalOperatorToQuery(left.LeftOperator, fieldMap)
+	case *query.LogicalOperator_LeftStringCondition:
+		l, err = StringConditionToQuery(left.LeftStringCondition, fieldMap)
+	case *query.LogicalOperator_LeftNumberCondition:
+		l, err = NumberConditionToQuery(left.LeftNumberCondition, fieldMap)
+	case *query.LogicalOperator_LeftNullCondition:
+		l, err = NullConditionToQuery(left.LeftNullCondition, fieldMap)
+	case *query.LogicalOperator_LeftBoolCondition:
+		l, err = BoolConditionToQuery(left.LeftBoolCondition, fieldMap)
+	case *query.LogicalOperator_LeftStringArrayCondition:
+		l, err = StringArrayConditionToQuery(left.LeftStringArrayCondition, fieldMap)
+	case *query.LogicalOperator_LeftNumberArrayCondition:
+		l, err = NumberArrayConditionToQuery(left.LeftNumberArrayCondition, fieldMap)
+	default:
+		return nil, fmt.Errorf("%T type is not supported in Filtering", left)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch right := lop.Right.(type) {
+	case *query.LogicalOperator_RightOperator:
+		r, err = LogicalOperatorToQuery(right.RightOperator, fieldMap)
+	case *query.LogicalOperator_RightStringCondition:
+		r, err = StringConditionToQuery(right.RightStringCondition, fieldMap)
+	case *query.LogicalOperator_RightNumberCondition:
+		r, err = NumberConditionToQuery(right.RightNumberCondition, fieldMap)
+	case *query.LogicalOperator_RightNullCondition:
+		r, err = NullConditionToQuery(right.RightNullCondition, fieldMap)
+	case *query.LogicalOperator_RightBoolCondition:
+		r, err = BoolConditionToQuery(right.RightBoolCondition, fieldMap)
+	case *query.LogicalOperator_RightStringArrayCondition:
+		r, err = StringArrayConditionToQuery(right.RightStringArrayCondition, fieldMap)
+	case *query.LogicalOperator_RightNumberArrayCondition:
+		r, err = NumberArrayConditionToQuery(right.RightNumberArrayCondition, fieldMap)
+	default:
+		return nil, fmt.Errorf("%T type is not supported in Filtering", right)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var res M
+	switch lop.Type {
+	case query.LogicalOperator_AND:
+		res = M{"bool": M{"must": []interface{}{l, r}}}
+	case query.LogicalOperator_OR:
+		res = M{"bool": M{"should": []interface{}{l, r}, "minimum_should_match": 1}}
+	default:
+		return nil, fmt.Errorf("%s logical operator is not supported", lop.Type)
+	}
+	return not(res, lop.IsNegative), nil
+}
+
+// StringConditionToQuery returns Elasticsearch query DSL representation of the string condition.
+func StringConditionToQuery(c *query.StringCondition, fieldMap map[string]string) (M, error) {
+	field, err := fieldName(c.FieldPath, fieldMap)
+	if err != nil {
+		return nil, err
+	}
+	if c.RelativeTime {
+		return relativeTimeToQuery(c, field)
+	}
+	var res M
+	switch c.Type {
+	case query.StringCondition_EQ:
+		res = M{"term": M{field: c.Value}}
+	case query.StringCondition_IEQ:
+		res = M{"term": M{field: M{"value": c.Value, "case_insensitive": true}}}
+	case query.StringCondition_MATCH:
+		res = M{"regexp": M{field: regexpToLucene(c.Value)}}
+	case query.StringCondition_LIKE:
+		res = M{"wildcard": M{field: M{"value": LikeToWildcard(c.Value)}}}
+	case query.StringCondition_ILIKE:
+		res = M{"wildcard": M{field: M{"value": LikeToWildcard(c.Value), "case_insensitive": true}}}
+	case query.StringCondition_IN_CIDR:
+		res = M{"term": M{field: c.Value}}
+	case query.StringCondition_GT:
+		res = rangeQuery(field, "gt", c.Value)
+	case query.StringCondition_GE:
+		res = rangeQuery(field, "gte", c.Value)
+	case query.StringCondition_LT:
+		res = rangeQuery(field, "lt", c.Value)
+	case query.StringCondition_LE:
+		res = rangeQuery(field, "lte", c.Value)
+	default:
+		return nil, fmt.Errorf("%s string condition is not supported", c.Type)
+	}
+	return not(res, c.IsNegative), nil
+}
+
+// relativeTimeToQuery translates comparison with a relative time literal to a range query with date math,
+// which has the same syntax, e.g. now-7d, so it is resolved by Elasticsearch at search time.
+func relativeTimeToQuery(c *query.StringCondition, field string) (M, error) {
+	value := strings.ToLower(c.Value)
+	if _, err := query.ResolveRelativeTime(value, nil); err != nil {
+		return nil, err
+	}
+	var res M
+	switch c.Type {
+	case query.StringCondition_EQ:
+		res = M{"range": M{field: M{"gte": value, "lte": value}}}
+	case query.StringCondition_GT:
+		res = rangeQuery(field, "gt", value)
+	case query.StringCondition_GE:
+		res = rangeQuery(field, "gte", value)
+	case query.StringCondition_LT:
+		res = rangeQuery(field, "lt", value)
+	case query.StringCondition_LE:
+		res = rangeQuery(field, "lte", value)
+	default:
+		return nil, fmt.Errorf("%s relative time condition is not supported", c.Type)
+	}
+	return not(res, c.IsNegative), nil
+}
+
+// NumberConditionToQuery returns Elasticsearch query DSL representation of the number condition.
+func NumberConditionToQuery(c *query.NumberCondition, fieldMap map[string]string) (M, error) {
+	field, err := fieldName(c.FieldPath, fieldMap)
+	if err != nil {
+		return nil, err
+	}
+	var res M
+	switch c.Type {
+	case query.NumberCondition_EQ:
+		res = M{"term": M{field: c.Value}}
+	case query.NumberCondition_GT:
+		res = rangeQuery(field, "gt", c.Value)
+	case query.NumberCondition_GE:
+		res = rangeQuery(field, "gte", c.Value)
+	case query.NumberCondition_LT:
+		res = rangeQuery(field, "lt", c.Value)
+	case query.NumberCondition_LE:
+		res = rangeQuery(field, "lte", c.Value)
+	default:
+		return nil, fmt.Errorf("%s number condition is not supported", c.Type)
+	}
+	return not(res, c.IsNegative), nil
+}
+
+// NullConditionToQuery returns Elasticsearch query DSL representation of the null condition.
+// A field is null if it does not exist in the document.
+func NullConditionToQuery(c *query.NullCondition, fieldMap map[string]string) (M, error) {
+	field, err := fieldName(c.FieldPath, fieldMap)
+	if err != nil {
+		return nil, err
+	}
+	return not(M{"exists": M{"field": field}}, !c.IsNegative), nil
+}
+
+// BoolConditionToQuery returns Elasticsearch query DSL representation of the bool condition.
+func BoolConditionToQuery(c *query.BoolCondition, fieldMap map[string]string) (M, error) {
+	field, err := fieldName(c.FieldPath, fieldMap)
+	if err != nil {
+		return nil, err
+	}
+	var res M
+	switch c.Type {
+	case query.BoolCondition_EQ:
+		res = M{"term": M{field: c.Value}}
+	case query.BoolCondition_GT:
+		res = rangeQuery(field, "gt", c.Value)
+	case query.BoolCondition_GE:
+		res = rangeQuery(field, "gte", c.Value)
+	case query.BoolCondition_LT:
+		res = rangeQuery(field, "lt", c.Value)
+	case query.BoolCondition_LE:
+		res = rangeQuery(field, "lte", c.Value)
+	default:
+		return nil, fmt.Errorf("%s bool condition is not supported", c.Type)
+	}
+	return not(res, c.IsNegative), nil
+}
+
+// StringArrayConditionToQuery returns Elasticsearch query DSL representation of the string array condition.
+func StringArrayConditionToQuery(c *query.StringArrayCondition, fieldMap map[string]string) (M, error) {
+	field, err := fieldName(c.FieldPath, fieldMap)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]interface{}, len(c.Values))
+	for i, v := range c.Values {
+		values[i] = v
+	}
+	return not(M{"terms": M{field: values}}, c.IsNegative), nil
+}
+
+// NumberArrayConditionToQuery returns Elasticsearch query DSL representation of the number array condition.
+func NumberArrayConditionToQuery(c *query.NumberArrayCondition, fieldMap map[string]string) (M, error) {
+	field, err := fieldName(c.FieldPath, fieldMap)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]interface{}, len(c.Values))
+	for i, v := range c.Values {
+		values[i] = v
+	}
+	return not(M{"terms": M{field: values}}, c.IsNegative), nil
+}
+
+// LikeToWildcard translates SQL LIKE pattern to an equivalent Elasticsearch wildcard pattern:
+// % is replaced with * and _ is replaced with ?. Backslash escapes the following character,
+// e.g. \% matches literal percent sign.
+func LikeToWildcard(pattern string) string {
+	var b strings.Builder
+	escaped := false
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			if r == '*' || r == '?' || r == '\\' {
+				b.WriteRune('\\')
+			}
+			b.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '%':
+			b.WriteRune('*')
+		case r == '_':
+			b.WriteRune('?')
+		case r == '*' || r == '?':
+			b.WriteRune('\\')
+			b.WriteRune(r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	if escaped {
+		b.WriteString(`\\`)
+	}
+	return b.String()
+}
+
+// regexpToLucene adapts a regular expression to Lucene syntax used by regexp query
+// which is always anchored: leading ^ and trailing $ are removed, otherwise
+// the pattern is surrounded with .* to match a substring.
+func regexpToLucene(pattern string) string {
+	if strings.HasPrefix(pattern, "^") {
+		pattern = pattern[1:]
+	} else {
+		pattern = ".*" + pattern
+	}
+	if strings.HasSuffix(pattern, "$") && !strings.HasSuffix(pattern, `\$`) {
+		pattern = pattern[:len(pattern)-1]
+	} else {
+		pattern = pattern + ".*"
+	}
+	return pattern
+}
+
+func fieldName(fieldPath []string, fieldMap map[string]string) (string, error) {
+	if name, ok := fieldMap[strings.Join(fieldPath, ".")]; ok {
+		return name, nil
+	}
+	return "", &query.UnknownFieldError{FieldPath: fieldPath}
+}
+
+func rangeQuery(field, op string, value interface{}) M {
+	return M{"range": M{field: M{op: value}}}
+}
+
+func not(q M, neg bool) M {
+	if neg {
+		return M{"bool": M{"must_not": []interface{}{q}}}
+	}
+	return q
+}
@@ -0,0 +1,155 @@
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/partitio/atlas-app-toolkit/query"
+)
+
+func TestToQuery(t *testing.T) {
+	fieldMap := map[string]string{
+		"name":         "name",
+		"age":          "age",
+		"active":       "is_active",
+		"address.city": "address.city",
+		"created":      "created_at",
+	}
+
+	tests := []struct {
+		filter string
+		res    M
+	}{
+		{
+			filter: "",
+			res:    M{"match_all": M{}},
+		},
+		{
+			filter: "name == 'John'",
+			res:    M{"term": M{"name": "John"}},
+		},
+		{
+			filter: "name != 'John'",
+			res:    M{"bool": M{"must_not": []interface{}{M{"term": M{"name": "John"}}}}},
+		},
+		{
+			filter: "age < 18",
+			res:    M{"range": M{"age": M{"lt": 18.0}}},
+		},
+		{
+			filter: "created > NOW-7d",
+			res:    M{"range": M{"created_at": M{"gt": "now-7d"}}},
+		},
+		{
+			filter: "created != now-1d+12h",
+			res:    M{"bool": M{"must_not": []interface{}{M{"range": M{"created_at": M{"gte": "now-1d+12h", "lte": "now-1d+12h"}}}}}},
+		},
+		{
+			filter: "not age >= 18",
+			res:    M{"bool": M{"must_not": []interface{}{M{"range": M{"age": M{"gte": 18.0}}}}}},
+		},
+		{
+			filter: "name > 'a' and name <= 'b'",
+			res: M{"bool": M{"must": []interface{}{
+				M{"range": M{"name": M{"gt": "a"}}},
+				M{"range": M{"name": M{"lte": "b"}}},
+			}}},
+		},
+		{
+			filter: "name ~ '^Jo' or name !~ 'hn$'",
+			res: M{"bool": M{"should": []interface{}{
+				M{"regexp": M{"name": "Jo.*"}},
+				M{"bool": M{"must_not": []interface{}{M{"regexp": M{"name": ".*hn"}}}}},
+			}, "minimum_should_match": 1}},
+		},
+		{
+			filter: "name like 'J_n%' and name not like '%\\%*'",
+			res: M{"bool": M{"must": []interface{}{
+				M{"wildcard": M{"name": M{"value": "J?n*"}}},
+				M{"bool": M{"must_not": []interface{}{M{"wildcard": M{"name": M{"value": `*%\*`}}}}}},
+			}}},
+		},
+		{
+			filter: "name ilike 'j_n%'",
+			res:    M{"wildcard": M{"name": M{"value": "j?n*", "case_insensitive": true}}},
+		},
+		{
+			filter: "name in_cidr '10.0.0.0/8'",
+			res:    M{"term": M{"name": "10.0.0.0/8"}},
+		},
+		{
+			filter: "name := 'john'",
+			res:    M{"term": M{"name": M{"value": "john", "case_insensitive": true}}},
+		},
+		{
+			filter: "address.city == null",
+			res:    M{"bool": M{"must_not": []interface{}{M{"exists": M{"field": "address.city"}}}}},
+		},
+		{
+			filter: "address.city != null",
+			res:    M{"exists": M{"field": "address.city"}},
+		},
+		{
+			filter: "active == true",
+			res:    M{"term": M{"is_active": true}},
+		},
+		{
+			filter: "active >= true",
+			res:    M{"range": M{"is_active": M{"gte": true}}},
+		},
+		{
+			filter: "name in ['John', 'Jane'] and age not in [1, 2]",
+			res: M{"bool": M{"must": []interface{}{
+				M{"terms": M{"name": []interface{}{"John", "Jane"}}},
+				M{"bool": M{"must_not": []interface{}{M{"terms": M{"age": []interface{}{1.0, 2.0}}}}}},
+			}}},
+		},
+		{
+			filter: "name == 'John' or not (age > 18 and active == false)",
+			res: M{"bool": M{"should": []interface{}{
+				M{"term": M{"name": "John"}},
+				M{"bool": M{"must_not": []interface{}{
+					M{"bool": M{"must": []interface{}{
+						M{"range": M{"age": M{"gt": 18.0}}},
+						M{"term": M{"is_active": false}},
+					}}},
+				}}},
+			}, "minimum_should_match": 1}},
+		},
+	}
+
+	for _, test := range tests {
+		res, err := FilterStringToQuery(test.filter, fieldMap)
+		assert.Nil(t, err, test.filter)
+		assert.Equal(t, test.res, res, test.filter)
+		_, err = json.Marshal(res)
+		assert.Nil(t, err, test.filter)
+	}
+}
+
+func TestToQueryUnknownField(t *testing.T) {
+	fieldMap := map[string]string{"name": "name"}
+
+	for _, filter := range []string{"age == 1", "name == 'a' or age == 1", "not (name == 'a' and address.city == null)"} {
+		res, err := FilterStringToQuery(filter, fieldMap)
+		assert.Nil(t, res)
+		assert.IsType(t, &query.UnknownFieldError{}, err, filter)
+	}
+}
+
+func TestLikeToWildcard(t *testing.T) {
+	tests := map[string]string{
+		"abc":     "abc",
+		"a%c_":    "a*c?",
+		`a\%b\_`:  "a%b_",
+		"a*b?":    `a\*b\?`,
+		`a\\b\`:   `a\\b\\`,
+		`100\%%`:  "100%*",
+		`\*\?\\x`: `\*\?\\x`,
+	}
+	for pattern, expected := range tests {
+		assert.Equal(t, expected, LikeToWildcard(pattern), pattern)
+	}
+}
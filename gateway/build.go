@@ -0,0 +1,43 @@
+package gateway
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/partitio/atlas-app-toolkit/query"
+)
+
+// BuildQuery is the inverse of ParseQuery, it returns query parameters with default keys
+// representing collection operators f, s, fs and p, e.g. to build links to the next page
+// or to a refined collection. Nil and empty operators are omitted.
+// The filtering expression is rendered by Filtering.GoString, so it is parsed back
+// with ParseFiltering to the same expression.
+func BuildQuery(f *query.Filtering, s *query.Sorting, fs *query.FieldSelection, p *query.Pagination) url.Values {
+	return BuildQueryWithConfig(f, s, fs, p, QueryParamConfig{})
+}
+
+// BuildQueryWithConfig is the same as BuildQuery but uses query parameter keys from cfg.
+func BuildQueryWithConfig(f *query.Filtering, s *query.Sorting, fs *query.FieldSelection, p *query.Pagination, cfg QueryParamConfig) url.Values {
+	cfg = cfg.withDefaults()
+	vals := url.Values{}
+	if f.GetRoot() != nil {
+		vals.Set(cfg.FilterKey, f.GoString())
+	}
+	if len(s.GetCriterias()) > 0 {
+		vals.Set(cfg.SortKey, s.GoString())
+	}
+	if len(fs.GetFields()) > 0 {
+		vals.Set(cfg.FieldsKey, fs.GoString())
+	}
+	// explicit zero limit requests page info only, so it is kept
+	if p.GetLimit() > 0 || p.GetHasLimit() {
+		vals.Set(cfg.LimitKey, strconv.FormatInt(int64(p.GetLimit()), 10))
+	}
+	if p.GetOffset() > 0 {
+		vals.Set(cfg.OffsetKey, strconv.FormatInt(int64(p.GetOffset()), 10))
+	}
+	if pt := p.GetPageToken(); pt != "" {
+		vals.Set(cfg.PageTokenKey, pt)
+	}
+	return vals
+}
@@ -0,0 +1,64 @@
+package gateway
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/partitio/atlas-app-toolkit/query"
+)
+
+func TestBuildQuery(t *testing.T) {
+	vals := url.Values{
+		FilterQueryKey:    {"name == 'O\\'Brien' and (age > -1 or not tags in ['a', 'b']) and address.city ~ '^New'"},
+		SortQueryKey:      {"name desc nulls last, age"},
+		FieldsQueryKey:    {"name,address.city"},
+		LimitQueryKey:     {"10"},
+		PageTokenQueryKey: {"ptoken"},
+	}
+	req := &explainRequest{}
+	if err := ParseQuery(req, vals); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	built := BuildQuery(req.Filtering, req.Sorting, req.FieldSelection, req.Pagination)
+	if s := built.Get(SortQueryKey); s != "name DESC NULLS LAST, age ASC" {
+		t.Errorf("invalid sorting: %q - expected: %q", s, "name DESC NULLS LAST, age ASC")
+	}
+	if _, ok := built[OffsetQueryKey]; ok {
+		t.Errorf("unexpected offset: %q", built.Get(OffsetQueryKey))
+	}
+
+	res := &explainRequest{}
+	if err := ParseQuery(res, built); err != nil {
+		t.Fatalf("unexpected error for %v: %s", built, err)
+	}
+	if !proto.Equal(res.Filtering, req.Filtering) {
+		t.Errorf("invalid filtering: %s - expected: %s", res.Filtering.GoString(), req.Filtering.GoString())
+	}
+	if !proto.Equal(res.Sorting, req.Sorting) {
+		t.Errorf("invalid sorting: %v - expected: %v", res.Sorting, req.Sorting)
+	}
+	if !proto.Equal(res.FieldSelection, req.FieldSelection) {
+		t.Errorf("invalid field selection: %v - expected: %v", res.FieldSelection, req.FieldSelection)
+	}
+	if !proto.Equal(res.Pagination, req.Pagination) {
+		t.Errorf("invalid pagination: %v - expected: %v", res.Pagination, req.Pagination)
+	}
+
+	// zero limit is kept while the omitted one is not
+	built = BuildQuery(nil, nil, nil, &query.Pagination{HasLimit: true, Offset: 20})
+	if expected := (url.Values{LimitQueryKey: {"0"}, OffsetQueryKey: {"20"}}); !reflect.DeepEqual(built, expected) {
+		t.Errorf("invalid query: %v - expected: %v", built, expected)
+	}
+	if built := BuildQuery(nil, &query.Sorting{}, &query.FieldSelection{}, &query.Pagination{}); len(built) != 0 {
+		t.Errorf("invalid query: %v - expected: empty", built)
+	}
+
+	built = BuildQueryWithConfig(req.Filtering, nil, nil, &query.Pagination{Limit: 5}, QueryParamConfig{FilterKey: "filter", LimitKey: "limit"})
+	if built.Get("filter") != req.Filtering.GoString() || built.Get("limit") != "5" || len(built) != 2 {
+		t.Errorf("invalid query: %v - expected filter and limit keys", built)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	rpcdetails "google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/grpclog"
@@ -98,9 +99,14 @@ func (h *ProtoErrorHandler) writeError(ctx context.Context, headerWritten bool,
 	var fields interface{}
 
 	for _, d := range st.Details() {
-		switch d.(type) {
+		switch d := d.(type) {
 		case *errdetails.TargetInfo:
 			details = append(details, d)
+		case *rpcdetails.BadRequest:
+			// field violations are rendered as details targeting the fields
+			for _, v := range d.GetFieldViolations() {
+				details = append(details, errdetails.New(st.Code(), v.GetField(), v.GetDescription()))
+			}
 		case *errfields.FieldInfo:
 			fields = d
 		default:
@@ -111,6 +117,8 @@ func (h *ProtoErrorHandler) writeError(ctx context.Context, headerWritten bool,
 	}
 
 	restErr := map[string]interface{}{
+		"status":  HTTPStatus(ctx, st),
+		"code":    CodeName(st.Code()),
 		"message": st.Message(),
 	}
 	if len(details) > 0 {
@@ -147,6 +155,14 @@ func (h *ProtoErrorHandler) writeError(ctx context.Context, headerWritten bool,
 	}
 }
 
+// WriteError writes err to rw in the Error JSON format of REST API Syntax Specification
+// with HTTP status code that corresponds to gRPC code of err, errors that are not created
+// by status package are treated as Unknown. It allows to respond with errors returned by
+// ParseQuery or a gRPC service from plain HTTP handlers in the same format as the gateway does.
+func WriteError(rw http.ResponseWriter, err error) {
+	(&ProtoErrorHandler{}).writeError(context.Background(), false, &runtime.JSONBuiltin{}, rw, err)
+}
+
 // For small performance bump, switch map[string]string to a tuple-type (string, string)
 
 type MessageWithFields interface {
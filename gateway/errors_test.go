@@ -6,10 +6,12 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"reflect"
 	"testing"
 
 	"github.com/partitio/atlas-app-toolkit/errors"
+	"github.com/partitio/atlas-app-toolkit/query"
 
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
 	"google.golang.org/grpc/codes"
@@ -116,3 +118,84 @@ func TestWriteErrorContainer(t *testing.T) {
 	}
 
 }
+
+func TestWriteError(t *testing.T) {
+	err := ParseQuery(&testRequest{}, url.Values{FilterQueryKey: {"name =="}})
+
+	rw := httptest.NewRecorder()
+	WriteError(rw, err)
+
+	if ct := rw.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("invalid content-type: %s - expected: %s", ct, "application/json")
+	}
+	if rw.Code != http.StatusBadRequest {
+		t.Errorf("invalid http status code: %d - expected: %d", rw.Code, http.StatusBadRequest)
+	}
+
+	v := new(RestErrs)
+	if err := json.Unmarshal(rw.Body.Bytes(), v); err != nil {
+		t.Fatalf("failed to unmarshal response: %s", err)
+	}
+	if len(v.Error) != 1 {
+		t.Fatalf("invalid number of errors: %d - expected: 1", len(v.Error))
+	}
+	msg := "invalid filter at position 7: unexpected end of expression"
+	expected := map[string]interface{}{
+		"status":  float64(http.StatusBadRequest),
+		"code":    "INVALID_ARGUMENT",
+		"message": msg,
+		"details": []interface{}{
+			map[string]interface{}{
+				"code":    "INVALID_ARGUMENT",
+				"target":  FilterQueryKey,
+				"message": msg,
+			},
+		},
+	}
+	if !reflect.DeepEqual(v.Error[0], expected) {
+		t.Errorf("invalid error: %v - expected: %v", v.Error[0], expected)
+	}
+
+	// not a status error
+	rw = httptest.NewRecorder()
+	WriteError(rw, fmt.Errorf("simple text error"))
+	if rw.Code != http.StatusInternalServerError {
+		t.Errorf("invalid http status code: %d - expected: %d", rw.Code, http.StatusInternalServerError)
+	}
+}
+
+func TestWriteErrorTypeMismatch(t *testing.T) {
+	_, err := query.Filter(&struct {
+		Age int32 `json:"age"`
+	}{}, "age == 'old'")
+
+	rw := httptest.NewRecorder()
+	WriteError(rw, err)
+
+	if rw.Code != http.StatusBadRequest {
+		t.Errorf("invalid http status code: %d - expected: %d", rw.Code, http.StatusBadRequest)
+	}
+	v := new(RestErrs)
+	if err := json.Unmarshal(rw.Body.Bytes(), v); err != nil {
+		t.Fatalf("failed to unmarshal response: %s", err)
+	}
+	if len(v.Error) != 1 {
+		t.Fatalf("invalid number of errors: %d - expected: 1", len(v.Error))
+	}
+	msg := "age is not a string type, cannot compare with 'old'"
+	expected := map[string]interface{}{
+		"status":  float64(http.StatusBadRequest),
+		"code":    "INVALID_ARGUMENT",
+		"message": msg,
+		"details": []interface{}{
+			map[string]interface{}{
+				"code":    "INVALID_ARGUMENT",
+				"target":  "age",
+				"message": msg,
+			},
+		},
+	}
+	if !reflect.DeepEqual(v.Error[0], expected) {
+		t.Errorf("invalid error: %v - expected: %v", v.Error[0], expected)
+	}
+}
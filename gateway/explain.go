@@ -0,0 +1,120 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+
+	"github.com/partitio/atlas-app-toolkit/query"
+)
+
+// ExplainQueryKey is a query parameter that makes ExplainHandler respond
+// with the parsed collection operators instead of serving the request.
+const ExplainQueryKey = "_explain"
+
+// explainRequest holds collection operators parsed by ExplainQueryWithConfig.
+type explainRequest struct {
+	Filtering      *query.Filtering
+	Sorting        *query.Sorting
+	FieldSelection *query.FieldSelection
+	Pagination     *query.Pagination
+	CountOnly      *query.CountOnly
+}
+
+// ExplainQuery parses collection operators from query parameters vals using default keys
+// and returns them as a JSON-friendly structure, e.g. to debug complex filtering expressions:
+//
+//	{
+//	  "filtering": {"expression": "(a == 1 and b > 2)", "tree": {"operator": {...}}},
+//	  "sorting": {"expression": "name DESC", "tree": {"criterias": [...]}},
+//	  "fields": {"fields": {...}},
+//	  "pagination": {"limit": 10},
+//	  "count_only": {"enabled": true}
+//	}
+//
+// Trees are JSON representations of the corresponding proto messages with original field names.
+// Operators that are not specified are omitted. Errors are the same as returned by ParseQuery.
+func ExplainQuery(vals url.Values) (map[string]interface{}, error) {
+	return ExplainQueryWithConfig(vals, QueryParamConfig{})
+}
+
+// ExplainQueryWithConfig is the same as ExplainQuery but uses query parameter keys and limits from cfg.
+func ExplainQueryWithConfig(vals url.Values, cfg QueryParamConfig) (map[string]interface{}, error) {
+	req := &explainRequest{}
+	if err := ParseQueryWithConfig(req, vals, cfg); err != nil {
+		return nil, err
+	}
+	res := make(map[string]interface{})
+	if req.Filtering != nil {
+		tree, err := explainMessage(req.Filtering)
+		if err != nil {
+			return nil, err
+		}
+		res["filtering"] = map[string]interface{}{"expression": req.Filtering.GoString(), "tree": tree}
+	}
+	if req.Sorting != nil {
+		tree, err := explainMessage(req.Sorting)
+		if err != nil {
+			return nil, err
+		}
+		res["sorting"] = map[string]interface{}{"expression": req.Sorting.GoString(), "tree": tree}
+	}
+	ops := []struct {
+		key string
+		m   proto.Message
+		set bool
+	}{
+		{"fields", req.FieldSelection, req.FieldSelection != nil},
+		{"pagination", req.Pagination, req.Pagination != nil && !proto.Equal(req.Pagination, &query.Pagination{})},
+		{"count_only", req.CountOnly, req.CountOnly != nil},
+	}
+	for _, op := range ops {
+		if !op.set {
+			continue
+		}
+		tree, err := explainMessage(op.m)
+		if err != nil {
+			return nil, err
+		}
+		res[op.key] = tree
+	}
+	return res, nil
+}
+
+// ExplainHandler returns http.Handler that responds with JSON made by ExplainQueryWithConfig
+// if the request has "_explain=true" query parameter, otherwise the request is served by h,
+// e.g. ExplainHandler(mux, QueryParamConfig{}). Invalid collection operators are written by WriteError.
+func ExplainHandler(h http.Handler, cfg QueryParamConfig) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		vals := r.URL.Query()
+		if explain, _ := strconv.ParseBool(vals.Get(ExplainQueryKey)); !explain {
+			h.ServeHTTP(w, r)
+			return
+		}
+		res, err := ExplainQueryWithConfig(vals, cfg)
+		if err != nil {
+			WriteError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(res)
+	})
+}
+
+// explainMessage returns JSON representation of m decoded to generic values.
+func explainMessage(m proto.Message) (interface{}, error) {
+	var buf bytes.Buffer
+	if err := (&jsonpb.Marshaler{OrigName: true}).Marshal(&buf, m); err != nil {
+		return nil, err
+	}
+	var v interface{}
+	if err := json.Unmarshal(buf.Bytes(), &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
@@ -0,0 +1,97 @@
+package gateway
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestExplainQuery(t *testing.T) {
+	vals := url.Values{
+		FilterQueryKey: {"name == 'John' and not age > 18"},
+		SortQueryKey:   {"name desc"},
+		LimitQueryKey:  {"10"},
+	}
+	res, err := ExplainQuery(vals)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	b, err := json.Marshal(res)
+	if err != nil {
+		t.Fatalf("failed to marshal explanation: %s", err)
+	}
+	var actual map[string]interface{}
+	if err := json.Unmarshal(b, &actual); err != nil {
+		t.Fatalf("failed to unmarshal explanation: %s", err)
+	}
+	var expected map[string]interface{}
+	if err := json.Unmarshal([]byte(`{
+		"filtering": {
+			"expression": "(name == 'John' and not age > 18)",
+			"tree": {"operator": {
+				"left_string_condition": {"field_path": ["name"], "value": "John"},
+				"right_number_condition": {"field_path": ["age"], "value": 18, "type": "GT", "is_negative": true}
+			}}
+		},
+		"sorting": {
+			"expression": "name DESC",
+			"tree": {"criterias": [{"tag": "name", "order": "DESC"}]}
+		},
+		"pagination": {"limit": 10, "has_limit": true}
+	}`), &expected); err != nil {
+		t.Fatalf("invalid expected explanation: %s", err)
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("invalid explanation: %s", b)
+	}
+
+	res, err = ExplainQuery(url.Values{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(res) != 0 {
+		t.Errorf("invalid explanation: %v - expected: empty", res)
+	}
+
+	_, err = ExplainQuery(url.Values{FilterQueryKey: {"name =="}})
+	if s, ok := status.FromError(err); !ok || s.Code() != codes.InvalidArgument {
+		t.Errorf("invalid error: %v - expected: %s", err, codes.InvalidArgument)
+	}
+}
+
+func TestExplainHandler(t *testing.T) {
+	h := ExplainHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}), QueryParamConfig{FilterKey: "filter"})
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/v1/users?filter=a==1", nil))
+	if rec.Code != http.StatusTeapot {
+		t.Errorf("invalid status code: %d - expected: %d", rec.Code, http.StatusTeapot)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/v1/users?filter=a==1&_explain=true", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("invalid status code: %d - expected: %d", rec.Code, http.StatusOK)
+	}
+	var res map[string]map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil {
+		t.Fatalf("invalid response body %q: %s", rec.Body, err)
+	}
+	if e := res["filtering"]["expression"]; e != "a == 1" {
+		t.Errorf("invalid filtering expression: %v - expected: a == 1", e)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/v1/users?filter=a==&_explain=true", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("invalid status code: %d - expected: %d", rec.Code, http.StatusBadRequest)
+	}
+}
@@ -10,7 +10,8 @@ import (
 //retainFields function extracts the configuration for fields that
 //need to be ratained either from gRPC response or from original testRequest
 //(in case when gRPC side didn't set any preferences) and retains only
-//this fields on outgoing response (dynmap).
+//this fields on outgoing response (dynmap). If fields are excluded then
+//exactly this fields are removed from outgoing response.
 func retainFields(ctx context.Context, req *http.Request, dynmap map[string]interface{}) {
 	fieldsStr := ""
 	if req != nil {
@@ -25,16 +26,20 @@ func retainFields(ctx context.Context, req *http.Request, dynmap map[string]inte
 
 	fields := query.ParseFieldSelection(fieldsStr)
 	if fields != nil {
+		do := doRetainFields
+		if fields.GetExclude() {
+			do = doExcludeFields
+		}
 		for k, result := range dynmap {
 			if k != "page" {
 				if results, ok := result.([]interface{}); ok {
 					for _, r := range results {
 						if m, ok := r.(map[string]interface{}); ok {
-							doRetainFields(m, fields.Fields)
+							do(m, fields.Fields)
 						}
 					}
 				} else if m, ok := result.(map[string]interface{}); ok {
-					doRetainFields(m, fields.Fields)
+					do(m, fields.Fields)
 				}
 			}
 		}
@@ -67,3 +72,22 @@ func doRetainFields(obj map[string]interface{}, fields query.FieldSelectionMap)
 		}
 	}
 }
+
+func doExcludeFields(obj map[string]interface{}, fields query.FieldSelectionMap) {
+	for key, field := range fields {
+		if len(field.Subs) == 0 {
+			delete(obj, key)
+			continue
+		}
+		switch x := obj[key].(type) {
+		case map[string]interface{}:
+			doExcludeFields(x, field.Subs)
+		case []interface{}:
+			for _, r := range x {
+				if m, ok := r.(map[string]interface{}); ok {
+					doExcludeFields(m, field.Subs)
+				}
+			}
+		}
+	}
+}
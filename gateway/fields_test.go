@@ -63,6 +63,59 @@ func TestRetain(t *testing.T) {
 
 }
 
+func TestRetainExcluded(t *testing.T) {
+	data := `
+	{
+		"result": [
+		  {
+			"x": "1",
+			"y": {"a": "2", "b": "3"}
+		  },
+		  {
+			"x": "4",
+			"y": {"a": "5"},
+			"z": "6"
+		  }
+		]
+	 }`
+
+	expected := `
+	 {
+		 "result": [
+		   {
+			 "y": {"b": "3"}
+		   },
+		   {
+			 "y": {},
+			 "z": "6"
+		   }
+		 ]
+	  }`
+
+	var indata map[string]interface{}
+	err := json.Unmarshal([]byte(data), &indata)
+	if err != nil {
+		t.Errorf("Error parsing test input %s", data)
+		return
+	}
+
+	var expdata map[string]interface{}
+	err = json.Unmarshal([]byte(expected), &expdata)
+	if err != nil {
+		t.Errorf("Error parsing test expected result %s", expected)
+		return
+	}
+
+	req, _ := http.NewRequest("GET", "http://example.com?_fields=-x,-y.a", nil)
+
+	ctx := context.Background()
+	retainFields(ctx, req, indata)
+
+	if !reflect.DeepEqual(indata, expdata) {
+		t.Errorf("Unexpected result %v while expecting %v", indata, expdata)
+	}
+}
+
 func TestRetainSingleResult(t *testing.T) {
 	data := `
 	{
@@ -3,12 +3,9 @@ package gateway
 import (
 	"context"
 	"net/http"
-	"net/url"
 
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
 const (
@@ -33,18 +30,21 @@ type gateway struct {
 
 // ClientUnaryInterceptor parse collection operators and stores in corresponding message fields
 func ClientUnaryInterceptor(parentCtx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
-	raw, ok := Header(parentCtx, query_url)
-	if ok {
-		request, err := url.Parse(raw)
-		if err != nil {
-			return status.Error(codes.InvalidArgument, err.Error())
-		}
-		vals := request.Query()
-		if err := ParseQuery(req, vals); err != nil {
-			return err
+	return ClientUnaryInterceptorWithConfig(QueryParamConfig{})(parentCtx, method, req, reply, cc, invoker, opts...)
+}
+
+// ClientUnaryInterceptorWithConfig returns the same interceptor as ClientUnaryInterceptor
+// but it uses query parameter keys from cfg.
+func ClientUnaryInterceptorWithConfig(cfg QueryParamConfig) grpc.UnaryClientInterceptor {
+	return func(parentCtx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		raw, ok := Header(parentCtx, query_url)
+		if ok {
+			if err := parseQueryURL(req, raw, cfg); err != nil {
+				return err
+			}
 		}
+		return invoker(parentCtx, method, req, reply, cc, opts...)
 	}
-	return invoker(parentCtx, method, req, reply, cc, opts...)
 }
 
 // NewGateway creates a gRPC REST gateway with HTTP handlers that have been
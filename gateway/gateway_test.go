@@ -20,6 +20,10 @@ type testRequest struct {
 	FieldSelection *query.FieldSelection
 }
 
+func (*testRequest) Reset()         {}
+func (*testRequest) String() string { return "testRequest" }
+func (*testRequest) ProtoMessage()  {}
+
 type testResponse struct {
 	PageInfo *query.PageInfo
 }
@@ -92,10 +96,10 @@ func TestSorting(t *testing.T) {
 			t.Fatalf("invalid number of sort criterias: %d - expected: 2", len(s.GetCriterias()))
 		}
 		if c := s.GetCriterias(); c[0].GoString() != "name ASC" || c[0].Tag != "name" || c[0].Order != query.SortCriteria_ASC {
-			t.Errorf("invalid sort criteria: %v - expected: %v", c[0], query.SortCriteria{"name", query.SortCriteria_ASC})
+			t.Errorf("invalid sort criteria: %v - expected: %v", c[0], query.SortCriteria{Tag: "name", Order: query.SortCriteria_ASC})
 		}
 		if c := s.GetCriterias(); c[1].GoString() != "age DESC" || c[1].Tag != "age" || c[1].Order != query.SortCriteria_DESC {
-			t.Errorf("invalid sort criteria: %v - expected: %v", c[1], query.SortCriteria{"age", query.SortCriteria_DESC})
+			t.Errorf("invalid sort criteria: %v - expected: %v", c[1], query.SortCriteria{Tag: "age", Order: query.SortCriteria_DESC})
 		}
 		return nil
 	}
@@ -109,7 +113,7 @@ func TestSorting(t *testing.T) {
 
 func TestPagination(t *testing.T) {
 	// valid pagination testRequest
-	hreq, err := http.NewRequest(http.MethodGet, "http://app.com?_limit=20&_offset=10&_page_token=ptoken", nil)
+	hreq, err := http.NewRequest(http.MethodGet, "http://app.com?_limit=20&_page_token=ptoken", nil)
 	if err != nil {
 		t.Fatalf("failed to build new http testRequest: %s", err)
 	}
@@ -126,8 +130,8 @@ func TestPagination(t *testing.T) {
 			t.Fatalf("invalid error: %s, %s - expected: nil, nil", tstReq.Pagination, err)
 		}
 		page := tstReq.Pagination
-		if page.GetLimit() != 20 || page.GetOffset() != 10 || page.GetPageToken() != "ptoken" {
-			t.Errorf("invalid pagination: %s - expected: %s", page, &query.Pagination{Limit: 20, Offset: 10, PageToken: "ptoken"})
+		if page.GetLimit() != 20 || page.GetOffset() != 0 || page.GetPageToken() != "ptoken" {
+			t.Errorf("invalid pagination: %s - expected: %s", page, &query.Pagination{Limit: 20, PageToken: "ptoken"})
 		}
 		return nil
 	}
@@ -9,7 +9,9 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/partitio/atlas-app-toolkit/query"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/grpclog"
+	"google.golang.org/grpc/status"
 )
 
 // ParseQueryParameters is a middleware for github.com/partitio/micro-gateway
@@ -19,18 +21,22 @@ import (
 //     runtime.WithQueryParameterParser(ParseQueryParameters),
 // )
 func ParseQueryParameters(req proto.Message, vals url.Values) (map[string]bool, error) {
-	handled := map[string]bool{
-		SortQueryKey:      true,
-		FieldsQueryKey:    true,
-		FilterQueryKey:    true,
-		LimitQueryKey:     true,
-		OffsetQueryKey:    true,
-		PageTokenQueryKey: true,
-	}
-	if err := ParseQuery(req, vals); err != nil {
-		return handled, err
+	return ParseQueryParametersWithConfig(QueryParamConfig{})(req, vals)
+}
+
+// ParseQueryParametersWithConfig returns the same middleware as ParseQueryParameters
+// but it uses query parameter keys from cfg.
+func ParseQueryParametersWithConfig(cfg QueryParamConfig) func(proto.Message, url.Values) (map[string]bool, error) {
+	return func(req proto.Message, vals url.Values) (map[string]bool, error) {
+		handled := make(map[string]bool)
+		for _, k := range cfg.keys() {
+			handled[k] = true
+		}
+		if err := ParseQueryWithConfig(req, vals, cfg); err != nil {
+			return handled, err
+		}
+		return handled, nil
 	}
-	return handled, nil
 }
 
 // UnaryServerInterceptor returns grpc.UnaryServerInterceptor
@@ -67,6 +73,55 @@ func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
 	}
 }
 
+// QueryUnaryServerInterceptor returns grpc.UnaryServerInterceptor that parses collection
+// operators from the request URL stored in gRPC metadata by MetadataAnnotator and sets them
+// to the corresponding fields of the request message before the handler is called.
+// If there is no request URL in metadata the request is passed to the handler as is.
+func QueryUnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return QueryUnaryServerInterceptorWithConfig(QueryParamConfig{})
+}
+
+// QueryUnaryServerInterceptorWithConfig returns the same interceptor as QueryUnaryServerInterceptor
+// but it uses query parameter keys from cfg.
+func QueryUnaryServerInterceptorWithConfig(cfg QueryParamConfig) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		raw, ok := Header(ctx, query_url)
+		if !ok || req == nil {
+			return handler(ctx, req)
+		}
+		if err := parseQueryURL(req, raw, cfg); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// parseQueryURL parses collection operators from the query of the raw request URL
+// and stores them in req. cfg.URLRewrite is applied to the whole URL,
+// so that the rewrite can take the path into account.
+func parseQueryURL(req interface{}, raw string, cfg QueryParamConfig) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	u = cfg.rewriteURL(u)
+	cfg.URLRewrite = nil
+	return ParseQueryWithConfig(req, u.Query(), cfg)
+}
+
+// MissingFieldError describes a request of type Request that has no field
+// of collection operator type Op, e.g. *query.Filtering.
+type MissingFieldError struct {
+	Op      string
+	Request string
+}
+
+func (e *MissingFieldError) Error() string {
+	return fmt.Sprintf("request %s has no field of collection operator type %s", e.Request, e.Op)
+}
+
+// SetCollectionOps sets op to the fields of request req that are of the same type as op.
+// If req has no such field MissingFieldError is returned.
 func SetCollectionOps(req, op interface{}) error {
 	reqval := reflect.ValueOf(req)
 
@@ -80,12 +135,14 @@ func SetCollectionOps(req, op interface{}) error {
 		return fmt.Errorf("request value is not a struct - %s", reqval.Kind())
 	}
 
+	found := false
 	for i := 0; i < reqval.NumField(); i++ {
 		f := reqval.FieldByIndex([]int{i})
 
 		if f.Type() != reflect.TypeOf(op) {
 			continue
 		}
+		found = true
 
 		if !f.IsValid() || !f.CanSet() {
 			return fmt.Errorf("operation field %+v in request %+v is invalid or cannot be set", op, req)
@@ -97,9 +154,33 @@ func SetCollectionOps(req, op interface{}) error {
 
 	}
 
+	if !found {
+		return &MissingFieldError{Op: fmt.Sprintf("%T", op), Request: fmt.Sprintf("%T", req)}
+	}
 	return nil
 }
 
+// HasCollectionOp reports whether request req has a field of the same type as op, e.g. *query.Filtering,
+// that is set. It is false if req is not a pointer to a struct.
+func HasCollectionOp(req, op interface{}) bool {
+	reqval := reflect.ValueOf(req)
+	if reqval.Kind() != reflect.Ptr || reqval.IsNil() {
+		return false
+	}
+	reqval = reqval.Elem()
+	if reqval.Kind() != reflect.Struct {
+		return false
+	}
+	t := reflect.TypeOf(op)
+	for i := 0; i < reqval.NumField(); i++ {
+		f := reqval.Field(i)
+		if f.Type() == t && f.Kind() == reflect.Ptr && !f.IsNil() {
+			return true
+		}
+	}
+	return false
+}
+
 func GetCollectionOp(res, op interface{}) error {
 	_, err := getAndUnsetOp(res, op, false)
 	return err
@@ -149,6 +230,8 @@ func getAndUnsetOp(res, op interface{}, unset bool) (fieldName string, err error
 	return fieldName, nil
 }
 
+// GetPageInfo returns the name of page info field of response resp and its value,
+// which is empty but not nil if the field is not set. It is nil only if there is no such field.
 func GetPageInfo(resp proto.Message) (fieldName string, pg *query.PageInfo, err error) {
 	pg = new(query.PageInfo)
 	fieldName, err = getAndUnsetOp(resp, pg, false)
@@ -158,6 +241,9 @@ func GetPageInfo(resp proto.Message) (fieldName string, pg *query.PageInfo, err
 	return
 }
 
+// GetFiltering returns the name of filtering field of request req and the parsed filtering,
+// which is empty but not nil if the field is not set. It is nil only if there is no such field,
+// use RequestFiltering to tell whether the client supplied filtering.
 func GetFiltering(req proto.Message) (fieldName string, f *query.Filtering, err error) {
 	f = new(query.Filtering)
 	fieldName, err = getAndUnsetOp(req, f, false)
@@ -167,6 +253,9 @@ func GetFiltering(req proto.Message) (fieldName string, f *query.Filtering, err
 	return
 }
 
+// GetSorting returns the name of sorting field of request req and the parsed sorting,
+// which is empty but not nil if the field is not set. It is nil only if there is no such field,
+// use RequestSorting to tell whether the client supplied sorting.
 func GetSorting(req proto.Message) (fieldName string, s *query.Sorting, err error) {
 	s = new(query.Sorting)
 	fieldName, err = getAndUnsetOp(req, s, false)
@@ -176,6 +265,8 @@ func GetSorting(req proto.Message) (fieldName string, s *query.Sorting, err erro
 	return
 }
 
+// GetPagination returns the name of pagination field of request req and the parsed pagination,
+// which is empty but not nil if the field is not set. It is nil only if there is no such field.
 func GetPagination(req proto.Message) (fieldName string, p *query.Pagination, err error) {
 	p = new(query.Pagination)
 	fieldName, err = getAndUnsetOp(req, p, false)
@@ -185,6 +276,8 @@ func GetPagination(req proto.Message) (fieldName string, p *query.Pagination, er
 	return
 }
 
+// GetFieldSelection returns the name of field selection field of request req and the parsed field selection,
+// which is empty but not nil if the field is not set. It is nil only if there is no such field.
 func GetFieldSelection(req proto.Message) (fieldName string, fs *query.FieldSelection, err error) {
 	fs = new(query.FieldSelection)
 	fieldName, err = getAndUnsetOp(req, fs, false)
@@ -193,3 +286,29 @@ func GetFieldSelection(req proto.Message) (fieldName string, fs *query.FieldSele
 	}
 	return
 }
+
+// RequestFiltering returns filtering of request req, which is nil if the client did not supply it
+// or req has no filtering field.
+func RequestFiltering(req proto.Message) *query.Filtering {
+	f := new(query.Filtering)
+	if !HasCollectionOp(req, f) {
+		return nil
+	}
+	if _, err := getAndUnsetOp(req, f, false); err != nil {
+		return nil
+	}
+	return f
+}
+
+// RequestSorting returns sorting of request req, which is nil if the client did not supply it
+// or req has no sorting field.
+func RequestSorting(req proto.Message) *query.Sorting {
+	s := new(query.Sorting)
+	if !HasCollectionOp(req, s) {
+		return nil
+	}
+	if _, err := getAndUnsetOp(req, s, false); err != nil {
+		return nil
+	}
+	return s
+}
@@ -1,8 +1,15 @@
 package gateway
 
 import (
+	"context"
+	"net/url"
+	"reflect"
 	"testing"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
 	"github.com/partitio/atlas-app-toolkit/query"
 )
 
@@ -49,3 +56,151 @@ func TestUnsetOp(t *testing.T) {
 		t.Errorf("invalid error: %s - expected: %s", err, "response value is not a struct - int")
 	}
 }
+
+func TestSetCollectionOpsMissingField(t *testing.T) {
+	req := &testRequest{}
+	f := &query.Filtering{}
+	if err := SetCollectionOps(req, f); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if req.Filtering != f {
+		t.Errorf("invalid filtering: %v - expected: %v", req.Filtering, f)
+	}
+
+	err := SetCollectionOps(&testResponse{}, f)
+	expected := &MissingFieldError{Op: "*query.Filtering", Request: "*gateway.testResponse"}
+	if !reflect.DeepEqual(err, expected) {
+		t.Fatalf("invalid error: %v - expected: %v", err, expected)
+	}
+	if msg := "request *gateway.testResponse has no field of collection operator type *query.Filtering"; err.Error() != msg {
+		t.Errorf("invalid error message: %q - expected: %q", err.Error(), msg)
+	}
+}
+
+func TestQueryUnaryServerInterceptor(t *testing.T) {
+	var handled interface{}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		handled = req
+		return req, nil
+	}
+	interceptor := QueryUnaryServerInterceptor()
+
+	// no query URL in metadata
+	req := &testRequest{}
+	if _, err := interceptor(context.Background(), req, nil, handler); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if handled != req || req.Filtering != nil || req.Sorting != nil {
+		t.Errorf("invalid request: %v - expected: empty request", req)
+	}
+
+	md := metadata.Pairs(query_url, "http://app.com/v1/users?_filter=name=='John'&_order_by=name%20desc&_limit=10")
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+	req = &testRequest{}
+	if _, err := interceptor(ctx, req, nil, handler); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if handled != req {
+		t.Errorf("handler is not called with the request")
+	}
+	if req.Filtering.GetStringCondition().GetValue() != "John" {
+		t.Errorf("invalid filtering: %v - expected: name == 'John'", req.Filtering)
+	}
+	if c := req.Sorting.GetCriterias(); len(c) != 1 || c[0].GoString() != "name DESC" {
+		t.Errorf("invalid sorting: %v - expected: name DESC", req.Sorting)
+	}
+	if req.Pagination.GetLimit() != 10 {
+		t.Errorf("invalid pagination: %v - expected: limit 10", req.Pagination)
+	}
+
+	// invalid filter
+	handled = nil
+	md = metadata.Pairs(query_url, "http://app.com/v1/users?_filter=name==")
+	ctx = metadata.NewIncomingContext(context.Background(), md)
+	_, err := interceptor(ctx, &testRequest{}, nil, handler)
+	if s, ok := status.FromError(err); !ok || s.Code() != codes.InvalidArgument {
+		t.Errorf("invalid error: %v - expected: %s", err, codes.InvalidArgument)
+	}
+	if handled != nil {
+		t.Errorf("handler is called on invalid request")
+	}
+}
+
+func TestQueryUnaryServerInterceptorURLRewrite(t *testing.T) {
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return req, nil
+	}
+	var path string
+	interceptor := QueryUnaryServerInterceptorWithConfig(QueryParamConfig{
+		URLRewrite: func(u *url.URL) *url.URL {
+			path = u.Path
+			q := u.Query()
+			q.Set(FilterQueryKey, q.Get("filter"))
+			q.Del("filter")
+			u.RawQuery = q.Encode()
+			return u
+		},
+	})
+
+	md := metadata.Pairs(query_url, "http://app.com/proxy/v1/users?filter=name=='John'")
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+	req := &testRequest{}
+	if _, err := interceptor(ctx, req, nil, handler); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if path != "/proxy/v1/users" {
+		t.Errorf("invalid rewritten URL path: %q - expected: %q", path, "/proxy/v1/users")
+	}
+	if req.Filtering.GetStringCondition().GetValue() != "John" {
+		t.Errorf("invalid filtering: %v - expected: name == 'John'", req.Filtering)
+	}
+}
+
+func TestGetCollectionOps(t *testing.T) {
+	f := &query.Filtering{}
+	req := &testRequest{Filtering: f}
+
+	if !HasCollectionOp(req, f) {
+		t.Errorf("filtering is expected to be set")
+	}
+	if HasCollectionOp(req, &query.Sorting{}) {
+		t.Errorf("sorting is not expected to be set")
+	}
+	if HasCollectionOp(&testResponse{}, f) || HasCollectionOp(nil, f) || HasCollectionOp(testRequest{Filtering: f}, f) {
+		t.Errorf("filtering is not expected to be set in requests without it")
+	}
+
+	name, gf, err := GetFiltering(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if name != "Filtering" || gf == nil || !reflect.DeepEqual(gf, f) {
+		t.Errorf("invalid filtering: %s %v - expected: Filtering %v", name, gf, f)
+	}
+
+	name, s, err := GetSorting(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if name != "Sorting" || s == nil || len(s.GetCriterias()) != 0 {
+		t.Errorf("invalid sorting: %s %v - expected: Sorting {}", name, s)
+	}
+
+	name, fs, err := GetFieldSelection(&result{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if name != "" || fs != nil {
+		t.Errorf("invalid field selection: %q %v - expected: \"\" <nil>", name, fs)
+	}
+
+	if rf := RequestFiltering(req); rf == nil || !reflect.DeepEqual(rf, f) {
+		t.Errorf("invalid filtering: %v - expected: %v", rf, f)
+	}
+	if rs := RequestSorting(req); rs != nil {
+		t.Errorf("invalid sorting: %v - expected: <nil>", rs)
+	}
+	if rf := RequestFiltering(&result{}); rf != nil {
+		t.Errorf("invalid filtering: %v - expected: <nil>", rf)
+	}
+}
@@ -0,0 +1,70 @@
+package gateway
+
+// QueryParameter describes a query parameter carrying a collection operator
+// in the form of OpenAPI (Swagger 2.0) parameter object, so that it can be embedded
+// into generated API documentation.
+type QueryParameter struct {
+	Name        string `json:"name"`
+	In          string `json:"in"`
+	Type        string `json:"type"`
+	Format      string `json:"format,omitempty"`
+	Description string `json:"description"`
+}
+
+// QueryParameters returns descriptors of query parameters parsed by ParseQuery
+// with default keys, i.e. _filter, _order_by, _fields, _limit, _offset, _page_token and _count_only.
+func QueryParameters() []QueryParameter {
+	return QueryParametersWithConfig(QueryParamConfig{})
+}
+
+// QueryParametersWithConfig is the same as QueryParameters but uses query parameter keys from cfg,
+// so that documentation matches ParseQueryWithConfig.
+func QueryParametersWithConfig(cfg QueryParamConfig) []QueryParameter {
+	cfg = cfg.withDefaults()
+	return []QueryParameter{
+		{
+			Name:        cfg.FilterKey,
+			In:          "query",
+			Type:        "string",
+			Description: "A string expression containing JSON tags, literal values, and logical operators, e.g. first_name=='John' and age>=18.",
+		},
+		{
+			Name:        cfg.SortKey,
+			In:          "query",
+			Type:        "string",
+			Description: "A comma-separated list of JSON tag names with optional asc/desc suffixes, e.g. last_name desc,first_name.",
+		},
+		{
+			Name:        cfg.FieldsKey,
+			In:          "query",
+			Type:        "string",
+			Description: "A comma-separated list of JSON tag names to be returned, nested fields are separated by dots, e.g. name,address.city.",
+		},
+		{
+			Name:        cfg.LimitKey,
+			In:          "query",
+			Type:        "integer",
+			Format:      "int32",
+			Description: "The maximum number of resources to be returned.",
+		},
+		{
+			Name:        cfg.OffsetKey,
+			In:          "query",
+			Type:        "integer",
+			Format:      "int32",
+			Description: "The number of resources to be skipped from the beginning of the collection.",
+		},
+		{
+			Name:        cfg.PageTokenKey,
+			In:          "query",
+			Type:        "string",
+			Description: "A page token returned in the page info of the previous response to request the next page.",
+		},
+		{
+			Name:        cfg.CountOnlyKey,
+			In:          "query",
+			Type:        "boolean",
+			Description: "Whether only the total number of resources matching the request is to be returned without the resources.",
+		},
+	}
+}
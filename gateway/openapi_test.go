@@ -0,0 +1,32 @@
+package gateway
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestQueryParameters(t *testing.T) {
+	params := QueryParameters()
+	expected := []string{FilterQueryKey, SortQueryKey, FieldsQueryKey, LimitQueryKey, OffsetQueryKey, PageTokenQueryKey, CountOnlyQueryKey}
+	if len(params) != len(expected) {
+		t.Fatalf("invalid number of parameters: %d - expected: %d", len(params), len(expected))
+	}
+	for i, p := range params {
+		if p.Name != expected[i] || p.In != "query" || p.Description == "" {
+			t.Errorf("invalid parameter: %+v - expected: %s in query", p, expected[i])
+		}
+	}
+
+	data, err := json.Marshal(params[3])
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if s := string(data); s != `{"name":"_limit","in":"query","type":"integer","format":"int32","description":"The maximum number of resources to be returned."}` {
+		t.Errorf("invalid JSON: %s", s)
+	}
+
+	params = QueryParametersWithConfig(QueryParamConfig{FilterKey: "filter", LimitKey: "limit"})
+	if params[0].Name != "filter" || params[1].Name != SortQueryKey || params[3].Name != "limit" {
+		t.Errorf("invalid parameters: %+v - expected custom filter and limit keys", params)
+	}
+}
@@ -2,9 +2,13 @@ package gateway
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -12,6 +16,7 @@ import (
 	"google.golang.org/grpc/status"
 
 	"github.com/partitio/atlas-app-toolkit/query"
+	"github.com/partitio/atlas-app-toolkit/rpc/errdetails"
 )
 
 const (
@@ -21,9 +26,11 @@ const (
 	LimitQueryKey            = "_limit"
 	OffsetQueryKey           = "_offset"
 	PageTokenQueryKey        = "_page_token"
+	CountOnlyQueryKey        = "_count_only"
 	pageInfoSizeMetaKey      = "status-page-info-size"
 	pageInfoOffsetMetaKey    = "status-page-info-offset"
 	pageInfoPageTokenMetaKey = "status-page-info-page_token"
+	pageInfoTotalMetaKey     = "status-page-info-total"
 
 	query_url = "query_url"
 )
@@ -42,6 +49,18 @@ func MetadataAnnotator(ctx context.Context, req *http.Request) metadata.MD {
 // SetPagination sets page info to outgoing gRPC context.
 // Deprecated: Please add `infoblox.api.PageInfo` as part of gRPC message and do not rely on outgoing gRPC context.
 func SetPageInfo(ctx context.Context, p *query.PageInfo) error {
+	return grpc.SetHeader(ctx, pageInfoMetadata(p))
+}
+
+// SetPageInfoTrailer sets page info to gRPC trailer of ctx. It is meant for server-streaming RPCs
+// that know whether there are more pages only after all messages are sent, when headers are already sent.
+// See StreamTrailerClientInterceptor for how to surface it in REST responses.
+func SetPageInfoTrailer(ctx context.Context, p *query.PageInfo) error {
+	return grpc.SetTrailer(ctx, pageInfoMetadata(p))
+}
+
+// pageInfoMetadata returns gRPC metadata representation of page info p.
+func pageInfoMetadata(p *query.PageInfo) metadata.MD {
 	m := make(map[string]string)
 
 	if pt := p.GetPageToken(); pt != "" {
@@ -58,56 +77,365 @@ func SetPageInfo(ctx context.Context, p *query.PageInfo) error {
 		m[pageInfoSizeMetaKey] = strconv.FormatUint(uint64(s), 10)
 	}
 
-	return grpc.SetHeader(ctx, metadata.New(m))
+	// zero total is sent as well since it differs from the unknown one
+	if t, ok := p.Total(); ok {
+		m[pageInfoTotalMetaKey] = strconv.FormatInt(int64(t), 10)
+	}
+
+	return metadata.New(m)
+}
+
+// QueryParamConfig overrides query parameter keys used to pass collection operators
+// and limits applied to them. Empty keys default to the corresponding *QueryKey constants.
+type QueryParamConfig struct {
+	FilterKey    string
+	SortKey      string
+	FieldsKey    string
+	LimitKey     string
+	OffsetKey    string
+	PageTokenKey string
+	CountOnlyKey string
+
+	// FilteringLimits restricts complexity of filtering expressions,
+	// zero limits default to query.DefaultFilteringLimits.
+	FilteringLimits query.FilteringLimits
+
+	// SelectionLimits restricts the number of selected fields and sort criterias,
+	// zero maximums mean there is no maximum.
+	SelectionLimits query.SelectionLimits
+
+	// DefaultSorting returns sorting of request req applied if the client does not sort, e.g. by the primary key,
+	// so that pagination is stable. Its criterias are appended to the client sorting as tiebreakers as well.
+	// nil means there is no default sorting, as well as nil returned for a request.
+	DefaultSorting DefaultSortingFunc
+
+	// PageTokenKeys are keys page tokens are signed with by query.SignPageToken, the first one is the current key
+	// and the rest are old keys accepted during key rotation. If set, page tokens are verified as by
+	// query.RequireSignedPageToken and the request gets the original unsigned token, which is decoded as a cursor.
+	// Otherwise signed page tokens are never decoded as cursors.
+	PageTokenKeys [][]byte
+
+	// MaxLimit is the maximum pagination limit, requests exceeding it are rejected.
+	// Zero means there is no maximum.
+	MaxLimit int32
+
+	// AllErrors makes all query parameters to be parsed even if some of them are invalid,
+	// so that InvalidQueryError lists all of the invalid ones.
+	// Otherwise parsing stops at the first invalid parameter.
+	AllErrors bool
+
+	// URLRewrite transforms the request URL before query parameters are extracted from it,
+	// nil means the URL is used as is.
+	URLRewrite URLRewriteFunc
+}
+
+// DefaultSortingFunc returns the default sorting of request req, e.g. depending on its type
+// to sort resources of each endpoint by their primary key. Requests without a field of *query.Sorting type are left as is.
+type DefaultSortingFunc func(req interface{}) *query.Sorting
+
+// URLRewriteFunc transforms the request URL before collection operators are parsed from it,
+// e.g. it strips a path prefix added by a reverse proxy or renames deprecated query parameters.
+// The function may modify the passed URL in place. If it returns nil the original URL is used.
+type URLRewriteFunc func(*url.URL) *url.URL
+
+// rewriteURL applies cfg.URLRewrite to u.
+func (cfg QueryParamConfig) rewriteURL(u *url.URL) *url.URL {
+	if cfg.URLRewrite == nil {
+		return u
+	}
+	if r := cfg.URLRewrite(u); r != nil {
+		return r
+	}
+	return u
+}
+
+// withDefaults returns a copy of cfg with empty keys set to the default ones.
+func (cfg QueryParamConfig) withDefaults() QueryParamConfig {
+	if cfg.FilterKey == "" {
+		cfg.FilterKey = FilterQueryKey
+	}
+	if cfg.SortKey == "" {
+		cfg.SortKey = SortQueryKey
+	}
+	if cfg.FieldsKey == "" {
+		cfg.FieldsKey = FieldsQueryKey
+	}
+	if cfg.LimitKey == "" {
+		cfg.LimitKey = LimitQueryKey
+	}
+	if cfg.OffsetKey == "" {
+		cfg.OffsetKey = OffsetQueryKey
+	}
+	if cfg.PageTokenKey == "" {
+		cfg.PageTokenKey = PageTokenQueryKey
+	}
+	if cfg.CountOnlyKey == "" {
+		cfg.CountOnlyKey = CountOnlyQueryKey
+	}
+	return cfg
+}
+
+// keys returns all query parameter keys of cfg.
+func (cfg QueryParamConfig) keys() []string {
+	cfg = cfg.withDefaults()
+	return []string{cfg.FilterKey, cfg.SortKey, cfg.FieldsKey, cfg.LimitKey, cfg.OffsetKey, cfg.PageTokenKey, cfg.CountOnlyKey}
+}
+
+// setCollectionOps is the same as SetCollectionOps but returns MissingFieldError
+// as an internal error since it is caused by a request message definition.
+func setCollectionOps(req, op interface{}) error {
+	err := SetCollectionOps(req, op)
+	if _, ok := err.(*MissingFieldError); ok {
+		return status.Error(codes.Internal, err.Error())
+	}
+	return err
 }
 
+// invalidQueryError returns InvalidArgument error with err as a detail targeting query parameter key.
+func invalidQueryError(key string, err error) error {
+	st, derr := status.New(codes.InvalidArgument, err.Error()).WithDetails(errdetails.New(codes.InvalidArgument, key, err.Error()))
+	if derr != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return st.Err()
+}
+
+// InvalidQueryError lists all invalid query parameters of a request, it is returned
+// by ParseQueryWithConfig if QueryParamConfig.AllErrors is set.
+// It is converted to InvalidArgument gRPC status with a detail per invalid parameter.
+type InvalidQueryError struct {
+	Details []*errdetails.TargetInfo
+}
+
+func (e *InvalidQueryError) add(key string, err error) {
+	e.Details = append(e.Details, errdetails.New(codes.InvalidArgument, key, err.Error()))
+}
+
+func (e *InvalidQueryError) Error() string {
+	msgs := make([]string, len(e.Details))
+	for i, d := range e.Details {
+		msgs[i] = fmt.Sprintf("%s - %s", d.GetTarget(), d.GetMessage())
+	}
+	return fmt.Sprintf("invalid query parameters: %s", strings.Join(msgs, "; "))
+}
+
+// GetDetails returns a detail per invalid query parameter.
+func (e *InvalidQueryError) GetDetails() []*errdetails.TargetInfo {
+	return e.Details
+}
+
+// GRPCStatus returns InvalidArgument gRPC status of e with its details.
+func (e *InvalidQueryError) GRPCStatus() *status.Status {
+	st := status.New(codes.InvalidArgument, e.Error())
+	details := make([]proto.Message, len(e.Details))
+	for i, d := range e.Details {
+		details[i] = d
+	}
+	if dst, err := st.WithDetails(details...); err == nil {
+		return dst
+	}
+	return st
+}
+
+// ParseQuery parses collection operators from query parameters vals
+// using default keys and stores them in corresponding fields of req.
 func ParseQuery(req interface{}, vals url.Values) (err error) {
-	// extracts "_order_by" parameters from request
-	if v := vals.Get(SortQueryKey); v != "" {
-		s, err := query.ParseSorting(v)
-		if err != nil {
-			return status.Error(codes.InvalidArgument, err.Error())
+	return ParseQueryWithConfig(req, vals, QueryParamConfig{})
+}
+
+// ParseQueryWithConfig is the same as ParseQuery but uses query parameter keys from cfg.
+// If cfg.AllErrors is set then InvalidQueryError listing all invalid parameters is returned,
+// otherwise the error describes the first invalid parameter.
+// If cfg.URLRewrite is set it is applied to a URL holding vals as its query before parsing.
+func ParseQueryWithConfig(req interface{}, vals url.Values, cfg QueryParamConfig) (err error) {
+	cfg = cfg.withDefaults()
+	if cfg.URLRewrite != nil {
+		vals = cfg.rewriteURL(&url.URL{RawQuery: vals.Encode()}).Query()
+	}
+	qerr := &InvalidQueryError{}
+	// invalid returns an error for invalid query parameter key
+	// or records it if all errors are collected
+	invalid := func(key string, err error) error {
+		if cfg.AllErrors {
+			qerr.add(key, err)
+			return nil
 		}
-		err = SetCollectionOps(req, s)
-		if err != nil {
+		return invalidQueryError(key, err)
+	}
+
+	// extracts "_order_by" parameters from request, the default sorting is merged into it
+	var defaultSorting *query.Sorting
+	if cfg.DefaultSorting != nil {
+		defaultSorting = cfg.DefaultSorting(req)
+	}
+	if v := vals.Get(cfg.SortKey); v != "" {
+		if s, err := query.ParseSortingWithLimits(v, cfg.SelectionLimits); err != nil {
+			if err := invalid(cfg.SortKey, err); err != nil {
+				return err
+			}
+		} else if err := setCollectionOps(req, query.MergeSorting(s, defaultSorting)); err != nil {
 			return err
 		}
+	} else if len(defaultSorting.GetCriterias()) > 0 {
+		if err := SetCollectionOps(req, query.MergeSorting(nil, defaultSorting)); err != nil {
+			if _, ok := err.(*MissingFieldError); !ok {
+				return err
+			}
+		}
 	}
 	// extracts "_fields" parameters from request
-	if v := vals.Get(FieldsQueryKey); v != "" {
-		fs := query.ParseFieldSelection(v)
-		err := SetCollectionOps(req, fs)
-		if err != nil {
+	if v := vals.Get(cfg.FieldsKey); v != "" {
+		if fs, err := query.ParseFieldSelectionWithLimits(v, cfg.SelectionLimits); err != nil {
+			if err := invalid(cfg.FieldsKey, err); err != nil {
+				return err
+			}
+		} else if err := setCollectionOps(req, fs); err != nil {
 			return err
 		}
 	}
 
-	// extracts "_filter" parameters from request
-	if v := vals.Get(FilterQueryKey); v != "" {
-		f, err := query.ParseFiltering(v)
-		if err != nil {
-			return status.Error(codes.InvalidArgument, err.Error())
+	// extracts "_filter" parameters from request, repeated parameters are combined with "and"
+	if f, err := parseFilters(vals[cfg.FilterKey], cfg.FilteringLimits); err != nil {
+		if err := invalid(cfg.FilterKey, err); err != nil {
+			return err
 		}
-
-		err = SetCollectionOps(req, f)
-		if err != nil {
+	} else if f != nil {
+		if err := setCollectionOps(req, f); err != nil {
 			return err
 		}
 	}
 
+	// extracts "_count_only" parameter from request, it is set only if enabled
+	// so that requests without count only support accept "_count_only=false"
+	if v := vals.Get(cfg.CountOnlyKey); v != "" {
+		if c, err := query.ParseCountOnly(v); err != nil {
+			if err := invalid(cfg.CountOnlyKey, err); err != nil {
+				return err
+			}
+		} else if c.GetEnabled() {
+			if err := setCollectionOps(req, c); err != nil {
+				return err
+			}
+		}
+	}
+
 	// extracts "_limit", "_offset",  "_page_token" parameters from request
 	var p *query.Pagination
-	l := vals.Get(LimitQueryKey)
-	o := vals.Get(OffsetQueryKey)
-	pt := vals.Get(PageTokenQueryKey)
+	l := vals.Get(cfg.LimitKey)
+	o := vals.Get(cfg.OffsetKey)
+	pt := vals.Get(cfg.PageTokenKey)
 
-	p, err = query.ParsePagination(l, o, pt)
+	var popts []query.PaginationOption
+	if len(cfg.PageTokenKeys) > 0 {
+		popts = append(popts, query.RequireSignedPageToken(cfg.PageTokenKeys[0], cfg.PageTokenKeys[1:]...))
+	}
+	p, err = query.ParsePagination(l, o, pt, popts...)
 	if err != nil {
-		return status.Error(codes.InvalidArgument, err.Error())
+		if !cfg.AllErrors {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		// limit and offset are parsed separately to report both of them
+		_, lerr := query.ParsePagination(l, "", "")
+		_, oerr := query.ParsePagination("", o, "")
+		if lerr != nil {
+			invalid(cfg.LimitKey, lerr)
+		}
+		if oerr != nil {
+			invalid(cfg.OffsetKey, oerr)
+		}
+		if lerr == nil && oerr == nil {
+			invalid(cfg.PageTokenKey, err)
+		}
+		return qerr
+	}
+	if err := p.Validate(cfg.MaxLimit); err != nil {
+		key := cfg.LimitKey
+		if perr, ok := err.(*query.InvalidPaginationError); ok {
+			switch perr.Field {
+			case "offset":
+				key = cfg.OffsetKey
+			case "page_token":
+				key = cfg.PageTokenKey
+			}
+		}
+		if err := invalid(key, err); err != nil {
+			return err
+		}
+		return qerr
 	}
 	err = SetCollectionOps(req, p)
+	if _, ok := err.(*MissingFieldError); ok {
+		// pagination is optional unless it is requested explicitly
+		if l == "" && o == "" && pt == "" {
+			err = nil
+		} else {
+			err = status.Error(codes.Internal, err.Error())
+		}
+	}
 	if err != nil {
 		return err
 	}
+
+	// page token that is a cursor is translated to filtering
+	// which selects resources following the cursor
+	if c, cerr := query.DecodeCursor(p.GetPageToken()); pt != "" && cerr == nil && len(qerr.Details) == 0 {
+		cf, err := cursorFiltering(req, c, cfg.FilteringLimits)
+		if err != nil {
+			if err := invalid(cfg.PageTokenKey, err); err != nil {
+				return err
+			}
+			return qerr
+		}
+		f := new(query.Filtering)
+		if _, err := getAndUnsetOp(req, f, false); err != nil {
+			return err
+		}
+		err = setCollectionOps(req, query.AndFilterings(f, cf))
+		if err != nil {
+			return err
+		}
+	}
+	if len(qerr.Details) > 0 {
+		return qerr
+	}
 	return nil
 }
+
+// cursorFiltering returns filtering which selects resources following cursor c. Keys of the cursor must
+// match sorting of request req and the filtering is subject to limits as filtering supplied by the client is.
+func cursorFiltering(req interface{}, c *query.Cursor, limits query.FilteringLimits) (*query.Filtering, error) {
+	s := new(query.Sorting)
+	if _, err := getAndUnsetOp(req, s, false); err != nil {
+		return nil, err
+	}
+	if err := c.MatchSorting(s); err != nil {
+		return nil, err
+	}
+	f, err := c.Filtering()
+	if err != nil {
+		return nil, err
+	}
+	if err := limits.Check(f); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// parseFilters parses every value of a repeated filter parameter and combines them
+// with "and" in the order of occurrence. Empty values are ignored the same way as
+// a single empty parameter, so nil is returned if there are no non-empty values.
+// Limits are applied to each value separately.
+func parseFilters(values []string, limits query.FilteringLimits) (*query.Filtering, error) {
+	var res *query.Filtering
+	for _, v := range values {
+		if v == "" {
+			continue
+		}
+		f, err := query.ParseFilteringWithLimits(v, limits)
+		if err != nil {
+			return nil, err
+		}
+		res = query.AndFilterings(res, f)
+	}
+	return res, nil
+}
@@ -0,0 +1,542 @@
+package gateway
+
+import (
+	"context"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/partitio/atlas-app-toolkit/query"
+	"github.com/partitio/atlas-app-toolkit/rpc/errdetails"
+)
+
+func TestParseQueryWithConfig(t *testing.T) {
+	cfg := QueryParamConfig{
+		FilterKey: "filter",
+		SortKey:   "sort",
+		FieldsKey: "fields",
+		LimitKey:  "limit",
+	}
+	vals := url.Values{
+		"filter":       {"name == 'John'"},
+		"sort":         {"name desc"},
+		"fields":       {"name,age"},
+		"limit":        {"20"},
+		"_offset":      {"10"},
+		"_filter":      {"ignored"},
+		"someparam":    {"1"},
+		"_order_by":    {"age asc"},
+		"_fields":      {"id"},
+		"_limit":       {"1"},
+		"unrecognized": {"1"},
+	}
+
+	req := &testRequest{}
+	if err := ParseQueryWithConfig(req, vals, cfg); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if req.Filtering == nil || req.Filtering.GetStringCondition().GetValue() != "John" {
+		t.Errorf("invalid filtering: %v - expected: name == 'John'", req.Filtering)
+	}
+	if c := req.Sorting.GetCriterias(); len(c) != 1 || c[0].GoString() != "name DESC" {
+		t.Errorf("invalid sorting: %v - expected: name DESC", req.Sorting)
+	}
+	if fs := req.FieldSelection.GetFields(); len(fs) != 2 || fs["name"] == nil || fs["age"] == nil {
+		t.Errorf("invalid field selection: %v - expected: name,age", req.FieldSelection)
+	}
+	if p := req.Pagination; p.GetLimit() != 20 || p.GetOffset() != 10 {
+		t.Errorf("invalid pagination: %v - expected: limit 20, offset 10", p)
+	}
+
+	// offset and page token are mutually exclusive
+	err := ParseQueryWithConfig(&testRequest{}, url.Values{"_offset": {"10"}, "_page_token": {"ptoken"}}, cfg)
+	if s, ok := status.FromError(err); !ok || s.Code() != codes.InvalidArgument {
+		t.Errorf("invalid error: %v - expected: %s", err, codes.InvalidArgument)
+	}
+
+	// invalid filter under custom key
+	err = ParseQueryWithConfig(&testRequest{}, url.Values{"filter": {"name =="}}, cfg)
+	if s, ok := status.FromError(err); !ok || s.Code() != codes.InvalidArgument {
+		t.Errorf("invalid error: %v - expected: %s", err, codes.InvalidArgument)
+	}
+
+	// mixed inclusion and exclusion of fields
+	err = ParseQueryWithConfig(&testRequest{}, url.Values{"fields": {"name,-age"}}, cfg)
+	if s, ok := status.FromError(err); !ok || s.Code() != codes.InvalidArgument {
+		t.Errorf("invalid error: %v - expected: %s", err, codes.InvalidArgument)
+	}
+
+	// default keys are used if config is empty
+	req = &testRequest{}
+	if err := ParseQueryWithConfig(req, url.Values{"_filter": {"name == 'John'"}, "filter": {"age == 1"}}, QueryParamConfig{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if req.Filtering.GetStringCondition().GetValue() != "John" {
+		t.Errorf("invalid filtering: %v - expected: name == 'John'", req.Filtering)
+	}
+}
+
+func TestParseQueryFilteringLimits(t *testing.T) {
+	vals := url.Values{FilterQueryKey: {"a == 1 or b == 2 or c == 3"}}
+
+	req := &testRequest{}
+	if err := ParseQueryWithConfig(req, vals, QueryParamConfig{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if req.Filtering == nil {
+		t.Errorf("invalid filtering: %v - expected: a == 1 or b == 2 or c == 3", req.Filtering)
+	}
+
+	cfg := QueryParamConfig{FilteringLimits: query.FilteringLimits{MaxNodes: 3}}
+	err := ParseQueryWithConfig(&testRequest{}, vals, cfg)
+	if s, ok := status.FromError(err); !ok || s.Code() != codes.InvalidArgument {
+		t.Errorf("invalid error: %v - expected: %s", err, codes.InvalidArgument)
+	}
+}
+
+func TestParseQuerySelectionLimits(t *testing.T) {
+	vals := url.Values{SortQueryKey: {"name, age desc, name"}, FieldsQueryKey: {"name,age"}}
+	cfg := QueryParamConfig{SelectionLimits: query.SelectionLimits{MaxFields: 2, MaxSortCriterias: 2}}
+
+	req := &testRequest{}
+	if err := ParseQueryWithConfig(req, vals, cfg); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(req.Sorting.GetCriterias()) != 3 || len(req.FieldSelection.GetFields()) != 2 {
+		t.Errorf("invalid sorting or field selection: %v, %v", req.Sorting, req.FieldSelection)
+	}
+
+	tests := []struct {
+		vals url.Values
+		cfg  QueryParamConfig
+		key  string
+	}{
+		{url.Values{FieldsQueryKey: {"name,age,id"}}, cfg, FieldsQueryKey},
+		{url.Values{SortQueryKey: {"name, age, id"}}, cfg, SortQueryKey},
+		{vals, QueryParamConfig{SelectionLimits: query.SelectionLimits{RejectDuplicates: true}}, SortQueryKey},
+		{url.Values{FieldsQueryKey: {"id,id"}}, QueryParamConfig{SelectionLimits: query.SelectionLimits{RejectDuplicates: true}}, FieldsQueryKey},
+	}
+	for _, test := range tests {
+		err := ParseQueryWithConfig(&testRequest{}, test.vals, test.cfg)
+		s, ok := status.FromError(err)
+		if !ok || s.Code() != codes.InvalidArgument {
+			t.Errorf("invalid error for %v: %v - expected: %s", test.vals, err, codes.InvalidArgument)
+			continue
+		}
+		if len(s.Details()) != 1 {
+			t.Errorf("invalid error details for %v: %v - expected: 1 detail", test.vals, s.Details())
+			continue
+		}
+		if d, ok := s.Details()[0].(*errdetails.TargetInfo); !ok || d.GetTarget() != test.key {
+			t.Errorf("invalid error detail for %v: %v - expected target: %s", test.vals, s.Details()[0], test.key)
+		}
+	}
+}
+
+func TestParseQueryDefaultSorting(t *testing.T) {
+	byID := &query.Sorting{Criterias: []*query.SortCriteria{{Tag: "id", Order: query.SortCriteria_ASC}}}
+	cfg := QueryParamConfig{DefaultSorting: func(req interface{}) *query.Sorting {
+		if _, ok := req.(*testRequest); ok {
+			return byID
+		}
+		return nil
+	}}
+
+	tests := []struct {
+		vals     url.Values
+		expected string
+	}{
+		{url.Values{}, "id ASC"},
+		{url.Values{SortQueryKey: {"name desc"}}, "name DESC, id ASC"},
+		{url.Values{SortQueryKey: {"id desc"}}, "id DESC"},
+	}
+	for _, test := range tests {
+		req := &testRequest{}
+		if err := ParseQueryWithConfig(req, test.vals, cfg); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if s := req.Sorting.GoString(); s != test.expected {
+			t.Errorf("invalid sorting for %v: %s - expected: %s", test.vals, s, test.expected)
+		}
+	}
+	if len(byID.Criterias) != 1 || byID.Criterias[0].GetOrder() != query.SortCriteria_ASC {
+		t.Errorf("default sorting is modified: %v", byID)
+	}
+
+	// requests without sorting are left as is
+	cfg.DefaultSorting = func(interface{}) *query.Sorting { return byID }
+	if err := ParseQueryWithConfig(&testResponse{}, url.Values{}, cfg); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	// invalid client sorting is rejected rather than replaced with the default one
+	err := ParseQueryWithConfig(&testRequest{}, url.Values{SortQueryKey: {"name up"}}, cfg)
+	if s, ok := status.FromError(err); !ok || s.Code() != codes.InvalidArgument {
+		t.Errorf("invalid error: %v - expected: %s", err, codes.InvalidArgument)
+	}
+}
+
+func TestParseQueryRepeatedFilter(t *testing.T) {
+	vals := url.Values{FilterQueryKey: {"a == 1", "", "b == 2 or c == 3"}}
+	req := &testRequest{}
+	if err := ParseQuery(req, vals); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if s, expected := req.Filtering.GoString(), "(a == 1 and (b == 2 or c == 3))"; s != expected {
+		t.Errorf("invalid filtering: %s - expected: %s", s, expected)
+	}
+
+	req = &testRequest{}
+	if err := ParseQuery(req, url.Values{FilterQueryKey: {"", ""}}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if req.Filtering != nil {
+		t.Errorf("invalid filtering: %v - expected: nil", req.Filtering)
+	}
+
+	err := ParseQuery(&testRequest{}, url.Values{FilterQueryKey: {"a == 1", "b =="}})
+	if s, ok := status.FromError(err); !ok || s.Code() != codes.InvalidArgument {
+		t.Errorf("invalid error: %v - expected: %s", err, codes.InvalidArgument)
+	}
+}
+
+func TestParseQueryMaxLimit(t *testing.T) {
+	cfg := QueryParamConfig{MaxLimit: 100}
+	req := &testRequest{}
+	if err := ParseQueryWithConfig(req, url.Values{LimitQueryKey: {"100"}}, cfg); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if req.Pagination.GetLimit() != 100 {
+		t.Errorf("invalid pagination: %v - expected: limit 100", req.Pagination)
+	}
+
+	err := ParseQueryWithConfig(&testRequest{}, url.Values{LimitQueryKey: {"101"}}, cfg)
+	s, ok := status.FromError(err)
+	if !ok || s.Code() != codes.InvalidArgument {
+		t.Fatalf("invalid error: %v - expected: %s", err, codes.InvalidArgument)
+	}
+	if s.Message() != "pagination: limit - exceeds maximum value 100" {
+		t.Errorf("invalid error message: %q", s.Message())
+	}
+}
+
+func TestParseQueryFilteringError(t *testing.T) {
+	vals := url.Values{FilterQueryKey: {"name == 'John' and == 5"}}
+	err := ParseQuery(&testRequest{}, vals)
+	s, ok := status.FromError(err)
+	if !ok || s.Code() != codes.InvalidArgument {
+		t.Fatalf("invalid error: %v - expected: %s", err, codes.InvalidArgument)
+	}
+	if expected := `invalid filter at position 19: unexpected operator "=="`; s.Message() != expected {
+		t.Errorf("invalid error message: %q - expected: %q", s.Message(), expected)
+	}
+}
+
+func TestParseQueryAllErrors(t *testing.T) {
+	vals := url.Values{
+		FilterQueryKey: {"name =="},
+		SortQueryKey:   {"name up"},
+		LimitQueryKey:  {"-1"},
+		OffsetQueryKey: {"x"},
+	}
+
+	// the first error is returned by default
+	err := ParseQuery(&testRequest{}, vals)
+	if s, ok := status.FromError(err); !ok || s.Code() != codes.InvalidArgument || len(s.Details()) != 1 {
+		t.Fatalf("invalid error: %v - expected: %s with single detail", err, codes.InvalidArgument)
+	}
+
+	err = ParseQueryWithConfig(&testRequest{}, vals, QueryParamConfig{AllErrors: true})
+	qerr, ok := err.(*InvalidQueryError)
+	if !ok {
+		t.Fatalf("invalid error: %T - expected: %T", err, &InvalidQueryError{})
+	}
+	var targets []string
+	for _, d := range qerr.GetDetails() {
+		targets = append(targets, d.GetTarget())
+	}
+	expected := []string{SortQueryKey, FilterQueryKey, LimitQueryKey, OffsetQueryKey}
+	if strings.Join(targets, ",") != strings.Join(expected, ",") {
+		t.Errorf("invalid error targets: %v - expected: %v", targets, expected)
+	}
+
+	s, ok := status.FromError(err)
+	if !ok || s.Code() != codes.InvalidArgument {
+		t.Fatalf("invalid status: %v - expected: %s", s, codes.InvalidArgument)
+	}
+	if len(s.Details()) != len(expected) {
+		t.Errorf("invalid number of status details: %d - expected: %d", len(s.Details()), len(expected))
+	}
+	if !strings.HasPrefix(s.Message(), "invalid query parameters: _order_by - ") {
+		t.Errorf("invalid status message: %q", s.Message())
+	}
+
+	// mutually exclusive offset and page token
+	err = ParseQueryWithConfig(&testRequest{}, url.Values{OffsetQueryKey: {"10"}, PageTokenQueryKey: {"ptoken"}}, QueryParamConfig{AllErrors: true})
+	if qerr, ok := err.(*InvalidQueryError); !ok || len(qerr.Details) != 1 || qerr.Details[0].GetTarget() != PageTokenQueryKey {
+		t.Errorf("invalid error: %v - expected: invalid %s", err, PageTokenQueryKey)
+	}
+
+	if err := ParseQueryWithConfig(&testRequest{}, url.Values{FilterQueryKey: {"name == 'John'"}}, QueryParamConfig{AllErrors: true}); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestParseQueryMissingField(t *testing.T) {
+	// pagination is not required if it is not requested
+	if err := ParseQuery(&testResponse{}, url.Values{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for _, vals := range []url.Values{{FilterQueryKey: {"name == 'John'"}}, {LimitQueryKey: {"10"}}} {
+		err := ParseQuery(&testResponse{}, vals)
+		s, ok := status.FromError(err)
+		if !ok || s.Code() != codes.Internal {
+			t.Fatalf("invalid error: %v - expected: %s", err, codes.Internal)
+		}
+		if !strings.HasPrefix(s.Message(), "request *gateway.testResponse has no field of collection operator type") {
+			t.Errorf("invalid error message: %q", s.Message())
+		}
+	}
+}
+
+func TestParseQueryCountOnly(t *testing.T) {
+	type countRequest struct {
+		Filtering *query.Filtering
+		CountOnly *query.CountOnly
+	}
+
+	req := &countRequest{}
+	if err := ParseQuery(req, url.Values{FilterQueryKey: {"name == 'John'"}, CountOnlyQueryKey: {"true"}}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !req.CountOnly.GetEnabled() || req.Filtering == nil {
+		t.Errorf("invalid request: %+v - expected: count only with filtering", req)
+	}
+
+	// disabled count only is not required to be supported
+	if err := ParseQuery(&testRequest{}, url.Values{CountOnlyQueryKey: {"false"}}); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	err := ParseQuery(&testRequest{}, url.Values{CountOnlyQueryKey: {"true"}})
+	if s, ok := status.FromError(err); !ok || s.Code() != codes.Internal {
+		t.Errorf("invalid error: %v - expected: %s", err, codes.Internal)
+	}
+
+	err = ParseQuery(&countRequest{}, url.Values{CountOnlyQueryKey: {"yes"}})
+	if s, ok := status.FromError(err); !ok || s.Code() != codes.InvalidArgument {
+		t.Errorf("invalid error: %v - expected: %s", err, codes.InvalidArgument)
+	}
+}
+
+func TestSetPageInfoCountOnly(t *testing.T) {
+	stream := new(testServerTransportStream)
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+	if err := SetPageInfo(ctx, query.NewCountOnlyPageInfo(42)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if v := stream.header.Get(pageInfoTotalMetaKey); len(v) != 1 || v[0] != "42" {
+		t.Errorf("invalid total header: %v - expected: 42", v)
+	}
+	for _, k := range []string{pageInfoSizeMetaKey, pageInfoOffsetMetaKey, pageInfoPageTokenMetaKey} {
+		if v := stream.header.Get(k); len(v) != 0 {
+			t.Errorf("invalid %s header: %v - expected: none", k, v)
+		}
+	}
+}
+
+func TestParseQueryParametersWithConfig(t *testing.T) {
+	handled, err := ParseQueryParametersWithConfig(QueryParamConfig{SortKey: "sort"})(&query.PageInfo{}, url.Values{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for _, k := range []string{"sort", FilterQueryKey, FieldsQueryKey, LimitQueryKey, OffsetQueryKey, PageTokenQueryKey, CountOnlyQueryKey} {
+		if !handled[k] {
+			t.Errorf("%s query parameter is not handled", k)
+		}
+	}
+	if handled[SortQueryKey] {
+		t.Errorf("%s query parameter is handled", SortQueryKey)
+	}
+}
+
+func TestParseQueryURLRewrite(t *testing.T) {
+	cfg := QueryParamConfig{
+		URLRewrite: func(u *url.URL) *url.URL {
+			q := u.Query()
+			if v := q.Get("limit"); v != "" {
+				q.Set(LimitQueryKey, v)
+			}
+			return &url.URL{RawQuery: q.Encode()}
+		},
+	}
+	req := &testRequest{}
+	if err := ParseQueryWithConfig(req, url.Values{"limit": {"5"}, OffsetQueryKey: {"10"}}, cfg); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if req.Pagination.GetLimit() != 5 || req.Pagination.GetOffset() != 10 {
+		t.Errorf("invalid pagination: %v - expected: limit 5 offset 10", req.Pagination)
+	}
+
+	// nil result leaves the URL unchanged
+	cfg.URLRewrite = func(*url.URL) *url.URL { return nil }
+	req = &testRequest{}
+	if err := ParseQueryWithConfig(req, url.Values{LimitQueryKey: {"7"}}, cfg); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if req.Pagination.GetLimit() != 7 {
+		t.Errorf("invalid pagination: %v - expected: limit 7", req.Pagination)
+	}
+}
+
+func TestParseQueryCursor(t *testing.T) {
+	pt, err := query.EncodeCursor(&query.Cursor{Keys: []query.CursorKey{{Field: "id", Value: 42}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	req := &testRequest{}
+	if err := ParseQuery(req, url.Values{"_page_token": {pt}, "_filter": {"name == 'John'"}, "_order_by": {"id"}}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	exp, _ := query.ParseFiltering("name == 'John' and id > 42")
+	if !reflect.DeepEqual(req.Filtering, exp) {
+		t.Errorf("invalid filtering: %v - expected: %v", req.Filtering, exp)
+	}
+	if req.Pagination.GetPageToken() != pt {
+		t.Errorf("invalid page token: %s - expected: %s", req.Pagination.GetPageToken(), pt)
+	}
+
+	// opaque page token is left as is
+	req = &testRequest{}
+	if err := ParseQuery(req, url.Values{"_page_token": {"MTI6MzQ="}}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if req.Filtering != nil {
+		t.Errorf("invalid filtering: %v - expected: nil", req.Filtering)
+	}
+
+	// keys of the cursor must match sorting
+	for _, sort := range []string{"", "id desc", "name"} {
+		req = &testRequest{}
+		err := ParseQuery(req, url.Values{"_page_token": {pt}, "_order_by": {sort}})
+		if s, ok := status.FromError(err); !ok || s.Code() != codes.InvalidArgument {
+			t.Errorf("sorting %q: invalid error: %v - expected: %s", sort, err, codes.InvalidArgument)
+		}
+	}
+
+	// cursor with too many keys is not translated to filtering
+	keys := make([]query.CursorKey, query.MaxCursorKeys+1)
+	for i := range keys {
+		keys[i] = query.CursorKey{Field: "id", Value: i}
+	}
+	big, _ := query.EncodeCursor(&query.Cursor{Keys: keys})
+	req = &testRequest{}
+	if err := ParseQuery(req, url.Values{"_page_token": {big}, "_order_by": {"id"}}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if req.Filtering != nil {
+		t.Errorf("invalid filtering: %v - expected: nil", req.Filtering)
+	}
+
+	// filtering made from the cursor is subject to filtering limits
+	two, _ := query.EncodeCursor(&query.Cursor{Keys: []query.CursorKey{{Field: "name", Value: "John"}, {Field: "id", Value: 42}}})
+	cfg := QueryParamConfig{FilteringLimits: query.FilteringLimits{MaxNodes: 3}}
+	err = ParseQueryWithConfig(&testRequest{}, url.Values{"_page_token": {two}, "_order_by": {"name"}}, cfg)
+	if s, ok := status.FromError(err); !ok || s.Code() != codes.InvalidArgument {
+		t.Errorf("invalid error: %v - expected: %s", err, codes.InvalidArgument)
+	}
+}
+
+func TestParseQuerySignedCursor(t *testing.T) {
+	key, oldKey := []byte("key"), []byte("old key")
+	pt, err := query.EncodeCursor(&query.Cursor{Keys: []query.CursorKey{{Field: "id", Value: 42}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	cfg := QueryParamConfig{PageTokenKeys: [][]byte{key, oldKey}}
+
+	for _, k := range [][]byte{key, oldKey} {
+		signed := query.SignPageToken(pt, k)
+		req := &testRequest{}
+		if err := ParseQueryWithConfig(req, url.Values{"_page_token": {signed}, "_order_by": {"id"}}, cfg); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		exp, _ := query.ParseFiltering("id > 42")
+		if !reflect.DeepEqual(req.Filtering, exp) {
+			t.Errorf("invalid filtering: %v - expected: %v", req.Filtering, exp)
+		}
+		if req.Pagination.GetPageToken() != pt {
+			t.Errorf("invalid page token: %s - expected: %s", req.Pagination.GetPageToken(), pt)
+		}
+	}
+
+	for _, token := range []string{pt, query.SignPageToken(pt, []byte("other key"))} {
+		err := ParseQueryWithConfig(&testRequest{}, url.Values{"_page_token": {token}, "_order_by": {"id"}}, cfg)
+		if s, ok := status.FromError(err); !ok || s.Code() != codes.InvalidArgument {
+			t.Errorf("token %q: invalid error: %v - expected: %s", token, err, codes.InvalidArgument)
+		}
+	}
+
+	// without keys signed cursor is an opaque page token
+	req := &testRequest{}
+	if err := ParseQuery(req, url.Values{"_page_token": {query.SignPageToken(pt, key)}, "_order_by": {"id"}}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if req.Filtering != nil {
+		t.Errorf("invalid filtering: %v - expected: nil", req.Filtering)
+	}
+}
+
+type testServerTransportStream struct {
+	header  metadata.MD
+	trailer metadata.MD
+}
+
+func (s *testServerTransportStream) Method() string { return "" }
+
+func (s *testServerTransportStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func (s *testServerTransportStream) SendHeader(md metadata.MD) error { return s.SetHeader(md) }
+
+func (s *testServerTransportStream) SetTrailer(md metadata.MD) error {
+	s.trailer = metadata.Join(s.trailer, md)
+	return nil
+}
+
+func TestSetPageInfoTotal(t *testing.T) {
+	for _, total := range []int32{0, 42} {
+		stream := new(testServerTransportStream)
+		ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+
+		pi := &query.PageInfo{Size: 10}
+		pi.SetTotal(total)
+		if err := SetPageInfo(ctx, pi); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		expected := strconv.Itoa(int(total))
+		if v := stream.header.Get(pageInfoTotalMetaKey); len(v) != 1 || v[0] != expected {
+			t.Errorf("invalid total header: %v - expected: %s", v, expected)
+		}
+	}
+
+	stream := new(testServerTransportStream)
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+	if err := SetPageInfo(ctx, &query.PageInfo{Size: 10}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if v := stream.header.Get(pageInfoTotalMetaKey); len(v) != 0 {
+		t.Errorf("invalid total header: %v - expected: none", v)
+	}
+}
@@ -0,0 +1,113 @@
+package gateway
+
+import (
+	"net/url"
+	"reflect"
+	"strconv"
+)
+
+// Paging is implemented by a sub-message of list requests that pass collection operators
+// in the request body instead of query parameters, e.g. POST list RPCs.
+// The fields hold collection operators in their REST representation:
+//
+//	message Paging {
+//	  string filter = 1;
+//	  string order_by = 2;
+//	  string fields = 3;
+//	  int32 limit = 4;
+//	  int32 offset = 5;
+//	  string page_token = 6;
+//	}
+//
+//	message ListFoobarRequest {
+//	  Paging paging = 1;
+//	  infoblox.api.Filtering filter = 2;
+//	  infoblox.api.Sorting order_by = 3;
+//	  infoblox.api.FieldSelection fields = 4;
+//	  infoblox.api.Pagination paging_ops = 5;
+//	}
+//
+// Zero values mean that the corresponding operator is not passed.
+type Paging interface {
+	GetFilter() string
+	GetOrderBy() string
+	GetFields() string
+	GetLimit() int32
+	GetOffset() int32
+	GetPageToken() string
+}
+
+// pagingConfig maps Paging fields to query parameter keys, so that errors target the body fields.
+var pagingConfig = QueryParamConfig{
+	FilterKey:    "filter",
+	SortKey:      "order_by",
+	FieldsKey:    "fields",
+	LimitKey:     "limit",
+	OffsetKey:    "offset",
+	PageTokenKey: "page_token",
+}
+
+// NormalizeRequestPaging parses collection operators from the Paging sub-message of request req
+// and stores them in the corresponding fields of req the same way as ParseQuery does for query parameters,
+// so that GET and POST list RPCs share the same handling. Invalid operators result in InvalidArgument error
+// targeting the Paging field, e.g. "filter". If req has no non-nil Paging field nothing is done.
+func NormalizeRequestPaging(req interface{}) error {
+	return NormalizeRequestPagingWithConfig(req, QueryParamConfig{})
+}
+
+// NormalizeRequestPagingWithConfig is the same as NormalizeRequestPaging but applies
+// filtering and selection limits, default sorting, page token keys, maximum pagination limit and error reporting mode of cfg,
+// query parameter keys of cfg are ignored.
+func NormalizeRequestPagingWithConfig(req interface{}, cfg QueryParamConfig) error {
+	p := requestPaging(req)
+	if p == nil {
+		return nil
+	}
+	vals := url.Values{}
+	setValue := func(key, v string) {
+		if v != "" {
+			vals.Set(key, v)
+		}
+	}
+	setValue(pagingConfig.FilterKey, p.GetFilter())
+	setValue(pagingConfig.SortKey, p.GetOrderBy())
+	setValue(pagingConfig.FieldsKey, p.GetFields())
+	if l := p.GetLimit(); l != 0 {
+		setValue(pagingConfig.LimitKey, strconv.FormatInt(int64(l), 10))
+	}
+	if o := p.GetOffset(); o != 0 {
+		setValue(pagingConfig.OffsetKey, strconv.FormatInt(int64(o), 10))
+	}
+	setValue(pagingConfig.PageTokenKey, p.GetPageToken())
+
+	pcfg := pagingConfig
+	pcfg.FilteringLimits = cfg.FilteringLimits
+	pcfg.SelectionLimits = cfg.SelectionLimits
+	pcfg.DefaultSorting = cfg.DefaultSorting
+	pcfg.PageTokenKeys = cfg.PageTokenKeys
+	pcfg.AllErrors = cfg.AllErrors
+	pcfg.MaxLimit = cfg.MaxLimit
+	return ParseQueryWithConfig(req, vals, pcfg)
+}
+
+// requestPaging returns the first non-nil field of request req implementing Paging.
+func requestPaging(req interface{}) Paging {
+	reqval := reflect.ValueOf(req)
+	if reqval.Kind() != reflect.Ptr || reqval.IsNil() {
+		return nil
+	}
+	reqval = reqval.Elem()
+	if reqval.Kind() != reflect.Struct {
+		return nil
+	}
+	for i := 0; i < reqval.NumField(); i++ {
+		f := reqval.Field(i)
+		if f.Kind() != reflect.Ptr || f.IsNil() || !f.CanInterface() {
+			continue
+		}
+		if p, ok := f.Interface().(Paging); ok {
+			return p
+		}
+	}
+	return nil
+}
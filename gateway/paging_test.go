@@ -0,0 +1,116 @@
+package gateway
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/partitio/atlas-app-toolkit/query"
+)
+
+type testPaging struct {
+	Filter    string
+	OrderBy   string
+	Fields    string
+	Limit     int32
+	Offset    int32
+	PageToken string
+}
+
+func (p *testPaging) GetFilter() string    { return p.Filter }
+func (p *testPaging) GetOrderBy() string   { return p.OrderBy }
+func (p *testPaging) GetFields() string    { return p.Fields }
+func (p *testPaging) GetLimit() int32      { return p.Limit }
+func (p *testPaging) GetOffset() int32     { return p.Offset }
+func (p *testPaging) GetPageToken() string { return p.PageToken }
+
+type testPagingRequest struct {
+	Paging         *testPaging
+	Sorting        *query.Sorting
+	Pagination     *query.Pagination
+	Filtering      *query.Filtering
+	FieldSelection *query.FieldSelection
+}
+
+func TestNormalizeRequestPaging(t *testing.T) {
+	req := &testPagingRequest{Paging: &testPaging{
+		Filter:  "name == 'John'",
+		OrderBy: "name desc",
+		Fields:  "name,age",
+		Limit:   10,
+		Offset:  20,
+	}}
+	if err := NormalizeRequestPaging(req); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if req.Filtering.GetStringCondition().GetValue() != "John" {
+		t.Errorf("invalid filtering: %v - expected: name == 'John'", req.Filtering)
+	}
+	if c := req.Sorting.GetCriterias(); len(c) != 1 || c[0].GoString() != "name DESC" {
+		t.Errorf("invalid sorting: %v - expected: name DESC", req.Sorting)
+	}
+	if req.FieldSelection.Get("age") == nil {
+		t.Errorf("invalid field selection: %v - expected: name,age", req.FieldSelection)
+	}
+	if req.Pagination.GetLimit() != 10 || req.Pagination.GetOffset() != 20 {
+		t.Errorf("invalid pagination: %v - expected: limit 10 offset 20", req.Pagination)
+	}
+
+	// no paging
+	req = &testPagingRequest{}
+	if err := NormalizeRequestPaging(req); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if req.Filtering != nil || req.Sorting != nil || req.Pagination != nil || req.FieldSelection != nil {
+		t.Errorf("invalid request: %v - expected: empty request", req)
+	}
+	if err := NormalizeRequestPaging(&testRequest{}); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	// invalid operators target paging fields
+	req = &testPagingRequest{Paging: &testPaging{Filter: "name ==", Limit: -1}}
+	err := NormalizeRequestPagingWithConfig(req, QueryParamConfig{AllErrors: true})
+	qerr, ok := err.(*InvalidQueryError)
+	if !ok {
+		t.Fatalf("invalid error: %v - expected: InvalidQueryError", err)
+	}
+	if d := qerr.GetDetails(); len(d) != 2 || d[0].GetTarget() != "filter" || d[1].GetTarget() != "limit" {
+		t.Errorf("invalid error details: %v - expected: filter and limit", d)
+	}
+
+	err = NormalizeRequestPaging(&testPagingRequest{Paging: &testPaging{Filter: "name =="}})
+	if s, ok := status.FromError(err); !ok || s.Code() != codes.InvalidArgument {
+		t.Errorf("invalid error: %v - expected: %s", err, codes.InvalidArgument)
+	}
+}
+
+func TestNormalizeRequestPagingSignedCursor(t *testing.T) {
+	key := []byte("key")
+	cfg := QueryParamConfig{PageTokenKeys: [][]byte{key}}
+	pt, err := query.EncodeCursor(&query.Cursor{Keys: []query.CursorKey{{Field: "name", Value: "zzz"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	req := &testPagingRequest{Paging: &testPaging{OrderBy: "name", PageToken: query.SignPageToken(pt, key)}}
+	if err := NormalizeRequestPagingWithConfig(req, cfg); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if req.Filtering.GetStringCondition().GetValue() != "zzz" || req.Pagination.GetPageToken() != pt {
+		t.Errorf("invalid request: %v %v - expected: name > 'zzz' and page token %s", req.Filtering, req.Pagination, pt)
+	}
+
+	// forged and unsigned tokens are rejected
+	for _, token := range []string{pt, query.SignPageToken(pt, []byte("other key"))} {
+		req := &testPagingRequest{Paging: &testPaging{OrderBy: "name", PageToken: token}}
+		err := NormalizeRequestPagingWithConfig(req, cfg)
+		if s, ok := status.FromError(err); !ok || s.Code() != codes.InvalidArgument {
+			t.Errorf("token %q: invalid error: %v - expected: %s", token, err, codes.InvalidArgument)
+		}
+		if req.Filtering != nil {
+			t.Errorf("token %q: invalid filtering: %v - expected: nil", token, req.Filtering)
+		}
+	}
+}
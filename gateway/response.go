@@ -29,6 +29,12 @@ type ResponseForwarder struct {
 	OutgoingHeaderMatcher runtime.HeaderMatcherFunc
 	MessageErrHandler     runtime.ProtoErrorHandlerFunc
 	StreamErrHandler      ProtoStreamErrorHandlerFunc
+	// StreamPageInfo defines how page info sent in gRPC trailers of
+	// server-streaming RPCs is surfaced, see SetPageInfoTrailer.
+	StreamPageInfo StreamPageInfoMode
+	// PageInfoBody makes page info set by SetPageInfo to be sent as "page" object
+	// of the response body instead of gRPC metadata, see NewForwardResponseMessageWithPageInfo.
+	PageInfoBody bool
 }
 
 var (
@@ -40,13 +46,23 @@ var (
 
 // NewForwardResponseMessage returns ForwardResponseMessageFunc
 func NewForwardResponseMessage(out runtime.HeaderMatcherFunc, meh runtime.ProtoErrorHandlerFunc, seh ProtoStreamErrorHandlerFunc) ForwardResponseMessageFunc {
-	fw := &ResponseForwarder{out, meh, seh}
+	fw := &ResponseForwarder{out, meh, seh, StreamPageInfoNone, false}
+	return fw.ForwardMessage
+}
+
+// NewForwardResponseMessageWithPageInfo returns ForwardResponseMessageFunc that sends page info
+// set by SetPageInfo as "page" object of the response body, e.g.
+// {"users": [...], "page": {"page_token": "...", "offset": 10, "size": 10, "total_size": 42}},
+// instead of gRPC metadata, so that it does not leak into HTTP headers. The "null" offset
+// that indicates there are no more pages is omitted. A "page" field of the response takes precedence.
+func NewForwardResponseMessageWithPageInfo(out runtime.HeaderMatcherFunc, meh runtime.ProtoErrorHandlerFunc, seh ProtoStreamErrorHandlerFunc) ForwardResponseMessageFunc {
+	fw := &ResponseForwarder{out, meh, seh, StreamPageInfoNone, true}
 	return fw.ForwardMessage
 }
 
 // NewForwardResponseStream returns ForwardResponseStreamFunc
 func NewForwardResponseStream(out runtime.HeaderMatcherFunc, meh runtime.ProtoErrorHandlerFunc, seh ProtoStreamErrorHandlerFunc) ForwardResponseStreamFunc {
-	fw := &ResponseForwarder{out, meh, seh}
+	fw := &ResponseForwarder{out, meh, seh, StreamPageInfoNone, false}
 	return fw.ForwardStream
 }
 
@@ -58,6 +74,11 @@ func (fw *ResponseForwarder) ForwardMessage(ctx context.Context, mux *runtime.Se
 		fw.MessageErrHandler(ctx, mux, marshaler, rw, req, fmt.Errorf("forward response message: internal error"))
 	}
 
+	var page map[string]interface{}
+	if fw.PageInfoBody {
+		md.HeaderMD, page = pageInfoFromMetadata(md.HeaderMD)
+	}
+
 	handleForwardResponseServerMetadata(fw.OutgoingHeaderMatcher, rw, md)
 	handleForwardResponseTrailerHeader(rw, md)
 
@@ -90,6 +111,9 @@ func (fw *ResponseForwarder) ForwardMessage(ctx context.Context, mux *runtime.Se
 	}
 
 	retainFields(ctx, req, dynmap)
+	if _, ok := dynmap["page"]; len(page) > 0 && !ok {
+		dynmap["page"] = page
+	}
 	errs, suc := errorsAndSuccessFromContext(ctx)
 	if _, ok := dynmap["error"]; len(errs) > 0 && !ok {
 		dynmap["error"] = errs
@@ -161,6 +185,9 @@ func (fw *ResponseForwarder) ForwardStream(ctx context.Context, mux *runtime.Ser
 	for {
 		resp, err := recv()
 		if err == io.EOF {
+			if err := fw.handleStreamPageInfo(ctx, rw, delimiter); err != nil {
+				grpclog.Infof("forward response stream: failed to send page info: %v", err)
+			}
 			return
 		}
 		if err != nil {
@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/golang/protobuf/proto"
@@ -15,6 +16,8 @@ import (
 	"google.golang.org/grpc/metadata"
 
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+
+	"github.com/partitio/atlas-app-toolkit/query"
 )
 
 type user struct {
@@ -171,6 +174,49 @@ func TestForwardResponseMessageWithSuccessField(t *testing.T) {
 	}
 }
 
+func TestForwardResponseMessageWithPageInfo(t *testing.T) {
+	out := func(k string) (string, bool) { return runtime.MetadataHeaderPrefix + k, true }
+	forward := NewForwardResponseMessageWithPageInfo(out, ProtoMessageErrorHandler, ProtoStreamErrorHandler)
+
+	last := &query.PageInfo{Size: 10}
+	last.SetLastOffset()
+	next := &query.PageInfo{Offset: 20, Size: 10, PageToken: "abc"}
+	next.SetTotal(42)
+	numeric := &query.PageInfo{Size: 10, PageToken: "12345"}
+	for _, test := range []struct {
+		pi   *query.PageInfo
+		page string
+	}{
+		{next, `{"offset":20,"page_token":"abc","size":10,"total_size":42}`},
+		{last, `{"size":10}`},
+		{numeric, `{"page_token":"12345","size":10}`},
+	} {
+		md := runtime.ServerMetadata{HeaderMD: metadata.Join(pageInfoMetadata(test.pi), metadata.Pairs("x-custom", "1"))}
+		ctx := runtime.NewServerMetadataContext(context.Background(), md)
+		rw := httptest.NewRecorder()
+		forward(ctx, nil, &runtime.JSONBuiltin{}, rw, nil, &result{Users: []*user{{"Poe", 209}}})
+
+		var v map[string]json.RawMessage
+		if err := json.Unmarshal(rw.Body.Bytes(), &v); err != nil {
+			t.Fatalf("failed to unmarshal JSON response: %s", err)
+		}
+		if page := string(v["page"]); page != test.page {
+			t.Errorf("invalid page: %s - expected: %s", page, test.page)
+		}
+		if _, ok := v["users"]; !ok {
+			t.Errorf("invalid response: missing 'users' field")
+		}
+		for k := range rw.Header() {
+			if strings.HasPrefix(k, "Grpc-Metadata-Status-Page-Info") {
+				t.Errorf("unexpected page info header: %s", k)
+			}
+		}
+		if h := rw.Header().Get("Grpc-Metadata-X-Custom"); h != "1" {
+			t.Errorf("invalid custom header: %q - expected: 1", h)
+		}
+	}
+}
+
 func TestForwardResponseStream(t *testing.T) {
 	md := runtime.ServerMetadata{
 		HeaderMD: metadata.Pairs(
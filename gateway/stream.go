@@ -0,0 +1,192 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+)
+
+// StreamPageInfoMode defines how ResponseForwarder surfaces page info that
+// a server-streaming RPC sends in gRPC trailers by SetPageInfoTrailer.
+type StreamPageInfoMode int
+
+const (
+	// StreamPageInfoNone ignores page info trailers.
+	StreamPageInfoNone StreamPageInfoMode = iota
+	// StreamPageInfoTrailers sends page info as HTTP trailers,
+	// e.g. "Grpc-Trailer-Status-Page-Info-Offset".
+	StreamPageInfoTrailers
+	// StreamPageInfoChunk sends page info as the final chunk of the response
+	// in the form {"page_info": {"page_token": "...", "offset": 10, "size": 10, "total_size": 42}}.
+	StreamPageInfoChunk
+)
+
+// NewForwardResponseStreamWithPageInfo returns ForwardResponseStreamFunc that surfaces
+// page info trailers of server-streaming RPCs according to mode.
+func NewForwardResponseStreamWithPageInfo(out runtime.HeaderMatcherFunc, meh runtime.ProtoErrorHandlerFunc, seh ProtoStreamErrorHandlerFunc, mode StreamPageInfoMode) ForwardResponseStreamFunc {
+	fw := &ResponseForwarder{out, meh, seh, mode, false}
+	return fw.ForwardStream
+}
+
+type streamTrailerKey struct{}
+
+// streamTrailer holds trailer of a server-streaming call made while serving an HTTP request.
+type streamTrailer struct {
+	mu sync.Mutex
+	md metadata.MD
+}
+
+func (t *streamTrailer) set(md metadata.MD) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.md = md
+}
+
+func (t *streamTrailer) get() metadata.MD {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.md
+}
+
+// StreamTrailerHandler returns http.Handler that makes gRPC trailers of server-streaming calls
+// made by h while serving a request available to ResponseForwarder, e.g. StreamTrailerHandler(mux).
+// The gateway must be generated with "request_context=true" option, so that the request context
+// is passed to the gRPC client, and the client must use StreamTrailerClientInterceptor.
+func StreamTrailerHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), streamTrailerKey{}, &streamTrailer{})
+		h.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// StreamTrailerClientInterceptor returns grpc.StreamClientInterceptor that records trailer of
+// a server-streaming call once it is received, so that ResponseForwarder can surface it.
+// It has effect only for calls made within StreamTrailerHandler.
+func StreamTrailerClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			return cs, err
+		}
+		t, ok := ctx.Value(streamTrailerKey{}).(*streamTrailer)
+		if !ok {
+			return cs, nil
+		}
+		return &trailerClientStream{cs, t}, nil
+	}
+}
+
+type trailerClientStream struct {
+	grpc.ClientStream
+	trailer *streamTrailer
+}
+
+// RecvMsg records trailer of the stream as soon as it is available, i.e. the stream is finished.
+func (s *trailerClientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err != nil {
+		s.trailer.set(s.ClientStream.Trailer())
+	}
+	return err
+}
+
+// streamTrailerFromContext returns trailer of the server-streaming call recorded
+// by StreamTrailerClientInterceptor joined with the trailer of server metadata of ctx.
+func streamTrailerFromContext(ctx context.Context) metadata.MD {
+	var md metadata.MD
+	if smd, ok := runtime.ServerMetadataFromContext(ctx); ok {
+		md = smd.TrailerMD
+	}
+	if t, ok := ctx.Value(streamTrailerKey{}).(*streamTrailer); ok {
+		md = metadata.Join(md, t.get())
+	}
+	return md
+}
+
+var pageInfoMetaKeys = map[string]string{
+	pageInfoPageTokenMetaKey: "page_token",
+	pageInfoOffsetMetaKey:    "offset",
+	pageInfoSizeMetaKey:      "size",
+	pageInfoTotalMetaKey:     "total_size",
+}
+
+// handleStreamPageInfo sends page info from trailer of the stream according to fw.StreamPageInfo
+// after the last message of the stream is sent.
+func (fw *ResponseForwarder) handleStreamPageInfo(ctx context.Context, rw http.ResponseWriter, delimiter []byte) error {
+	if fw.StreamPageInfo == StreamPageInfoNone {
+		return nil
+	}
+	md := streamTrailerFromContext(ctx)
+	pi := make(map[string]interface{})
+	for k, vs := range md {
+		name, ok := pageInfoMetaKeys[k]
+		if !ok || len(vs) == 0 {
+			continue
+		}
+		switch fw.StreamPageInfo {
+		case StreamPageInfoTrailers:
+			for _, v := range vs {
+				rw.Header().Add(http.TrailerPrefix+runtime.MetadataTrailerPrefix+k, v)
+			}
+		case StreamPageInfoChunk:
+			pi[name] = pageInfoValue(name, vs[0])
+		}
+	}
+	if len(pi) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(map[string]interface{}{"page_info": pi})
+	if err != nil {
+		return err
+	}
+	if _, err := rw.Write(data); err != nil {
+		return err
+	}
+	_, err = rw.Write(delimiter)
+	return err
+}
+
+// pageInfoFromMetadata returns a copy of md without page info keys and JSON object of the page info,
+// the "null" offset is omitted.
+func pageInfoFromMetadata(md metadata.MD) (metadata.MD, map[string]interface{}) {
+	rest := metadata.MD{}
+	page := make(map[string]interface{})
+	for k, vs := range md {
+		name, ok := pageInfoMetaKeys[k]
+		if !ok {
+			rest[k] = vs
+			continue
+		}
+		if len(vs) == 0 {
+			continue
+		}
+		if v := pageInfoValue(name, vs[0]); v != nil {
+			page[name] = v
+		}
+	}
+	return rest, page
+}
+
+// pageInfoValue returns JSON value of page info metadata value v of field name,
+// "null" offset indicates there are no more pages. Page token is always a string,
+// even if it looks like a number, e.g. "12345".
+func pageInfoValue(name, v string) interface{} {
+	if strings.EqualFold(v, "null") {
+		return nil
+	}
+	if name == "page_token" {
+		return v
+	}
+	if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return i
+	}
+	return v
+}
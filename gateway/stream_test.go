@@ -0,0 +1,204 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/partitio/atlas-app-toolkit/query"
+)
+
+func TestSetPageInfoTrailer(t *testing.T) {
+	stream := new(testServerTransportStream)
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+
+	pi := &query.PageInfo{Size: 10, Offset: 20}
+	pi.SetTotal(42)
+	if err := SetPageInfoTrailer(ctx, pi); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(stream.header) != 0 {
+		t.Errorf("invalid header: %v - expected: none", stream.header)
+	}
+	for k, expected := range map[string]string{pageInfoSizeMetaKey: "10", pageInfoOffsetMetaKey: "20", pageInfoTotalMetaKey: "42"} {
+		if v := stream.trailer.Get(k); len(v) != 1 || v[0] != expected {
+			t.Errorf("invalid %s trailer: %v - expected: %s", k, v, expected)
+		}
+	}
+}
+
+type testClientStream struct {
+	grpc.ClientStream
+	msgs    int
+	trailer metadata.MD
+}
+
+func (s *testClientStream) RecvMsg(m interface{}) error {
+	if s.msgs == 0 {
+		return io.EOF
+	}
+	s.msgs--
+	return nil
+}
+
+func (s *testClientStream) Trailer() metadata.MD { return s.trailer }
+
+func TestStreamTrailerClientInterceptor(t *testing.T) {
+	trailer := metadata.Pairs(pageInfoOffsetMetaKey, "null")
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return &testClientStream{msgs: 1, trailer: trailer}, nil
+	}
+
+	var ctx context.Context
+	StreamTrailerHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx = r.Context()
+	})).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	cs, err := StreamTrailerClientInterceptor()(ctx, &grpc.StreamDesc{ServerStreams: true}, nil, "/test", streamer)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := cs.RecvMsg(nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if md := streamTrailerFromContext(ctx); len(md) != 0 {
+		t.Errorf("invalid trailer before the end of stream: %v - expected: none", md)
+	}
+	if err := cs.RecvMsg(nil); err != io.EOF {
+		t.Fatalf("invalid error: %v - expected: %v", err, io.EOF)
+	}
+	if v := streamTrailerFromContext(ctx).Get(pageInfoOffsetMetaKey); len(v) != 1 || v[0] != "null" {
+		t.Errorf("invalid offset trailer: %v - expected: null", v)
+	}
+
+	// calls made outside of StreamTrailerHandler are not affected
+	cs, err = StreamTrailerClientInterceptor()(context.Background(), &grpc.StreamDesc{ServerStreams: true}, nil, "/test", streamer)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, ok := cs.(*testClientStream); !ok {
+		t.Errorf("invalid client stream: %T - expected: %T", cs, &testClientStream{})
+	}
+}
+
+func streamPageInfoContext() context.Context {
+	ctx := context.WithValue(context.Background(), streamTrailerKey{}, &streamTrailer{
+		md: metadata.Pairs(
+			pageInfoPageTokenMetaKey, "abc",
+			pageInfoOffsetMetaKey, "null",
+			pageInfoSizeMetaKey, "10",
+			"other", "value",
+		),
+	})
+	return runtime.NewServerMetadataContext(ctx, runtime.ServerMetadata{
+		TrailerMD: metadata.Pairs(pageInfoTotalMetaKey, "42"),
+	})
+}
+
+func streamRecv() func() (proto.Message, error) {
+	sent := false
+	return func() (proto.Message, error) {
+		if sent {
+			return nil, io.EOF
+		}
+		sent = true
+		return &result{[]*user{{"Poe", 209}}}, nil
+	}
+}
+
+func TestForwardResponseStreamPageInfoTrailers(t *testing.T) {
+	forward := NewForwardResponseStreamWithPageInfo(PrefixOutgoingHeaderMatcher, ProtoMessageErrorHandler, ProtoStreamErrorHandler, StreamPageInfoTrailers)
+	rw := httptest.NewRecorder()
+	forward(streamPageInfoContext(), nil, &runtime.JSONBuiltin{}, rw, nil, streamRecv())
+
+	trailer := rw.Result().Trailer
+	for k, expected := range map[string]string{
+		"Grpc-Trailer-Status-Page-Info-Page_token": "abc",
+		"Grpc-Trailer-Status-Page-Info-Offset":     "null",
+		"Grpc-Trailer-Status-Page-Info-Size":       "10",
+		"Grpc-Trailer-Status-Page-Info-Total":      "42",
+	} {
+		if v := trailer[k]; len(v) != 1 || v[0] != expected {
+			t.Errorf("invalid %s trailer: %v - expected: %s", k, v, expected)
+		}
+	}
+	if v := trailer.Get("Grpc-Trailer-Other"); v != "" {
+		t.Errorf("invalid trailer: %s - expected: none", v)
+	}
+
+	dec := json.NewDecoder(rw.Body)
+	var rv *result
+	if err := dec.Decode(&rv); err != nil {
+		t.Fatalf("failed to unmarshal response chunked result: %s", err)
+	}
+	if err := dec.Decode(&rv); err != io.EOF {
+		t.Errorf("invalid error: %v - expected: %v", err, io.EOF)
+	}
+}
+
+func TestForwardResponseStreamPageInfoChunk(t *testing.T) {
+	forward := NewForwardResponseStreamWithPageInfo(PrefixOutgoingHeaderMatcher, ProtoMessageErrorHandler, ProtoStreamErrorHandler, StreamPageInfoChunk)
+	rw := httptest.NewRecorder()
+	forward(streamPageInfoContext(), nil, &runtime.JSONBuiltin{}, rw, nil, streamRecv())
+
+	if len(rw.Result().Trailer) != 0 {
+		t.Errorf("invalid trailer: %v - expected: none", rw.Result().Trailer)
+	}
+
+	dec := json.NewDecoder(rw.Body)
+	var rv *result
+	if err := dec.Decode(&rv); err != nil {
+		t.Fatalf("failed to unmarshal response chunked result: %s", err)
+	}
+	var chunk map[string]map[string]interface{}
+	if err := dec.Decode(&chunk); err != nil {
+		t.Fatalf("failed to unmarshal page info chunk: %s", err)
+	}
+	expected := map[string]interface{}{"page_token": "abc", "offset": nil, "size": float64(10), "total_size": float64(42)}
+	if pi := chunk["page_info"]; len(pi) != len(expected) {
+		t.Errorf("invalid page info: %v - expected: %v", pi, expected)
+	} else {
+		for k, v := range expected {
+			if pi[k] != v {
+				t.Errorf("invalid page info %s: %v - expected: %v", k, pi[k], v)
+			}
+		}
+	}
+
+	// numeric page token is sent as a string
+	ctx := context.WithValue(context.Background(), streamTrailerKey{}, &streamTrailer{
+		md: metadata.Pairs(pageInfoPageTokenMetaKey, "12345", pageInfoSizeMetaKey, "10"),
+	})
+	rw = httptest.NewRecorder()
+	forward(runtime.NewServerMetadataContext(ctx, runtime.ServerMetadata{}), nil, &runtime.JSONBuiltin{}, rw, nil, streamRecv())
+	dec = json.NewDecoder(rw.Body)
+	if err := dec.Decode(&rv); err != nil {
+		t.Fatalf("failed to unmarshal response chunked result: %s", err)
+	}
+	chunk = nil
+	if err := dec.Decode(&chunk); err != nil {
+		t.Fatalf("failed to unmarshal page info chunk: %s", err)
+	}
+	if pt := chunk["page_info"]["page_token"]; pt != "12345" {
+		t.Errorf("invalid page token: %#v - expected: %q", pt, "12345")
+	}
+	if size := chunk["page_info"]["size"]; size != float64(10) {
+		t.Errorf("invalid size: %#v - expected: %v", size, 10)
+	}
+
+	// no page info chunk is sent if there is no page info
+	rw = httptest.NewRecorder()
+	forward(runtime.NewServerMetadataContext(context.Background(), runtime.ServerMetadata{}), nil, &runtime.JSONBuiltin{}, rw, nil, streamRecv())
+	if bytes.Contains(rw.Body.Bytes(), []byte("page_info")) {
+		t.Errorf("invalid response: %s - expected no page info", rw.Body)
+	}
+}
@@ -3,6 +3,7 @@ module github.com/partitio/atlas-app-toolkit
 require (
 	github.com/DATA-DOG/go-sqlmock v1.3.0
 	github.com/dgrijalva/jwt-go v0.0.0-20180921172315-3af4c746e1c2
+	github.com/go-stack/stack v1.8.1 // indirect
 	github.com/golang/protobuf v0.0.0-20181022004443-7be363195599
 	github.com/google/uuid v1.0.0
 	github.com/grpc-ecosystem/go-grpc-middleware v1.0.0
@@ -12,10 +13,11 @@ require (
 	github.com/lib/pq v0.0.0-20181016162627-9eb73efc1fcc
 	github.com/sirupsen/logrus v1.3.0
 	github.com/stretchr/testify v1.2.2
+	go.mongodb.org/mongo-driver v1.0.0
 	golang.org/x/crypto v0.0.0-20181015023909-0c41d7ab0a0e // indirect
 	golang.org/x/net v0.0.0-20181017193950-04a2e542c03f
 	golang.org/x/sys v0.0.0-20181022074355-8b8824e799c8 // indirect
-	golang.org/x/text v0.3.0 // indirect
+	golang.org/x/text v0.3.0
 	google.golang.org/genproto v0.0.0-20181016170114-94acd270e44e
 	google.golang.org/grpc v1.13.0
 )
@@ -3,10 +3,13 @@ package gorm
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/jinzhu/gorm"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/partitio/atlas-app-toolkit/query"
 )
@@ -44,6 +47,63 @@ func ApplyCollectionOperators(ctx context.Context, db *gorm.DB, obj interface{},
 	return db, nil
 }
 
+// ApplyCollectionOperatorsWithMapping applies collection operators to gorm instance db
+// translating field paths to column names according to mapping instead of a GORM model.
+// Field paths are dot-separated, e.g. "address.city". Any of the operators can be nil.
+// InvalidArgument error is returned if filtering, sorting or field selection refers to a field
+// that is not in the mapping.
+func ApplyCollectionOperatorsWithMapping(ctx context.Context, db *gorm.DB, mapping map[string]string, f *query.Filtering, s *query.Sorting, fs *query.FieldSelection, p *query.Pagination) (*gorm.DB, error) {
+	where, args, err := query.ToSQL(f, query.WithSQLFieldMapping(mapping), query.WithSQLQuestionPlaceholders())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if where != "" {
+		db = db.Where(where, args...)
+	}
+
+	var crs []string
+	for _, cr := range s.GetCriterias() {
+		col, ok := mapping[cr.GetTag()]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "%s field is not allowed in sorting", cr.GetTag())
+		}
+		if cr.IsDesc() {
+			col += " desc"
+		}
+		col += nullsOrder(cr)
+		crs = append(crs, col)
+	}
+	if len(crs) > 0 {
+		db = db.Order(strings.Join(crs, ","))
+	}
+
+	if len(fs.GetFields()) > 0 {
+		var cols []string
+		fields := fs.Paths()
+		for _, field := range fields {
+			if _, ok := mapping[field]; !ok && (!fs.GetExclude() || !hasMappedSubfield(mapping, field)) {
+				return nil, status.Errorf(codes.InvalidArgument, "%s field is not allowed in field selection", field)
+			}
+		}
+		if fs.GetExclude() {
+			// all mapped fields except the excluded ones are selected
+			fields = nil
+			for field := range mapping {
+				if fs.Selected(field) {
+					fields = append(fields, field)
+				}
+			}
+			sort.Strings(fields)
+		}
+		for _, field := range fields {
+			cols = append(cols, mapping[field])
+		}
+		db = db.Select(cols)
+	}
+
+	return ApplyPagination(ctx, db, p), nil
+}
+
 // ApplyFiltering applies filtering operator f to gorm instance db.
 func ApplyFiltering(ctx context.Context, db *gorm.DB, f *query.Filtering, obj interface{}, pb proto.Message) (*gorm.DB, map[string]struct{}, error) {
 	str, args, assocToJoin, err := FilteringToGorm(ctx, f, obj, pb)
@@ -72,10 +132,9 @@ func ApplySorting(ctx context.Context, db *gorm.DB, s *query.Sorting, obj interf
 			assocToJoin[assoc] = struct{}{}
 		}
 		if cr.IsDesc() {
-			crs = append(crs, dbName+" desc")
-		} else {
-			crs = append(crs, dbName)
+			dbName += " desc"
 		}
+		crs = append(crs, dbName+nullsOrder(cr))
 	}
 	if len(crs) == 0 {
 		return db, nil, nil
@@ -83,6 +142,29 @@ func ApplySorting(ctx context.Context, db *gorm.DB, s *query.Sorting, obj interf
 	return db.Order(strings.Join(crs, ",")), assocToJoin, nil
 }
 
+// hasMappedSubfield reports whether mapping contains a subfield of the field.
+func hasMappedSubfield(mapping map[string]string, field string) bool {
+	for f := range mapping {
+		if strings.HasPrefix(f, field+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// nullsOrder returns ORDER BY suffix for the nulls ordering of cr
+// or an empty string if the database default one is used.
+func nullsOrder(cr *query.SortCriteria) string {
+	switch {
+	case cr.IsNullsFirst():
+		return " nulls first"
+	case cr.IsNullsLast():
+		return " nulls last"
+	default:
+		return ""
+	}
+}
+
 // JoinAssociations joins obj's associations from assoc to the current gorm query.
 func JoinAssociations(ctx context.Context, db *gorm.DB, assoc map[string]struct{}, obj interface{}) (*gorm.DB, error) {
 	for k := range assoc {
@@ -108,7 +190,7 @@ func ApplyPagination(ctx context.Context, db *gorm.DB, p *query.Pagination) *gor
 			db = db.Offset(p.GetOffset())
 		}
 
-		if p.GetLimit() > 0 {
+		if p.GetLimit() > 0 || p.ZeroLimit() {
 			db = db.Limit(p.GetLimit())
 		}
 	}
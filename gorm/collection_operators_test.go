@@ -10,7 +10,9 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/jinzhu/gorm"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	"github.com/partitio/atlas-app-toolkit/gateway"
 	"github.com/partitio/atlas-app-toolkit/query"
@@ -125,3 +127,111 @@ func TestApplyCollectionOperators(t *testing.T) {
 		t.Fatal("no error returned")
 	}
 }
+
+func TestApplyCollectionOperatorsWithMapping(t *testing.T) {
+	mapping := map[string]string{
+		"id":          "people.id",
+		"name":        "people.name",
+		"age":         "people.age",
+		"parent.name": "parents.name",
+	}
+
+	f, err := query.ParseFiltering("age <= 25 and parent.name == 'Mike'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := query.ParseSorting("age, name desc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fs := query.ParseFieldSelection("name,id")
+	p := &query.Pagination{Limit: 2, Offset: 1}
+
+	gormDB, mock := setUp(t)
+	gormDB, err = ApplyCollectionOperatorsWithMapping(context.Background(), gormDB, mapping, f, s, fs, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock.ExpectQuery(fixedFullRe(`SELECT people.id, people.name FROM "people" WHERE (((people.age <= $1) AND (parents.name = $2))) ORDER BY people.age,people.name desc LIMIT 2 OFFSET 1`)).WithArgs(25.0, "Mike").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(111, "Mike"))
+
+	var actual []Person
+	if err := gormDB.Find(&actual).Error; err != nil {
+		t.Error(err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+
+	gormDB, _ = setUp(t)
+	gormDB, err = ApplyCollectionOperatorsWithMapping(context.Background(), gormDB, mapping, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		f  *query.Filtering
+		s  *query.Sorting
+		fs *query.FieldSelection
+	}{
+		{f: &query.Filtering{Root: &query.Filtering_NullCondition{NullCondition: &query.NullCondition{FieldPath: []string{"unknown"}}}}},
+		{s: &query.Sorting{Criterias: []*query.SortCriteria{{Tag: "unknown"}}}},
+		{fs: query.ParseFieldSelection("unknown")},
+	} {
+		_, err = ApplyCollectionOperatorsWithMapping(context.Background(), gormDB, mapping, test.f, test.s, test.fs, nil)
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("expected InvalidArgument error, got %v", err)
+		}
+	}
+}
+
+func TestApplySortingNulls(t *testing.T) {
+	s, err := query.ParseSorting("age nulls first, name desc nulls last, id")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gormDB, mock := setUp(t)
+	gormDB, _, err = ApplySorting(context.Background(), gormDB, s, &Person{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock.ExpectQuery(fixedFullRe(`SELECT * FROM "people" ORDER BY people.age nulls first,people.name desc nulls last,people.id`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(111, "Mike"))
+
+	var actual []Person
+	if err := gormDB.Find(&actual).Error; err != nil {
+		t.Error(err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestApplyCollectionOperatorsWithMappingExcludedFields(t *testing.T) {
+	mapping := map[string]string{
+		"id":          "people.id",
+		"name":        "people.name",
+		"age":         "people.age",
+		"parent.name": "parents.name",
+	}
+
+	gormDB, mock := setUp(t)
+	gormDB, err := ApplyCollectionOperatorsWithMapping(context.Background(), gormDB, mapping, nil, nil, query.ParseFieldSelection("-age,-parent"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock.ExpectQuery(fixedFullRe(`SELECT people.id, people.name FROM "people"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(111, "Mike"))
+
+	var actual []Person
+	if err := gormDB.Find(&actual).Error; err != nil {
+		t.Error(err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
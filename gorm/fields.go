@@ -15,15 +15,23 @@ import (
 // FieldSelectionStringToGorm is a shortcut to parse a string into FieldSelection struct and
 // receive a list of associations to preload.
 func FieldSelectionStringToGorm(ctx context.Context, fs string, obj interface{}) ([]string, error) {
-	return FieldSelectionToGorm(ctx, query.ParseFieldSelection(fs), obj)
+	f, err := query.ParseFieldSelectionStrict(fs)
+	if err != nil {
+		return nil, err
+	}
+	return FieldSelectionToGorm(ctx, f, obj)
 }
 
 // FieldSelectionToGorm receives FieldSelection struct and returns a list of associations to preload.
+// If fields are excluded then all associations except the excluded ones are preloaded.
 func FieldSelectionToGorm(ctx context.Context, fs *query.FieldSelection, obj interface{}) ([]string, error) {
 	objType := indirectType(reflect.TypeOf(obj))
 	if fs.GetFields() == nil {
 		return preloadEverything(objType, nil)
 	}
+	if fs.GetExclude() {
+		return preloadExcept(objType, fs)
+	}
 	var toPreload []string
 	fieldNames := getSortedFieldNames(fs.GetFields())
 	for _, fieldName := range fieldNames {
@@ -70,6 +78,32 @@ fields:
 	return toPreload, nil
 }
 
+func preloadExcept(objType reflect.Type, fs *query.FieldSelection) ([]string, error) {
+	all, err := preloadEverything(objType, nil)
+	if err != nil {
+		return nil, err
+	}
+	var excluded []string
+	for _, path := range fs.Paths() {
+		parts := strings.Split(path, ".")
+		for i, p := range parts {
+			parts[i] = generator.CamelCase(p)
+		}
+		excluded = append(excluded, strings.Join(parts, "."))
+	}
+	var toPreload []string
+preloads:
+	for _, p := range all {
+		for _, e := range excluded {
+			if p == e || strings.HasPrefix(p, e+".") {
+				continue preloads
+			}
+		}
+		toPreload = append(toPreload, p)
+	}
+	return toPreload, nil
+}
+
 func handlePreloads(f *query.Field, objType reflect.Type) ([]string, error) {
 	sf, ok := objType.FieldByName(generator.CamelCase(f.GetName()))
 	if !ok {
@@ -81,6 +81,21 @@ func TestGormFieldSelection(t *testing.T) {
 			[]string{"SubModel.SubSubModel", "SubModel", "SubModels.SubSubModel", "SubModels", "CycleModel", "PreloadObj.SubSubModel", "PreloadObj"},
 			false,
 		},
+		{
+			"-sub_model,-cycle_model",
+			[]string{"SubModels.SubSubModel", "SubModels", "PreloadObj.SubSubModel", "PreloadObj"},
+			false,
+		},
+		{
+			"-sub_models.sub_sub_model,-property",
+			[]string{"SubModel.SubSubModel", "SubModel", "SubModels", "CycleModel", "PreloadObj.SubSubModel", "PreloadObj"},
+			false,
+		},
+		{
+			"sub_model,-sub_models",
+			nil,
+			true,
+		},
 	}
 	for _, test := range tests {
 		toPreload, err := FieldSelectionStringToGorm(context.Background(), test.fs, &Model{})
@@ -38,6 +38,12 @@ func FilteringToGorm(ctx context.Context, m *query.Filtering, obj interface{}, p
 		return NumberConditionToGorm(ctx, r.NumberCondition, obj, pb)
 	case *query.Filtering_NullCondition:
 		return NullConditionToGorm(ctx, r.NullCondition, obj, pb)
+	case *query.Filtering_ExistsCondition:
+		return ExistsConditionToGorm(ctx, r.ExistsCondition, obj, pb)
+	case *query.Filtering_FieldCondition:
+		return FieldConditionToGorm(ctx, r.FieldCondition, obj, pb)
+	case *query.Filtering_ContainsCondition:
+		return ContainsConditionToGorm(ctx, r.ContainsCondition, obj, pb)
 	case *query.Filtering_NumberArrayCondition:
 		return NumberArrayConditionToGorm(ctx, r.NumberArrayCondition, obj, pb)
 	case *query.Filtering_StringArrayCondition:
@@ -64,6 +70,12 @@ func LogicalOperatorToGorm(ctx context.Context, lop *query.LogicalOperator, obj
 		lres, largs, lAssocToJoin, err = NumberConditionToGorm(ctx, l.LeftNumberCondition, obj, pb)
 	case *query.LogicalOperator_LeftNullCondition:
 		lres, largs, lAssocToJoin, err = NullConditionToGorm(ctx, l.LeftNullCondition, obj, pb)
+	case *query.LogicalOperator_LeftExistsCondition:
+		lres, largs, lAssocToJoin, err = ExistsConditionToGorm(ctx, l.LeftExistsCondition, obj, pb)
+	case *query.LogicalOperator_LeftFieldCondition:
+		lres, largs, lAssocToJoin, err = FieldConditionToGorm(ctx, l.LeftFieldCondition, obj, pb)
+	case *query.LogicalOperator_LeftContainsCondition:
+		lres, largs, lAssocToJoin, err = ContainsConditionToGorm(ctx, l.LeftContainsCondition, obj, pb)
 	case *query.LogicalOperator_LeftBoolCondition:
 		lres, largs, lAssocToJoin, err = BoolConditionToGorm(ctx, l.LeftBoolCondition, obj, pb)
 	case *query.LogicalOperator_LeftNumberArrayCondition:
@@ -90,6 +102,12 @@ func LogicalOperatorToGorm(ctx context.Context, lop *query.LogicalOperator, obj
 		rres, rargs, rAssocToJoin, err = NumberConditionToGorm(ctx, r.RightNumberCondition, obj, pb)
 	case *query.LogicalOperator_RightNullCondition:
 		rres, rargs, rAssocToJoin, err = NullConditionToGorm(ctx, r.RightNullCondition, obj, pb)
+	case *query.LogicalOperator_RightExistsCondition:
+		rres, rargs, rAssocToJoin, err = ExistsConditionToGorm(ctx, r.RightExistsCondition, obj, pb)
+	case *query.LogicalOperator_RightFieldCondition:
+		rres, rargs, rAssocToJoin, err = FieldConditionToGorm(ctx, r.RightFieldCondition, obj, pb)
+	case *query.LogicalOperator_RightContainsCondition:
+		rres, rargs, rAssocToJoin, err = ContainsConditionToGorm(ctx, r.RightContainsCondition, obj, pb)
 	case *query.LogicalOperator_RightBoolCondition:
 		rres, rargs, rAssocToJoin, err = BoolConditionToGorm(ctx, r.RightBoolCondition, obj, pb)
 	case *query.LogicalOperator_RightNumberArrayCondition:
@@ -151,6 +169,10 @@ func StringConditionToGorm(ctx context.Context, c *query.StringCondition, obj in
 		o = "="
 	case query.StringCondition_MATCH:
 		o = "~"
+	case query.StringCondition_LIKE:
+		o = "LIKE"
+	case query.StringCondition_ILIKE:
+		o = "ILIKE"
 	case query.StringCondition_GT:
 		o = ">"
 	case query.StringCondition_GE:
@@ -160,6 +182,9 @@ func StringConditionToGorm(ctx context.Context, c *query.StringCondition, obj in
 	case query.StringCondition_LE:
 		o = "<="
 	}
+	if c.NullSafe {
+		o = "IS NOT DISTINCT FROM"
+	}
 	var neg string
 	if c.IsNegative {
 		neg = "NOT"
@@ -175,6 +200,16 @@ func StringConditionToGorm(ctx context.Context, c *query.StringCondition, obj in
 	if c.Type == query.StringCondition_IEQ {
 		return insensitiveCaseStringConditionToGorm(neg, dbName), []interface{}{value}, assocToJoin, nil
 	}
+	if c.Type == query.StringCondition_IN_CIDR {
+		return fmt.Sprintf("%s(%s <<= ?::inet)", neg, dbName), []interface{}{c.Value}, assocToJoin, nil
+	}
+	if c.RelativeTime {
+		expr, err := query.RelativeTimeSQL(c.Value)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		return fmt.Sprintf("%s(%s %s %s)", neg, dbName, o, expr), nil, assocToJoin, nil
+	}
 
 	return fmt.Sprintf("%s(%s %s ?)", neg, dbName, o), []interface{}{value}, assocToJoin, nil
 }
@@ -258,6 +293,9 @@ func NumberConditionToGorm(ctx context.Context, c *query.NumberCondition, obj in
 	case query.NumberCondition_LE:
 		o = "<="
 	}
+	if c.NullSafe {
+		o = "IS NOT DISTINCT FROM"
+	}
 	var neg string
 	if c.IsNegative {
 		neg = "NOT"
@@ -284,6 +322,70 @@ func NullConditionToGorm(ctx context.Context, c *query.NullCondition, obj interf
 	return fmt.Sprintf("%s(%s %s)", neg, dbName, o), nil, assocToJoin, nil
 }
 
+// ExistsConditionToGorm returns GORM Plain SQL representation of the exists condition,
+// which is the same as the negated null condition.
+func ExistsConditionToGorm(ctx context.Context, c *query.ExistsCondition, obj interface{}, pb proto.Message) (string, []interface{}, map[string]struct{}, error) {
+	return NullConditionToGorm(ctx, &query.NullCondition{FieldPath: c.FieldPath, IsNegative: !c.IsNegative}, obj, pb)
+}
+
+// FieldConditionToGorm returns GORM Plain SQL representation of the field condition,
+// i.e. comparison of two columns.
+func FieldConditionToGorm(ctx context.Context, c *query.FieldCondition, obj interface{}, pb proto.Message) (string, []interface{}, map[string]struct{}, error) {
+	dbName, assoc, err := HandleFieldPath(ctx, c.FieldPath, obj)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	valueDBName, valueAssoc, err := HandleFieldPath(ctx, c.ValueFieldPath, obj)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	var assocToJoin map[string]struct{}
+	for _, a := range []string{assoc, valueAssoc} {
+		if a != "" {
+			if assocToJoin == nil {
+				assocToJoin = make(map[string]struct{})
+			}
+			assocToJoin[a] = struct{}{}
+		}
+	}
+	var o string
+	switch c.Type {
+	case query.FieldCondition_EQ:
+		o = "="
+	case query.FieldCondition_GT:
+		o = ">"
+	case query.FieldCondition_GE:
+		o = ">="
+	case query.FieldCondition_LT:
+		o = "<"
+	case query.FieldCondition_LE:
+		o = "<="
+	}
+	var neg string
+	if c.IsNegative {
+		neg = "NOT"
+	}
+	return fmt.Sprintf("%s(%s %s %s)", neg, dbName, o, valueDBName), nil, assocToJoin, nil
+}
+
+// ContainsConditionToGorm returns GORM Plain SQL representation of the contains condition,
+// i.e. Postgres jsonb containment check of the column.
+func ContainsConditionToGorm(ctx context.Context, c *query.ContainsCondition, obj interface{}, pb proto.Message) (string, []interface{}, map[string]struct{}, error) {
+	dbName, assoc, err := HandleFieldPath(ctx, c.FieldPath, obj)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	var assocToJoin map[string]struct{}
+	if assoc != "" {
+		assocToJoin = map[string]struct{}{assoc: {}}
+	}
+	var neg string
+	if c.IsNegative {
+		neg = "NOT"
+	}
+	return fmt.Sprintf("%s(%s @> ?::jsonb)", neg, dbName), []interface{}{c.Value}, assocToJoin, nil
+}
+
 // BoolConditionToGorm returns GORM Plain SQL representation of the bool condition.
 func BoolConditionToGorm(ctx context.Context, c *query.BoolCondition, obj interface{}, pb proto.Message) (string, []interface{}, map[string]struct{}, error) {
 	var assocToJoin map[string]struct{}
@@ -295,14 +397,31 @@ func BoolConditionToGorm(ctx context.Context, c *query.BoolCondition, obj interf
 		assocToJoin = make(map[string]struct{})
 		assocToJoin[assoc] = struct{}{}
 	}
-	o := ""
-	if !c.Value {
-		o = "NOT "
-	}
 	var neg string
 	if c.IsNegative {
 		neg = "NOT"
 	}
+	if c.NullSafe {
+		return fmt.Sprintf("%s(%s IS NOT DISTINCT FROM ?)", neg, dbName), []interface{}{c.Value}, assocToJoin, nil
+	}
+	var co string
+	switch c.Type {
+	case query.BoolCondition_GT:
+		co = ">"
+	case query.BoolCondition_GE:
+		co = ">="
+	case query.BoolCondition_LT:
+		co = "<"
+	case query.BoolCondition_LE:
+		co = "<="
+	}
+	if co != "" {
+		return fmt.Sprintf("%s(%s %s ?)", neg, dbName, co), []interface{}{c.Value}, assocToJoin, nil
+	}
+	o := ""
+	if !c.Value {
+		o = "NOT "
+	}
 	return fmt.Sprintf("%s(%s%s)", neg, o, dbName), nil, assocToJoin, nil
 }
 func NumberArrayConditionToGorm(ctx context.Context, c *query.NumberArrayCondition, obj interface{}, pb proto.Message) (string, []interface{}, map[string]struct{}, error) {
@@ -79,6 +79,62 @@ func TestGormFiltering(t *testing.T) {
 			nil,
 			nil,
 		},
+		{
+			"field1 like '%value_'",
+			"(entities.field1 LIKE ?)",
+			[]interface{}{"%value_"},
+			nil,
+			nil,
+		},
+		{
+			"field1 not like '%value_'",
+			"NOT(entities.field1 LIKE ?)",
+			[]interface{}{"%value_"},
+			nil,
+			nil,
+		},
+		{
+			"field1 ilike '%value_'",
+			"(entities.field1 ILIKE ?)",
+			[]interface{}{"%value_"},
+			nil,
+			nil,
+		},
+		{
+			"field1 not ilike '%value_'",
+			"NOT(entities.field1 ILIKE ?)",
+			[]interface{}{"%value_"},
+			nil,
+			nil,
+		},
+		{
+			"field1 in_cidr '10.0.0.0/8'",
+			"(entities.field1 <<= ?::inet)",
+			[]interface{}{"10.0.0.0/8"},
+			nil,
+			nil,
+		},
+		{
+			"field1 not in_cidr '10.0.0.0/8'",
+			"NOT(entities.field1 <<= ?::inet)",
+			[]interface{}{"10.0.0.0/8"},
+			nil,
+			nil,
+		},
+		{
+			"field1 > now-7d",
+			"(entities.field1 > now() - interval '7 days')",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"field1 != now+1h-30m",
+			"NOT(entities.field1 = now() + interval '1 hour' - interval '30 minutes')",
+			nil,
+			nil,
+			nil,
+		},
 		{
 			"field1 == 22",
 			"(entities.field1 = ?)",
@@ -191,6 +247,27 @@ func TestGormFiltering(t *testing.T) {
 			nil,
 			nil,
 		},
+		{
+			"field1 < field2 and field3 != nested_entity.nested_field1",
+			"((entities.field1 < entities.field2) AND NOT(entities.field3 = nested_entity.nested_field1))",
+			nil,
+			map[string]struct{}{"NestedEntity": {}},
+			nil,
+		},
+		{
+			"field_string <=> 'str' and not field1 <=> 7 or field2 <=> true",
+			"(((entities.field_string IS NOT DISTINCT FROM ?) AND NOT(entities.field1 IS NOT DISTINCT FROM ?)) OR (entities.field2 IS NOT DISTINCT FROM ?))",
+			[]interface{}{"str", 7.0, true},
+			nil,
+			nil,
+		},
+		{
+			"field2 > false and not field2 <= true",
+			"((entities.field2 > ?) AND NOT(entities.field2 <= ?))",
+			[]interface{}{false, true},
+			nil,
+			nil,
+		},
 		{
 			"field1 != null",
 			"NOT(entities.field1 IS NULL)",
@@ -233,12 +310,40 @@ func TestGormFiltering(t *testing.T) {
 			map[string]struct{}{"NestedEntity": {}},
 			nil,
 		},
+		{
+			`field1 contains '{"tier": "gold"}'`,
+			"(entities.field1 @> ?::jsonb)",
+			[]interface{}{`{"tier": "gold"}`},
+			nil,
+			nil,
+		},
+		{
+			`not field1 contains '{"tier": "gold"}'`,
+			"NOT(entities.field1 @> ?::jsonb)",
+			[]interface{}{`{"tier": "gold"}`},
+			nil,
+			nil,
+		},
+		{
+			"field1 exists",
+			"NOT(entities.field1 IS NULL)",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"field1 not exists",
+			"(entities.field1 IS NULL)",
+			nil,
+			nil,
+			nil,
+		},
 		{
 			"field1 === null",
 			"",
 			nil,
 			nil,
-			&query.UnexpectedSymbolError{},
+			&query.ParseError{},
 		},
 		{
 			"id == 'id' and ref == 'ref'",
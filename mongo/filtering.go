@@ -0,0 +1,284 @@
+package mongo
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/partitio/atlas-app-toolkit/query"
+)
+
+// FilterStringToBSON is a shortcut to parse a filter string using default FilteringParser implementation
+// and call ToBSON on the returned filtering expression.
+func FilterStringToBSON(filter string, fieldMap map[string]string) (bson.M, error) {
+	f, err := query.ParseFiltering(filter)
+	if err != nil {
+		return nil, err
+	}
+	return ToBSON(f, fieldMap)
+}
+
+// ToBSON returns MongoDB query document representation of the filtering expression f.
+// fieldMap maps dot-separated field paths of the filtering expression to document field names,
+// fields that are not in fieldMap are rejected with query.UnknownFieldError.
+// Negated logical operators are translated to $nor since MongoDB supports $not for field expressions only.
+func ToBSON(f *query.Filtering, fieldMap map[string]string) (bson.M, error) {
+	if f == nil || f.Root == nil {
+		return bson.M{}, nil
+	}
+	switch r := f.Root.(type) {
+	case *query.Filtering_Operator:
+		return LogicalOperatorToBSON(r.Operator, fieldMap)
+	case *query.Filtering_StringCondition:
+		return StringConditionToBSON(r.StringCondition, fieldMap)
+	case *query.Filtering_NumberCondition:
+		return NumberConditionToBSON(r.NumberCondition, fieldMap)
+	case *query.Filtering_NullCondition:
+		return NullConditionToBSON(r.NullCondition, fieldMap)
+	case *query.Filtering_BoolCondition:
+		return BoolConditionToBSON(r.BoolCondition, fieldMap)
+	case *query.Filtering_StringArrayCondition:
+		return StringArrayConditionToBSON(r.StringArrayCondition, fieldMap)
+	case *query.Filtering_NumberArrayCondition:
+		return NumberArrayConditionToBSON(r.NumberArrayCondition, fieldMap)
+	default:
+		return nil, fmt.Errorf("%T type is not supported in Filtering", r)
+	}
+}
+
+// LogicalOperatorToBSON returns MongoDB query document representation of the logical operator.
+func LogicalOperatorToBSON(lop *query.LogicalOperator, fieldMap map[string]string) (bson.M, error) {
+	var l, r bson.M
+	var err error
+	switch left := lop.Left.(type) {
+	case *query.LogicalOperator_LeftOperator:
+		l, err = LogicalOperatorToBSON(left.LeftOperator, fieldMap)
+	case *query.LogicalOperator_LeftStringCondition:
+		l, err = StringConditionToBSON(left.LeftStringCondition, fieldMap)
+	case *query.LogicalOperator_LeftNumberCondition:
+		l, err = NumberConditionToBSON(left.LeftNumberCondition, fieldMap)
+	case *query.LogicalOperator_LeftNullCondition:
+		l, err = NullConditionToBSON(left.LeftNullCondition, fieldMap)
+	case *query.LogicalOperator_LeftBoolCondition:
+		l, err = BoolConditionToBSON(left.LeftBoolCondition, fieldMap)
+	case *query.LogicalOperator_LeftStringArrayCondition:
+		l, err = StringArrayConditionToBSON(left.LeftStringArrayCondition, fieldMap)
+	case *query.LogicalOperator_LeftNumberArrayCondition:
+		l, err = NumberArrayConditionToBSON(left.LeftNumberArrayCondition, fieldMap)
+	default:
+		return nil, fmt.Errorf("%T type is not supported in Filtering", left)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch right := lop.Right.(type) {
+	case *query.LogicalOperator_RightOperator:
+		r, err = LogicalOperatorToBSON(right.RightOperator, fieldMap)
+	case *query.LogicalOperator_RightStringCondition:
+		r, err = StringConditionToBSON(right.RightStringCondition, fieldMap)
+	case *query.LogicalOperator_RightNumberCondition:
+		r, err = NumberConditionToBSON(right.RightNumberCondition, fieldMap)
+	case *query.LogicalOperator_RightNullCondition:
+		r, err = NullConditionToBSON(right.RightNullCondition, fieldMap)
+	case *query.LogicalOperator_RightBoolCondition:
+		r, err = BoolConditionToBSON(right.RightBoolCondition, fieldMap)
+	case *query.LogicalOperator_RightStringArrayCondition:
+		r, err = StringArrayConditionToBSON(right.RightStringArrayCondition, fieldMap)
+	case *query.LogicalOperator_RightNumberArrayCondition:
+		r, err = NumberArrayConditionToBSON(right.RightNumberArrayCondition, fieldMap)
+	default:
+		return nil, fmt.Errorf("%T type is not supported in Filtering", right)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var o string
+	switch lop.Type {
+	case query.LogicalOperator_AND:
+		o = "$and"
+	case query.LogicalOperator_OR:
+		o = "$or"
+	default:
+		return nil, fmt.Errorf("%s logical operator is not supported", lop.Type)
+	}
+	res := bson.M{o: bson.A{l, r}}
+	if lop.IsNegative {
+		return bson.M{"$nor": bson.A{res}}, nil
+	}
+	return res, nil
+}
+
+// StringConditionToBSON returns MongoDB query document representation of the string condition.
+func StringConditionToBSON(c *query.StringCondition, fieldMap map[string]string) (bson.M, error) {
+	field, err := fieldName(c.FieldPath, fieldMap)
+	if err != nil {
+		return nil, err
+	}
+	if c.RelativeTime {
+		return relativeTimeToBSON(c, field)
+	}
+	switch c.Type {
+	case query.StringCondition_EQ:
+		return equal(field, c.Value, c.IsNegative), nil
+	case query.StringCondition_IEQ:
+		return match(field, primitive.Regex{Pattern: "^" + regexp.QuoteMeta(c.Value) + "$", Options: "i"}, c.IsNegative), nil
+	case query.StringCondition_MATCH:
+		return match(field, primitive.Regex{Pattern: c.Value}, c.IsNegative), nil
+	case query.StringCondition_LIKE:
+		return match(field, primitive.Regex{Pattern: query.LikeToRegexp(c.Value)}, c.IsNegative), nil
+	case query.StringCondition_ILIKE:
+		return match(field, primitive.Regex{Pattern: query.LikeToRegexp(c.Value), Options: "i"}, c.IsNegative), nil
+	case query.StringCondition_GT:
+		return compare(field, "$gt", c.Value, c.IsNegative), nil
+	case query.StringCondition_GE:
+		return compare(field, "$gte", c.Value, c.IsNegative), nil
+	case query.StringCondition_LT:
+		return compare(field, "$lt", c.Value, c.IsNegative), nil
+	case query.StringCondition_LE:
+		return compare(field, "$lte", c.Value, c.IsNegative), nil
+	default:
+		return nil, fmt.Errorf("%s string condition is not supported", c.Type)
+	}
+}
+
+// relativeTimeToBSON translates comparison with a relative time literal to comparison with the absolute time
+// it denotes at the moment of translation, since MongoDB query documents have no date math.
+func relativeTimeToBSON(c *query.StringCondition, field string) (bson.M, error) {
+	t, err := query.ResolveRelativeTime(c.Value, nil)
+	if err != nil {
+		return nil, err
+	}
+	switch c.Type {
+	case query.StringCondition_EQ:
+		return equal(field, t, c.IsNegative), nil
+	case query.StringCondition_GT:
+		return compare(field, "$gt", t, c.IsNegative), nil
+	case query.StringCondition_GE:
+		return compare(field, "$gte", t, c.IsNegative), nil
+	case query.StringCondition_LT:
+		return compare(field, "$lt", t, c.IsNegative), nil
+	case query.StringCondition_LE:
+		return compare(field, "$lte", t, c.IsNegative), nil
+	default:
+		return nil, fmt.Errorf("%s relative time condition is not supported", c.Type)
+	}
+}
+
+// NumberConditionToBSON returns MongoDB query document representation of the number condition.
+func NumberConditionToBSON(c *query.NumberCondition, fieldMap map[string]string) (bson.M, error) {
+	field, err := fieldName(c.FieldPath, fieldMap)
+	if err != nil {
+		return nil, err
+	}
+	switch c.Type {
+	case query.NumberCondition_EQ:
+		return equal(field, c.Value, c.IsNegative), nil
+	case query.NumberCondition_GT:
+		return compare(field, "$gt", c.Value, c.IsNegative), nil
+	case query.NumberCondition_GE:
+		return compare(field, "$gte", c.Value, c.IsNegative), nil
+	case query.NumberCondition_LT:
+		return compare(field, "$lt", c.Value, c.IsNegative), nil
+	case query.NumberCondition_LE:
+		return compare(field, "$lte", c.Value, c.IsNegative), nil
+	default:
+		return nil, fmt.Errorf("%s number condition is not supported", c.Type)
+	}
+}
+
+// NullConditionToBSON returns MongoDB query document representation of the null condition.
+func NullConditionToBSON(c *query.NullCondition, fieldMap map[string]string) (bson.M, error) {
+	field, err := fieldName(c.FieldPath, fieldMap)
+	if err != nil {
+		return nil, err
+	}
+	return equal(field, nil, c.IsNegative), nil
+}
+
+// BoolConditionToBSON returns MongoDB query document representation of the bool condition.
+func BoolConditionToBSON(c *query.BoolCondition, fieldMap map[string]string) (bson.M, error) {
+	field, err := fieldName(c.FieldPath, fieldMap)
+	if err != nil {
+		return nil, err
+	}
+	switch c.Type {
+	case query.BoolCondition_EQ:
+		return equal(field, c.Value, c.IsNegative), nil
+	case query.BoolCondition_GT:
+		return compare(field, "$gt", c.Value, c.IsNegative), nil
+	case query.BoolCondition_GE:
+		return compare(field, "$gte", c.Value, c.IsNegative), nil
+	case query.BoolCondition_LT:
+		return compare(field, "$lt", c.Value, c.IsNegative), nil
+	case query.BoolCondition_LE:
+		return compare(field, "$lte", c.Value, c.IsNegative), nil
+	default:
+		return nil, fmt.Errorf("%s bool condition is not supported", c.Type)
+	}
+}
+
+// StringArrayConditionToBSON returns MongoDB query document representation of the string array condition.
+func StringArrayConditionToBSON(c *query.StringArrayCondition, fieldMap map[string]string) (bson.M, error) {
+	field, err := fieldName(c.FieldPath, fieldMap)
+	if err != nil {
+		return nil, err
+	}
+	values := make(bson.A, len(c.Values))
+	for i, v := range c.Values {
+		values[i] = v
+	}
+	return in(field, values, c.IsNegative), nil
+}
+
+// NumberArrayConditionToBSON returns MongoDB query document representation of the number array condition.
+func NumberArrayConditionToBSON(c *query.NumberArrayCondition, fieldMap map[string]string) (bson.M, error) {
+	field, err := fieldName(c.FieldPath, fieldMap)
+	if err != nil {
+		return nil, err
+	}
+	values := make(bson.A, len(c.Values))
+	for i, v := range c.Values {
+		values[i] = v
+	}
+	return in(field, values, c.IsNegative), nil
+}
+
+func fieldName(fieldPath []string, fieldMap map[string]string) (string, error) {
+	if name, ok := fieldMap[strings.Join(fieldPath, ".")]; ok {
+		return name, nil
+	}
+	return "", &query.UnknownFieldError{FieldPath: fieldPath}
+}
+
+func equal(field string, value interface{}, neg bool) bson.M {
+	if neg {
+		return bson.M{field: bson.M{"$ne": value}}
+	}
+	return bson.M{field: value}
+}
+
+func compare(field, op string, value interface{}, neg bool) bson.M {
+	if neg {
+		return bson.M{field: bson.M{"$not": bson.M{op: value}}}
+	}
+	return bson.M{field: bson.M{op: value}}
+}
+
+func match(field string, re primitive.Regex, neg bool) bson.M {
+	if neg {
+		return bson.M{field: bson.M{"$not": re}}
+	}
+	return bson.M{field: bson.M{"$regex": re}}
+}
+
+func in(field string, values bson.A, neg bool) bson.M {
+	if neg {
+		return bson.M{field: bson.M{"$nin": values}}
+	}
+	return bson.M{field: bson.M{"$in": values}}
+}
@@ -0,0 +1,153 @@
+package mongo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/partitio/atlas-app-toolkit/query"
+)
+
+func TestToBSON(t *testing.T) {
+	fieldMap := map[string]string{
+		"name":         "name",
+		"age":          "age",
+		"active":       "is_active",
+		"address.city": "address.city",
+	}
+
+	tests := []struct {
+		filter string
+		res    bson.M
+	}{
+		{
+			filter: "",
+			res:    bson.M{},
+		},
+		{
+			filter: "name == 'John'",
+			res:    bson.M{"name": "John"},
+		},
+		{
+			filter: "name != 'John'",
+			res:    bson.M{"name": bson.M{"$ne": "John"}},
+		},
+		{
+			filter: "age < 18",
+			res:    bson.M{"age": bson.M{"$lt": 18.0}},
+		},
+		{
+			filter: "not age >= 18",
+			res:    bson.M{"age": bson.M{"$not": bson.M{"$gte": 18.0}}},
+		},
+		{
+			filter: "name > 'a' and name <= 'b'",
+			res: bson.M{"$and": bson.A{
+				bson.M{"name": bson.M{"$gt": "a"}},
+				bson.M{"name": bson.M{"$lte": "b"}},
+			}},
+		},
+		{
+			filter: "name ~ '^Jo' or name !~ 'hn$'",
+			res: bson.M{"$or": bson.A{
+				bson.M{"name": bson.M{"$regex": primitive.Regex{Pattern: "^Jo"}}},
+				bson.M{"name": bson.M{"$not": primitive.Regex{Pattern: "hn$"}}},
+			}},
+		},
+		{
+			filter: "name like 'J_n%' and name not like '%\\%'",
+			res: bson.M{"$and": bson.A{
+				bson.M{"name": bson.M{"$regex": primitive.Regex{Pattern: `(?s)^J.n.*$`}}},
+				bson.M{"name": bson.M{"$not": primitive.Regex{Pattern: `(?s)^.*%$`}}},
+			}},
+		},
+		{
+			filter: "name ilike 'j_n%' or name not ilike '%x'",
+			res: bson.M{"$or": bson.A{
+				bson.M{"name": bson.M{"$regex": primitive.Regex{Pattern: `(?s)^j.n.*$`, Options: "i"}}},
+				bson.M{"name": bson.M{"$not": primitive.Regex{Pattern: `(?s)^.*x$`, Options: "i"}}},
+			}},
+		},
+		{
+			filter: "name := 'j.n'",
+			res:    bson.M{"name": bson.M{"$regex": primitive.Regex{Pattern: `^j\.n$`, Options: "i"}}},
+		},
+		{
+			filter: "address.city == null",
+			res:    bson.M{"address.city": nil},
+		},
+		{
+			filter: "address.city != null",
+			res:    bson.M{"address.city": bson.M{"$ne": nil}},
+		},
+		{
+			filter: "active == true",
+			res:    bson.M{"is_active": true},
+		},
+		{
+			filter: "active > false and not active <= true",
+			res: bson.M{"$and": bson.A{
+				bson.M{"is_active": bson.M{"$gt": false}},
+				bson.M{"is_active": bson.M{"$not": bson.M{"$lte": true}}},
+			}},
+		},
+		{
+			filter: "name in ['John', 'Jane'] and age not in [1, 2]",
+			res: bson.M{"$and": bson.A{
+				bson.M{"name": bson.M{"$in": bson.A{"John", "Jane"}}},
+				bson.M{"age": bson.M{"$nin": bson.A{1.0, 2.0}}},
+			}},
+		},
+		{
+			filter: "(name == 'John' or not (age > 18 and active == false)) and address.city != 'Paris'",
+			res: bson.M{"$and": bson.A{
+				bson.M{"$or": bson.A{
+					bson.M{"name": "John"},
+					bson.M{"$nor": bson.A{
+						bson.M{"$and": bson.A{
+							bson.M{"age": bson.M{"$gt": 18.0}},
+							bson.M{"is_active": false},
+						}},
+					}},
+				}},
+				bson.M{"address.city": bson.M{"$ne": "Paris"}},
+			}},
+		},
+	}
+
+	for _, test := range tests {
+		res, err := FilterStringToBSON(test.filter, fieldMap)
+		assert.Nil(t, err, test.filter)
+		assert.Equal(t, test.res, res, test.filter)
+	}
+}
+
+func TestToBSONUnknownField(t *testing.T) {
+	fieldMap := map[string]string{"name": "name"}
+
+	for _, filter := range []string{"age == 1", "name == 'a' or age == 1", "not (name == 'a' and address.city == null)"} {
+		res, err := FilterStringToBSON(filter, fieldMap)
+		assert.Nil(t, res)
+		assert.IsType(t, &query.UnknownFieldError{}, err, filter)
+	}
+}
+
+func TestToBSONUnsupportedCondition(t *testing.T) {
+	res, err := FilterStringToBSON("ip in_cidr '10.0.0.0/8'", map[string]string{"ip": "ip"})
+	assert.Nil(t, res)
+	assert.EqualError(t, err, "IN_CIDR string condition is not supported")
+}
+
+func TestToBSONRelativeTime(t *testing.T) {
+	before := time.Now()
+	res, err := FilterStringToBSON("created > now-7d", map[string]string{"created": "created_at"})
+	after := time.Now()
+	assert.Nil(t, err)
+	lit, ok := res["created_at"].(bson.M)["$gt"].(time.Time)
+	assert.True(t, ok)
+	assert.False(t, lit.Before(before.Add(-7*24*time.Hour)))
+	assert.False(t, lit.After(after.Add(-7*24*time.Hour)))
+}
@@ -20,14 +20,20 @@ It has these top-level messages:
 	BoolCondition
 	StringArrayCondition
 	NumberArrayCondition
+	ExistsCondition
+	FieldCondition
+	ContainsCondition
+	CustomCondition
 	Pagination
 	PageInfo
+	CountOnly
 */
 package query
 
 import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
+import google_protobuf "github.com/golang/protobuf/ptypes/wrappers"
 import _ "github.com/grpc-ecosystem/grpc-gateway/protoc-gen-swagger/options"
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -65,6 +71,34 @@ func (x SortCriteria_Order) String() string {
 }
 func (SortCriteria_Order) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{0, 0} }
 
+// Nulls is an ordering of null values.
+type SortCriteria_Nulls int32
+
+const (
+	// database default ordering of nulls
+	SortCriteria_NULLS_DEFAULT SortCriteria_Nulls = 0
+	// nulls are placed before non-null values
+	SortCriteria_NULLS_FIRST SortCriteria_Nulls = 1
+	// nulls are placed after non-null values
+	SortCriteria_NULLS_LAST SortCriteria_Nulls = 2
+)
+
+var SortCriteria_Nulls_name = map[int32]string{
+	0: "NULLS_DEFAULT",
+	1: "NULLS_FIRST",
+	2: "NULLS_LAST",
+}
+var SortCriteria_Nulls_value = map[string]int32{
+	"NULLS_DEFAULT": 0,
+	"NULLS_FIRST":   1,
+	"NULLS_LAST":    2,
+}
+
+func (x SortCriteria_Nulls) String() string {
+	return proto.EnumName(SortCriteria_Nulls_name, int32(x))
+}
+func (SortCriteria_Nulls) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{0, 1} }
+
 type LogicalOperator_Type int32
 
 const (
@@ -89,13 +123,16 @@ func (LogicalOperator_Type) EnumDescriptor() ([]byte, []int) { return fileDescri
 type StringCondition_Type int32
 
 const (
-	StringCondition_EQ    StringCondition_Type = 0
-	StringCondition_MATCH StringCondition_Type = 1
-	StringCondition_GT    StringCondition_Type = 2
-	StringCondition_GE    StringCondition_Type = 3
-	StringCondition_LT    StringCondition_Type = 4
-	StringCondition_LE    StringCondition_Type = 5
-	StringCondition_IEQ   StringCondition_Type = 6
+	StringCondition_EQ      StringCondition_Type = 0
+	StringCondition_MATCH   StringCondition_Type = 1
+	StringCondition_GT      StringCondition_Type = 2
+	StringCondition_GE      StringCondition_Type = 3
+	StringCondition_LT      StringCondition_Type = 4
+	StringCondition_LE      StringCondition_Type = 5
+	StringCondition_IEQ     StringCondition_Type = 6
+	StringCondition_LIKE    StringCondition_Type = 7
+	StringCondition_ILIKE   StringCondition_Type = 8
+	StringCondition_IN_CIDR StringCondition_Type = 9
 )
 
 var StringCondition_Type_name = map[int32]string{
@@ -106,15 +143,21 @@ var StringCondition_Type_name = map[int32]string{
 	4: "LT",
 	5: "LE",
 	6: "IEQ",
+	7: "LIKE",
+	8: "ILIKE",
+	9: "IN_CIDR",
 }
 var StringCondition_Type_value = map[string]int32{
-	"EQ":    0,
-	"MATCH": 1,
-	"GT":    2,
-	"GE":    3,
-	"LT":    4,
-	"LE":    5,
-	"IEQ":   6,
+	"EQ":      0,
+	"MATCH":   1,
+	"GT":      2,
+	"GE":      3,
+	"LT":      4,
+	"LE":      5,
+	"IEQ":     6,
+	"LIKE":    7,
+	"ILIKE":   8,
+	"IN_CIDR": 9,
 }
 
 func (x StringCondition_Type) String() string {
@@ -152,6 +195,36 @@ func (x NumberCondition_Type) String() string {
 }
 func (NumberCondition_Type) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{7, 0} }
 
+type BoolCondition_Type int32
+
+const (
+	BoolCondition_EQ BoolCondition_Type = 0
+	BoolCondition_GT BoolCondition_Type = 1
+	BoolCondition_GE BoolCondition_Type = 2
+	BoolCondition_LT BoolCondition_Type = 3
+	BoolCondition_LE BoolCondition_Type = 4
+)
+
+var BoolCondition_Type_name = map[int32]string{
+	0: "EQ",
+	1: "GT",
+	2: "GE",
+	3: "LT",
+	4: "LE",
+}
+var BoolCondition_Type_value = map[string]int32{
+	"EQ": 0,
+	"GT": 1,
+	"GE": 2,
+	"LT": 3,
+	"LE": 4,
+}
+
+func (x BoolCondition_Type) String() string {
+	return proto.EnumName(BoolCondition_Type_name, int32(x))
+}
+func (BoolCondition_Type) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{9, 0} }
+
 type StringArrayCondition_Type int32
 
 const (
@@ -192,11 +265,42 @@ func (NumberArrayCondition_Type) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor0, []int{11, 0}
 }
 
+type FieldCondition_Type int32
+
+const (
+	FieldCondition_EQ FieldCondition_Type = 0
+	FieldCondition_GT FieldCondition_Type = 1
+	FieldCondition_GE FieldCondition_Type = 2
+	FieldCondition_LT FieldCondition_Type = 3
+	FieldCondition_LE FieldCondition_Type = 4
+)
+
+var FieldCondition_Type_name = map[int32]string{
+	0: "EQ",
+	1: "GT",
+	2: "GE",
+	3: "LT",
+	4: "LE",
+}
+var FieldCondition_Type_value = map[string]int32{
+	"EQ": 0,
+	"GT": 1,
+	"GE": 2,
+	"LT": 3,
+	"LE": 4,
+}
+
+func (x FieldCondition_Type) String() string {
+	return proto.EnumName(FieldCondition_Type_name, int32(x))
+}
+func (FieldCondition_Type) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{13, 0} }
+
 // SortCriteria represents sort criteria
 type SortCriteria struct {
 	// Tag is a JSON tag.
 	Tag   string             `protobuf:"bytes,1,opt,name=tag" json:"tag,omitempty"`
 	Order SortCriteria_Order `protobuf:"varint,2,opt,name=order,enum=infoblox.api.SortCriteria_Order" json:"order,omitempty"`
+	Nulls SortCriteria_Nulls `protobuf:"varint,3,opt,name=nulls,enum=infoblox.api.SortCriteria_Nulls" json:"nulls,omitempty"`
 }
 
 func (m *SortCriteria) Reset()                    { *m = SortCriteria{} }
@@ -218,6 +322,13 @@ func (m *SortCriteria) GetOrder() SortCriteria_Order {
 	return SortCriteria_ASC
 }
 
+func (m *SortCriteria) GetNulls() SortCriteria_Nulls {
+	if m != nil {
+		return m.Nulls
+	}
+	return SortCriteria_NULLS_DEFAULT
+}
+
 // Sorting represents list of sort criterias.
 type Sorting struct {
 	Criterias []*SortCriteria `protobuf:"bytes,1,rep,name=criterias" json:"criterias,omitempty"`
@@ -240,6 +351,9 @@ func (m *Sorting) GetCriterias() []*SortCriteria {
 // need to be ratained prior to sending object as a response
 type FieldSelection struct {
 	Fields map[string]*Field `protobuf:"bytes,1,rep,name=fields" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Exclude indicates that fields are excluded rather than retained,
+	// i.e. all fields except the specified ones are retained.
+	Exclude bool `protobuf:"varint,2,opt,name=exclude" json:"exclude,omitempty"`
 }
 
 func (m *FieldSelection) Reset()                    { *m = FieldSelection{} }
@@ -254,6 +368,13 @@ func (m *FieldSelection) GetFields() map[string]*Field {
 	return nil
 }
 
+func (m *FieldSelection) GetExclude() bool {
+	if m != nil {
+		return m.Exclude
+	}
+	return false
+}
+
 // Field represents a single field for an object.
 // It contains fields name and also may contain a group of sub-fields for cases
 // when a fields represents some structure.
@@ -292,6 +413,10 @@ type Filtering struct {
 	//	*Filtering_StringArrayCondition
 	//	*Filtering_NumberArrayCondition
 	//	*Filtering_BoolCondition
+	//	*Filtering_CustomCondition
+	//	*Filtering_ExistsCondition
+	//	*Filtering_FieldCondition
+	//	*Filtering_ContainsCondition
 	Root isFiltering_Root `protobuf_oneof:"root"`
 }
 
@@ -323,6 +448,18 @@ type Filtering_NumberArrayCondition struct {
 type Filtering_BoolCondition struct {
 	BoolCondition *BoolCondition `protobuf:"bytes,7,opt,name=bool_condition,json=boolCondition,oneof"`
 }
+type Filtering_CustomCondition struct {
+	CustomCondition *CustomCondition `protobuf:"bytes,8,opt,name=custom_condition,json=customCondition,oneof"`
+}
+type Filtering_ExistsCondition struct {
+	ExistsCondition *ExistsCondition `protobuf:"bytes,9,opt,name=exists_condition,json=existsCondition,oneof"`
+}
+type Filtering_FieldCondition struct {
+	FieldCondition *FieldCondition `protobuf:"bytes,10,opt,name=field_condition,json=fieldCondition,oneof"`
+}
+type Filtering_ContainsCondition struct {
+	ContainsCondition *ContainsCondition `protobuf:"bytes,11,opt,name=contains_condition,json=containsCondition,oneof"`
+}
 
 func (*Filtering_Operator) isFiltering_Root()             {}
 func (*Filtering_StringCondition) isFiltering_Root()      {}
@@ -331,6 +468,10 @@ func (*Filtering_NullCondition) isFiltering_Root()        {}
 func (*Filtering_StringArrayCondition) isFiltering_Root() {}
 func (*Filtering_NumberArrayCondition) isFiltering_Root() {}
 func (*Filtering_BoolCondition) isFiltering_Root()        {}
+func (*Filtering_CustomCondition) isFiltering_Root()      {}
+func (*Filtering_ExistsCondition) isFiltering_Root()      {}
+func (*Filtering_FieldCondition) isFiltering_Root()       {}
+func (*Filtering_ContainsCondition) isFiltering_Root()    {}
 
 func (m *Filtering) GetRoot() isFiltering_Root {
 	if m != nil {
@@ -388,6 +529,34 @@ func (m *Filtering) GetBoolCondition() *BoolCondition {
 	return nil
 }
 
+func (m *Filtering) GetCustomCondition() *CustomCondition {
+	if x, ok := m.GetRoot().(*Filtering_CustomCondition); ok {
+		return x.CustomCondition
+	}
+	return nil
+}
+
+func (m *Filtering) GetExistsCondition() *ExistsCondition {
+	if x, ok := m.GetRoot().(*Filtering_ExistsCondition); ok {
+		return x.ExistsCondition
+	}
+	return nil
+}
+
+func (m *Filtering) GetFieldCondition() *FieldCondition {
+	if x, ok := m.GetRoot().(*Filtering_FieldCondition); ok {
+		return x.FieldCondition
+	}
+	return nil
+}
+
+func (m *Filtering) GetContainsCondition() *ContainsCondition {
+	if x, ok := m.GetRoot().(*Filtering_ContainsCondition); ok {
+		return x.ContainsCondition
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*Filtering) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _Filtering_OneofMarshaler, _Filtering_OneofUnmarshaler, _Filtering_OneofSizer, []interface{}{
@@ -398,6 +567,10 @@ func (*Filtering) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) err
 		(*Filtering_StringArrayCondition)(nil),
 		(*Filtering_NumberArrayCondition)(nil),
 		(*Filtering_BoolCondition)(nil),
+		(*Filtering_CustomCondition)(nil),
+		(*Filtering_ExistsCondition)(nil),
+		(*Filtering_FieldCondition)(nil),
+		(*Filtering_ContainsCondition)(nil),
 	}
 }
 
@@ -440,6 +613,26 @@ func _Filtering_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.BoolCondition); err != nil {
 			return err
 		}
+	case *Filtering_CustomCondition:
+		b.EncodeVarint(8<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.CustomCondition); err != nil {
+			return err
+		}
+	case *Filtering_ExistsCondition:
+		b.EncodeVarint(9<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ExistsCondition); err != nil {
+			return err
+		}
+	case *Filtering_FieldCondition:
+		b.EncodeVarint(10<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.FieldCondition); err != nil {
+			return err
+		}
+	case *Filtering_ContainsCondition:
+		b.EncodeVarint(11<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ContainsCondition); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("Filtering.Root has unexpected type %T", x)
@@ -506,6 +699,38 @@ func _Filtering_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buff
 		err := b.DecodeMessage(msg)
 		m.Root = &Filtering_BoolCondition{msg}
 		return true, err
+	case 8: // root.custom_condition
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(CustomCondition)
+		err := b.DecodeMessage(msg)
+		m.Root = &Filtering_CustomCondition{msg}
+		return true, err
+	case 9: // root.exists_condition
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ExistsCondition)
+		err := b.DecodeMessage(msg)
+		m.Root = &Filtering_ExistsCondition{msg}
+		return true, err
+	case 10: // root.field_condition
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(FieldCondition)
+		err := b.DecodeMessage(msg)
+		m.Root = &Filtering_FieldCondition{msg}
+		return true, err
+	case 11: // root.contains_condition
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ContainsCondition)
+		err := b.DecodeMessage(msg)
+		m.Root = &Filtering_ContainsCondition{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -550,6 +775,26 @@ func _Filtering_OneofSizer(msg proto.Message) (n int) {
 		n += proto.SizeVarint(7<<3 | proto.WireBytes)
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *Filtering_CustomCondition:
+		s := proto.Size(x.CustomCondition)
+		n += proto.SizeVarint(8<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Filtering_ExistsCondition:
+		s := proto.Size(x.ExistsCondition)
+		n += proto.SizeVarint(9<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Filtering_FieldCondition:
+		s := proto.Size(x.FieldCondition)
+		n += proto.SizeVarint(10<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Filtering_ContainsCondition:
+		s := proto.Size(x.ContainsCondition)
+		n += proto.SizeVarint(11<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -570,6 +815,10 @@ type LogicalOperator struct {
 	//	*LogicalOperator_LeftStringArrayCondition
 	//	*LogicalOperator_LeftNumberArrayCondition
 	//	*LogicalOperator_LeftBoolCondition
+	//	*LogicalOperator_LeftCustomCondition
+	//	*LogicalOperator_LeftExistsCondition
+	//	*LogicalOperator_LeftFieldCondition
+	//	*LogicalOperator_LeftContainsCondition
 	Left isLogicalOperator_Left `protobuf_oneof:"left"`
 	// Types that are valid to be assigned to Right:
 	//	*LogicalOperator_RightOperator
@@ -579,6 +828,10 @@ type LogicalOperator struct {
 	//	*LogicalOperator_RightStringArrayCondition
 	//	*LogicalOperator_RightNumberArrayCondition
 	//	*LogicalOperator_RightBoolCondition
+	//	*LogicalOperator_RightCustomCondition
+	//	*LogicalOperator_RightExistsCondition
+	//	*LogicalOperator_RightFieldCondition
+	//	*LogicalOperator_RightContainsCondition
 	Right      isLogicalOperator_Right `protobuf_oneof:"right"`
 	Type       LogicalOperator_Type    `protobuf:"varint,9,opt,name=type,enum=infoblox.api.LogicalOperator_Type" json:"type,omitempty"`
 	IsNegative bool                    `protobuf:"varint,10,opt,name=is_negative,json=isNegative" json:"is_negative,omitempty"`
@@ -613,6 +866,18 @@ type LogicalOperator_LeftNumberArrayCondition struct {
 type LogicalOperator_LeftBoolCondition struct {
 	LeftBoolCondition *BoolCondition `protobuf:"bytes,15,opt,name=left_bool_condition,json=leftBoolCondition,oneof"`
 }
+type LogicalOperator_LeftCustomCondition struct {
+	LeftCustomCondition *CustomCondition `protobuf:"bytes,17,opt,name=left_custom_condition,json=leftCustomCondition,oneof"`
+}
+type LogicalOperator_LeftExistsCondition struct {
+	LeftExistsCondition *ExistsCondition `protobuf:"bytes,19,opt,name=left_exists_condition,json=leftExistsCondition,oneof"`
+}
+type LogicalOperator_LeftFieldCondition struct {
+	LeftFieldCondition *FieldCondition `protobuf:"bytes,21,opt,name=left_field_condition,json=leftFieldCondition,oneof"`
+}
+type LogicalOperator_LeftContainsCondition struct {
+	LeftContainsCondition *ContainsCondition `protobuf:"bytes,23,opt,name=left_contains_condition,json=leftContainsCondition,oneof"`
+}
 type LogicalOperator_RightOperator struct {
 	RightOperator *LogicalOperator `protobuf:"bytes,5,opt,name=right_operator,json=rightOperator,oneof"`
 }
@@ -634,6 +899,18 @@ type LogicalOperator_RightNumberArrayCondition struct {
 type LogicalOperator_RightBoolCondition struct {
 	RightBoolCondition *BoolCondition `protobuf:"bytes,16,opt,name=right_bool_condition,json=rightBoolCondition,oneof"`
 }
+type LogicalOperator_RightCustomCondition struct {
+	RightCustomCondition *CustomCondition `protobuf:"bytes,18,opt,name=right_custom_condition,json=rightCustomCondition,oneof"`
+}
+type LogicalOperator_RightExistsCondition struct {
+	RightExistsCondition *ExistsCondition `protobuf:"bytes,20,opt,name=right_exists_condition,json=rightExistsCondition,oneof"`
+}
+type LogicalOperator_RightFieldCondition struct {
+	RightFieldCondition *FieldCondition `protobuf:"bytes,22,opt,name=right_field_condition,json=rightFieldCondition,oneof"`
+}
+type LogicalOperator_RightContainsCondition struct {
+	RightContainsCondition *ContainsCondition `protobuf:"bytes,24,opt,name=right_contains_condition,json=rightContainsCondition,oneof"`
+}
 
 func (*LogicalOperator_LeftOperator) isLogicalOperator_Left()               {}
 func (*LogicalOperator_LeftStringCondition) isLogicalOperator_Left()        {}
@@ -642,6 +919,10 @@ func (*LogicalOperator_LeftNullCondition) isLogicalOperator_Left()          {}
 func (*LogicalOperator_LeftStringArrayCondition) isLogicalOperator_Left()   {}
 func (*LogicalOperator_LeftNumberArrayCondition) isLogicalOperator_Left()   {}
 func (*LogicalOperator_LeftBoolCondition) isLogicalOperator_Left()          {}
+func (*LogicalOperator_LeftCustomCondition) isLogicalOperator_Left()        {}
+func (*LogicalOperator_LeftExistsCondition) isLogicalOperator_Left()        {}
+func (*LogicalOperator_LeftFieldCondition) isLogicalOperator_Left()         {}
+func (*LogicalOperator_LeftContainsCondition) isLogicalOperator_Left()      {}
 func (*LogicalOperator_RightOperator) isLogicalOperator_Right()             {}
 func (*LogicalOperator_RightStringCondition) isLogicalOperator_Right()      {}
 func (*LogicalOperator_RightNumberCondition) isLogicalOperator_Right()      {}
@@ -649,6 +930,10 @@ func (*LogicalOperator_RightNullCondition) isLogicalOperator_Right()        {}
 func (*LogicalOperator_RightStringArrayCondition) isLogicalOperator_Right() {}
 func (*LogicalOperator_RightNumberArrayCondition) isLogicalOperator_Right() {}
 func (*LogicalOperator_RightBoolCondition) isLogicalOperator_Right()        {}
+func (*LogicalOperator_RightCustomCondition) isLogicalOperator_Right()      {}
+func (*LogicalOperator_RightExistsCondition) isLogicalOperator_Right()      {}
+func (*LogicalOperator_RightFieldCondition) isLogicalOperator_Right()       {}
+func (*LogicalOperator_RightContainsCondition) isLogicalOperator_Right()    {}
 
 func (m *LogicalOperator) GetLeft() isLogicalOperator_Left {
 	if m != nil {
@@ -712,6 +997,34 @@ func (m *LogicalOperator) GetLeftBoolCondition() *BoolCondition {
 	return nil
 }
 
+func (m *LogicalOperator) GetLeftCustomCondition() *CustomCondition {
+	if x, ok := m.GetLeft().(*LogicalOperator_LeftCustomCondition); ok {
+		return x.LeftCustomCondition
+	}
+	return nil
+}
+
+func (m *LogicalOperator) GetLeftExistsCondition() *ExistsCondition {
+	if x, ok := m.GetLeft().(*LogicalOperator_LeftExistsCondition); ok {
+		return x.LeftExistsCondition
+	}
+	return nil
+}
+
+func (m *LogicalOperator) GetLeftFieldCondition() *FieldCondition {
+	if x, ok := m.GetLeft().(*LogicalOperator_LeftFieldCondition); ok {
+		return x.LeftFieldCondition
+	}
+	return nil
+}
+
+func (m *LogicalOperator) GetLeftContainsCondition() *ContainsCondition {
+	if x, ok := m.GetLeft().(*LogicalOperator_LeftContainsCondition); ok {
+		return x.LeftContainsCondition
+	}
+	return nil
+}
+
 func (m *LogicalOperator) GetRightOperator() *LogicalOperator {
 	if x, ok := m.GetRight().(*LogicalOperator_RightOperator); ok {
 		return x.RightOperator
@@ -761,6 +1074,34 @@ func (m *LogicalOperator) GetRightBoolCondition() *BoolCondition {
 	return nil
 }
 
+func (m *LogicalOperator) GetRightCustomCondition() *CustomCondition {
+	if x, ok := m.GetRight().(*LogicalOperator_RightCustomCondition); ok {
+		return x.RightCustomCondition
+	}
+	return nil
+}
+
+func (m *LogicalOperator) GetRightExistsCondition() *ExistsCondition {
+	if x, ok := m.GetRight().(*LogicalOperator_RightExistsCondition); ok {
+		return x.RightExistsCondition
+	}
+	return nil
+}
+
+func (m *LogicalOperator) GetRightFieldCondition() *FieldCondition {
+	if x, ok := m.GetRight().(*LogicalOperator_RightFieldCondition); ok {
+		return x.RightFieldCondition
+	}
+	return nil
+}
+
+func (m *LogicalOperator) GetRightContainsCondition() *ContainsCondition {
+	if x, ok := m.GetRight().(*LogicalOperator_RightContainsCondition); ok {
+		return x.RightContainsCondition
+	}
+	return nil
+}
+
 func (m *LogicalOperator) GetType() LogicalOperator_Type {
 	if m != nil {
 		return m.Type
@@ -785,6 +1126,10 @@ func (*LogicalOperator) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffe
 		(*LogicalOperator_LeftStringArrayCondition)(nil),
 		(*LogicalOperator_LeftNumberArrayCondition)(nil),
 		(*LogicalOperator_LeftBoolCondition)(nil),
+		(*LogicalOperator_LeftCustomCondition)(nil),
+		(*LogicalOperator_LeftExistsCondition)(nil),
+		(*LogicalOperator_LeftFieldCondition)(nil),
+		(*LogicalOperator_LeftContainsCondition)(nil),
 		(*LogicalOperator_RightOperator)(nil),
 		(*LogicalOperator_RightStringCondition)(nil),
 		(*LogicalOperator_RightNumberCondition)(nil),
@@ -792,6 +1137,10 @@ func (*LogicalOperator) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffe
 		(*LogicalOperator_RightStringArrayCondition)(nil),
 		(*LogicalOperator_RightNumberArrayCondition)(nil),
 		(*LogicalOperator_RightBoolCondition)(nil),
+		(*LogicalOperator_RightCustomCondition)(nil),
+		(*LogicalOperator_RightExistsCondition)(nil),
+		(*LogicalOperator_RightFieldCondition)(nil),
+		(*LogicalOperator_RightContainsCondition)(nil),
 	}
 }
 
@@ -834,6 +1183,26 @@ func _LogicalOperator_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.LeftBoolCondition); err != nil {
 			return err
 		}
+	case *LogicalOperator_LeftCustomCondition:
+		b.EncodeVarint(17<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.LeftCustomCondition); err != nil {
+			return err
+		}
+	case *LogicalOperator_LeftExistsCondition:
+		b.EncodeVarint(19<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.LeftExistsCondition); err != nil {
+			return err
+		}
+	case *LogicalOperator_LeftFieldCondition:
+		b.EncodeVarint(21<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.LeftFieldCondition); err != nil {
+			return err
+		}
+	case *LogicalOperator_LeftContainsCondition:
+		b.EncodeVarint(23<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.LeftContainsCondition); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("LogicalOperator.Left has unexpected type %T", x)
@@ -875,6 +1244,26 @@ func _LogicalOperator_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.RightBoolCondition); err != nil {
 			return err
 		}
+	case *LogicalOperator_RightCustomCondition:
+		b.EncodeVarint(18<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.RightCustomCondition); err != nil {
+			return err
+		}
+	case *LogicalOperator_RightExistsCondition:
+		b.EncodeVarint(20<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.RightExistsCondition); err != nil {
+			return err
+		}
+	case *LogicalOperator_RightFieldCondition:
+		b.EncodeVarint(22<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.RightFieldCondition); err != nil {
+			return err
+		}
+	case *LogicalOperator_RightContainsCondition:
+		b.EncodeVarint(24<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.RightContainsCondition); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("LogicalOperator.Right has unexpected type %T", x)
@@ -941,6 +1330,38 @@ func _LogicalOperator_OneofUnmarshaler(msg proto.Message, tag, wire int, b *prot
 		err := b.DecodeMessage(msg)
 		m.Left = &LogicalOperator_LeftBoolCondition{msg}
 		return true, err
+	case 17: // left.left_custom_condition
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(CustomCondition)
+		err := b.DecodeMessage(msg)
+		m.Left = &LogicalOperator_LeftCustomCondition{msg}
+		return true, err
+	case 19: // left.left_exists_condition
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ExistsCondition)
+		err := b.DecodeMessage(msg)
+		m.Left = &LogicalOperator_LeftExistsCondition{msg}
+		return true, err
+	case 21: // left.left_field_condition
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(FieldCondition)
+		err := b.DecodeMessage(msg)
+		m.Left = &LogicalOperator_LeftFieldCondition{msg}
+		return true, err
+	case 23: // left.left_contains_condition
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ContainsCondition)
+		err := b.DecodeMessage(msg)
+		m.Left = &LogicalOperator_LeftContainsCondition{msg}
+		return true, err
 	case 5: // right.right_operator
 		if wire != proto.WireBytes {
 			return true, proto.ErrInternalBadWireType
@@ -997,6 +1418,38 @@ func _LogicalOperator_OneofUnmarshaler(msg proto.Message, tag, wire int, b *prot
 		err := b.DecodeMessage(msg)
 		m.Right = &LogicalOperator_RightBoolCondition{msg}
 		return true, err
+	case 18: // right.right_custom_condition
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(CustomCondition)
+		err := b.DecodeMessage(msg)
+		m.Right = &LogicalOperator_RightCustomCondition{msg}
+		return true, err
+	case 20: // right.right_exists_condition
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ExistsCondition)
+		err := b.DecodeMessage(msg)
+		m.Right = &LogicalOperator_RightExistsCondition{msg}
+		return true, err
+	case 22: // right.right_field_condition
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(FieldCondition)
+		err := b.DecodeMessage(msg)
+		m.Right = &LogicalOperator_RightFieldCondition{msg}
+		return true, err
+	case 24: // right.right_contains_condition
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ContainsCondition)
+		err := b.DecodeMessage(msg)
+		m.Right = &LogicalOperator_RightContainsCondition{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -1041,6 +1494,26 @@ func _LogicalOperator_OneofSizer(msg proto.Message) (n int) {
 		n += proto.SizeVarint(15<<3 | proto.WireBytes)
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *LogicalOperator_LeftCustomCondition:
+		s := proto.Size(x.LeftCustomCondition)
+		n += proto.SizeVarint(17<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *LogicalOperator_LeftExistsCondition:
+		s := proto.Size(x.LeftExistsCondition)
+		n += proto.SizeVarint(19<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *LogicalOperator_LeftFieldCondition:
+		s := proto.Size(x.LeftFieldCondition)
+		n += proto.SizeVarint(21<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *LogicalOperator_LeftContainsCondition:
+		s := proto.Size(x.LeftContainsCondition)
+		n += proto.SizeVarint(23<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -1082,6 +1555,26 @@ func _LogicalOperator_OneofSizer(msg proto.Message) (n int) {
 		n += proto.SizeVarint(16<<3 | proto.WireBytes)
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *LogicalOperator_RightCustomCondition:
+		s := proto.Size(x.RightCustomCondition)
+		n += proto.SizeVarint(18<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *LogicalOperator_RightExistsCondition:
+		s := proto.Size(x.RightExistsCondition)
+		n += proto.SizeVarint(20<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *LogicalOperator_RightFieldCondition:
+		s := proto.Size(x.RightFieldCondition)
+		n += proto.SizeVarint(22<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *LogicalOperator_RightContainsCondition:
+		s := proto.Size(x.RightContainsCondition)
+		n += proto.SizeVarint(24<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -1094,11 +1587,16 @@ func _LogicalOperator_OneofSizer(msg proto.Message) (n int) {
 // value is the string literal.
 // type is a type of the condition.
 // is_negative is set to true if the condition is negated.
+// null_safe is set to true for null-safe equality, e.g. field <=> 'abc', that is false for null field
+// and is true for null field if negated.
+// relative_time is set to true if value is a relative time literal resolved at evaluation time, e.g. now-7d.
 type StringCondition struct {
-	FieldPath  []string             `protobuf:"bytes,1,rep,name=field_path,json=fieldPath" json:"field_path,omitempty"`
-	Value      string               `protobuf:"bytes,2,opt,name=value" json:"value,omitempty"`
-	Type       StringCondition_Type `protobuf:"varint,3,opt,name=type,enum=infoblox.api.StringCondition_Type" json:"type,omitempty"`
-	IsNegative bool                 `protobuf:"varint,4,opt,name=is_negative,json=isNegative" json:"is_negative,omitempty"`
+	FieldPath    []string             `protobuf:"bytes,1,rep,name=field_path,json=fieldPath" json:"field_path,omitempty"`
+	Value        string               `protobuf:"bytes,2,opt,name=value" json:"value,omitempty"`
+	Type         StringCondition_Type `protobuf:"varint,3,opt,name=type,enum=infoblox.api.StringCondition_Type" json:"type,omitempty"`
+	IsNegative   bool                 `protobuf:"varint,4,opt,name=is_negative,json=isNegative" json:"is_negative,omitempty"`
+	NullSafe     bool                 `protobuf:"varint,5,opt,name=null_safe,json=nullSafe" json:"null_safe,omitempty"`
+	RelativeTime bool                 `protobuf:"varint,6,opt,name=relative_time,json=relativeTime" json:"relative_time,omitempty"`
 }
 
 func (m *StringCondition) Reset()                    { *m = StringCondition{} }
@@ -1134,16 +1632,32 @@ func (m *StringCondition) GetIsNegative() bool {
 	return false
 }
 
+func (m *StringCondition) GetNullSafe() bool {
+	if m != nil {
+		return m.NullSafe
+	}
+	return false
+}
+
+func (m *StringCondition) GetRelativeTime() bool {
+	if m != nil {
+		return m.RelativeTime
+	}
+	return false
+}
+
 // NumberCondition represents a condition with a number literal, e.g. field > 3.
 // field_path is a reference to a value of a resource.
 // value is the number literal.
 // type is a type of the condition.
 // is_negative is set to true if the condition is negated.
+// null_safe is set to true for null-safe equality, e.g. field <=> 3.
 type NumberCondition struct {
 	FieldPath  []string             `protobuf:"bytes,1,rep,name=field_path,json=fieldPath" json:"field_path,omitempty"`
 	Value      float64              `protobuf:"fixed64,2,opt,name=value" json:"value,omitempty"`
 	Type       NumberCondition_Type `protobuf:"varint,3,opt,name=type,enum=infoblox.api.NumberCondition_Type" json:"type,omitempty"`
 	IsNegative bool                 `protobuf:"varint,4,opt,name=is_negative,json=isNegative" json:"is_negative,omitempty"`
+	NullSafe   bool                 `protobuf:"varint,5,opt,name=null_safe,json=nullSafe" json:"null_safe,omitempty"`
 }
 
 func (m *NumberCondition) Reset()                    { *m = NumberCondition{} }
@@ -1179,12 +1693,22 @@ func (m *NumberCondition) GetIsNegative() bool {
 	return false
 }
 
+func (m *NumberCondition) GetNullSafe() bool {
+	if m != nil {
+		return m.NullSafe
+	}
+	return false
+}
+
 // NullCondition represents a condition with a null literal, e.g. field == null.
 // field_path is a reference to a value of a resource.
 // is_negative is set to true if the condition is negated.
+// null_safe is set to true for null-safe equality, e.g. field <=> null, that is false
+// for fields that cannot be null instead of being a type mismatch.
 type NullCondition struct {
 	FieldPath  []string `protobuf:"bytes,1,rep,name=field_path,json=fieldPath" json:"field_path,omitempty"`
 	IsNegative bool     `protobuf:"varint,2,opt,name=is_negative,json=isNegative" json:"is_negative,omitempty"`
+	NullSafe   bool     `protobuf:"varint,3,opt,name=null_safe,json=nullSafe" json:"null_safe,omitempty"`
 }
 
 func (m *NullCondition) Reset()                    { *m = NullCondition{} }
@@ -1206,13 +1730,24 @@ func (m *NullCondition) GetIsNegative() bool {
 	return false
 }
 
+func (m *NullCondition) GetNullSafe() bool {
+	if m != nil {
+		return m.NullSafe
+	}
+	return false
+}
+
 // BoolCondition represents a condition with a bool literal, e.g. field == true.
 // field_path is a reference to a value of a resource.
 // is_negative is set to true if the condition is negated.
+// null_safe is set to true for null-safe equality, e.g. field <=> true.
+// type is a comparison operator, bool values are ordered so that false < true, e.g. field > false.
 type BoolCondition struct {
-	FieldPath  []string `protobuf:"bytes,1,rep,name=field_path,json=fieldPath" json:"field_path,omitempty"`
-	IsNegative bool     `protobuf:"varint,2,opt,name=is_negative,json=isNegative" json:"is_negative,omitempty"`
-	Value      bool     `protobuf:"varint,3,opt,name=value" json:"value,omitempty"`
+	FieldPath  []string           `protobuf:"bytes,1,rep,name=field_path,json=fieldPath" json:"field_path,omitempty"`
+	IsNegative bool               `protobuf:"varint,2,opt,name=is_negative,json=isNegative" json:"is_negative,omitempty"`
+	Value      bool               `protobuf:"varint,3,opt,name=value" json:"value,omitempty"`
+	NullSafe   bool               `protobuf:"varint,4,opt,name=null_safe,json=nullSafe" json:"null_safe,omitempty"`
+	Type       BoolCondition_Type `protobuf:"varint,5,opt,name=type,enum=infoblox.api.BoolCondition_Type" json:"type,omitempty"`
 }
 
 func (m *BoolCondition) Reset()                    { *m = BoolCondition{} }
@@ -1241,6 +1776,20 @@ func (m *BoolCondition) GetValue() bool {
 	return false
 }
 
+func (m *BoolCondition) GetNullSafe() bool {
+	if m != nil {
+		return m.NullSafe
+	}
+	return false
+}
+
+func (m *BoolCondition) GetType() BoolCondition_Type {
+	if m != nil {
+		return m.Type
+	}
+	return BoolCondition_EQ
+}
+
 // StringArrayCondition represents a condition with string arrays, e.g. field in ['hello','world']
 // field_path is a reference to a value of a resource.
 // is_negative is set to true if the condition is negated
@@ -1327,6 +1876,382 @@ func (m *NumberArrayCondition) GetIsNegative() bool {
 	return false
 }
 
+// ExistsCondition represents a check of presence of a field, e.g. field exists.
+// field_path is a reference to a value of a resource.
+// is_negative is set to true if the condition is negated.
+type ExistsCondition struct {
+	FieldPath  []string `protobuf:"bytes,1,rep,name=field_path,json=fieldPath" json:"field_path,omitempty"`
+	IsNegative bool     `protobuf:"varint,2,opt,name=is_negative,json=isNegative" json:"is_negative,omitempty"`
+}
+
+func (m *ExistsCondition) Reset()                    { *m = ExistsCondition{} }
+func (m *ExistsCondition) String() string            { return proto.CompactTextString(m) }
+func (*ExistsCondition) ProtoMessage()               {}
+func (*ExistsCondition) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *ExistsCondition) GetFieldPath() []string {
+	if m != nil {
+		return m.FieldPath
+	}
+	return nil
+}
+
+func (m *ExistsCondition) GetIsNegative() bool {
+	if m != nil {
+		return m.IsNegative
+	}
+	return false
+}
+
+// FieldCondition represents a comparison of two fields, e.g. start_date < end_date.
+// field_path is a reference to a value of a resource.
+// value_field_path is a reference to another value of the resource field_path is compared to.
+// type is a type of the condition.
+// is_negative is set to true if the condition is negated.
+type FieldCondition struct {
+	FieldPath      []string            `protobuf:"bytes,1,rep,name=field_path,json=fieldPath" json:"field_path,omitempty"`
+	ValueFieldPath []string            `protobuf:"bytes,2,rep,name=value_field_path,json=valueFieldPath" json:"value_field_path,omitempty"`
+	Type           FieldCondition_Type `protobuf:"varint,3,opt,name=type,enum=infoblox.api.FieldCondition_Type" json:"type,omitempty"`
+	IsNegative     bool                `protobuf:"varint,4,opt,name=is_negative,json=isNegative" json:"is_negative,omitempty"`
+}
+
+func (m *FieldCondition) Reset()                    { *m = FieldCondition{} }
+func (m *FieldCondition) String() string            { return proto.CompactTextString(m) }
+func (*FieldCondition) ProtoMessage()               {}
+func (*FieldCondition) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *FieldCondition) GetFieldPath() []string {
+	if m != nil {
+		return m.FieldPath
+	}
+	return nil
+}
+
+func (m *FieldCondition) GetValueFieldPath() []string {
+	if m != nil {
+		return m.ValueFieldPath
+	}
+	return nil
+}
+
+func (m *FieldCondition) GetType() FieldCondition_Type {
+	if m != nil {
+		return m.Type
+	}
+	return FieldCondition_EQ
+}
+
+func (m *FieldCondition) GetIsNegative() bool {
+	if m != nil {
+		return m.IsNegative
+	}
+	return false
+}
+
+// ContainsCondition represents a check that a JSON value of a field contains a JSON document,
+// e.g. attributes contains '{"tier": "gold"}', as the Postgres jsonb @> operator does.
+// field_path is a reference to a value of a resource.
+// value is the JSON text of the contained document.
+// is_negative is set to true if the condition is negated.
+type ContainsCondition struct {
+	FieldPath  []string `protobuf:"bytes,1,rep,name=field_path,json=fieldPath" json:"field_path,omitempty"`
+	Value      string   `protobuf:"bytes,2,opt,name=value" json:"value,omitempty"`
+	IsNegative bool     `protobuf:"varint,3,opt,name=is_negative,json=isNegative" json:"is_negative,omitempty"`
+}
+
+func (m *ContainsCondition) Reset()                    { *m = ContainsCondition{} }
+func (m *ContainsCondition) String() string            { return proto.CompactTextString(m) }
+func (*ContainsCondition) ProtoMessage()               {}
+func (*ContainsCondition) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *ContainsCondition) GetFieldPath() []string {
+	if m != nil {
+		return m.FieldPath
+	}
+	return nil
+}
+
+func (m *ContainsCondition) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *ContainsCondition) GetIsNegative() bool {
+	if m != nil {
+		return m.IsNegative
+	}
+	return false
+}
+
+// CustomCondition represents a condition with an operator registered via RegisterOperator, e.g. field within [1, 2, 3].
+// field_path is a reference to a value of a resource.
+// operator is the registered symbol of the operator.
+// value is the literal, either a string, a number, a bool or an array of strings or numbers.
+// is_negative is set to true if the condition is negated.
+type CustomCondition struct {
+	FieldPath []string `protobuf:"bytes,1,rep,name=field_path,json=fieldPath" json:"field_path,omitempty"`
+	Operator  string   `protobuf:"bytes,2,opt,name=operator" json:"operator,omitempty"`
+	// Types that are valid to be assigned to Value:
+	//	*CustomCondition_StringValue
+	//	*CustomCondition_NumberValue
+	//	*CustomCondition_BoolValue
+	//	*CustomCondition_StringArrayValue
+	//	*CustomCondition_NumberArrayValue
+	Value      isCustomCondition_Value `protobuf_oneof:"value"`
+	IsNegative bool                    `protobuf:"varint,8,opt,name=is_negative,json=isNegative" json:"is_negative,omitempty"`
+}
+
+func (m *CustomCondition) Reset()                    { *m = CustomCondition{} }
+func (m *CustomCondition) String() string            { return proto.CompactTextString(m) }
+func (*CustomCondition) ProtoMessage()               {}
+func (*CustomCondition) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+type isCustomCondition_Value interface{ isCustomCondition_Value() }
+
+type CustomCondition_StringValue struct {
+	StringValue string `protobuf:"bytes,3,opt,name=string_value,json=stringValue,oneof"`
+}
+type CustomCondition_NumberValue struct {
+	NumberValue float64 `protobuf:"fixed64,4,opt,name=number_value,json=numberValue,oneof"`
+}
+type CustomCondition_BoolValue struct {
+	BoolValue bool `protobuf:"varint,5,opt,name=bool_value,json=boolValue,oneof"`
+}
+type CustomCondition_StringArrayValue struct {
+	StringArrayValue *CustomCondition_StringArray `protobuf:"bytes,6,opt,name=string_array_value,json=stringArrayValue,oneof"`
+}
+type CustomCondition_NumberArrayValue struct {
+	NumberArrayValue *CustomCondition_NumberArray `protobuf:"bytes,7,opt,name=number_array_value,json=numberArrayValue,oneof"`
+}
+
+func (*CustomCondition_StringValue) isCustomCondition_Value()      {}
+func (*CustomCondition_NumberValue) isCustomCondition_Value()      {}
+func (*CustomCondition_BoolValue) isCustomCondition_Value()        {}
+func (*CustomCondition_StringArrayValue) isCustomCondition_Value() {}
+func (*CustomCondition_NumberArrayValue) isCustomCondition_Value() {}
+
+func (m *CustomCondition) GetValue() isCustomCondition_Value {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *CustomCondition) GetFieldPath() []string {
+	if m != nil {
+		return m.FieldPath
+	}
+	return nil
+}
+
+func (m *CustomCondition) GetOperator() string {
+	if m != nil {
+		return m.Operator
+	}
+	return ""
+}
+
+func (m *CustomCondition) GetStringValue() string {
+	if x, ok := m.GetValue().(*CustomCondition_StringValue); ok {
+		return x.StringValue
+	}
+	return ""
+}
+
+func (m *CustomCondition) GetNumberValue() float64 {
+	if x, ok := m.GetValue().(*CustomCondition_NumberValue); ok {
+		return x.NumberValue
+	}
+	return 0
+}
+
+func (m *CustomCondition) GetBoolValue() bool {
+	if x, ok := m.GetValue().(*CustomCondition_BoolValue); ok {
+		return x.BoolValue
+	}
+	return false
+}
+
+func (m *CustomCondition) GetStringArrayValue() *CustomCondition_StringArray {
+	if x, ok := m.GetValue().(*CustomCondition_StringArrayValue); ok {
+		return x.StringArrayValue
+	}
+	return nil
+}
+
+func (m *CustomCondition) GetNumberArrayValue() *CustomCondition_NumberArray {
+	if x, ok := m.GetValue().(*CustomCondition_NumberArrayValue); ok {
+		return x.NumberArrayValue
+	}
+	return nil
+}
+
+func (m *CustomCondition) GetIsNegative() bool {
+	if m != nil {
+		return m.IsNegative
+	}
+	return false
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*CustomCondition) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _CustomCondition_OneofMarshaler, _CustomCondition_OneofUnmarshaler, _CustomCondition_OneofSizer, []interface{}{
+		(*CustomCondition_StringValue)(nil),
+		(*CustomCondition_NumberValue)(nil),
+		(*CustomCondition_BoolValue)(nil),
+		(*CustomCondition_StringArrayValue)(nil),
+		(*CustomCondition_NumberArrayValue)(nil),
+	}
+}
+
+func _CustomCondition_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*CustomCondition)
+	// value
+	switch x := m.Value.(type) {
+	case *CustomCondition_StringValue:
+		b.EncodeVarint(3<<3 | proto.WireBytes)
+		b.EncodeStringBytes(x.StringValue)
+	case *CustomCondition_NumberValue:
+		b.EncodeVarint(4<<3 | proto.WireFixed64)
+		b.EncodeFixed64(math.Float64bits(x.NumberValue))
+	case *CustomCondition_BoolValue:
+		t := uint64(0)
+		if x.BoolValue {
+			t = 1
+		}
+		b.EncodeVarint(5<<3 | proto.WireVarint)
+		b.EncodeVarint(t)
+	case *CustomCondition_StringArrayValue:
+		b.EncodeVarint(6<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.StringArrayValue); err != nil {
+			return err
+		}
+	case *CustomCondition_NumberArrayValue:
+		b.EncodeVarint(7<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.NumberArrayValue); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("CustomCondition.Value has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _CustomCondition_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*CustomCondition)
+	switch tag {
+	case 3: // value.string_value
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeStringBytes()
+		m.Value = &CustomCondition_StringValue{x}
+		return true, err
+	case 4: // value.number_value
+		if wire != proto.WireFixed64 {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeFixed64()
+		m.Value = &CustomCondition_NumberValue{math.Float64frombits(x)}
+		return true, err
+	case 5: // value.bool_value
+		if wire != proto.WireVarint {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeVarint()
+		m.Value = &CustomCondition_BoolValue{x != 0}
+		return true, err
+	case 6: // value.string_array_value
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(CustomCondition_StringArray)
+		err := b.DecodeMessage(msg)
+		m.Value = &CustomCondition_StringArrayValue{msg}
+		return true, err
+	case 7: // value.number_array_value
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(CustomCondition_NumberArray)
+		err := b.DecodeMessage(msg)
+		m.Value = &CustomCondition_NumberArrayValue{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _CustomCondition_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*CustomCondition)
+	// value
+	switch x := m.Value.(type) {
+	case *CustomCondition_StringValue:
+		n += proto.SizeVarint(3<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(len(x.StringValue)))
+		n += len(x.StringValue)
+	case *CustomCondition_NumberValue:
+		n += proto.SizeVarint(4<<3 | proto.WireFixed64)
+		n += 8
+	case *CustomCondition_BoolValue:
+		n += proto.SizeVarint(5<<3 | proto.WireVarint)
+		n += 1
+	case *CustomCondition_StringArrayValue:
+		s := proto.Size(x.StringArrayValue)
+		n += proto.SizeVarint(6<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *CustomCondition_NumberArrayValue:
+		s := proto.Size(x.NumberArrayValue)
+		n += proto.SizeVarint(7<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+type CustomCondition_StringArray struct {
+	Values []string `protobuf:"bytes,1,rep,name=values" json:"values,omitempty"`
+}
+
+func (m *CustomCondition_StringArray) Reset()         { *m = CustomCondition_StringArray{} }
+func (m *CustomCondition_StringArray) String() string { return proto.CompactTextString(m) }
+func (*CustomCondition_StringArray) ProtoMessage()    {}
+func (*CustomCondition_StringArray) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{15, 0}
+}
+
+func (m *CustomCondition_StringArray) GetValues() []string {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+type CustomCondition_NumberArray struct {
+	Values []float64 `protobuf:"fixed64,1,rep,packed,name=values" json:"values,omitempty"`
+}
+
+func (m *CustomCondition_NumberArray) Reset()         { *m = CustomCondition_NumberArray{} }
+func (m *CustomCondition_NumberArray) String() string { return proto.CompactTextString(m) }
+func (*CustomCondition_NumberArray) ProtoMessage()    {}
+func (*CustomCondition_NumberArray) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{15, 1}
+}
+
+func (m *CustomCondition_NumberArray) GetValues() []float64 {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
 // Pagination represents both server-driven and client-driven pagination request.
 // Server-driven pagination is a model in which the server returns some
 // amount of data along with an token indicating there is more data
@@ -1344,12 +2269,16 @@ type Pagination struct {
 	// The service may impose maximum value.
 	// If omitted the service may impose a default value.
 	Limit int32 `protobuf:"varint,3,opt,name=limit" json:"limit,omitempty"`
+	// Whether the limit is explicitly specified, so that zero limit
+	// requests no resources but page info only, while omitted limit
+	// leaves the service default one.
+	HasLimit bool `protobuf:"varint,4,opt,name=has_limit,json=hasLimit" json:"has_limit,omitempty"`
 }
 
 func (m *Pagination) Reset()                    { *m = Pagination{} }
 func (m *Pagination) String() string            { return proto.CompactTextString(m) }
 func (*Pagination) ProtoMessage()               {}
-func (*Pagination) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (*Pagination) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
 
 func (m *Pagination) GetPageToken() string {
 	if m != nil {
@@ -1372,6 +2301,13 @@ func (m *Pagination) GetLimit() int32 {
 	return 0
 }
 
+func (m *Pagination) GetHasLimit() bool {
+	if m != nil {
+		return m.HasLimit
+	}
+	return false
+}
+
 // PageInfo represents both server-driven and client-driven pagination response.
 // Server-driven pagination is a model in which the server returns some
 // amount of data along with an token indicating there is more data
@@ -1388,12 +2324,15 @@ type PageInfo struct {
 	// The service may optionally include the offset of the next page of resources.
 	// A null value indicates no more pages.
 	Offset int32 `protobuf:"varint,3,opt,name=offset" json:"offset,omitempty"`
+	// The service may optionally include the total number of resources matching the request.
+	// Unset value indicates the total is unknown or was not computed.
+	TotalSize *google_protobuf.Int32Value `protobuf:"bytes,4,opt,name=total_size,json=totalSize" json:"total_size,omitempty"`
 }
 
 func (m *PageInfo) Reset()                    { *m = PageInfo{} }
 func (m *PageInfo) String() string            { return proto.CompactTextString(m) }
 func (*PageInfo) ProtoMessage()               {}
-func (*PageInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (*PageInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
 
 func (m *PageInfo) GetPageToken() string {
 	if m != nil {
@@ -1416,6 +2355,34 @@ func (m *PageInfo) GetOffset() int32 {
 	return 0
 }
 
+func (m *PageInfo) GetTotalSize() *google_protobuf.Int32Value {
+	if m != nil {
+		return m.TotalSize
+	}
+	return nil
+}
+
+// CountOnly represents a request for the total number of resources matching
+// the request without the resources themselves.
+// The service is expected to return an empty collection along with PageInfo
+// that has only total_size set.
+type CountOnly struct {
+	// Whether only the total number of resources is requested.
+	Enabled bool `protobuf:"varint,1,opt,name=enabled" json:"enabled,omitempty"`
+}
+
+func (m *CountOnly) Reset()                    { *m = CountOnly{} }
+func (m *CountOnly) String() string            { return proto.CompactTextString(m) }
+func (*CountOnly) ProtoMessage()               {}
+func (*CountOnly) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+func (m *CountOnly) GetEnabled() bool {
+	if m != nil {
+		return m.Enabled
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*SortCriteria)(nil), "infoblox.api.SortCriteria")
 	proto.RegisterType((*Sorting)(nil), "infoblox.api.Sorting")
@@ -1429,14 +2396,24 @@ func init() {
 	proto.RegisterType((*BoolCondition)(nil), "infoblox.api.BoolCondition")
 	proto.RegisterType((*StringArrayCondition)(nil), "infoblox.api.StringArrayCondition")
 	proto.RegisterType((*NumberArrayCondition)(nil), "infoblox.api.NumberArrayCondition")
+	proto.RegisterType((*ExistsCondition)(nil), "infoblox.api.ExistsCondition")
+	proto.RegisterType((*FieldCondition)(nil), "infoblox.api.FieldCondition")
+	proto.RegisterType((*ContainsCondition)(nil), "infoblox.api.ContainsCondition")
+	proto.RegisterType((*CustomCondition)(nil), "infoblox.api.CustomCondition")
+	proto.RegisterType((*CustomCondition_StringArray)(nil), "infoblox.api.CustomCondition.StringArray")
+	proto.RegisterType((*CustomCondition_NumberArray)(nil), "infoblox.api.CustomCondition.NumberArray")
 	proto.RegisterType((*Pagination)(nil), "infoblox.api.Pagination")
 	proto.RegisterType((*PageInfo)(nil), "infoblox.api.PageInfo")
+	proto.RegisterType((*CountOnly)(nil), "infoblox.api.CountOnly")
 	proto.RegisterEnum("infoblox.api.SortCriteria_Order", SortCriteria_Order_name, SortCriteria_Order_value)
+	proto.RegisterEnum("infoblox.api.SortCriteria_Nulls", SortCriteria_Nulls_name, SortCriteria_Nulls_value)
 	proto.RegisterEnum("infoblox.api.LogicalOperator_Type", LogicalOperator_Type_name, LogicalOperator_Type_value)
 	proto.RegisterEnum("infoblox.api.StringCondition_Type", StringCondition_Type_name, StringCondition_Type_value)
 	proto.RegisterEnum("infoblox.api.NumberCondition_Type", NumberCondition_Type_name, NumberCondition_Type_value)
+	proto.RegisterEnum("infoblox.api.BoolCondition_Type", BoolCondition_Type_name, BoolCondition_Type_value)
 	proto.RegisterEnum("infoblox.api.StringArrayCondition_Type", StringArrayCondition_Type_name, StringArrayCondition_Type_value)
 	proto.RegisterEnum("infoblox.api.NumberArrayCondition_Type", NumberArrayCondition_Type_name, NumberArrayCondition_Type_value)
+	proto.RegisterEnum("infoblox.api.FieldCondition_Type", FieldCondition_Type_name, FieldCondition_Type_value)
 }
 
 func init() {
@@ -1444,79 +2421,120 @@ func init() {
 }
 
 var fileDescriptor0 = []byte{
-	// 1176 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x57, 0xd1, 0x4e, 0xe3, 0x46,
-	0x17, 0x8e, 0x13, 0x27, 0xc1, 0x07, 0x12, 0xbc, 0x03, 0x3f, 0x1b, 0x60, 0xf7, 0x5f, 0x6a, 0x55,
-	0x2a, 0x95, 0x4a, 0xa2, 0xcd, 0x4a, 0xab, 0x15, 0x7b, 0xd3, 0x00, 0xa1, 0x4b, 0xc5, 0x02, 0xeb,
-	0xb0, 0x37, 0x7b, 0x13, 0x4d, 0xc2, 0xc4, 0x58, 0x18, 0x8f, 0x6b, 0x4f, 0x68, 0xd3, 0xc7, 0xe0,
-	0xb2, 0xea, 0x9b, 0xf4, 0x05, 0x7a, 0x51, 0xa9, 0x52, 0x5f, 0xa1, 0x2f, 0x52, 0xcd, 0x78, 0x9c,
-	0xd8, 0x8e, 0xd9, 0x24, 0xcb, 0x0d, 0xf6, 0x1c, 0xce, 0xf9, 0xbe, 0xf3, 0x9d, 0xf9, 0xec, 0x8c,
-	0xe1, 0xd8, 0xb2, 0xd9, 0xf5, 0xb0, 0x57, 0xef, 0xd3, 0xdb, 0x86, 0x87, 0x7d, 0x66, 0x33, 0x9b,
-	0x36, 0x30, 0x73, 0x70, 0xb0, 0x87, 0x3d, 0x6f, 0x8f, 0x51, 0xea, 0xdc, 0xd8, 0xac, 0xf1, 0xd3,
-	0x90, 0xf8, 0xa3, 0x46, 0x9f, 0x3a, 0x0e, 0xe9, 0x33, 0x9b, 0xba, 0x5d, 0xea, 0x11, 0x1f, 0x33,
-	0xea, 0x07, 0x75, 0xcf, 0xa7, 0x8c, 0xa2, 0x15, 0xdb, 0x1d, 0xd0, 0x9e, 0x43, 0x7f, 0xa9, 0x63,
-	0xcf, 0xde, 0xfa, 0x4e, 0x04, 0xfb, 0x7b, 0x16, 0x71, 0xf7, 0x82, 0x9f, 0xb1, 0x65, 0x11, 0xbf,
-	0x41, 0x3d, 0x5e, 0x18, 0x34, 0xb0, 0xeb, 0x52, 0x86, 0xc5, 0x7d, 0x58, 0x6b, 0x30, 0x58, 0xe9,
-	0x50, 0x9f, 0x1d, 0xfa, 0x36, 0x23, 0xbe, 0x8d, 0x91, 0x0e, 0x05, 0x86, 0xad, 0x9a, 0xb2, 0xa3,
-	0xec, 0x6a, 0x26, 0xbf, 0x45, 0xaf, 0xa1, 0x48, 0xfd, 0x2b, 0xe2, 0xd7, 0xf2, 0x3b, 0xca, 0x6e,
-	0xb5, 0xb9, 0x53, 0x8f, 0xb3, 0xd5, 0xe3, 0xc5, 0xf5, 0x73, 0x9e, 0x67, 0x86, 0xe9, 0xc6, 0x16,
-	0x14, 0xc5, 0x1a, 0x95, 0xa1, 0xd0, 0xea, 0x1c, 0xea, 0x39, 0xb4, 0x04, 0xea, 0x51, 0xbb, 0x73,
-	0xa8, 0x2b, 0x06, 0x86, 0x32, 0x2f, 0xb4, 0x5d, 0x0b, 0xbd, 0x01, 0xad, 0x2f, 0xeb, 0x83, 0x9a,
-	0xb2, 0x53, 0xd8, 0x5d, 0x6e, 0x6e, 0x3d, 0x4c, 0x61, 0x4e, 0x92, 0xf7, 0x9f, 0xdd, 0xb7, 0x36,
-	0xe1, 0x69, 0xf3, 0x89, 0x98, 0x98, 0xc8, 0x0c, 0x42, 0xcc, 0xdf, 0xf2, 0x4a, 0xd9, 0xf8, 0x53,
-	0x81, 0xea, 0xb1, 0x4d, 0x9c, 0xab, 0x0e, 0x91, 0x73, 0x43, 0xdf, 0x43, 0x69, 0xc0, 0x23, 0x11,
-	0xcf, 0x6e, 0x92, 0x27, 0x99, 0x1d, 0x2e, 0x83, 0xb6, 0xcb, 0xfc, 0x91, 0x29, 0xeb, 0xb6, 0xce,
-	0x60, 0x39, 0x16, 0xe6, 0xc3, 0xba, 0x21, 0xa3, 0x68, 0x58, 0x37, 0x64, 0x84, 0xbe, 0x85, 0xe2,
-	0x1d, 0x76, 0x86, 0x44, 0x0c, 0x6b, 0xb9, 0xb9, 0x96, 0xc1, 0x60, 0x86, 0x19, 0xfb, 0xf9, 0x37,
-	0xca, 0xfe, 0xd7, 0xf7, 0xad, 0xaf, 0xe0, 0x45, 0x73, 0x73, 0x22, 0x41, 0x10, 0x75, 0x83, 0xa8,
-	0x0b, 0x21, 0xe5, 0x77, 0x05, 0x8a, 0xa2, 0x14, 0x21, 0x50, 0x5d, 0x7c, 0x4b, 0x24, 0xa3, 0xb8,
-	0x47, 0x2f, 0x41, 0x0d, 0x86, 0xbd, 0xa0, 0x96, 0x17, 0x9a, 0x9e, 0x67, 0x30, 0xd6, 0x3b, 0xc3,
-	0x9e, 0x14, 0x22, 0x52, 0xb7, 0x4e, 0x41, 0x1b, 0x87, 0x1e, 0x2d, 0xc2, 0xf8, 0x4b, 0x05, 0xed,
-	0xd8, 0x76, 0xf8, 0xae, 0xb8, 0x16, 0x7a, 0x0b, 0x4b, 0x91, 0x3f, 0x05, 0xe6, 0x54, 0x4b, 0xa7,
-	0xd4, 0xb2, 0xfb, 0xd8, 0x39, 0x97, 0x49, 0xef, 0x72, 0xe6, 0xb8, 0x00, 0xfd, 0x08, 0x7a, 0xc0,
-	0x38, 0x4c, 0xb7, 0x4f, 0xdd, 0x2b, 0xfe, 0x3c, 0xb8, 0xb2, 0x89, 0x14, 0x48, 0x47, 0x64, 0x1d,
-	0x46, 0x49, 0xef, 0x72, 0xe6, 0x6a, 0x90, 0x0c, 0x71, 0x2c, 0x77, 0x78, 0xdb, 0x23, 0x7e, 0x0c,
-	0xab, 0x90, 0x85, 0x75, 0x26, 0xb2, 0x12, 0x58, 0x6e, 0x32, 0x84, 0x8e, 0xa0, 0xea, 0x0e, 0x1d,
-	0x27, 0x86, 0xa4, 0x0a, 0xa4, 0xed, 0x34, 0x92, 0xe3, 0xc4, 0x71, 0x2a, 0x6e, 0x3c, 0x80, 0x3e,
-	0xc1, 0x86, 0x54, 0x87, 0x7d, 0x1f, 0x8f, 0x62, 0x68, 0x45, 0x81, 0x66, 0x64, 0x69, 0x6c, 0xf1,
-	0xd4, 0x38, 0xe8, 0x7a, 0x90, 0x11, 0xe7, 0xd8, 0x52, 0x6d, 0x1a, 0xbb, 0x94, 0x85, 0x1d, 0x6a,
-	0x9e, 0xc6, 0x76, 0x33, 0xe2, 0x5c, 0x7d, 0x8f, 0xd2, 0xb8, 0xfa, 0x72, 0x96, 0xfa, 0x03, 0x4a,
-	0x93, 0xea, 0x7b, 0xf1, 0xc0, 0xfe, 0xff, 0xef, 0x5b, 0xdb, 0xb0, 0xd9, 0x5c, 0x8b, 0x7b, 0x5d,
-	0x9a, 0x86, 0xbb, 0xfc, 0xa0, 0x04, 0xaa, 0x4f, 0x29, 0x33, 0xfe, 0x01, 0x58, 0x4d, 0x79, 0x04,
-	0x1d, 0x41, 0xc5, 0x21, 0x03, 0xd6, 0x5d, 0xd4, 0x59, 0x2b, 0xbc, 0x6a, 0x8c, 0xd2, 0x81, 0xff,
-	0x09, 0x94, 0x2f, 0xb5, 0xd8, 0x1a, 0xaf, 0x4e, 0x85, 0xc7, 0xa0, 0x5f, 0xea, 0x35, 0x01, 0x9a,
-	0x0a, 0xa3, 0xf7, 0xb0, 0x26, 0x41, 0x17, 0x37, 0xdd, 0x93, 0x10, 0x30, 0x6e, 0xbc, 0x3e, 0x6c,
-	0xc7, 0x85, 0xa7, 0x1d, 0xb2, 0xbc, 0x80, 0xfb, 0x6a, 0x93, 0x19, 0xa4, 0x5c, 0x12, 0x91, 0x3c,
-	0x60, 0xc3, 0x95, 0x05, 0x6c, 0x58, 0x9b, 0xcc, 0x24, 0x45, 0x12, 0x0d, 0x26, 0xe5, 0xc7, 0xd5,
-	0x79, 0xfc, 0x28, 0x06, 0x93, 0x08, 0xa2, 0x63, 0xa8, 0xfa, 0xb6, 0x75, 0x1d, 0x33, 0x56, 0x71,
-	0x1e, 0x63, 0x29, 0x66, 0x45, 0x94, 0x8d, 0x9d, 0xf5, 0x11, 0x36, 0x42, 0x9c, 0x29, 0x6b, 0x95,
-	0xe6, 0xb1, 0x96, 0x62, 0xae, 0x8b, 0xf2, 0xb4, 0xb7, 0xc6, 0xb0, 0x53, 0xe6, 0x2a, 0xcf, 0x63,
-	0xae, 0x08, 0x36, 0xed, 0xae, 0x73, 0x58, 0x8f, 0x60, 0x13, 0xf6, 0x5a, 0x9a, 0x6d, 0x2f, 0xc5,
-	0x44, 0x12, 0x32, 0xee, 0x2f, 0x02, 0xcf, 0x12, 0xf2, 0xd3, 0x7b, 0x5f, 0x99, 0xdb, 0x60, 0x8a,
-	0xb9, 0x19, 0x9b, 0x44, 0x6a, 0xf3, 0xc7, 0x34, 0x0f, 0x58, 0xac, 0x3a, 0xb7, 0xc5, 0x22, 0x9a,
-	0x4c, 0x8f, 0x8d, 0xc7, 0x93, 0x32, 0x99, 0x3e, 0xdb, 0x64, 0xd1, 0x78, 0x92, 0x2e, 0x7b, 0x0d,
-	0x2a, 0x1b, 0x79, 0xa4, 0xa6, 0x89, 0x03, 0x94, 0xf1, 0x59, 0x6f, 0xd5, 0x2f, 0x47, 0x1e, 0x31,
-	0x45, 0x3e, 0x7a, 0x01, 0xcb, 0x76, 0xd0, 0x75, 0x89, 0x85, 0x99, 0x7d, 0x47, 0x6a, 0xb0, 0xa3,
-	0xec, 0x2e, 0x99, 0x60, 0x07, 0x67, 0x32, 0x62, 0x3c, 0x05, 0x95, 0xa7, 0x8b, 0x13, 0xd6, 0xd9,
-	0x91, 0x9e, 0x43, 0x25, 0xc8, 0x9f, 0x9b, 0xba, 0xc2, 0xdf, 0xa5, 0xc2, 0xec, 0x65, 0x28, 0x8a,
-	0x7e, 0x8c, 0x7f, 0x15, 0x58, 0x4d, 0xbb, 0xeb, 0x39, 0x40, 0x78, 0xda, 0xf0, 0x30, 0xbb, 0x16,
-	0x47, 0x22, 0xcd, 0xd4, 0x44, 0xe4, 0x02, 0xb3, 0x6b, 0xb4, 0x1e, 0x3f, 0x05, 0x68, 0xf2, 0x07,
-	0x7f, 0xac, 0xa5, 0x90, 0xa5, 0x25, 0xc5, 0xf0, 0x19, 0x2d, 0xea, 0x94, 0x96, 0x03, 0xa9, 0xa5,
-	0x04, 0xf9, 0xf6, 0x07, 0x3d, 0x87, 0x34, 0x28, 0xbe, 0x6f, 0x5d, 0x1e, 0xbe, 0xd3, 0x15, 0x1e,
-	0xfa, 0xe1, 0x52, 0xcf, 0x8b, 0x6b, 0x5b, 0x2f, 0xf0, 0xeb, 0xe9, 0xa5, 0xae, 0x8a, 0x6b, 0x5b,
-	0x2f, 0x72, 0xf9, 0x27, 0xed, 0x0f, 0x7a, 0xc9, 0xf8, 0x5b, 0x81, 0xd5, 0xb4, 0xd9, 0x17, 0x51,
-	0xa9, 0xcc, 0xa5, 0x32, 0xc5, 0xb0, 0x90, 0xca, 0x7a, 0x4a, 0x65, 0x28, 0x4d, 0x91, 0xd2, 0xf2,
-	0x52, 0x5a, 0x41, 0x4a, 0x53, 0x8d, 0x73, 0xa8, 0x24, 0x1f, 0xb5, 0x19, 0x72, 0x52, 0x0d, 0xe4,
-	0xa7, 0x1a, 0x20, 0x50, 0x49, 0x9a, 0xf3, 0x91, 0x80, 0x93, 0x01, 0x16, 0xc4, 0xbf, 0xc2, 0x85,
-	0xf1, 0x87, 0x02, 0xeb, 0x99, 0xcf, 0xf0, 0x0c, 0xba, 0x0d, 0x28, 0x09, 0x80, 0xf0, 0x38, 0xab,
-	0x99, 0x72, 0x85, 0xde, 0x26, 0x36, 0xe4, 0x9b, 0xd9, 0x6f, 0x92, 0x85, 0x76, 0xa5, 0x3a, 0xd9,
-	0x95, 0x93, 0x33, 0x3d, 0x27, 0xba, 0xcf, 0x7c, 0x35, 0x2c, 0xd4, 0xbd, 0x32, 0x5f, 0xf7, 0x59,
-	0x44, 0x8f, 0xea, 0xfe, 0x0e, 0xe0, 0x02, 0x5b, 0xb6, 0x8b, 0xa3, 0x96, 0x3d, 0x6c, 0x91, 0x2e,
-	0xa3, 0x37, 0xc4, 0x95, 0xa7, 0x7c, 0x8d, 0x47, 0x2e, 0x79, 0x80, 0xb7, 0x4c, 0x07, 0x83, 0x80,
-	0x30, 0xb1, 0xb5, 0x45, 0x53, 0xae, 0xf8, 0xb6, 0x3a, 0xf6, 0xad, 0xcd, 0x44, 0xcf, 0x45, 0x33,
-	0x5c, 0xec, 0x6f, 0xdf, 0xb7, 0x6a, 0xb0, 0xd1, 0xd4, 0x27, 0x67, 0x38, 0x8f, 0x33, 0x85, 0x5f,
-	0x5c, 0x1f, 0x61, 0xe9, 0x02, 0x5b, 0xe4, 0xc4, 0x1d, 0xd0, 0x59, 0xac, 0x08, 0xd4, 0xc0, 0xfe,
-	0x95, 0x48, 0x4e, 0x71, 0x1f, 0xeb, 0xa4, 0x10, 0xef, 0xe4, 0xe0, 0xd5, 0xa7, 0x97, 0x0b, 0x7c,
-	0x27, 0xbf, 0x15, 0x7f, 0x7b, 0x25, 0xf1, 0x75, 0xfb, 0xea, 0xbf, 0x00, 0x00, 0x00, 0xff, 0xff,
-	0x85, 0x64, 0x88, 0xaf, 0x63, 0x0f, 0x00, 0x00,
+	// 1831 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x59, 0xdd, 0x6e, 0xdb, 0xc8,
+	0x15, 0x16, 0xf5, 0xcf, 0x23, 0x5b, 0xa2, 0xc7, 0x8e, 0xa3, 0xc8, 0x9b, 0xac, 0xcb, 0xa0, 0xa8,
+	0x17, 0x68, 0x64, 0xac, 0xd2, 0x2e, 0x16, 0xce, 0x4d, 0x15, 0x5b, 0x5e, 0xab, 0xd5, 0xda, 0x0e,
+	0xa5, 0x04, 0xd8, 0xed, 0x85, 0x4a, 0xc9, 0x23, 0x9a, 0x08, 0xcd, 0x51, 0x49, 0x6a, 0x37, 0xda,
+	0x27, 0xe8, 0x6d, 0x7d, 0x95, 0x8b, 0xbe, 0x49, 0x1f, 0xa0, 0xcf, 0xd1, 0x8b, 0x02, 0x2d, 0xd0,
+	0x67, 0x28, 0x8a, 0x99, 0x21, 0xa5, 0xe1, 0x88, 0xb1, 0xa9, 0x64, 0x6f, 0x2c, 0xce, 0xc7, 0x33,
+	0xdf, 0xf9, 0xe3, 0x37, 0x1c, 0x8e, 0xe1, 0xd4, 0xb2, 0x83, 0xeb, 0xd9, 0xa8, 0x39, 0x26, 0x37,
+	0x87, 0x53, 0xd3, 0x0b, 0xec, 0xc0, 0x26, 0x87, 0x66, 0xe0, 0x98, 0xfe, 0x33, 0x73, 0x3a, 0x7d,
+	0x16, 0x10, 0xe2, 0xbc, 0xb5, 0x83, 0xc3, 0x3f, 0xcf, 0xb0, 0x37, 0x3f, 0x1c, 0x13, 0xc7, 0xc1,
+	0xe3, 0xc0, 0x26, 0xee, 0x90, 0x4c, 0xb1, 0x67, 0x06, 0xc4, 0xf3, 0x9b, 0x53, 0x8f, 0x04, 0x04,
+	0x6d, 0xd8, 0xee, 0x84, 0x8c, 0x1c, 0xf2, 0xae, 0x69, 0x4e, 0xed, 0xc6, 0x13, 0x8b, 0x10, 0xcb,
+	0xc1, 0x87, 0xec, 0xde, 0x68, 0x36, 0x39, 0xfc, 0xd1, 0x33, 0xa7, 0x53, 0x1c, 0x59, 0x37, 0x7e,
+	0xcd, 0x7e, 0xc6, 0xcf, 0x2c, 0xec, 0x3e, 0xf3, 0x7f, 0x34, 0x2d, 0x0b, 0x7b, 0x87, 0x64, 0x4a,
+	0x89, 0xfd, 0x43, 0xd3, 0x75, 0x49, 0x60, 0xb2, 0x6b, 0x6e, 0xad, 0xff, 0x47, 0x81, 0x8d, 0x3e,
+	0xf1, 0x82, 0x63, 0xcf, 0x0e, 0xb0, 0x67, 0x9b, 0x48, 0x83, 0x5c, 0x60, 0x5a, 0x75, 0x65, 0x5f,
+	0x39, 0x50, 0x0d, 0x7a, 0x89, 0xbe, 0x82, 0x02, 0xf1, 0xae, 0xb0, 0x57, 0xcf, 0xee, 0x2b, 0x07,
+	0xd5, 0xd6, 0x7e, 0x53, 0x0c, 0xa7, 0x29, 0x4e, 0x6e, 0x5e, 0x50, 0x3b, 0x83, 0x9b, 0xd3, 0x79,
+	0xee, 0xcc, 0x71, 0xfc, 0x7a, 0xee, 0xde, 0x79, 0xe7, 0xd4, 0xce, 0xe0, 0xe6, 0x7a, 0x03, 0x0a,
+	0x8c, 0x07, 0x95, 0x20, 0xd7, 0xee, 0x1f, 0x6b, 0x19, 0x54, 0x86, 0xfc, 0x49, 0xa7, 0x7f, 0xac,
+	0x29, 0xfa, 0x0b, 0x28, 0x30, 0x5b, 0xb4, 0x05, 0x9b, 0xe7, 0xaf, 0x7b, 0xbd, 0xfe, 0xf0, 0xa4,
+	0x73, 0xda, 0x7e, 0xdd, 0x1b, 0x68, 0x19, 0x54, 0x83, 0x0a, 0x87, 0x4e, 0xbb, 0x46, 0x7f, 0xa0,
+	0x29, 0xa8, 0x0a, 0xc0, 0x81, 0x5e, 0xbb, 0x3f, 0xd0, 0xb2, 0xfa, 0x9f, 0xa0, 0x44, 0xbd, 0xda,
+	0xae, 0x85, 0xbe, 0x06, 0x75, 0x1c, 0x3a, 0xf7, 0xeb, 0xca, 0x7e, 0xee, 0xa0, 0xd2, 0x6a, 0x7c,
+	0x38, 0x3e, 0x63, 0x69, 0x7c, 0xb4, 0x77, 0xdb, 0xae, 0xc3, 0x6e, 0x6b, 0x8b, 0xf5, 0x91, 0x59,
+	0xfa, 0x9c, 0xf3, 0x7d, 0xb6, 0xa4, 0xff, 0x53, 0x81, 0xea, 0xa9, 0x8d, 0x9d, 0xab, 0x3e, 0x0e,
+	0x9b, 0x89, 0x7e, 0x07, 0xc5, 0x09, 0x45, 0x22, 0x37, 0x07, 0x71, 0x37, 0x71, 0x6b, 0x3e, 0xf4,
+	0x3b, 0x6e, 0xe0, 0xcd, 0x8d, 0x70, 0x1e, 0xaa, 0x43, 0x09, 0xbf, 0x1b, 0x3b, 0xb3, 0x2b, 0xcc,
+	0x3a, 0x50, 0x36, 0xa2, 0x61, 0xe3, 0x1c, 0x2a, 0xc2, 0x04, 0xda, 0xba, 0xb7, 0x78, 0x1e, 0xb5,
+	0xee, 0x2d, 0x9e, 0xa3, 0x2f, 0xa0, 0xf0, 0x83, 0xe9, 0xcc, 0xf8, 0xc4, 0x4a, 0x6b, 0x3b, 0xc1,
+	0xb7, 0xc1, 0x2d, 0x8e, 0xb2, 0x5f, 0x2b, 0x47, 0x4f, 0x6f, 0xdb, 0xfb, 0xf0, 0xa4, 0xf5, 0x68,
+	0x99, 0x1b, 0x0b, 0x61, 0xe8, 0x47, 0xf1, 0xd1, 0x1c, 0xff, 0xa6, 0x40, 0x81, 0xcd, 0x44, 0x08,
+	0xf2, 0xae, 0x79, 0x83, 0x43, 0x87, 0xec, 0x1a, 0x7d, 0x09, 0x79, 0x7f, 0x36, 0xf2, 0xeb, 0x59,
+	0x96, 0xec, 0xe3, 0x04, 0x87, 0xcd, 0xfe, 0x6c, 0x14, 0x66, 0xc8, 0x4c, 0x1b, 0x3d, 0x50, 0x17,
+	0xd0, 0x27, 0xe7, 0xa0, 0xff, 0xaf, 0x08, 0xea, 0xa9, 0xed, 0xd0, 0x6e, 0xb9, 0x16, 0x7a, 0x01,
+	0xe5, 0x48, 0x4d, 0x8c, 0x73, 0x25, 0xa4, 0x1e, 0xb1, 0xec, 0xb1, 0xe9, 0x5c, 0x84, 0x46, 0x67,
+	0x19, 0x63, 0x31, 0x01, 0xfd, 0x1e, 0x34, 0x3f, 0xa0, 0x34, 0xc3, 0x31, 0x71, 0xaf, 0xa8, 0x7a,
+	0xdd, 0x7a, 0x36, 0x89, 0xa4, 0xcf, 0xac, 0x8e, 0x23, 0xa3, 0xb3, 0x8c, 0x51, 0xf3, 0xe3, 0x10,
+	0xe5, 0x72, 0x67, 0x37, 0x23, 0xec, 0x09, 0x5c, 0xb9, 0x24, 0xae, 0x73, 0x66, 0x15, 0xe3, 0x72,
+	0xe3, 0x10, 0x3a, 0x81, 0x2a, 0x55, 0x8a, 0xc0, 0x94, 0x67, 0x4c, 0x7b, 0x32, 0x93, 0xe3, 0x88,
+	0x3c, 0x9b, 0xae, 0x08, 0xa0, 0xef, 0x61, 0x37, 0xcc, 0xce, 0xf4, 0x3c, 0x73, 0x2e, 0xb0, 0x15,
+	0x18, 0x9b, 0x9e, 0x94, 0x63, 0x9b, 0x9a, 0x8a, 0xa4, 0x3b, 0x7e, 0x02, 0x4e, 0xb9, 0xc3, 0x6c,
+	0x65, 0xee, 0x62, 0x12, 0x37, 0xcf, 0x79, 0x95, 0xdb, 0x4d, 0xc0, 0x69, 0xf6, 0x23, 0x42, 0xc4,
+	0xec, 0x4b, 0x49, 0xd9, 0xbf, 0x24, 0x24, 0x9e, 0xfd, 0x48, 0x04, 0x68, 0x3f, 0xc6, 0x33, 0x3f,
+	0x20, 0x37, 0x02, 0x4f, 0x39, 0xa9, 0x1f, 0xc7, 0xcc, 0x2a, 0xd6, 0x8f, 0x71, 0x1c, 0xa2, 0x5c,
+	0xf8, 0x9d, 0xed, 0x07, 0xbe, 0xc0, 0xa5, 0x26, 0x71, 0x75, 0x98, 0x55, 0x8c, 0x0b, 0xc7, 0x21,
+	0xf4, 0x0d, 0xd4, 0xb8, 0xe6, 0x96, 0x54, 0xc0, 0xa8, 0x3e, 0x4b, 0x78, 0xee, 0x45, 0xa6, 0xea,
+	0x24, 0x86, 0xa0, 0x4b, 0x40, 0x63, 0xe2, 0x06, 0xa6, 0xed, 0x8a, 0x61, 0x55, 0x18, 0xd7, 0xe7,
+	0x52, 0x8a, 0xa1, 0x9d, 0x48, 0xb7, 0x35, 0x96, 0xc1, 0xa3, 0xc7, 0xb7, 0xed, 0x06, 0xd4, 0x5b,
+	0xdb, 0xe2, 0xea, 0x10, 0xea, 0xec, 0x7d, 0xb6, 0xf4, 0xb2, 0x08, 0x79, 0x8f, 0x90, 0x40, 0xff,
+	0x8b, 0x06, 0x35, 0x49, 0x55, 0xe8, 0x04, 0x36, 0x1d, 0x3c, 0x09, 0x86, 0xeb, 0x6a, 0x71, 0x83,
+	0xce, 0x5a, 0xb0, 0xf4, 0xe1, 0x01, 0x63, 0xf9, 0x58, 0x51, 0x6e, 0xd3, 0xd9, 0x12, 0xbc, 0x20,
+	0xfd, 0x58, 0x75, 0x32, 0x52, 0x09, 0x46, 0xdf, 0xc2, 0x76, 0x48, 0xba, 0xbe, 0x4c, 0xb7, 0x38,
+	0xa1, 0x00, 0xa2, 0x31, 0xec, 0x89, 0x89, 0xcb, 0x9a, 0xaa, 0xac, 0xa1, 0xd7, 0xfa, 0xb2, 0x06,
+	0xf1, 0x7b, 0x0b, 0x27, 0x1f, 0x10, 0xee, 0xc6, 0x1a, 0xc2, 0xad, 0x2f, 0x6b, 0x22, 0x39, 0x89,
+	0x0a, 0x23, 0x29, 0xb8, 0x96, 0x46, 0xc1, 0xac, 0x30, 0x31, 0x70, 0xd1, 0xbc, 0x15, 0x29, 0x6f,
+	0xa5, 0x93, 0x32, 0x0b, 0x46, 0x82, 0x17, 0xa4, 0x2b, 0x9a, 0xde, 0x4e, 0xa7, 0x69, 0x46, 0x2a,
+	0xc1, 0xe8, 0x12, 0x76, 0x18, 0xa9, 0x2c, 0xee, 0x07, 0xa9, 0xc4, 0x8d, 0xe8, 0xdc, 0x38, 0x8a,
+	0xbe, 0x83, 0x87, 0x3c, 0xf7, 0x55, 0x95, 0x3f, 0x4c, 0xab, 0x72, 0x96, 0xe8, 0xca, 0x0d, 0x74,
+	0x0a, 0x55, 0xcf, 0xb6, 0xae, 0x05, 0xbd, 0x16, 0xd2, 0xe8, 0x55, 0x31, 0x36, 0xd9, 0xb4, 0x08,
+	0x40, 0xaf, 0x61, 0x97, 0xf3, 0xac, 0x28, 0xb6, 0x98, 0x46, 0xb1, 0x8a, 0xb1, 0xc3, 0xa6, 0x4b,
+	0xf8, 0x92, 0x76, 0x45, 0xb3, 0xa5, 0x34, 0x9a, 0x8d, 0x68, 0x25, 0x1c, 0x5d, 0xc0, 0x4e, 0x44,
+	0xeb, 0x38, 0x2b, 0xaf, 0x85, 0x3b, 0x55, 0xab, 0x18, 0x28, 0xa4, 0x14, 0x50, 0x84, 0xe1, 0xb3,
+	0x58, 0xfa, 0xb2, 0xa4, 0x36, 0x53, 0xeb, 0x56, 0x31, 0x1e, 0x09, 0x95, 0x88, 0xdf, 0x5c, 0xba,
+	0xf9, 0x80, 0x72, 0xab, 0xa9, 0x95, 0x1b, 0xb9, 0x49, 0xba, 0xb9, 0x2c, 0x8f, 0xa4, 0x5d, 0xed,
+	0x7e, 0xed, 0x46, 0xe5, 0x89, 0xa1, 0xcb, 0x36, 0xae, 0xa8, 0x17, 0xa5, 0x51, 0x6f, 0xd4, 0x46,
+	0x09, 0x5f, 0xd2, 0xae, 0xe8, 0x77, 0x27, 0x8d, 0x7e, 0x23, 0x5a, 0x09, 0x47, 0x06, 0x3c, 0xe0,
+	0xb4, 0xb2, 0x82, 0x77, 0x53, 0x28, 0x58, 0x31, 0xb6, 0xd9, 0xe4, 0x38, 0x8c, 0xfe, 0x08, 0xf5,
+	0xb0, 0x02, 0xab, 0x1a, 0xae, 0xa7, 0xd3, 0xb0, 0x62, 0xf0, 0x6c, 0x57, 0xee, 0xa0, 0xaf, 0x20,
+	0x1f, 0xcc, 0xa7, 0x98, 0xed, 0x44, 0xaa, 0x2d, 0xfd, 0x4e, 0xe9, 0x36, 0x07, 0xf3, 0x29, 0x36,
+	0x98, 0x3d, 0xfa, 0x1c, 0x2a, 0xb6, 0x3f, 0x74, 0xb1, 0x65, 0x06, 0xf6, 0x0f, 0x98, 0xed, 0x3e,
+	0xca, 0x06, 0xd8, 0xfe, 0x79, 0x88, 0xe8, 0x0f, 0x21, 0x4f, 0xcd, 0xd9, 0xe7, 0xd9, 0xf9, 0x89,
+	0x96, 0x41, 0x45, 0xc8, 0x5e, 0x18, 0x9a, 0x42, 0x77, 0x00, 0x6c, 0x89, 0x2e, 0x41, 0x81, 0xc5,
+	0xa4, 0xff, 0x23, 0x0b, 0x35, 0x59, 0xbc, 0x8f, 0x01, 0x78, 0x05, 0xa7, 0x66, 0x70, 0xcd, 0xbe,
+	0x89, 0x54, 0x43, 0x65, 0xc8, 0xa5, 0x19, 0x5c, 0xa3, 0x1d, 0x71, 0xb7, 0xaf, 0x86, 0x1b, 0xfb,
+	0x45, 0x2e, 0xb9, 0xa4, 0x5c, 0x24, 0x0f, 0x77, 0xe4, 0x92, 0x97, 0x73, 0x41, 0x7b, 0xa0, 0x32,
+	0xb5, 0xfb, 0xe6, 0x04, 0xb3, 0x45, 0xae, 0x6c, 0x94, 0x29, 0xd0, 0x37, 0x27, 0x18, 0x3d, 0x85,
+	0x4d, 0x0f, 0x3b, 0xcc, 0x70, 0x18, 0xd8, 0x37, 0x98, 0xad, 0x5a, 0x65, 0x63, 0x23, 0x02, 0x07,
+	0xf6, 0x0d, 0xd6, 0xaf, 0xc2, 0x6a, 0x14, 0x21, 0xdb, 0x79, 0xa5, 0x65, 0x90, 0x0a, 0x85, 0x6f,
+	0xdb, 0x83, 0xe3, 0x33, 0x4d, 0xa1, 0xd0, 0x37, 0x03, 0x2d, 0xcb, 0x7e, 0x3b, 0x5a, 0x8e, 0xfe,
+	0xf6, 0x06, 0x5a, 0x9e, 0xfd, 0x76, 0xb4, 0x02, 0x2d, 0x60, 0xb7, 0xf3, 0x4a, 0x2b, 0xd2, 0xef,
+	0xdb, 0x5e, 0xf7, 0x0f, 0x1d, 0xad, 0x44, 0x67, 0x77, 0xd9, 0x65, 0x19, 0x55, 0xa0, 0xd4, 0x3d,
+	0x1f, 0x1e, 0x77, 0x4f, 0x0c, 0x4d, 0xd5, 0xff, 0xab, 0x40, 0x4d, 0x5e, 0xaf, 0xd6, 0xa9, 0xa4,
+	0x92, 0xaa, 0x92, 0x92, 0x87, 0x9f, 0xad, 0x92, 0x7a, 0x53, 0x2a, 0x12, 0xaf, 0x8c, 0x12, 0x56,
+	0x26, 0x1b, 0x56, 0x26, 0x17, 0x56, 0x26, 0xaf, 0x3b, 0xb0, 0x19, 0x5f, 0x4a, 0xef, 0xc9, 0x55,
+	0x8a, 0x2e, 0x7b, 0x77, 0x74, 0x39, 0x29, 0xba, 0x7f, 0x2b, 0xb0, 0x19, 0x5f, 0x9a, 0x3e, 0xd5,
+	0xdd, 0xa2, 0xf6, 0xdc, 0x15, 0x1f, 0xc4, 0x83, 0xc8, 0x4b, 0x0f, 0xdb, 0x6f, 0xc2, 0xc6, 0x14,
+	0x92, 0x0e, 0x4b, 0x62, 0xd1, 0x09, 0x6d, 0x59, 0xbb, 0xb0, 0x7f, 0x57, 0x60, 0x27, 0xf1, 0x25,
+	0x72, 0x4f, 0xc6, 0xbb, 0x50, 0x64, 0x39, 0xf0, 0x0f, 0x7b, 0xd5, 0x08, 0x47, 0xe8, 0x45, 0xec,
+	0x71, 0xfa, 0xd5, 0xfd, 0xaf, 0xb2, 0x75, 0x9e, 0x29, 0xbd, 0xba, 0xcc, 0xae, 0x7b, 0xae, 0x65,
+	0x58, 0xf4, 0x89, 0xef, 0xa6, 0xb5, 0xa2, 0x57, 0xd2, 0x45, 0x9f, 0xe4, 0xe8, 0x93, 0xa2, 0x7f,
+	0x05, 0x35, 0xf9, 0xa5, 0xf2, 0x89, 0xcf, 0xd9, 0xf2, 0xbc, 0x29, 0x35, 0xe5, 0x01, 0x68, 0x2c,
+	0xf9, 0xa1, 0x60, 0xc4, 0x5b, 0x5a, 0x65, 0xf8, 0xe9, 0xc2, 0xf2, 0xb7, 0xb1, 0xe2, 0xfc, 0xe2,
+	0xae, 0xf7, 0xdb, 0x5a, 0x65, 0x59, 0xf7, 0x91, 0xb5, 0x61, 0x6b, 0xf5, 0xe5, 0xf6, 0x51, 0x6f,
+	0x11, 0x29, 0xb4, 0xdc, 0x4a, 0x68, 0xff, 0xca, 0x41, 0x4d, 0xde, 0x4e, 0xdc, 0xe3, 0xa9, 0x21,
+	0x1c, 0x30, 0x71, 0x67, 0x8b, 0x31, 0x7a, 0x0a, 0x1b, 0xe1, 0xce, 0x6f, 0xb9, 0x18, 0xa8, 0x67,
+	0x19, 0xa3, 0xc2, 0xd1, 0x37, 0x2c, 0xa8, 0xa7, 0xb0, 0x11, 0xee, 0xdb, 0xb8, 0x11, 0x2d, 0x98,
+	0x42, 0x8d, 0x38, 0xfa, 0x26, 0x8c, 0x1c, 0xd8, 0xae, 0x8b, 0x9b, 0xb0, 0xd5, 0xf5, 0x2c, 0x63,
+	0xa8, 0x14, 0xe3, 0x06, 0xdf, 0x01, 0x8a, 0x6d, 0x32, 0xb9, 0x21, 0xdf, 0x65, 0x7f, 0x71, 0xe7,
+	0x3e, 0x4a, 0x54, 0xe9, 0x59, 0xc6, 0xd0, 0x84, 0xf3, 0x9c, 0x05, 0x75, 0x6c, 0x63, 0xc9, 0xa9,
+	0x4b, 0x69, 0xa8, 0x05, 0x09, 0x51, 0x6a, 0xe1, 0x38, 0xe7, 0x4d, 0x52, 0x43, 0xca, 0x72, 0x43,
+	0x1a, 0xbf, 0x84, 0x8a, 0x10, 0x9e, 0xa0, 0x63, 0x45, 0x5c, 0x85, 0xa8, 0x99, 0xe0, 0x4a, 0x32,
+	0x5b, 0xc8, 0x9d, 0xee, 0x4b, 0xd8, 0x95, 0x7e, 0xab, 0x00, 0x5c, 0x9a, 0x96, 0xed, 0x9a, 0x51,
+	0x8b, 0xa7, 0xa6, 0x85, 0x87, 0x01, 0x79, 0x8b, 0xdd, 0xf0, 0xe8, 0x51, 0xa5, 0xc8, 0x80, 0x02,
+	0x94, 0x8e, 0x4c, 0x26, 0x3e, 0x0e, 0x58, 0x83, 0x0b, 0x46, 0x38, 0xa2, 0x0f, 0x99, 0x63, 0xdf,
+	0xd8, 0x01, 0xeb, 0x6b, 0xc1, 0xe0, 0x03, 0xba, 0xc8, 0x5f, 0x9b, 0xfe, 0x90, 0xdf, 0x09, 0x17,
+	0xf9, 0x6b, 0xd3, 0xef, 0xd1, 0xf1, 0x51, 0xe3, 0xb6, 0xfd, 0x10, 0x1e, 0xb4, 0xb4, 0xe5, 0x11,
+	0xca, 0xd4, 0xb4, 0xf8, 0xf9, 0x89, 0xfe, 0x57, 0x05, 0xca, 0x97, 0xa6, 0x85, 0xbb, 0xee, 0x84,
+	0xdc, 0x17, 0x12, 0x82, 0xbc, 0x6f, 0xff, 0x84, 0xc3, 0x80, 0xd8, 0xb5, 0x10, 0x66, 0x2e, 0x16,
+	0xe6, 0x11, 0x40, 0x40, 0x02, 0xd3, 0x19, 0xb2, 0x19, 0xd1, 0x11, 0x04, 0xff, 0x27, 0x42, 0x33,
+	0xfa, 0x27, 0x42, 0xb3, 0xeb, 0x06, 0xcf, 0x5b, 0xac, 0x2b, 0x86, 0xca, 0xcc, 0xfb, 0xf6, 0x4f,
+	0x58, 0xef, 0x80, 0x7a, 0x4c, 0x66, 0x6e, 0x70, 0xe1, 0x3a, 0x73, 0x76, 0x0e, 0xed, 0x9a, 0x23,
+	0x07, 0x5f, 0xd5, 0x95, 0xf0, 0x1c, 0x9a, 0x0f, 0x8f, 0x9e, 0xdc, 0xb6, 0xf7, 0xe0, 0x51, 0x6b,
+	0x67, 0x99, 0xd6, 0x98, 0xce, 0x1a, 0x12, 0xd7, 0x99, 0xbf, 0xcf, 0x66, 0x5f, 0x3e, 0xff, 0xfe,
+	0xcb, 0x35, 0xfe, 0x15, 0xf2, 0x82, 0xfd, 0x1d, 0x15, 0x59, 0x6c, 0xcf, 0xff, 0x3f, 0x00, 0x9d,
+	0xb7, 0xbf, 0x7d, 0x46, 0x19, 0x00, 0x00,
 }
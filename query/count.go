@@ -0,0 +1,25 @@
+package query
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ParseCountOnly parses string representation of count only flag, which is either "true" or "false"
+// or any other representation accepted by strconv.ParseBool, e.g. "1" or "0".
+func ParseCountOnly(s string) (*CountOnly, error) {
+	v, err := strconv.ParseBool(s)
+	if err != nil {
+		return nil, fmt.Errorf("count only: %q is not a boolean value", s)
+	}
+	return &CountOnly{Enabled: v}, nil
+}
+
+// NewCountOnlyPageInfo returns page info of a count only response, i.e. the response
+// to a request with CountOnly enabled that contains no resources. Only total is set,
+// there are neither size nor a next page.
+func NewCountOnlyPageInfo(total int32) *PageInfo {
+	p := &PageInfo{}
+	p.SetTotal(total)
+	return p
+}
@@ -0,0 +1,44 @@
+package query
+
+import (
+	"testing"
+)
+
+func TestParseCountOnly(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected bool
+		err      bool
+	}{
+		{s: "true", expected: true},
+		{s: "1", expected: true},
+		{s: "false", expected: false},
+		{s: "yes", err: true},
+		{s: "", err: true},
+	}
+	for _, test := range tests {
+		c, err := ParseCountOnly(test.s)
+		if test.err {
+			if err == nil {
+				t.Errorf("expected error for %q", test.s)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if c.GetEnabled() != test.expected {
+			t.Errorf("invalid count only of %q: %t - expected: %t", test.s, c.GetEnabled(), test.expected)
+		}
+	}
+}
+
+func TestNewCountOnlyPageInfo(t *testing.T) {
+	p := NewCountOnlyPageInfo(0)
+	if total, ok := p.Total(); !ok || total != 0 {
+		t.Errorf("invalid total: %d, %t - expected: 0, true", total, ok)
+	}
+	if p.GetSize() != 0 || p.GetOffset() != 0 || p.GetPageToken() != "" {
+		t.Errorf("invalid page info: %v - expected only total", p)
+	}
+}
@@ -0,0 +1,311 @@
+package query
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/duration"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"google.golang.org/grpc/codes"
+
+	"github.com/partitio/atlas-app-toolkit/errors"
+)
+
+// Cursor represents a position in a collection for keyset pagination,
+// i.e. sort key values of the last seen resource.
+//
+// Cursor is passed as a page token which is URL-safe base64 (without padding) encoding of JSON:
+//
+//	{"keys":[{"field":"name","value":"John","desc":true},{"field":"id","value":42}]}
+//
+// so that it can be produced and consumed by different stores.
+type Cursor struct {
+	Keys []CursorKey `json:"keys"`
+}
+
+// CursorKey is a sort key value of the last seen resource.
+// Value is either a string, a number (json.Number after decoding) or a bool.
+type CursorKey struct {
+	Field string      `json:"field"`
+	Value interface{} `json:"value"`
+	Desc  bool        `json:"desc,omitempty"`
+}
+
+// MaxCursorKeys is the maximum number of keys of a cursor accepted by DecodeCursor.
+// Cursor.Filtering produces a few nodes per key, so the limit keeps expressions
+// made from page tokens supplied by clients small.
+const MaxCursorKeys = 16
+
+// EncodeCursor encodes cursor c to a page token.
+func EncodeCursor(c *Cursor) (string, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeCursor decodes cursor from the page token ptoken.
+// Returns error if provided token is malformed or has more than MaxCursorKeys keys.
+func DecodeCursor(ptoken string) (*Cursor, error) {
+	errC := errors.InitContainer()
+	data, err := base64.RawURLEncoding.DecodeString(ptoken)
+	if err != nil {
+		return nil, errC.New(codes.InvalidArgument, "Invalid page token %q.", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var c Cursor
+	if err := dec.Decode(&c); err != nil || len(c.Keys) == 0 {
+		return nil, errC.New(codes.InvalidArgument, "Malformed page token.")
+	}
+	if len(c.Keys) > MaxCursorKeys {
+		return nil, errC.New(codes.InvalidArgument, "Page token exceeds maximum number of %d keys.", MaxCursorKeys)
+	}
+	for _, k := range c.Keys {
+		if k.Field == "" {
+			return nil, errC.New(codes.InvalidArgument, "Malformed page token.")
+		}
+	}
+	return &c, nil
+}
+
+// NewCursor builds a cursor pointing to obj, which is the last resource of a page
+// sorted according to s. Tiebreakers are the fields that make the sort keys unique,
+// e.g. "id", they are appended to the sort keys in ascending order.
+// Fields are resolved in the same way as in filtering, so dot-separated paths are supported.
+// An error is returned for null key values, which cannot be compared with, and for integers
+// beyond ±2^53, which cannot be represented exactly in filtering expressions.
+func NewCursor(obj interface{}, s *Sorting, tiebreakers ...string) (*Cursor, error) {
+	c := &Cursor{}
+	for _, cr := range s.GetCriterias() {
+		v, err := cursorValue(obj, cr.Tag)
+		if err != nil {
+			return nil, err
+		}
+		c.Keys = append(c.Keys, CursorKey{Field: cr.Tag, Value: v, Desc: cr.IsDesc()})
+	}
+	for _, t := range tiebreakers {
+		v, err := cursorValue(obj, t)
+		if err != nil {
+			return nil, err
+		}
+		c.Keys = append(c.Keys, CursorKey{Field: t, Value: v})
+	}
+	for _, k := range c.Keys {
+		if _, err := k.after(); err != nil {
+			return nil, err
+		}
+	}
+	if len(c.Keys) == 0 {
+		return nil, fmt.Errorf("cursor: no sort keys")
+	}
+	return c, nil
+}
+
+func cursorValue(obj interface{}, field string) (interface{}, error) {
+	fv := fieldByFieldPath(obj, strings.Split(field, "."))
+	if !fv.IsValid() {
+		return nil, &UnknownFieldError{strings.Split(field, ".")}
+	}
+	switch fv.Type() {
+	case timestampType:
+		if fv.IsNil() {
+			return nil, nil
+		}
+		t, err := ptypes.Timestamp(fv.Interface().(*timestamp.Timestamp))
+		if err != nil {
+			return nil, err
+		}
+		return t.Format(time.RFC3339Nano), nil
+	case durationType:
+		if fv.IsNil() {
+			return nil, nil
+		}
+		d, err := ptypes.Duration(fv.Interface().(*duration.Duration))
+		if err != nil {
+			return nil, err
+		}
+		return d.String(), nil
+	}
+	if isNilValue(fv) {
+		return nil, nil
+	}
+	fv = dereferenceValue(fv)
+	switch fv.Kind() {
+	case reflect.String:
+		return fv.String(), nil
+	case reflect.Bool:
+		return fv.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return fv.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return fv.Uint(), nil
+	case reflect.Float32, reflect.Float64:
+		return fv.Float(), nil
+	default:
+		return nil, &TypeMismatchError{"sortable", strings.Split(field, "."), ""}
+	}
+}
+
+// Filtering returns filtering expression that selects resources following the cursor position,
+// e.g. for keys a ASC, b DESC it is equivalent to "a > va or (a == va and b < vb)".
+// Store adapters can apply it in the same way as any other filtering expression.
+func (c *Cursor) Filtering() (*Filtering, error) {
+	var root FilteringExpression
+	for i := len(c.Keys) - 1; i >= 0; i-- {
+		after, err := c.Keys[i].after()
+		if err != nil {
+			return nil, err
+		}
+		if root == nil {
+			root = after
+			continue
+		}
+		and := &LogicalOperator{Type: LogicalOperator_AND}
+		if err := and.SetLeft(c.Keys[i].equal()); err != nil {
+			return nil, err
+		}
+		if err := and.SetRight(root); err != nil {
+			return nil, err
+		}
+		if after == nil {
+			root = and
+			continue
+		}
+		or := &LogicalOperator{Type: LogicalOperator_OR}
+		if err := or.SetLeft(after); err != nil {
+			return nil, err
+		}
+		if err := or.SetRight(and); err != nil {
+			return nil, err
+		}
+		root = or
+	}
+	if root == nil {
+		// nothing can follow the cursor
+		eq := c.Keys[0].equal()
+		ne := c.Keys[0].equal()
+		(&filteringParser{}).negateNode(ne)
+		and := &LogicalOperator{Type: LogicalOperator_AND}
+		if err := and.SetLeft(eq); err != nil {
+			return nil, err
+		}
+		if err := and.SetRight(ne); err != nil {
+			return nil, err
+		}
+		root = and
+	}
+	f := &Filtering{}
+	if err := f.SetRoot(root); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// after returns condition that selects resources following the key value,
+// i.e. "field > value" or "field < value" for descending keys.
+// Nil is returned if there are no such values.
+func (k CursorKey) after() (FilteringExpression, error) {
+	fieldPath := strings.Split(k.Field, ".")
+	switch v := k.Value.(type) {
+	case string:
+		c := &StringCondition{FieldPath: fieldPath, Value: v, Type: StringCondition_GT}
+		if k.Desc {
+			c.Type = StringCondition_LT
+		}
+		return c, nil
+	case bool:
+		// false < true
+		if v == k.Desc {
+			return &BoolCondition{FieldPath: fieldPath, Value: !v}, nil
+		}
+		return nil, nil
+	}
+	f, err := k.number()
+	if err != nil {
+		return nil, err
+	}
+	c := &NumberCondition{FieldPath: fieldPath, Value: f, Type: NumberCondition_GT}
+	if k.Desc {
+		c.Type = NumberCondition_LT
+	}
+	return c, nil
+}
+
+// equal returns "field == value" condition.
+func (k CursorKey) equal() FilteringExpression {
+	fieldPath := strings.Split(k.Field, ".")
+	switch v := k.Value.(type) {
+	case string:
+		return &StringCondition{FieldPath: fieldPath, Value: v, Type: StringCondition_EQ}
+	case bool:
+		return &BoolCondition{FieldPath: fieldPath, Value: v}
+	}
+	f, _ := k.number()
+	return &NumberCondition{FieldPath: fieldPath, Value: f, Type: NumberCondition_EQ}
+}
+
+// maxExactInt is the maximum magnitude of integers that float64 represents exactly.
+const maxExactInt = 1 << 53
+
+// number returns the numeric value of k as float64, integers beyond ±2^53 are rejected
+// since comparing with their float64 approximation would skip or repeat resources.
+func (k CursorKey) number() (float64, error) {
+	i, isInt, ok := cursorInteger(k.Value)
+	if isInt {
+		if !ok || i > maxExactInt || i < -maxExactInt {
+			return 0, fmt.Errorf("cursor: integer value %v of %s field cannot be represented exactly", k.Value, k.Field)
+		}
+		return float64(i), nil
+	}
+	var f float64
+	if n, isNumber := k.Value.(json.Number); isNumber {
+		var err error
+		f, err = n.Float64()
+		ok = err == nil
+	} else if k.Value != nil {
+		f, ok = numberValue(reflect.ValueOf(k.Value))
+	}
+	if !ok {
+		if k.Value == nil {
+			return 0, fmt.Errorf("cursor: null value of %s field", k.Field)
+		}
+		return 0, fmt.Errorf("cursor: unsupported value %v of %s field", k.Value, k.Field)
+	}
+	return f, nil
+}
+
+// cursorInteger returns v as int64 if it is an integer, i.e. a Go integer or json.Number without
+// fraction and exponent. ok is false for integers that do not fit int64.
+func cursorInteger(v interface{}) (i int64, isInt, ok bool) {
+	if n, isNumber := v.(json.Number); isNumber {
+		if strings.ContainsAny(string(n), ".eE") {
+			return 0, false, false
+		}
+		i, err := n.Int64()
+		return i, true, err == nil
+	}
+	if v == nil {
+		return 0, false, false
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), true, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if rv.Uint() > math.MaxInt64 {
+			return 0, true, false
+		}
+		return int64(rv.Uint()), true, true
+	default:
+		return 0, false, false
+	}
+}
@@ -0,0 +1,192 @@
+package query
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursorEncoding(t *testing.T) {
+	c := &Cursor{Keys: []CursorKey{
+		{Field: "name", Value: "John", Desc: true},
+		{Field: "id", Value: 42},
+	}}
+	pt, err := EncodeCursor(c)
+	assert.Nil(t, err)
+	assert.Equal(t, "eyJrZXlzIjpbeyJmaWVsZCI6Im5hbWUiLCJ2YWx1ZSI6IkpvaG4iLCJkZXNjIjp0cnVlfSx7ImZpZWxkIjoiaWQiLCJ2YWx1ZSI6NDJ9XX0", pt)
+
+	dc, err := DecodeCursor(pt)
+	assert.Nil(t, err)
+	assert.Equal(t, &Cursor{Keys: []CursorKey{
+		{Field: "name", Value: "John", Desc: true},
+		{Field: "id", Value: json.Number("42")},
+	}}, dc)
+
+	for _, pt := range []string{"", "MTI6MzQ=", "MTI6MzQ", "e30", "eyJrZXlzIjpbXX0", "eyJrZXlzIjpbeyJ2YWx1ZSI6MX1dfQ"} {
+		_, err := DecodeCursor(pt)
+		assert.NotNil(t, err, pt)
+	}
+
+	keys := make([]CursorKey, MaxCursorKeys+1)
+	for i := range keys {
+		keys[i] = CursorKey{Field: "id", Value: i}
+	}
+	pt, err = EncodeCursor(&Cursor{Keys: keys})
+	assert.Nil(t, err)
+	_, err = DecodeCursor(pt)
+	assert.NotNil(t, err)
+
+	// filtering of the longest cursor is within the default limits
+	pt, err = EncodeCursor(&Cursor{Keys: keys[:MaxCursorKeys]})
+	assert.Nil(t, err)
+	dc, err = DecodeCursor(pt)
+	assert.Nil(t, err)
+	f, err := dc.Filtering()
+	assert.Nil(t, err)
+	assert.Nil(t, DefaultFilteringLimits.Check(f))
+}
+
+func TestNewCursorUnrepresentableKeys(t *testing.T) {
+	type row struct {
+		Name *string `json:"name"`
+		ID   int64   `json:"id"`
+	}
+	name := "John"
+	s, err := ParseSorting("name")
+	assert.Nil(t, err)
+
+	_, err = NewCursor(&row{Name: nil, ID: 3}, s, "id")
+	assert.EqualError(t, err, "cursor: null value of name field")
+	_, err = NewCursor(&TestProtoMessage{Str: "abc"}, &Sorting{Criterias: []*SortCriteria{{Tag: "created_at"}}}, "str")
+	assert.EqualError(t, err, "cursor: null value of created_at field")
+
+	_, err = NewCursor(&row{Name: &name, ID: 1<<53 + 1}, s, "id")
+	assert.EqualError(t, err, "cursor: integer value 9007199254740993 of id field cannot be represented exactly")
+	c, err := NewCursor(&row{Name: &name, ID: 1 << 53}, s, "id")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1<<53), c.Keys[1].Value)
+
+	// cursors supplied by clients are checked when they are applied
+	for _, pt := range []string{
+		`{"keys":[{"field":"id","value":9007199254740993}]}`,
+		`{"keys":[{"field":"id","value":18446744073709551616}]}`,
+		`{"keys":[{"field":"id","value":null}]}`,
+	} {
+		c, err := DecodeCursor(base64.RawURLEncoding.EncodeToString([]byte(pt)))
+		assert.Nil(t, err, pt)
+		_, err = c.Filtering()
+		assert.Error(t, err, pt)
+		_, _, err = PaginationToSQL(&Pagination{PageToken: base64.RawURLEncoding.EncodeToString([]byte(pt))},
+			&Sorting{Criterias: []*SortCriteria{{Tag: "id"}}}, WithSQLFieldMapping(map[string]string{"id": "id"}))
+		assert.Error(t, err, pt)
+	}
+
+	// integers are passed to SQL exactly
+	c = &Cursor{Keys: []CursorKey{{Field: "id", Value: json.Number("9007199254740992")}}}
+	pt, err := EncodeCursor(c)
+	assert.Nil(t, err)
+	_, args, err := PaginationToSQL(&Pagination{PageToken: pt}, &Sorting{Criterias: []*SortCriteria{{Tag: "id"}}},
+		WithSQLFieldMapping(map[string]string{"id": "id"}))
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{int64(1 << 53)}, args)
+}
+
+func TestNewCursor(t *testing.T) {
+	obj := &TestProtoMessage{
+		Str:       "abc",
+		Int:       3,
+		Bool:      true,
+		Nested:    &NestedMessage{Str: "nested"},
+		CreatedAt: &timestamp.Timestamp{Seconds: 1672531200},
+	}
+	s, err := ParseSorting("str desc, nested.str, created_at, bool")
+	assert.Nil(t, err)
+
+	c, err := NewCursor(obj, s, "int")
+	assert.Nil(t, err)
+	assert.Equal(t, &Cursor{Keys: []CursorKey{
+		{Field: "str", Value: "abc", Desc: true},
+		{Field: "nested.str", Value: "nested"},
+		{Field: "created_at", Value: "2023-01-01T00:00:00Z"},
+		{Field: "bool", Value: true},
+		{Field: "int", Value: int64(3)},
+	}}, c)
+
+	_, err = NewCursor(obj, s, "unknown")
+	assert.IsType(t, &UnknownFieldError{}, err)
+
+	_, err = NewCursor(obj, nil)
+	assert.NotNil(t, err)
+}
+
+func TestCursorFiltering(t *testing.T) {
+	tests := []struct {
+		cursor *Cursor
+		exp    string
+	}{
+		{
+			cursor: &Cursor{Keys: []CursorKey{{Field: "id", Value: json.Number("42")}}},
+			exp:    "id > 42",
+		},
+		{
+			cursor: &Cursor{Keys: []CursorKey{{Field: "name", Value: "John", Desc: true}, {Field: "id", Value: 42}}},
+			exp:    "name < 'John' or (name == 'John' and id > 42)",
+		},
+		{
+			cursor: &Cursor{Keys: []CursorKey{{Field: "a", Value: 1}, {Field: "b", Value: true}, {Field: "c", Value: "x", Desc: true}}},
+			exp:    "a > 1 or (a == 1 and (b == true and c < 'x'))",
+		},
+		{
+			cursor: &Cursor{Keys: []CursorKey{{Field: "a", Value: false}, {Field: "b", Value: 1.5}}},
+			exp:    "a == true or (a == false and b > 1.5)",
+		},
+		{
+			cursor: &Cursor{Keys: []CursorKey{{Field: "a", Value: true}}},
+			exp:    "a == true and a != true",
+		},
+	}
+
+	for _, test := range tests {
+		f, err := test.cursor.Filtering()
+		assert.Nil(t, err)
+		exp, err := ParseFiltering(test.exp)
+		assert.Nil(t, err)
+		assert.Equal(t, exp, f, test.exp)
+	}
+
+	_, err := (&Cursor{Keys: []CursorKey{{Field: "a", Value: nil}}}).Filtering()
+	assert.NotNil(t, err)
+}
+
+func TestCursorPagination(t *testing.T) {
+	rows := []*TestProtoMessage{
+		{Str: "c", Int: 1},
+		{Str: "b", Int: 2},
+		{Str: "b", Int: 3},
+		{Str: "a", Int: 4},
+	}
+	s, err := ParseSorting("str desc")
+	assert.Nil(t, err)
+
+	c, err := NewCursor(rows[1], s, "int")
+	assert.Nil(t, err)
+	pt, err := EncodeCursor(c)
+	assert.Nil(t, err)
+	c, err = DecodeCursor(pt)
+	assert.Nil(t, err)
+	f, err := c.Filtering()
+	assert.Nil(t, err)
+
+	var next []*TestProtoMessage
+	for _, r := range rows {
+		ok, err := f.Filter(r)
+		assert.Nil(t, err)
+		if ok {
+			next = append(next, r)
+		}
+	}
+	assert.Equal(t, rows[2:], next)
+}
@@ -2,6 +2,8 @@ package query
 
 import (
 	"errors"
+	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/golang/protobuf/protoc-gen-go/generator"
@@ -11,6 +13,7 @@ import (
 const (
 	opCommonDelimiter      = ","
 	opCommonInnerDelimiter = "."
+	opExcludePrefix        = "-"
 )
 
 //FieldSelectionMap is a convenience type that represents map[string]*Field
@@ -30,30 +33,61 @@ func toParts(input string, delimiter ...string) []string {
 	return strings.Split(input, split)
 }
 
+//MixedFieldSelectionError describes a field selection that mixes
+//inclusion and exclusion of fields.
+type MixedFieldSelectionError struct {
+	Field string
+}
+
+func (e *MixedFieldSelectionError) Error() string {
+	return fmt.Sprintf("cannot mix inclusion and exclusion of fields: %s", e.Field)
+}
+
 //ParseFieldSelection transforms a string with comma-separated fields that comes
 //from client to FieldSelection struct. For complex fields dot is used as a delimeter by
 //default, but it is also possible to specify a different delimiter.
+//Dotted paths are built into a tree of selected subfields. A field without subfields
+//selects its whole subtree, while a field with subfields selects only them, so
+//"owner,owner.name" is the same as "owner.name" regardless of the order.
+//Fields prefixed with "-" are excluded instead, e.g. "-thumbnail,-raw_bytes" retains all
+//fields except the specified ones. ParseFieldSelection returns nil if inclusion and exclusion
+//are mixed, use ParseFieldSelectionStrict to get an error in this case.
 func ParseFieldSelection(input string, delimiter ...string) *FieldSelection {
+	fs, _ := ParseFieldSelectionStrict(input, delimiter...)
+	return fs
+}
+
+//ParseFieldSelectionStrict is the same as ParseFieldSelection but returns
+//MixedFieldSelectionError if inclusion and exclusion of fields are mixed.
+func ParseFieldSelectionStrict(input string, delimiter ...string) (*FieldSelection, error) {
 	if len(input) == 0 {
-		return nil
+		return nil, nil
 	}
 
 	fields := strings.Split(input, opCommonDelimiter)
 	result := &FieldSelection{Fields: make(map[string]*Field, len(fields))}
+	result.Exclude = strings.HasPrefix(fields[0], opExcludePrefix)
 
 	for _, field := range fields {
-		result.Add(field, delimiter...)
+		if strings.HasPrefix(field, opExcludePrefix) != result.Exclude {
+			return nil, &MixedFieldSelectionError{Field: field}
+		}
+		result.Add(strings.TrimPrefix(field, opExcludePrefix), delimiter...)
 	}
 
-	return result
+	return result, nil
 }
 
 //GoString converts FieldSelection to a string representation
 //It implements fmt.GoStringer interface and returns dot-notated fields separated by commas
 func (f *FieldSelection) GoString() string {
 	result := make([]string, 0, len(f.Fields))
+	prefix := ""
+	if f.Exclude {
+		prefix = opExcludePrefix
+	}
 	for _, field := range f.Fields {
-		addChildFieldString(&result, "", field)
+		addChildFieldString(&result, prefix, field)
 	}
 	return strings.Join(result, opCommonDelimiter)
 }
@@ -145,27 +179,165 @@ func (f *FieldSelection) Get(field string, delimiter ...string) *Field {
 	return tmp[name]
 }
 
+//Walk calls fn for each field of FieldSelection in depth-first order with the path to the field.
+//Fields of the same level are visited in the order of their names. Walk stops on the first error
+//returned by fn and returns it.
+func (f *FieldSelection) Walk(fn func(path []string, field *Field) error) error {
+	return walkFields(nil, f.GetFields(), fn)
+}
+
+func walkFields(parent []string, fields FieldSelectionMap, fn func(path []string, field *Field) error) error {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		path := append(append(make([]string, 0, len(parent)+1), parent...), name)
+		if err := fn(path, fields[name]); err != nil {
+			return err
+		}
+		if err := walkFields(path, fields[name].GetSubs(), fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//Paths returns sorted paths of the fields that are selected with their whole subtree,
+//i.e. fields without subfields. For complex fields dot is used as a delimiter by default.
+//If fields are excluded then these are paths of the excluded fields.
+func (f *FieldSelection) Paths(delimiter ...string) []string {
+	var paths []string
+	f.Walk(func(path []string, field *Field) error {
+		if len(field.GetSubs()) == 0 {
+			paths = append(paths, strings.Join(path, innerDelimiter(delimiter...)))
+		}
+		return nil
+	})
+	return paths
+}
+
+//Selected reports whether the field is selected by FieldSelection with the whole subtree,
+//that is either the field or one of its parents is selected without subfields.
+//If fields are excluded then it reports whether neither the field nor any of its parents
+//or subfields is excluded.
+func (f *FieldSelection) Selected(field string, delimiter ...string) bool {
+	if len(field) == 0 {
+		return false
+	}
+	tmp := f.GetFields()
+	for _, name := range toParts(field, delimiter...) {
+		fld, ok := tmp[name]
+		if !ok {
+			return f.GetExclude()
+		}
+		if len(fld.GetSubs()) == 0 {
+			return !f.GetExclude()
+		}
+		tmp = fld.GetSubs()
+	}
+	return false
+}
+
+//Contains reports whether the field is included in the response according to FieldSelection,
+//either with the whole subtree or partially, so that a handler can skip computing fields
+//that are not included. Unlike Selected it is true for parents of selected fields, e.g. "owner"
+//for "owner.name", and for all fields if FieldSelection is nil or empty.
+//If fields are excluded then it reports whether neither the field nor any of its parents is excluded.
+func (f *FieldSelection) Contains(field string, delimiter ...string) bool {
+	if len(f.GetFields()) == 0 {
+		return true
+	}
+	if len(field) == 0 {
+		return false
+	}
+	tmp := f.GetFields()
+	for _, name := range toParts(field, delimiter...) {
+		fld, ok := tmp[name]
+		if !ok {
+			return f.GetExclude()
+		}
+		if len(fld.GetSubs()) == 0 {
+			return !f.GetExclude()
+		}
+		tmp = fld.GetSubs()
+	}
+	return true
+}
+
+//FieldSelectionToFieldMask converts FieldSelection to a FieldMask with paths
+//of the fields that are selected with their whole subtree, see Paths.
+//Path parts are converted to CamelCase. Exclusion of fields cannot be represented by FieldMask.
 func FieldSelectionToFieldMask(fs *FieldSelection) (*fieldmask.FieldMask, error) {
 	if fs == nil {
 		return nil, errors.New("FieldSelection cannot be nil")
 	}
-	return &fieldmask.FieldMask{Paths: join("", fs.Fields)}, nil
+	if fs.Exclude {
+		return nil, errors.New("FieldSelection with excluded fields cannot be converted to FieldMask")
+	}
+	var paths []string
+	fs.Walk(func(path []string, field *Field) error {
+		if len(field.GetSubs()) == 0 {
+			parts := make([]string, len(path))
+			for i, p := range path {
+				parts[i] = generator.CamelCase(p)
+			}
+			paths = append(paths, strings.Join(parts, opCommonInnerDelimiter))
+		}
+		return nil
+	})
+	return &fieldmask.FieldMask{Paths: paths}, nil
 }
 
-func join(prefix string, f map[string]*Field) (fields []string) {
-	for _, v := range f {
-		var n string
-		if n == "" {
-			n = v.Name
-		} else {
-			n = prefix + "." + v.Name
+//FieldSelectionToSQLColumns returns SQL columns for the fields selected by fs according to fieldMap
+//from dot-separated field paths to columns. Columns of mandatory list (e.g. primary key) are always
+//returned first, then the selected ones follow in the order of their field paths without duplicates.
+//A mapped field is selected if it or any of its subfields or parents are selected, so a column of
+//a nested message is retained even if only some of its subfields are selected. The same applies to
+//excluded fields: a column is omitted only if the field or any of its parents is excluded as a whole.
+//If fs is nil or empty all mapped columns are returned. Selection of a field that is not in fieldMap
+//is rejected with UnknownFieldError, so that the result can be safely used in SELECT clause.
+func FieldSelectionToSQLColumns(fs *FieldSelection, fieldMap map[string]string, mandatory ...string) ([]string, error) {
+	names := make([]string, 0, len(fieldMap))
+	for name := range fieldMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, path := range fs.Paths() {
+		if !fieldPathMapped(path, names) {
+			return nil, &UnknownFieldError{toParts(path)}
+		}
+	}
+
+	var columns []string
+	seen := make(map[string]struct{})
+	add := func(column string) {
+		if _, ok := seen[column]; !ok {
+			seen[column] = struct{}{}
+			columns = append(columns, column)
 		}
-		n = generator.CamelCase(n)
-		if len(v.Subs) > 0 {
-			fields = append(join(n, v.Subs))
-		} else {
-			fields = append(fields, n)
+	}
+	for _, column := range mandatory {
+		add(column)
+	}
+	for _, name := range names {
+		if len(fs.GetFields()) == 0 || fs.Selected(name) || len(fs.Get(name).GetSubs()) > 0 {
+			add(fieldMap[name])
+		}
+	}
+	return columns, nil
+}
+
+//fieldPathMapped reports whether path is one of names, its parent or its subfield.
+func fieldPathMapped(path string, names []string) bool {
+	for _, name := range names {
+		if name == path ||
+			strings.HasPrefix(path, name+opCommonInnerDelimiter) ||
+			strings.HasPrefix(name, path+opCommonInnerDelimiter) {
+			return true
 		}
 	}
-	return
+	return false
 }
@@ -0,0 +1,100 @@
+package query
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// ApplyFieldSelection clears fields of msg that are not present in the field selection fs.
+// Fields are matched by either their proto or JSON names. A field selected by a dotted path
+// retains only the selected subfields of a nested message, its siblings are cleared.
+// If fields are excluded then exactly the specified fields (or subfields) are cleared.
+// Subfields are applied to every element of repeated and map fields of message type.
+// Fields of the selection that are not found in msg are ignored.
+// A nil or empty selection leaves msg untouched.
+func ApplyFieldSelection(msg proto.Message, fs *FieldSelection) error {
+	if len(fs.GetFields()) == 0 || msg == nil {
+		return nil
+	}
+	v := reflect.ValueOf(msg)
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return nil
+	}
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("message %T is not a pointer to struct", msg)
+	}
+	applyFieldSelection(v.Elem(), fs.GetFields(), fs.GetExclude())
+	return nil
+}
+
+// applyFieldSelection clears fields of proto struct v that are not present in fields
+// (or present without subfields if exclude is set) and applies subfields to the other ones.
+func applyFieldSelection(v reflect.Value, fields FieldSelectionMap, exclude bool) {
+	t := v.Type()
+	props := proto.GetProperties(t)
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if strings.HasPrefix(sf.Name, "XXX_") {
+			continue
+		}
+		fv := v.Field(i)
+		p := props.Prop[i]
+		if sf.Tag.Get("protobuf_oneof") != "" {
+			if fv.IsNil() {
+				continue
+			}
+			// the concrete oneof wrapper determines which field is set
+			p = nil
+			for _, oop := range props.OneofTypes {
+				if oop.Type == fv.Elem().Type() {
+					p = oop.Prop
+					break
+				}
+			}
+			if p == nil {
+				continue
+			}
+			// subfields are applied to the value of the oneof wrapper
+			fv = fv.Elem().Elem().Field(0)
+		}
+		f := selectedField(fields, p)
+		switch {
+		case f != nil && len(f.GetSubs()) > 0:
+			applySubFieldSelection(fv, f.GetSubs(), exclude)
+		case (f != nil) == exclude:
+			v.Field(i).Set(reflect.Zero(v.Field(i).Type()))
+		}
+	}
+}
+
+// applySubFieldSelection applies subfields to the message, repeated or map field value v.
+func applySubFieldSelection(v reflect.Value, subs FieldSelectionMap, exclude bool) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() && v.Elem().Kind() == reflect.Struct {
+			applyFieldSelection(v.Elem(), subs, exclude)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			applySubFieldSelection(v.Index(i), subs, exclude)
+		}
+	case reflect.Map:
+		for _, k := range v.MapKeys() {
+			applySubFieldSelection(v.MapIndex(k), subs, exclude)
+		}
+	}
+}
+
+// selectedField returns a field from fields that matches either proto or JSON name of p.
+func selectedField(fields FieldSelectionMap, p *proto.Properties) *Field {
+	if f, ok := fields[p.OrigName]; ok && p.OrigName != "" {
+		return f
+	}
+	if f, ok := fields[p.JSONName]; ok && p.JSONName != "" {
+		return f
+	}
+	return nil
+}
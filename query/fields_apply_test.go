@@ -0,0 +1,117 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/wrappers"
+)
+
+func TestApplyFieldSelection(t *testing.T) {
+	pageInfo := func() *PageInfo {
+		return &PageInfo{PageToken: "ptoken", Offset: 10, Size: 20, TotalSize: &wrappers.Int32Value{Value: 30}}
+	}
+	sorting := func() *Sorting {
+		return &Sorting{Criterias: []*SortCriteria{
+			{Tag: "name", Order: SortCriteria_DESC, Nulls: SortCriteria_NULLS_LAST},
+			{Tag: "age", Order: SortCriteria_DESC},
+		}}
+	}
+	filtering := func() *Filtering {
+		return &Filtering{Root: &Filtering_StringCondition{StringCondition: &StringCondition{
+			FieldPath: []string{"name"}, Value: "John", Type: StringCondition_MATCH,
+		}}}
+	}
+
+	tests := []struct {
+		msg      proto.Message
+		fields   string
+		expected proto.Message
+	}{
+		{
+			msg:      pageInfo(),
+			fields:   "",
+			expected: pageInfo(),
+		},
+		{
+			msg:      pageInfo(),
+			fields:   "size,total_size",
+			expected: &PageInfo{Size: 20, TotalSize: &wrappers.Int32Value{Value: 30}},
+		},
+		{
+			msg:      pageInfo(),
+			fields:   "page_token,totalSize,unknown",
+			expected: &PageInfo{PageToken: "ptoken", TotalSize: &wrappers.Int32Value{Value: 30}},
+		},
+		{
+			msg:      pageInfo(),
+			fields:   "offset,total_size.unknown",
+			expected: &PageInfo{Offset: 10, TotalSize: &wrappers.Int32Value{}},
+		},
+		{
+			msg:    sorting(),
+			fields: "criterias.tag",
+			expected: &Sorting{Criterias: []*SortCriteria{
+				{Tag: "name"},
+				{Tag: "age"},
+			}},
+		},
+		{
+			msg:      filtering(),
+			fields:   "string_condition.value",
+			expected: &Filtering{Root: &Filtering_StringCondition{StringCondition: &StringCondition{Value: "John"}}},
+		},
+		{
+			msg:      filtering(),
+			fields:   "string_condition",
+			expected: filtering(),
+		},
+		{
+			msg:      filtering(),
+			fields:   "number_condition",
+			expected: &Filtering{},
+		},
+		{
+			msg:      pageInfo(),
+			fields:   "-page_token,-totalSize",
+			expected: &PageInfo{Offset: 10, Size: 20},
+		},
+		{
+			msg:    sorting(),
+			fields: "-criterias.order,-criterias.nulls",
+			expected: &Sorting{Criterias: []*SortCriteria{
+				{Tag: "name"},
+				{Tag: "age"},
+			}},
+		},
+		{
+			msg:      filtering(),
+			fields:   "-string_condition.field_path",
+			expected: &Filtering{Root: &Filtering_StringCondition{StringCondition: &StringCondition{Value: "John", Type: StringCondition_MATCH}}},
+		},
+		{
+			msg:      filtering(),
+			fields:   "-string_condition",
+			expected: &Filtering{},
+		},
+		{
+			msg:      filtering(),
+			fields:   "-number_condition",
+			expected: filtering(),
+		},
+	}
+
+	for _, test := range tests {
+		if err := ApplyFieldSelection(test.msg, ParseFieldSelection(test.fields)); err != nil {
+			t.Fatalf("unexpected error for %q: %s", test.fields, err)
+		}
+		if !proto.Equal(test.msg, test.expected) {
+			t.Errorf("invalid message for %q: %v - expected: %v", test.fields, test.msg, test.expected)
+		}
+	}
+
+	var pi *PageInfo
+	if err := ApplyFieldSelection(pi, ParseFieldSelection("size")); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
@@ -1,6 +1,7 @@
 package query
 
 import (
+	"errors"
 	"reflect"
 	"strings"
 	"testing"
@@ -164,3 +165,218 @@ func validateGet(t *testing.T, flds *FieldSelection, field string, expected *Fie
 		t.Errorf("Unexpected get result for %s", field)
 	}
 }
+
+func TestParseNested(t *testing.T) {
+	expected := FieldSelection{Fields: FieldSelectionMap{"id": &Field{Name: "id"}, "owner": &Field{Name: "owner", Subs: FieldSelectionMap{"name": &Field{Name: "name"}, "email": &Field{Name: "email"}}}}}
+	validateParse(t, ParseFieldSelection("id,owner.name,owner.email"), &expected)
+
+	// conflicting entries, the more specific one wins regardless of the order
+	expected = FieldSelection{Fields: FieldSelectionMap{"owner": &Field{Name: "owner", Subs: FieldSelectionMap{"name": &Field{Name: "name"}}}}}
+	validateParse(t, ParseFieldSelection("owner,owner.name"), &expected)
+	validateParse(t, ParseFieldSelection("owner.name,owner"), &expected)
+}
+
+func TestWalk(t *testing.T) {
+	var visited []string
+	flds := ParseFieldSelection("owner.name,id,owner.address.city")
+	err := flds.Walk(func(path []string, field *Field) error {
+		if path[len(path)-1] != field.Name {
+			t.Errorf("invalid field %s for path %v", field.Name, path)
+		}
+		visited = append(visited, strings.Join(path, "."))
+		return nil
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	expected := []string{"id", "owner", "owner.address", "owner.address.city", "owner.name"}
+	if !reflect.DeepEqual(visited, expected) {
+		t.Errorf("invalid visited fields: %v - expected: %v", visited, expected)
+	}
+
+	stop := errors.New("stop")
+	visited = nil
+	err = flds.Walk(func(path []string, field *Field) error {
+		visited = append(visited, strings.Join(path, "."))
+		return stop
+	})
+	if err != stop || len(visited) != 1 {
+		t.Errorf("invalid walk result: %v, %v - expected: %v, [id]", err, visited, stop)
+	}
+}
+
+func TestPaths(t *testing.T) {
+	if paths := ParseFieldSelection("").Paths(); paths != nil {
+		t.Errorf("invalid paths: %v - expected: nil", paths)
+	}
+
+	flds := ParseFieldSelection("owner,id,owner.name,owner.address.city")
+	expected := []string{"id", "owner.address.city", "owner.name"}
+	if paths := flds.Paths(); !reflect.DeepEqual(paths, expected) {
+		t.Errorf("invalid paths: %v - expected: %v", paths, expected)
+	}
+	expected = []string{"id", "owner/address/city", "owner/name"}
+	if paths := flds.Paths("/"); !reflect.DeepEqual(paths, expected) {
+		t.Errorf("invalid paths: %v - expected: %v", paths, expected)
+	}
+}
+
+func TestSelected(t *testing.T) {
+	flds := ParseFieldSelection("id,owner.name,owner.address")
+	for field, expected := range map[string]bool{
+		"":                   false,
+		"id":                 true,
+		"id.value":           true,
+		"owner":              false,
+		"owner.name":         true,
+		"owner.email":        false,
+		"owner.address.city": true,
+		"parent":             false,
+	} {
+		if selected := flds.Selected(field); selected != expected {
+			t.Errorf("invalid selected result for %q: %v - expected: %v", field, selected, expected)
+		}
+	}
+}
+
+func TestContains(t *testing.T) {
+	flds := ParseFieldSelection("id,owner.name,owner.address")
+	for field, expected := range map[string]bool{
+		"":                   false,
+		"id":                 true,
+		"id.value":           true,
+		"owner":              true,
+		"owner.name":         true,
+		"owner.email":        false,
+		"owner.address":      true,
+		"owner.address.city": true,
+		"parent":             false,
+	} {
+		if contains := flds.Contains(field); contains != expected {
+			t.Errorf("invalid contains result for %q: %v - expected: %v", field, contains, expected)
+		}
+	}
+
+	excluded := ParseFieldSelection("-thumbnail,-owner.raw_bytes")
+	for field, expected := range map[string]bool{
+		"id":              true,
+		"thumbnail":       false,
+		"thumbnail.size":  false,
+		"owner":           true,
+		"owner.name":      true,
+		"owner.raw_bytes": false,
+	} {
+		if contains := excluded.Contains(field); contains != expected {
+			t.Errorf("invalid contains result for %q of excluded fields: %v - expected: %v", field, contains, expected)
+		}
+	}
+
+	var empty *FieldSelection
+	if !empty.Contains("owner.name") || !(&FieldSelection{}).Contains("id") {
+		t.Error("empty field selection must contain all fields")
+	}
+	if !ParseFieldSelection("owner/name", "/").Contains("owner/name", "/") {
+		t.Error("invalid contains result for custom delimiter")
+	}
+}
+
+func TestFieldSelectionToFieldMask(t *testing.T) {
+	if _, err := FieldSelectionToFieldMask(nil); err == nil {
+		t.Error("expected error - got nil")
+	}
+
+	fm, err := FieldSelectionToFieldMask(ParseFieldSelection("id,owner.first_name,owner.address.city"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := []string{"Id", "Owner.Address.City", "Owner.FirstName"}
+	if !reflect.DeepEqual(fm.GetPaths(), expected) {
+		t.Errorf("invalid field mask paths: %v - expected: %v", fm.GetPaths(), expected)
+	}
+}
+
+func TestParseExcluded(t *testing.T) {
+	expected := FieldSelection{Fields: FieldSelectionMap{"thumbnail": &Field{Name: "thumbnail"}, "owner": &Field{Name: "owner", Subs: FieldSelectionMap{"raw_bytes": &Field{Name: "raw_bytes"}}}}, Exclude: true}
+	validateParse(t, ParseFieldSelection("-thumbnail,-owner.raw_bytes"), &expected)
+
+	fs, err := ParseFieldSelectionStrict("-thumbnail,-owner.raw_bytes")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	validateParse(t, fs, &expected)
+	validateGoString(t, "-thumbnail,-owner.raw_bytes")
+
+	for _, input := range []string{"-thumbnail,owner", "owner,-thumbnail"} {
+		validateParse(t, ParseFieldSelection(input), nil)
+		if _, err := ParseFieldSelectionStrict(input); err == nil {
+			t.Errorf("expected error for %q - got nil", input)
+		} else if _, ok := err.(*MixedFieldSelectionError); !ok {
+			t.Errorf("invalid error for %q: %v - expected: MixedFieldSelectionError", input, err)
+		}
+	}
+
+	if _, err := FieldSelectionToFieldMask(fs); err == nil {
+		t.Error("expected error - got nil")
+	}
+
+	for field, expected := range map[string]bool{
+		"id":              true,
+		"thumbnail":       false,
+		"thumbnail.size":  false,
+		"owner":           false,
+		"owner.name":      true,
+		"owner.raw_bytes": false,
+	} {
+		if selected := fs.Selected(field); selected != expected {
+			t.Errorf("invalid selected result for %q: %v - expected: %v", field, selected, expected)
+		}
+	}
+}
+
+func TestFieldSelectionToSQLColumns(t *testing.T) {
+	fieldMap := map[string]string{
+		"id":         "id",
+		"name":       "full_name",
+		"owner.name": "owner_name",
+		"owner.age":  "owner_age",
+		"address":    "address_json",
+	}
+
+	tests := []struct {
+		fields   string
+		expected []string
+	}{
+		{"", []string{"id", "address_json", "full_name", "owner_age", "owner_name"}},
+		{"name", []string{"id", "full_name"}},
+		{"id,name", []string{"id", "full_name"}},
+		{"owner", []string{"id", "owner_age", "owner_name"}},
+		{"owner.name,address.city", []string{"id", "address_json", "owner_name"}},
+		{"-owner", []string{"id", "address_json", "full_name"}},
+		{"-address.city,-owner.age", []string{"id", "address_json", "full_name", "owner_name"}},
+	}
+	for _, test := range tests {
+		var fs *FieldSelection
+		if test.fields != "" {
+			fs = ParseFieldSelection(test.fields)
+		}
+		columns, err := FieldSelectionToSQLColumns(fs, fieldMap, "id")
+		if err != nil {
+			t.Errorf("unexpected error for %q: %s", test.fields, err)
+			continue
+		}
+		if !reflect.DeepEqual(columns, test.expected) {
+			t.Errorf("invalid columns for %q: %v - expected: %v", test.fields, columns, test.expected)
+		}
+	}
+
+	if columns, err := FieldSelectionToSQLColumns(&FieldSelection{}, fieldMap); err != nil || len(columns) != len(fieldMap) {
+		t.Errorf("invalid columns for empty selection: %v, %v - expected all mapped columns", columns, err)
+	}
+
+	for _, fields := range []string{"password", "owner.email", "-password"} {
+		_, err := FieldSelectionToSQLColumns(ParseFieldSelection(fields), fieldMap, "id")
+		if _, ok := err.(*UnknownFieldError); !ok {
+			t.Errorf("invalid error for %q: %v - expected: UnknownFieldError", fields, err)
+		}
+	}
+}
@@ -2,21 +2,146 @@ package query
 
 import (
 	"fmt"
+	"math"
 	"reflect"
 	"regexp"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
 
 	"github.com/golang/protobuf/proto"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // Filter is a shortcut to parse a filter string using default FilteringParser implementation
-// and call Filter on the returned filtering expression.
-func Filter(obj interface{}, filter string) (bool, error) {
+// and call FilterWithOptions on the returned filtering expression.
+func Filter(obj interface{}, filter string, opts ...FilterOption) (bool, error) {
 	f, err := ParseFiltering(filter)
 	if err != nil {
 		return false, err
 	}
-	return f.Filter(obj)
+	return f.FilterWithOptions(obj, opts...)
+}
+
+// FilterWithOptions is the same as Filter.
+//
+// Deprecated: use Filter which accepts options as well.
+func FilterWithOptions(obj interface{}, filter string, opts ...FilterOption) (bool, error) {
+	return Filter(obj, filter, opts...)
+}
+
+type filterOptions struct {
+	caseInsensitive bool
+	// foldLanguage is a language of case folding set by CaseFoldLanguage,
+	// nil means language-neutral folding
+	foldLanguage *language.Tag
+	// base64Bytes makes literals compared with bytes fields to be decoded from base64 instead of hex
+	base64Bytes bool
+	// upperEnumNames makes enum names to be uppercased before lookup
+	upperEnumNames bool
+	// timeLayouts are alternative layouts of time literals
+	timeLayouts []string
+	// maxRegexpSize and disallowedRegexpFeatures restrict regular expressions of match conditions
+	maxRegexpSize            int
+	disallowedRegexpFeatures []RegexpFeature
+	// aliases maps field paths of the expression to the ones of evaluated objects
+	aliases map[string]string
+	// resolver resolves fields that are not found by reflection
+	resolver FieldResolverFunc
+	// regexps holds precompiled regular expressions of match conditions
+	regexps map[*StringCondition]*regexp.Regexp
+	// operands holds flattened operands of logical operators of a compiled filter
+	operands map[*LogicalOperator][]interface{}
+	// unknownFields is set by WithUnknownFieldPolicy
+	unknownFields UnknownFieldPolicy
+	// floatTolerance is set by WithFloatTolerance
+	floatTolerance float64
+	// clock is set by WithClock
+	clock func() time.Time
+}
+
+// FilterOption is a type of function that alters evaluation of a filtering expression.
+type FilterOption func(*filterOptions)
+
+// CaseInsensitive makes string fields to be compared regardless of case.
+// It affects ==, !=, <, <=, >, >=, in operators and regular expression matching.
+func CaseInsensitive() FilterOption {
+	return func(o *filterOptions) {
+		o.caseInsensitive = true
+	}
+}
+
+// CaseFoldLanguage makes case-insensitive comparisons to fold strings according to the rules
+// of language tag, e.g. language.Turkish maps "I" to dotless "ı". By default strings are folded
+// in a language-neutral way with full Unicode case folding, e.g. "ß" matches "SS".
+// It does not affect regular expression matching.
+func CaseFoldLanguage(tag language.Tag) FilterOption {
+	return func(o *filterOptions) {
+		o.foldLanguage = &tag
+	}
+}
+
+// WithFloatTolerance makes == and != conditions on float fields to compare numbers with absolute tolerance eps,
+// so that e.g. "price == 11.11" matches 11.109999999 if eps is 1e-6. Ordering operators and in conditions
+// as well as integer fields are compared exactly. Zero eps, which is the default, means exact comparison.
+func WithFloatTolerance(eps float64) FilterOption {
+	return func(o *filterOptions) {
+		o.floatTolerance = eps
+	}
+}
+
+// fold returns s folded for case-insensitive comparison.
+// A caser is made per call since casers are not safe for concurrent use.
+func (o *filterOptions) fold(s string) string {
+	if o.foldLanguage != nil {
+		return cases.Lower(*o.foldLanguage).String(s)
+	}
+	if isASCII(s) {
+		return strings.ToLower(s)
+	}
+	return cases.Fold().String(s)
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+func newFilterOptions(opts []FilterOption) *filterOptions {
+	o := &filterOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// filterer is implemented by AST nodes that are able to evaluate themselves with options.
+type filterer interface {
+	filter(obj interface{}, o *filterOptions) (bool, error)
+}
+
+func filterNode(node interface{}, obj interface{}, o *filterOptions) (bool, error) {
+	if f, ok := unwrapNode(node).(filterer); ok {
+		res, err := f.filter(obj, o)
+		if err != nil && err != errUnknownField && o.unknownFields != RejectUnknownField && o.isUnknownField(unwrapNode(node), obj) {
+			return false, errUnknownField
+		}
+		return res, err
+	}
+	if f, ok := node.(FilteringExpression); ok {
+		return f.Filter(obj)
+	}
+	return false, fmt.Errorf("%T type does not implement FilteringExpression", node)
 }
 
 // FilteringExpression is the interface implemented by types that represent nodes in a filtering expression AST.
@@ -32,30 +157,53 @@ type Matcher interface {
 // Filter evaluates underlying filtering expression against obj.
 // If obj implements Matcher, call it's custom implementation.
 func (m *Filtering) Filter(obj interface{}) (bool, error) {
+	return m.FilterWithOptions(obj)
+}
+
+// FilterWithOptions evaluates underlying filtering expression against obj using options opts.
+// If obj implements Matcher, call it's custom implementation with field aliases already applied.
+func (m *Filtering) FilterWithOptions(obj interface{}, opts ...FilterOption) (bool, error) {
 	if m == nil {
 		return true, nil
 	}
+	o := newFilterOptions(opts)
+	m = AliasFiltering(m, o.aliases)
 	if matcher, ok := obj.(Matcher); ok {
 		return matcher.Match(m)
 	}
-	r := m.Root
-	if f, ok := r.(FilteringExpression); ok {
-		return f.Filter(obj)
-	} else {
-		return false, fmt.Errorf("%T type does not implement FilteringExpression", r)
-	}
+	return filterRoot(m.Root, obj, o)
 }
 
 // TypeMismatchError representes a type that is required for a value under FieldPath.
+// Value is the literal of the condition that cannot be compared with the value, if any.
 type TypeMismatchError struct {
 	ReqType   string
 	FieldPath []string
+	Value     string
 }
 
 func (e *TypeMismatchError) Error() string {
+	if e.Value != "" {
+		return fmt.Sprintf("%s is not a %s type, cannot compare with %s", strings.Join(e.FieldPath, "."), e.ReqType, e.Value)
+	}
 	return fmt.Sprintf("%s is not a %s type", strings.Join(e.FieldPath, "."), e.ReqType)
 }
 
+// GRPCStatus returns InvalidArgument status with BadRequest detail
+// holding a violation of the field, so that clients can tell which field is mismatched.
+func (e *TypeMismatchError) GRPCStatus() *status.Status {
+	st := status.New(codes.InvalidArgument, e.Error())
+	br := &errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: strings.Join(e.FieldPath, "."), Description: e.Error()},
+		},
+	}
+	if dst, err := st.WithDetails(br); err == nil {
+		return dst
+	}
+	return st
+}
+
 // UnsupportedOperatorError represents an operator that is not supported by a particular field type.
 type UnsupportedOperatorError struct {
 	Type string
@@ -68,64 +216,147 @@ func (e *UnsupportedOperatorError) Error() string {
 
 // Filter evaluates filtering expression against obj.
 func (lop *LogicalOperator) Filter(obj interface{}) (bool, error) {
-	var res bool
-	var err error
-	l := lop.Left
-	if f, ok := l.(FilteringExpression); ok {
-		res, err = f.Filter(obj)
+	return lop.filter(obj, &filterOptions{})
+}
+
+func (lop *LogicalOperator) filter(obj interface{}, o *filterOptions) (bool, error) {
+	// evaluation stops as soon as an operand of OR is true or an operand of AND is false
+	stop := lop.Type == LogicalOperator_OR
+	operands, ok := o.operands[lop]
+	if !ok {
+		operands = lop.operands()
+	}
+	skipped := 0
+	for _, node := range operands {
+		res, err := filterNode(node, obj, o)
+		if err == errUnknownField {
+			// see WithUnknownFieldPolicy
+			if o.unknownFields == SkipUnknownField {
+				skipped++
+				continue
+			}
+			res, err = false, nil
+		}
 		if err != nil {
 			return false, err
 		}
-	} else {
-		return false, fmt.Errorf("%T type does not implement FilteringExpression", l)
+		if res == stop {
+			return negateIfNeeded(lop.IsNegative, stop), nil
+		}
 	}
-	if lop.Type == LogicalOperator_AND && !res {
-		return negateIfNeeded(lop.IsNegative, false), nil
-	} else if lop.Type == LogicalOperator_OR && res {
-		return negateIfNeeded(lop.IsNegative, true), nil
+	if skipped == len(operands) {
+		return false, errUnknownField
 	}
-	r := lop.Right
-	if f, ok := r.(FilteringExpression); ok {
-		res, err = f.Filter(obj)
-		if err != nil {
-			return false, err
+	return negateIfNeeded(lop.IsNegative, !stop), nil
+}
+
+// operands returns operands of lop in order from left to right flattening nested
+// non-negated operators of the same type, e.g. a, b and c for "(a or b) or c".
+// The tree is traversed iteratively, so that chains of operators are evaluated in a loop.
+func (lop *LogicalOperator) operands() []interface{} {
+	var res []interface{}
+	stack := []interface{}{lop.Right, lop.Left}
+	for len(stack) > 0 {
+		node := unwrapNode(stack[len(stack)-1])
+		stack = stack[:len(stack)-1]
+		if l, ok := node.(*LogicalOperator); ok && l.Type == lop.Type && !l.IsNegative {
+			stack = append(stack, l.Right, l.Left)
+			continue
 		}
-	} else {
-		return false, fmt.Errorf("%T type does not implement FilteringExpression", r)
+		res = append(res, node)
 	}
-	return negateIfNeeded(lop.IsNegative, res), nil
+	return res
 }
 
 // Filter evaluates string condition against obj.
 // If obj is a proto message, then 'protobuf' tag is used to map FieldPath to obj's struct fields,
 // otherwise 'json' tag is used.
 func (c *StringCondition) Filter(obj interface{}) (bool, error) {
-	fv := fieldByFieldPath(obj, c.FieldPath)
+	return c.filter(obj, &filterOptions{})
+}
+
+func (c *StringCondition) filter(obj interface{}, o *filterOptions) (bool, error) {
+	if c.NullSafe {
+		nc := *c
+		nc.NullSafe, nc.IsNegative = false, false
+		return filterNullSafe(&nc, obj, c.FieldPath, c.IsNegative, o)
+	}
+	if res, ok, err := filterRepeated(c, obj, c.FieldPath, c.IsNegative, o); ok {
+		return res, err
+	}
+	if res, ok, err := filterDynamic(c, obj, c.FieldPath, c.IsNegative, o); ok {
+		return res, err
+	}
+	fv := o.fieldByFieldPath(obj, c.FieldPath)
+	if fv.IsValid() && fv.Type() == timestampType {
+		return c.filterTimestamp(fv, o)
+	}
+	if isTimeValue(fv) {
+		return c.filterTime(fv, o)
+	}
+	if c.RelativeTime {
+		return false, &TypeMismatchError{"time", c.FieldPath, c.Value}
+	}
+	if fv.IsValid() && fv.Type() == durationType {
+		return c.filterDuration(fv)
+	}
+	if fv.IsValid() && isEnumValue(fv) {
+		return c.filterEnum(obj, fv, o)
+	}
+	if isIPValue(fv) {
+		return c.filterIP(fv)
+	}
+	if isIPNetValue(fv) {
+		return c.filterIPNet(fv)
+	}
+	if isBytesValue(fv) {
+		return c.filterBytes(fv, o)
+	}
+	if isNilValue(fv) && indirectKind(fv) == reflect.String {
+		return false, nil
+	}
 	fv = dereferenceValue(fv)
 	if fv.Kind() != reflect.String {
-		return false, &TypeMismatchError{"string", c.FieldPath}
+		return false, &TypeMismatchError{"string", c.FieldPath, literalString(c.Value)}
+	}
+	if c.Type == StringCondition_IN_CIDR {
+		return c.filterIPString(fv.String())
+	}
+	s, value := fv.String(), c.Value
+	if o.caseInsensitive && c.Type != StringCondition_MATCH && c.Type != StringCondition_LIKE && c.Type != StringCondition_ILIKE {
+		s, value = o.fold(s), o.fold(value)
 	}
-	s := fv.String()
 	switch c.Type {
 	case StringCondition_EQ:
-		return negateIfNeeded(s == c.Value, c.IsNegative), nil
+		return negateIfNeeded(s == value, c.IsNegative), nil
 	case StringCondition_IEQ:
-		return negateIfNeeded(strings.ToLower(s) == strings.ToLower(c.Value), c.IsNegative), nil
+		return negateIfNeeded(o.fold(s) == o.fold(value), c.IsNegative), nil
 	case StringCondition_MATCH:
-		// add regex caching
-		matched, err := regexp.MatchString(c.Value, s)
-		if err != nil {
-			return false, err
+		re, ok := o.regexps[c]
+		if !ok {
+			var err error
+			if re, err = compileMatch(value, o); err != nil {
+				return false, err
+			}
 		}
-		return negateIfNeeded(matched, c.IsNegative), nil
+		return negateIfNeeded(re.MatchString(s), c.IsNegative), nil
+	case StringCondition_LIKE, StringCondition_ILIKE:
+		re, ok := o.regexps[c]
+		if !ok {
+			var err error
+			if re, err = compileLike(value, c.Type == StringCondition_ILIKE, o); err != nil {
+				return false, err
+			}
+		}
+		return negateIfNeeded(re.MatchString(s), c.IsNegative), nil
 	case StringCondition_GT:
-		return negateIfNeeded(s > c.Value, c.IsNegative), nil
+		return negateIfNeeded(s > value, c.IsNegative), nil
 	case StringCondition_GE:
-		return negateIfNeeded(s >= c.Value, c.IsNegative), nil
+		return negateIfNeeded(s >= value, c.IsNegative), nil
 	case StringCondition_LT:
-		return negateIfNeeded(s < c.Value, c.IsNegative), nil
+		return negateIfNeeded(s < value, c.IsNegative), nil
 	case StringCondition_LE:
-		return negateIfNeeded(s <= c.Value, c.IsNegative), nil
+		return negateIfNeeded(s <= value, c.IsNegative), nil
 	default:
 		return false, &UnsupportedOperatorError{"string", c.Type.String()}
 	}
@@ -135,30 +366,42 @@ func (c *StringCondition) Filter(obj interface{}) (bool, error) {
 // If obj is a proto message, then 'protobuf' tag is used to map FieldPath to obj's struct fields,
 // otherwise 'json' tag is used.
 func (c *NumberCondition) Filter(obj interface{}) (bool, error) {
-	fv := fieldByFieldPath(obj, c.FieldPath)
+	return c.filter(obj, &filterOptions{})
+}
+
+func (c *NumberCondition) filter(obj interface{}, o *filterOptions) (bool, error) {
+	if c.NullSafe {
+		nc := *c
+		nc.NullSafe, nc.IsNegative = false, false
+		return filterNullSafe(&nc, obj, c.FieldPath, c.IsNegative, o)
+	}
+	if res, ok, err := filterRepeated(c, obj, c.FieldPath, c.IsNegative, o); ok {
+		return res, err
+	}
+	if res, ok, err := filterDynamic(c, obj, c.FieldPath, c.IsNegative, o); ok {
+		return res, err
+	}
+	fv := o.fieldByFieldPath(obj, c.FieldPath)
+	if isNilValue(fv) && isNumberKind(indirectKind(fv)) {
+		return false, nil
+	}
 	fv = dereferenceValue(fv)
-	var f float64
-	switch fv.Kind() {
-	case reflect.Float32, reflect.Float64:
-		f = fv.Float()
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		f = float64(fv.Int())
-	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		f = float64(fv.Uint())
-	default:
-		return false, &TypeMismatchError{"number", c.FieldPath}
+	f, ok := numberValue(fv)
+	if !ok {
+		return false, &TypeMismatchError{"number", c.FieldPath, literalString(c.Value)}
 	}
+	value := numberLiteral(fv, c.Value)
 	switch c.Type {
 	case NumberCondition_EQ:
-		return negateIfNeeded(f == c.Value, c.IsNegative), nil
+		return negateIfNeeded(o.numberEqual(fv, f, value), c.IsNegative), nil
 	case NumberCondition_GT:
-		return negateIfNeeded(f > c.Value, c.IsNegative), nil
+		return negateIfNeeded(f > value, c.IsNegative), nil
 	case NumberCondition_GE:
-		return negateIfNeeded(f >= c.Value, c.IsNegative), nil
+		return negateIfNeeded(f >= value, c.IsNegative), nil
 	case NumberCondition_LT:
-		return negateIfNeeded(f < c.Value, c.IsNegative), nil
+		return negateIfNeeded(f < value, c.IsNegative), nil
 	case NumberCondition_LE:
-		return negateIfNeeded(f <= c.Value, c.IsNegative), nil
+		return negateIfNeeded(f <= value, c.IsNegative), nil
 	default:
 		return false, &UnsupportedOperatorError{"number", c.Type.String()}
 	}
@@ -168,36 +411,158 @@ func (c *NumberCondition) Filter(obj interface{}) (bool, error) {
 // If obj is a proto message, then 'protobuf' tag is used to map FieldPath to obj's struct fields,
 // otherwise 'json' tag is used.
 func (c *NullCondition) Filter(obj interface{}) (bool, error) {
-	fv := fieldByFieldPath(obj, c.FieldPath)
+	return c.filter(obj, &filterOptions{})
+}
+
+func (c *NullCondition) filter(obj interface{}, o *filterOptions) (bool, error) {
+	if c.NullSafe {
+		nc := *c
+		nc.NullSafe, nc.IsNegative = false, false
+		return filterNullSafe(&nc, obj, c.FieldPath, c.IsNegative, o)
+	}
+	if res, ok, err := filterRepeated(c, obj, c.FieldPath, c.IsNegative, o); ok {
+		return res, err
+	}
+	if res, ok, err := filterDynamic(c, obj, c.FieldPath, c.IsNegative, o); ok {
+		return res, err
+	}
+	fv := o.rawFieldByFieldPath(obj, c.FieldPath)
+	if isBytesValue(fv) {
+		return negateIfNeeded(fv.IsNil(), c.IsNegative), nil
+	}
+	// zero time is null as well as a nil pointer to it
+	if fv.IsValid() && fv.Type() == timeType {
+		return negateIfNeeded(fv.Interface().(time.Time).IsZero(), c.IsNegative), nil
+	}
 	if fv.Kind() != reflect.Ptr {
-		return false, &TypeMismatchError{"nullable", c.FieldPath}
+		return false, &TypeMismatchError{"nullable", c.FieldPath, "null"}
 	}
 	return negateIfNeeded(fv.IsNil(), c.IsNegative), nil
 }
 
+// Filter evaluates exists condition against obj.
+// The field is present unless it is a nil pointer, interface, map or slice, or a missing key of a map.
+// Fields of other types are always present, they are never compared with zero values.
+// As for other conditions a repeated field is checked element-wise, so it is present if it is not empty.
+func (c *ExistsCondition) Filter(obj interface{}) (bool, error) {
+	return c.filter(obj, &filterOptions{})
+}
+
+func (c *ExistsCondition) filter(obj interface{}, o *filterOptions) (bool, error) {
+	if res, ok, err := filterRepeated(c, obj, c.FieldPath, c.IsNegative, o); ok {
+		return res, err
+	}
+	if res, ok, err := filterDynamic(c, obj, c.FieldPath, c.IsNegative, o); ok {
+		return res, err
+	}
+	fv := o.rawFieldByFieldPath(obj, c.FieldPath)
+	switch fv.Kind() {
+	case reflect.Invalid:
+		return false, &UnknownFieldError{c.FieldPath}
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+		return negateIfNeeded(!fv.IsNil(), c.IsNegative), nil
+	default:
+		return negateIfNeeded(true, c.IsNegative), nil
+	}
+}
+
+// Filter evaluates bool condition against obj.
 func (c *BoolCondition) Filter(obj interface{}) (bool, error) {
-	fv := fieldByFieldPath(obj, c.FieldPath)
+	return c.filter(obj, &filterOptions{})
+}
+
+func (c *BoolCondition) filter(obj interface{}, o *filterOptions) (bool, error) {
+	if c.NullSafe {
+		nc := *c
+		nc.NullSafe, nc.IsNegative = false, false
+		return filterNullSafe(&nc, obj, c.FieldPath, c.IsNegative, o)
+	}
+	if res, ok, err := filterRepeated(c, obj, c.FieldPath, c.IsNegative, o); ok {
+		return res, err
+	}
+	if res, ok, err := filterDynamic(c, obj, c.FieldPath, c.IsNegative, o); ok {
+		return res, err
+	}
+	fv := o.fieldByFieldPath(obj, c.FieldPath)
+	if isNilValue(fv) && indirectKind(fv) == reflect.Bool {
+		return false, nil
+	}
+	fv = dereferenceValue(fv)
 	if fv.Kind() != reflect.Bool {
-		return false, &TypeMismatchError{"bool", c.FieldPath}
+		return false, &TypeMismatchError{"bool", c.FieldPath, literalString(c.Value)}
+	}
+	return negateIfNeeded(c.IsNegative, c.compare(fv.Bool())), nil
+}
+
+// compare reports whether v satisfies the comparison of c, bool values are ordered so that false < true.
+func (c *BoolCondition) compare(v bool) bool {
+	switch c.Type {
+	case BoolCondition_GT:
+		return v && !c.Value
+	case BoolCondition_GE:
+		return v || !c.Value
+	case BoolCondition_LT:
+		return !v && c.Value
+	case BoolCondition_LE:
+		return !v || c.Value
+	default:
+		return v == c.Value
 	}
-	return negateIfNeeded(c.IsNegative, fv.Bool() == c.Value), nil
 }
 
+// Filter evaluates string array condition against obj.
+// Enum fields are matched against the values by their symbolic names.
 func (c *StringArrayCondition) Filter(obj interface{}) (bool, error) {
-	fv := fieldByFieldPath(obj, c.FieldPath)
+	return c.filter(obj, &filterOptions{})
+}
+
+func (c *StringArrayCondition) filter(obj interface{}, o *filterOptions) (bool, error) {
+	if res, ok, err := filterRepeated(c, obj, c.FieldPath, c.IsNegative, o); ok {
+		return res, err
+	}
+	if res, ok, err := filterDynamic(c, obj, c.FieldPath, c.IsNegative, o); ok {
+		return res, err
+	}
+	fv := o.fieldByFieldPath(obj, c.FieldPath)
+	if k := indirectKind(fv); isNilValue(fv) && (k == reflect.String || k == reflect.Int32) {
+		return false, nil
+	}
 	fv = dereferenceValue(fv)
-	if fv.Kind() != reflect.String {
-		return false, &TypeMismatchError{"string", c.FieldPath}
+	var s string
+	values := c.Values
+	if name, ok := enumName(fv); ok {
+		s = name
+		if o.upperEnumNames {
+			values = upperStrings(values)
+		}
+	} else if fv.Kind() == reflect.String {
+		s = fv.String()
+	} else {
+		return false, &TypeMismatchError{"string", c.FieldPath, literalString(c.Values)}
 	}
-	s := fv.String()
 	switch c.Type {
 	case StringArrayCondition_IN:
-		return negateIfNeeded(stringInSlice(s, c.Values), c.IsNegative), nil
+		if o.caseInsensitive {
+			return negateIfNeeded(stringInSliceFold(s, values, o), c.IsNegative), nil
+		}
+		return negateIfNeeded(stringInSlice(s, values), c.IsNegative), nil
 	default:
 		return false, &UnsupportedOperatorError{"[]string", c.Type.String()}
 	}
 }
 
+// enumName returns the symbolic name of v if v holds an enum value,
+// i.e. an int32 based type implementing fmt.Stringer as generated proto enums do.
+func enumName(v reflect.Value) (string, bool) {
+	if !v.IsValid() || v.Kind() != reflect.Int32 || !v.CanInterface() {
+		return "", false
+	}
+	if s, ok := v.Interface().(fmt.Stringer); ok {
+		return s.String(), true
+	}
+	return "", false
+}
+
 func stringInSlice(s string, slice []string) bool {
 	for _, val := range slice {
 		if val == s {
@@ -208,31 +573,88 @@ func stringInSlice(s string, slice []string) bool {
 	return false
 }
 
+// Filter evaluates number array condition against obj.
 func (c *NumberArrayCondition) Filter(obj interface{}) (bool, error) {
-	fv := fieldByFieldPath(obj, c.FieldPath)
+	return c.filter(obj, &filterOptions{})
+}
+
+func (c *NumberArrayCondition) filter(obj interface{}, o *filterOptions) (bool, error) {
+	if res, ok, err := filterRepeated(c, obj, c.FieldPath, c.IsNegative, o); ok {
+		return res, err
+	}
+	if res, ok, err := filterDynamic(c, obj, c.FieldPath, c.IsNegative, o); ok {
+		return res, err
+	}
+	fv := o.fieldByFieldPath(obj, c.FieldPath)
+	if isNilValue(fv) && isNumberKind(indirectKind(fv)) {
+		return false, nil
+	}
 	fv = dereferenceValue(fv)
-	var f float64
-	switch fv.Kind() {
-	case reflect.Float32, reflect.Float64:
-		f = fv.Float()
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		f = float64(fv.Int())
-	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		f = float64(fv.Uint())
-	default:
-		return false, &TypeMismatchError{"number", c.FieldPath}
+	f, ok := numberValue(fv)
+	if !ok {
+		return false, &TypeMismatchError{"number", c.FieldPath, literalString(c.Values)}
 	}
 	switch c.Type {
 	case NumberArrayCondition_IN:
-		return negateIfNeeded(floatInSlice(f, c.Values), c.IsNegative), nil
+		return negateIfNeeded(numberInSlice(fv, f, c.Values), c.IsNegative), nil
 	default:
 		return false, &UnsupportedOperatorError{"number", c.Type.String()}
 	}
 }
 
-func floatInSlice(digit float64, slice []float64) bool {
+func compileMatch(expr string, o *filterOptions) (*regexp.Regexp, error) {
+	return compileRegexp(expr, expr, o, true)
+}
+
+// compileLike compiles LIKE pattern translated to a regular expression,
+// the pattern is matched regardless of case if fold is true as ILIKE does.
+func compileLike(pattern string, fold bool, o *filterOptions) (*regexp.Regexp, error) {
+	if fold {
+		return compileRegexp(ILikeToRegexp(pattern), pattern, o, false)
+	}
+	return compileRegexp(LikeToRegexp(pattern), pattern, o, false)
+}
+
+// LikeToRegexp translates SQL LIKE pattern to an equivalent regular expression
+// that matches the whole string: % matches any sequence of characters and _ matches
+// any single character. Backslash escapes the following character, e.g. \% matches
+// literal percent sign.
+func LikeToRegexp(pattern string) string {
+	var b strings.Builder
+	b.WriteString("(?s)^")
+	escaped := false
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '%':
+			b.WriteString(".*")
+		case r == '_':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	if escaped {
+		b.WriteString(regexp.QuoteMeta("\\"))
+	}
+	b.WriteString("$")
+	return b.String()
+}
+
+// ILikeToRegexp is the same as LikeToRegexp but the regular expression is prefixed with (?i) flag,
+// so that it matches regardless of case as ILIKE does, e.g. for engines lacking ILIKE operator.
+func ILikeToRegexp(pattern string) string {
+	return "(?i)" + LikeToRegexp(pattern)
+}
+
+func stringInSliceFold(s string, slice []string, o *filterOptions) bool {
+	s = o.fold(s)
 	for _, val := range slice {
-		if digit == val {
+		if o.fold(val) == s {
 			return true
 		}
 	}
@@ -240,49 +662,347 @@ func floatInSlice(digit float64, slice []float64) bool {
 	return false
 }
 
-func fieldByFieldPath(obj interface{}, fieldPath []string) reflect.Value {
-	var v reflect.Value
-	switch obj.(type) {
-	case proto.Message:
-		v = fieldByProtoPath(obj, fieldPath)
+// numberValue converts numeric value v to float64.
+func numberValue(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
 	default:
-		v = fieldByJSONPath(obj, fieldPath)
+		return 0, false
+	}
+}
+
+// numberEqual reports whether f, the value of v, is equal to value
+// within the tolerance set by WithFloatTolerance if v is a float.
+func (o *filterOptions) numberEqual(v reflect.Value, f, value float64) bool {
+	if o.floatTolerance > 0 && (v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64) {
+		return math.Abs(f-value) <= o.floatTolerance
 	}
-	if v, ok := wrappedValue(v); ok {
-		return v
+	return f == value
+}
+
+// numberLiteral rounds literal f to the precision of numeric value v,
+// so that e.g. float32 field holding 1.1 is equal to 1.1 literal.
+func numberLiteral(v reflect.Value, f float64) float64 {
+	if v.Kind() == reflect.Float32 {
+		return float64(float32(f))
+	}
+	return f
+}
+
+func isNumberKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	default:
+		return false
+	}
+}
+
+// numberInSlice reports whether f that is a value of v equals to any of the number literals
+// converted to the precision of v. It stops on the first match.
+func numberInSlice(v reflect.Value, f float64, slice []float64) bool {
+	for _, val := range slice {
+		if f == numberLiteral(v, val) {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldByFieldPath resolves fieldPath against obj descending through nested structs.
+// A path segment following a map field is used as a key of the map, the value of a missing key is null.
+// If obj or any of the intermediate fields is nil then a nil pointer of the leaf field type is returned,
+// so that the leaf is treated as null.
+// Well-known wrapper types (google.protobuf.*Value) are unwrapped to their inner scalar values,
+// unset wrapper is returned as a nil pointer to the scalar type.
+func fieldByFieldPath(obj interface{}, fieldPath []string) reflect.Value {
+	v := rawFieldByFieldPath(obj, fieldPath)
+	if wv, ok := wrappedValue(v); ok {
+		return wv
 	}
 	return v
 }
 
-func fieldByProtoPath(obj interface{}, protoPath []string) reflect.Value {
-	v := dereferenceValue(reflect.ValueOf(obj))
-	props := proto.GetProperties(v.Type())
-	for _, p := range props.Prop {
-		if p.OrigName == protoPath[0] {
-			return v.FieldByName(p.Name)
+// rawFieldByFieldPath is the same as fieldByFieldPath but does not unwrap wrapper types.
+func rawFieldByFieldPath(obj interface{}, fieldPath []string) reflect.Value {
+	v := reflect.ValueOf(obj)
+	isNil := false
+	for _, name := range fieldPath {
+		if v.Kind() == reflect.Ptr && v.IsNil() {
+			if v.Type().Elem().Kind() != reflect.Struct {
+				return reflect.Value{}
+			}
+			v = reflect.New(v.Type().Elem())
+			isNil = true
+		}
+		v = fieldByName(v, name)
+		if !v.IsValid() {
+			return v
+		}
+	}
+	if isNil {
+		return nilValue(v.Type())
+	}
+	return collapsePointer(v)
+}
+
+// collapsePointer dereferences pointers to pointers, e.g. **int, down to a single pointer,
+// so that a nil pointer at any level is treated as null and otherwise the value is
+// handled as the pointed-to type.
+func collapsePointer(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Ptr && v.Type().Elem().Kind() == reflect.Ptr {
+		if v.IsNil() {
+			t := v.Type().Elem()
+			for t.Elem().Kind() == reflect.Ptr {
+				t = t.Elem()
+			}
+			return reflect.Zero(t)
 		}
-		if p.JSONName == protoPath[0] {
-			return v.FieldByName(p.Name)
+		v = v.Elem()
+	}
+	return v
+}
+
+func fieldByName(v reflect.Value, name string) reflect.Value {
+	v = dereferenceValue(v)
+	switch v.Kind() {
+	case reflect.Map:
+		return mapValueByKey(v, name)
+	case reflect.Struct:
+		if index, ok := structFieldIndexes(v.Type())[name]; ok {
+			return fieldByIndex(v, index)
 		}
 	}
 	return reflect.Value{}
 }
 
-func fieldByJSONPath(obj interface{}, jsonPath []string) reflect.Value {
-	v := dereferenceValue(reflect.ValueOf(obj))
-	t := v.Type()
+var protoMessageType = reflect.TypeOf((*proto.Message)(nil)).Elem()
+
+// isProtoMessage reports whether t or a pointer to it is a proto message.
+// Structs that implement proto.Message only by embedding a message are not messages themselves,
+// their fields are resolved as fields of plain structs.
+func isProtoMessage(t reflect.Type) bool {
+	if t.Kind() != reflect.Ptr {
+		t = reflect.PtrTo(t)
+	}
+	if !t.Implements(protoMessageType) {
+		return false
+	}
+	st := t.Elem()
+	if st.Kind() != reflect.Struct {
+		return true
+	}
+	for i := 0; i < st.NumField(); i++ {
+		sf := st.Field(i)
+		if !sf.Anonymous {
+			continue
+		}
+		if et := sf.Type; et.Implements(protoMessageType) || et.Kind() != reflect.Ptr && reflect.PtrTo(et).Implements(protoMessageType) {
+			return false
+		}
+	}
+	return true
+}
+
+// fieldIndexCache maps struct types to indexes of their fields by names used in filtering expressions.
+var fieldIndexCache sync.Map // map[reflect.Type]map[string][]int
+
+// structFieldIndexes returns indexes of fields of struct type t by their names.
+// Fields of proto messages are named by both their proto and JSON names,
+// fields of other structs are named by both their JSON names (see getJSONName) and Go names.
+// JSON names take precedence, so a field whose JSON name equals the Go name of another field
+// is referred to by that name. Fields ignored by JSON encoding cannot be referred to.
+// Fields of embedded structs and proto messages are promoted following Go rules:
+// a shallower field hides deeper ones and names ambiguous at the same depth are not promoted.
+// The result is computed once per type and must not be modified.
+func structFieldIndexes(t reflect.Type) map[string][]int {
+	if m, ok := fieldIndexCache.Load(t); ok {
+		return m.(map[string][]int)
+	}
+	m := fieldIndexes(t, map[reflect.Type]bool{t: true})
+	actual, _ := fieldIndexCache.LoadOrStore(t, m)
+	return actual.(map[string][]int)
+}
+
+// fieldIndexes computes structFieldIndexes of t, types of the enclosing embedded structs
+// are marked in seen to stop recursion of self-embedding types.
+func fieldIndexes(t reflect.Type, seen map[reflect.Type]bool) map[string][]int {
+	m := make(map[string][]int)
+	add := func(name string, index []int) {
+		if _, ok := m[name]; !ok {
+			m[name] = index
+		}
+	}
+	if isProtoMessage(t) {
+		for _, p := range proto.GetProperties(t).Prop {
+			if sf, ok := t.FieldByName(p.Name); ok {
+				add(p.OrigName, sf.Index)
+				add(p.JSONName, sf.Index)
+			}
+		}
+		return m
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if name := getJSONName(t.Field(i)); name != "" {
+			add(name, []int{i})
+		}
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if sf := t.Field(i); getJSONName(sf) != "" {
+			add(sf.Name, []int{i})
+		}
+	}
+
+	// promoted fields of embedded structs, the shortest index wins
+	// and names found at the same depth in several embedded structs are ambiguous
+	promoted := make(map[string][]int)
+	ambiguous := make(map[string]bool)
 	for i := 0; i < t.NumField(); i++ {
 		sf := t.Field(i)
-		if getJSONName(sf) == jsonPath[0] {
-			return v.Field(i)
+		et := sf.Type
+		if et.Kind() == reflect.Ptr {
+			et = et.Elem()
+		}
+		if !sf.Anonymous || et.Kind() != reflect.Struct || getJSONName(sf) == "" || seen[et] {
+			continue
 		}
+		seen[et] = true
+		for name, index := range fieldIndexes(et, seen) {
+			index = append([]int{i}, index...)
+			if p, ok := promoted[name]; !ok || len(index) < len(p) {
+				promoted[name] = index
+				delete(ambiguous, name)
+			} else if len(index) == len(p) {
+				ambiguous[name] = true
+			}
+		}
+		delete(seen, et)
 	}
-	return reflect.Value{}
+	for name, index := range promoted {
+		if !ambiguous[name] {
+			add(name, index)
+		}
+	}
+	return m
+}
+
+// fieldByIndex returns the nested field of struct v by index like reflect.Value.FieldByIndex does,
+// but if any of embedded structs on the way is a nil pointer then a nil value of the field type is returned,
+// so that the field is treated as null.
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return nilValue(v.Type().Elem().FieldByIndex(index[i:]).Type)
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+// mapValueByKey returns a pointer to the value of map v by key parsed to the key type of the map.
+// If the key is not found (or cannot be parsed) then a nil pointer is returned, so that
+// the value is treated as null.
+func mapValueByKey(v reflect.Value, key string) reflect.Value {
+	t := v.Type()
+	var mv reflect.Value
+	if k, ok := mapKey(t.Key(), key); ok {
+		mv = v.MapIndex(k)
+	}
+	if t.Elem().Kind() == reflect.Ptr {
+		if !mv.IsValid() {
+			return reflect.Zero(t.Elem())
+		}
+		return mv
+	}
+	if !mv.IsValid() {
+		return reflect.Zero(reflect.PtrTo(t.Elem()))
+	}
+	p := reflect.New(t.Elem())
+	p.Elem().Set(mv)
+	return p
+}
+
+// mapKey parses s to a map key of type t, string, integer and bool keys are supported.
+func mapKey(t reflect.Type, s string) (reflect.Value, bool) {
+	k := reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.String:
+		k.SetString(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, t.Bits())
+		if err != nil {
+			return reflect.Value{}, false
+		}
+		k.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 10, t.Bits())
+		if err != nil {
+			return reflect.Value{}, false
+		}
+		k.SetUint(u)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return reflect.Value{}, false
+		}
+		k.SetBool(b)
+	default:
+		return reflect.Value{}, false
+	}
+	return k, true
+}
+
+// nilValue returns a nil value that can hold a value of type t.
+func nilValue(t reflect.Type) reflect.Value {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map:
+		return reflect.Zero(t)
+	default:
+		return reflect.Zero(reflect.PtrTo(t))
+	}
+}
+
+// isNilValue reports whether v is a nil pointer.
+func isNilValue(v reflect.Value) bool {
+	return v.Kind() == reflect.Ptr && v.IsNil()
 }
 
+// indirectKind returns the kind of the type v holds or points to.
+func indirectKind(v reflect.Value) reflect.Kind {
+	if !v.IsValid() {
+		return reflect.Invalid
+	}
+	return indirectType(v.Type()).Kind()
+}
+
+// indirectType returns t or the type t points to.
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// getJSONName returns the name of sf in JSON encoding, i.e. the name from its json tag
+// without options like ",omitempty" or the Go name if the tag has no name.
+// Empty string is returned for fields ignored by JSON encoding, i.e. tagged with "-".
 func getJSONName(sf reflect.StructField) string {
-	if jsonTag, ok := sf.Tag.Lookup("json"); ok {
-		return strings.Split(jsonTag, ",")[0]
+	jsonTag := sf.Tag.Get("json")
+	if jsonTag == "-" {
+		return ""
+	}
+	if name := strings.Split(jsonTag, ",")[0]; name != "" {
+		return name
 	}
 	return sf.Name
 }
@@ -296,11 +1016,19 @@ func dereferenceValue(value reflect.Value) reflect.Value {
 	return value
 }
 
-var wrapRegEx = regexp.MustCompile("^wrappers.(String|UInt|Int|Float|Double|Bool|Byte)(16|32|64)?Value$")
+var wrapRegEx = regexp.MustCompile("^wrappers.(String|UInt|Int|Float|Double|Bool|Bytes)(16|32|64)?Value$")
 
 func wrappedValue(v reflect.Value) (reflect.Value, bool) {
 	o := v
-	if o.Kind() == reflect.Ptr && !o.IsNil() {
+	if o.Kind() == reflect.Ptr {
+		if !wrapRegEx.MatchString(o.Type().Elem().String()) {
+			return v, false
+		}
+		if o.IsNil() {
+			// unset wrapper is treated as null
+			sf, _ := o.Type().Elem().FieldByName("Value")
+			return nilValue(sf.Type), true
+		}
 		o = v.Elem()
 	}
 	if !o.IsValid() || !wrapRegEx.MatchString(o.Type().String()) {
@@ -344,6 +1072,22 @@ func (m *Filtering_NumberArrayCondition) Filter(obj interface{}) (bool, error) {
 	return m.NumberArrayCondition.Filter(obj)
 }
 
+func (m *Filtering_CustomCondition) Filter(obj interface{}) (bool, error) {
+	return m.CustomCondition.Filter(obj)
+}
+
+func (m *Filtering_ExistsCondition) Filter(obj interface{}) (bool, error) {
+	return m.ExistsCondition.Filter(obj)
+}
+
+func (m *Filtering_FieldCondition) Filter(obj interface{}) (bool, error) {
+	return m.FieldCondition.Filter(obj)
+}
+
+func (m *Filtering_ContainsCondition) Filter(obj interface{}) (bool, error) {
+	return m.ContainsCondition.Filter(obj)
+}
+
 func (m *LogicalOperator_LeftOperator) Filter(obj interface{}) (bool, error) {
 	return m.LeftOperator.Filter(obj)
 }
@@ -370,6 +1114,22 @@ func (m *LogicalOperator_LeftNumberArrayCondition) Filter(obj interface{}) (bool
 	return m.LeftNumberArrayCondition.Filter(obj)
 }
 
+func (m *LogicalOperator_LeftCustomCondition) Filter(obj interface{}) (bool, error) {
+	return m.LeftCustomCondition.Filter(obj)
+}
+
+func (m *LogicalOperator_LeftExistsCondition) Filter(obj interface{}) (bool, error) {
+	return m.LeftExistsCondition.Filter(obj)
+}
+
+func (m *LogicalOperator_LeftFieldCondition) Filter(obj interface{}) (bool, error) {
+	return m.LeftFieldCondition.Filter(obj)
+}
+
+func (m *LogicalOperator_LeftContainsCondition) Filter(obj interface{}) (bool, error) {
+	return m.LeftContainsCondition.Filter(obj)
+}
+
 func (m *LogicalOperator_RightOperator) Filter(obj interface{}) (bool, error) {
 	return m.RightOperator.Filter(obj)
 }
@@ -397,6 +1157,154 @@ func (m *LogicalOperator_RightNumberArrayCondition) Filter(obj interface{}) (boo
 	return m.RightNumberArrayCondition.Filter(obj)
 }
 
+func (m *LogicalOperator_RightCustomCondition) Filter(obj interface{}) (bool, error) {
+	return m.RightCustomCondition.Filter(obj)
+}
+
+func (m *LogicalOperator_RightExistsCondition) Filter(obj interface{}) (bool, error) {
+	return m.RightExistsCondition.Filter(obj)
+}
+
+func (m *LogicalOperator_RightFieldCondition) Filter(obj interface{}) (bool, error) {
+	return m.RightFieldCondition.Filter(obj)
+}
+
+func (m *LogicalOperator_RightContainsCondition) Filter(obj interface{}) (bool, error) {
+	return m.RightContainsCondition.Filter(obj)
+}
+
+// walkNode calls fn for node and all of its descendants in depth-first order.
+// node may be either an AST node or one of the oneof wrappers.
+func walkNode(node interface{}, fn func(interface{}) error) error {
+	node = unwrapNode(node)
+	if node == nil {
+		return nil
+	}
+	if err := fn(node); err != nil {
+		return err
+	}
+	if lop, ok := node.(*LogicalOperator); ok {
+		if err := walkNode(lop.Left, fn); err != nil {
+			return err
+		}
+		return walkNode(lop.Right, fn)
+	}
+	return nil
+}
+
+// unwrapNode returns an AST node wrapped into one of the oneof structures
+// of Filtering.Root, LogicalOperator.Left or LogicalOperator.Right.
+func unwrapNode(x interface{}) interface{} {
+	switch v := x.(type) {
+	case *Filtering_Operator:
+		return v.Operator
+	case *Filtering_StringCondition:
+		return v.StringCondition
+	case *Filtering_NumberCondition:
+		return v.NumberCondition
+	case *Filtering_NullCondition:
+		return v.NullCondition
+	case *Filtering_BoolCondition:
+		return v.BoolCondition
+	case *Filtering_StringArrayCondition:
+		return v.StringArrayCondition
+	case *Filtering_NumberArrayCondition:
+		return v.NumberArrayCondition
+	case *Filtering_CustomCondition:
+		return v.CustomCondition
+	case *Filtering_ExistsCondition:
+		return v.ExistsCondition
+	case *Filtering_FieldCondition:
+		return v.FieldCondition
+	case *Filtering_ContainsCondition:
+		return v.ContainsCondition
+	case *LogicalOperator_LeftOperator:
+		return v.LeftOperator
+	case *LogicalOperator_LeftStringCondition:
+		return v.LeftStringCondition
+	case *LogicalOperator_LeftNumberCondition:
+		return v.LeftNumberCondition
+	case *LogicalOperator_LeftNullCondition:
+		return v.LeftNullCondition
+	case *LogicalOperator_LeftBoolCondition:
+		return v.LeftBoolCondition
+	case *LogicalOperator_LeftStringArrayCondition:
+		return v.LeftStringArrayCondition
+	case *LogicalOperator_LeftNumberArrayCondition:
+		return v.LeftNumberArrayCondition
+	case *LogicalOperator_LeftCustomCondition:
+		return v.LeftCustomCondition
+	case *LogicalOperator_LeftExistsCondition:
+		return v.LeftExistsCondition
+	case *LogicalOperator_LeftFieldCondition:
+		return v.LeftFieldCondition
+	case *LogicalOperator_LeftContainsCondition:
+		return v.LeftContainsCondition
+	case *LogicalOperator_RightOperator:
+		return v.RightOperator
+	case *LogicalOperator_RightStringCondition:
+		return v.RightStringCondition
+	case *LogicalOperator_RightNumberCondition:
+		return v.RightNumberCondition
+	case *LogicalOperator_RightNullCondition:
+		return v.RightNullCondition
+	case *LogicalOperator_RightBoolCondition:
+		return v.RightBoolCondition
+	case *LogicalOperator_RightStringArrayCondition:
+		return v.RightStringArrayCondition
+	case *LogicalOperator_RightNumberArrayCondition:
+		return v.RightNumberArrayCondition
+	case *LogicalOperator_RightCustomCondition:
+		return v.RightCustomCondition
+	case *LogicalOperator_RightExistsCondition:
+		return v.RightExistsCondition
+	case *LogicalOperator_RightFieldCondition:
+		return v.RightFieldCondition
+	case *LogicalOperator_RightContainsCondition:
+		return v.RightContainsCondition
+	default:
+		return x
+	}
+}
+
+// AndFilterings returns filtering expression that is a conjunction of a and b, e.g. a base filter
+// of a tenant can be applied to the one supplied by a client without reparsing. The operands are cloned,
+// so the result can be modified without affecting a and b. If either of them is empty a clone of the other one is returned.
+func AndFilterings(a, b *Filtering) *Filtering {
+	return combineFiltering(LogicalOperator_AND, cloneFiltering(a), cloneFiltering(b))
+}
+
+// OrFilterings returns filtering expression that is a disjunction of a and b.
+// The operands are cloned as by AndFilterings.
+func OrFilterings(a, b *Filtering) *Filtering {
+	return combineFiltering(LogicalOperator_OR, cloneFiltering(a), cloneFiltering(b))
+}
+
+// cloneFiltering returns a deep copy of f, which is nil for nil f.
+func cloneFiltering(f *Filtering) *Filtering {
+	if f == nil {
+		return nil
+	}
+	return proto.Clone(f).(*Filtering)
+}
+
+// combineFiltering returns filtering expression that combines l and r with logical operator t
+// as is, so the result shares nodes with them. If either of them is empty the other one is returned.
+func combineFiltering(t LogicalOperator_Type, l, r *Filtering) *Filtering {
+	if l == nil || l.Root == nil {
+		return r
+	}
+	if r == nil || r.Root == nil {
+		return l
+	}
+	// every node a root may hold is a valid operand of a logical operator
+	// (isFiltering_Root cannot be implemented outside of the package), so the errors are always nil
+	lop := &LogicalOperator{Type: t}
+	_ = lop.SetLeft(unwrapNode(l.Root))
+	_ = lop.SetRight(unwrapNode(r.Root))
+	return &Filtering{Root: &Filtering_Operator{lop}}
+}
+
 // SetRoot automatically wraps r into appropriate oneof structure and sets it to Root.
 func (m *Filtering) SetRoot(r interface{}) error {
 	switch x := r.(type) {
@@ -414,6 +1322,14 @@ func (m *Filtering) SetRoot(r interface{}) error {
 		m.Root = &Filtering_StringArrayCondition{x}
 	case *NumberArrayCondition:
 		m.Root = &Filtering_NumberArrayCondition{x}
+	case *CustomCondition:
+		m.Root = &Filtering_CustomCondition{x}
+	case *ExistsCondition:
+		m.Root = &Filtering_ExistsCondition{x}
+	case *FieldCondition:
+		m.Root = &Filtering_FieldCondition{x}
+	case *ContainsCondition:
+		m.Root = &Filtering_ContainsCondition{x}
 	case nil:
 		m.Root = nil
 	default:
@@ -439,6 +1355,14 @@ func (m *LogicalOperator) SetLeft(l interface{}) error {
 		m.Left = &LogicalOperator_LeftStringArrayCondition{x}
 	case *NumberArrayCondition:
 		m.Left = &LogicalOperator_LeftNumberArrayCondition{x}
+	case *CustomCondition:
+		m.Left = &LogicalOperator_LeftCustomCondition{x}
+	case *ExistsCondition:
+		m.Left = &LogicalOperator_LeftExistsCondition{x}
+	case *FieldCondition:
+		m.Left = &LogicalOperator_LeftFieldCondition{x}
+	case *ContainsCondition:
+		m.Left = &LogicalOperator_LeftContainsCondition{x}
 	case nil:
 		m.Left = nil
 	default:
@@ -464,6 +1388,14 @@ func (m *LogicalOperator) SetRight(r interface{}) error {
 		m.Right = &LogicalOperator_RightStringArrayCondition{x}
 	case *NumberArrayCondition:
 		m.Right = &LogicalOperator_RightNumberArrayCondition{x}
+	case *CustomCondition:
+		m.Right = &LogicalOperator_RightCustomCondition{x}
+	case *ExistsCondition:
+		m.Right = &LogicalOperator_RightExistsCondition{x}
+	case *FieldCondition:
+		m.Right = &LogicalOperator_RightFieldCondition{x}
+	case *ContainsCondition:
+		m.Right = &LogicalOperator_RightContainsCondition{x}
 	case nil:
 		m.Right = nil
 	default:
@@ -0,0 +1,89 @@
+package query
+
+import (
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// WithFieldAliases makes field paths of a filtering expression to be translated according to aliases
+// before they are resolved against an object, e.g. {"display_name": "Label"} makes "display_name == 'a'"
+// to be evaluated against the Label field. Keys and values are dot-separated field paths,
+// an alias of a message field applies to its nested fields as well, e.g. {"addr": "address"}
+// translates "addr.city" to "address.city". Fields without aliases are resolved as usual,
+// the translated names are resolved the same way as the original ones, i.e. by JSON or proto names.
+func WithFieldAliases(aliases map[string]string) FilterOption {
+	return func(o *filterOptions) {
+		o.aliases = aliases
+	}
+}
+
+// AliasFiltering returns a copy of f with field paths translated according to aliases
+// the same way as WithFieldAliases does. f itself is not modified.
+func AliasFiltering(f *Filtering, aliases map[string]string) *Filtering {
+	if f == nil || len(aliases) == 0 {
+		return f
+	}
+	res := proto.Clone(f).(*Filtering)
+	walkNode(res.Root, func(node interface{}) error {
+		if fp := conditionFieldPath(node); fp != nil {
+			setConditionFieldPath(node, aliasFieldPath(fp, aliases))
+		}
+		if fc, ok := node.(*FieldCondition); ok {
+			fc.ValueFieldPath = aliasFieldPath(fc.ValueFieldPath, aliases)
+		}
+		return nil
+	})
+	return res
+}
+
+// AliasSorting returns a copy of s with tags of sort criterias translated according to aliases
+// the same way as WithFieldAliases does. s itself is not modified.
+func AliasSorting(s *Sorting, aliases map[string]string) *Sorting {
+	if s == nil || len(aliases) == 0 {
+		return s
+	}
+	res := &Sorting{}
+	for _, c := range s.GetCriterias() {
+		cc := *c
+		cc.Tag = strings.Join(aliasFieldPath(strings.Split(c.Tag, "."), aliases), ".")
+		res.Criterias = append(res.Criterias, &cc)
+	}
+	return res
+}
+
+// aliasFieldPath translates the longest prefix of fieldPath that has an alias.
+func aliasFieldPath(fieldPath []string, aliases map[string]string) []string {
+	for i := len(fieldPath); i > 0; i-- {
+		if alias, ok := aliases[strings.Join(fieldPath[:i], ".")]; ok {
+			return append(strings.Split(alias, "."), fieldPath[i:]...)
+		}
+	}
+	return fieldPath
+}
+
+// setConditionFieldPath sets a field path of a condition node.
+func setConditionFieldPath(node interface{}, fieldPath []string) {
+	switch n := node.(type) {
+	case *StringCondition:
+		n.FieldPath = fieldPath
+	case *NumberCondition:
+		n.FieldPath = fieldPath
+	case *NullCondition:
+		n.FieldPath = fieldPath
+	case *BoolCondition:
+		n.FieldPath = fieldPath
+	case *StringArrayCondition:
+		n.FieldPath = fieldPath
+	case *NumberArrayCondition:
+		n.FieldPath = fieldPath
+	case *CustomCondition:
+		n.FieldPath = fieldPath
+	case *ExistsCondition:
+		n.FieldPath = fieldPath
+	case *FieldCondition:
+		n.FieldPath = fieldPath
+	case *ContainsCondition:
+		n.FieldPath = fieldPath
+	}
+}
@@ -0,0 +1,87 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TestAliasedObject struct {
+	Label   string
+	Address *TestAddress `json:"address"`
+}
+
+type TestAddress struct {
+	City string `json:"city"`
+}
+
+func TestFilteringFieldAliases(t *testing.T) {
+	obj := &TestAliasedObject{Label: "Acme", Address: &TestAddress{City: "Tacoma"}}
+	msg := &TestProtoMessage{Str: "abc", Nested: &NestedMessage{Str: "nested"}}
+	aliases := WithFieldAliases(map[string]string{
+		"display_name": "Label",
+		"location":     "address.city",
+		"addr":         "address",
+		"parent":       "nestedJSON",
+		"title":        "str",
+	})
+
+	tests := []struct {
+		obj    interface{}
+		filter string
+		res    bool
+	}{
+		{obj, "display_name == 'Acme'", true},
+		{obj, "display_name ~ '^A' and Label == 'Acme'", true},
+		{obj, "location == 'Tacoma' and addr.city ~ '^T'", true},
+		{obj, "addr == null", false},
+		{obj, "address.city in ['Tacoma']", true},
+		{msg, "parent.str == 'nested' and title == 'abc'", true},
+		{msg, "nested.str like 'n%'", true},
+	}
+
+	for _, test := range tests {
+		res, err := Filter(test.obj, test.filter, aliases)
+		assert.NoError(t, err, test.filter)
+		assert.Equal(t, test.res, res, test.filter)
+
+		cf, err := CompileFilter(test.filter, aliases)
+		assert.NoError(t, err, test.filter)
+		res, err = cf.Match(test.obj)
+		assert.NoError(t, err, test.filter)
+		assert.Equal(t, test.res, res, test.filter)
+	}
+
+	// aliases are not applied without the option
+	_, err := Filter(obj, "display_name == 'Acme'")
+	assert.Error(t, err)
+}
+
+func TestAliasFiltering(t *testing.T) {
+	f, err := ParseFiltering("display_name == 'a' or not addr.city in ['b']")
+	assert.NoError(t, err)
+	aliases := map[string]string{"display_name": "label", "addr": "address"}
+
+	res := AliasFiltering(f, aliases)
+	assert.Equal(t, "(label == 'a' or address.city not in ['b'])", res.GoString())
+	assert.Equal(t, "(display_name == 'a' or addr.city not in ['b'])", f.GoString())
+	assert.Nil(t, AliasFiltering(nil, aliases))
+
+	f, err = ParseFiltering("display_name == addr.city")
+	assert.NoError(t, err)
+	assert.Equal(t, "label == address.city", AliasFiltering(f, aliases).GoString())
+}
+
+func TestAliasSorting(t *testing.T) {
+	s, err := ParseSorting("display_name desc, addr.city, id")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	res := AliasSorting(s, map[string]string{"display_name": "label", "addr": "address"})
+	if res.GoString() != "label DESC, address.city ASC, id ASC" {
+		t.Errorf("invalid sorting: %s - expected: label DESC, address.city ASC, id ASC", res.GoString())
+	}
+	if s.GoString() != "display_name DESC, addr.city ASC, id ASC" {
+		t.Errorf("original sorting is modified: %s", s.GoString())
+	}
+}
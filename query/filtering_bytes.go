@@ -0,0 +1,44 @@
+package query
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"reflect"
+)
+
+// Base64Bytes makes string literals that are compared with bytes fields to be decoded
+// from standard base64 encoding instead of hex.
+func Base64Bytes() FilterOption {
+	return func(o *filterOptions) {
+		o.base64Bytes = true
+	}
+}
+
+// isBytesValue reports whether v is a bytes field.
+func isBytesValue(v reflect.Value) bool {
+	return v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8
+}
+
+// filterBytes evaluates string condition against bytes value fv.
+// The string literal is decoded from hex or, if Base64Bytes option is set, from base64,
+// ordering operators compare raw bytes lexically.
+func (c *StringCondition) filterBytes(fv reflect.Value, o *filterOptions) (bool, error) {
+	if !c.isComparison() {
+		return false, &UnsupportedOperatorError{"bytes", c.Type.String()}
+	}
+	var lit []byte
+	var err error
+	if o.base64Bytes {
+		lit, err = base64.StdEncoding.DecodeString(c.Value)
+	} else {
+		lit, err = hex.DecodeString(c.Value)
+	}
+	if err != nil {
+		return false, &InvalidLiteralError{"bytes", c.Value, err}
+	}
+	if fv.IsNil() {
+		return false, nil
+	}
+	return c.compare(bytes.Compare(fv.Bytes(), lit), "bytes")
+}
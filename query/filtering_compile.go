@@ -0,0 +1,121 @@
+package query
+
+import (
+	"context"
+	"regexp"
+)
+
+// CompiledFilter is a parsed filtering expression with precompiled regular expressions.
+// It is safe for concurrent use by multiple goroutines.
+type CompiledFilter struct {
+	filtering *Filtering
+	options   *filterOptions
+}
+
+// CompileFilter parses filter using default FilteringParser implementation and precompiles
+// regular expressions of all match, like and ilike conditions, so the filter can be evaluated against
+// many objects without parsing and compiling it again.
+func CompileFilter(filter string, opts ...FilterOption) (*CompiledFilter, error) {
+	f, err := ParseFiltering(filter)
+	if err != nil {
+		return nil, err
+	}
+	return CompileFiltering(f, opts...)
+}
+
+// CompileFiltering precompiles regular expressions of all match, like and ilike conditions of f
+// and flattens chains of logical operators, so that evaluation does not allocate.
+// f must not be modified after compilation.
+// If WithFieldAliases option is given, field paths are translated once at compilation.
+func CompileFiltering(f *Filtering, opts ...FilterOption) (*CompiledFilter, error) {
+	o := newFilterOptions(opts)
+	f = AliasFiltering(f, o.aliases)
+	o.regexps = make(map[*StringCondition]*regexp.Regexp)
+	o.operands = make(map[*LogicalOperator][]interface{})
+	if f != nil {
+		err := walkNode(f.Root, func(node interface{}) error {
+			if lop, ok := node.(*LogicalOperator); ok {
+				o.operands[lop] = lop.operands()
+				return nil
+			}
+			c, ok := node.(*StringCondition)
+			if !ok || (c.Type != StringCondition_MATCH && c.Type != StringCondition_LIKE && c.Type != StringCondition_ILIKE) {
+				return nil
+			}
+			var re *regexp.Regexp
+			var err error
+			if c.Type == StringCondition_MATCH {
+				re, err = compileMatch(c.Value, o)
+			} else {
+				re, err = compileLike(c.Value, c.Type == StringCondition_ILIKE, o)
+			}
+			if err != nil {
+				return err
+			}
+			o.regexps[c] = re
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &CompiledFilter{filtering: f, options: o}, nil
+}
+
+// Filtering returns the underlying filtering expression.
+func (cf *CompiledFilter) Filtering() *Filtering {
+	return cf.filtering
+}
+
+// Match evaluates the compiled filtering expression against obj.
+// If obj implements Matcher, call it's custom implementation.
+func (cf *CompiledFilter) Match(obj interface{}) (bool, error) {
+	if cf.filtering == nil {
+		return true, nil
+	}
+	if matcher, ok := obj.(Matcher); ok {
+		return matcher.Match(cf.filtering)
+	}
+	return filterRoot(cf.filtering.Root, obj, cf.options)
+}
+
+// filterSliceCheckInterval is a number of objects FilterSlice evaluates between checks of context cancellation.
+const filterSliceCheckInterval = 64
+
+// FilterContext is the same as Filter but returns ctx.Err() without evaluating
+// the filter if ctx is done.
+func FilterContext(ctx context.Context, obj interface{}, filter string, opts ...FilterOption) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	return Filter(obj, filter, opts...)
+}
+
+// FilterSlice returns objects of objs that match filter, preserving their order.
+// The filter is compiled once and evaluated against every object, see CompileFilter.
+// ctx is checked periodically and ctx.Err() is returned as soon as ctx is done.
+func FilterSlice(ctx context.Context, objs []interface{}, filter string, opts ...FilterOption) ([]interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	cf, err := CompileFilter(filter, opts...)
+	if err != nil {
+		return nil, err
+	}
+	var res []interface{}
+	for i, obj := range objs {
+		if i%filterSliceCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		ok, err := cf.Match(obj)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			res = append(res, obj)
+		}
+	}
+	return res, nil
+}
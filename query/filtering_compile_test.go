@@ -0,0 +1,183 @@
+package query
+
+import (
+	"context"
+	"regexp/syntax"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompileFilter(t *testing.T) {
+	cf, err := CompileFilter("str ~ '^a+$' and int > 1 or str !~ 'b'")
+	assert.NoError(t, err)
+	assert.Len(t, cf.options.regexps, 2)
+
+	tests := []struct {
+		obj interface{}
+		res bool
+	}{
+		{&TestProtoMessage{Str: "aaa", Int: 2}, true},
+		{&TestProtoMessage{Str: "aab", Int: 2}, false},
+		{&TestProtoMessage{Str: "ccc", Int: 0}, true},
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, test := range tests {
+				res, err := cf.Match(test.obj)
+				assert.NoError(t, err)
+				assert.Equal(t, test.res, res)
+			}
+		}()
+	}
+	wg.Wait()
+
+	cf, err = CompileFilter("str ~ '^A'", CaseInsensitive())
+	assert.NoError(t, err)
+	res, err := cf.Match(&TestProtoMessage{Str: "abc"})
+	assert.NoError(t, err)
+	assert.True(t, res)
+
+	cf, err = CompileFilter("")
+	assert.NoError(t, err)
+	res, err = cf.Match(&TestProtoMessage{})
+	assert.NoError(t, err)
+	assert.True(t, res)
+
+	_, err = CompileFilter("str ~ '11[1'")
+	assert.IsType(t, &syntax.Error{}, err)
+
+	_, err = CompileFilter("str ~ ")
+	assert.IsType(t, &ParseError{}, err)
+}
+
+func TestCompiledFilterAllocs(t *testing.T) {
+	cf, err := CompileFilter(benchmarkFilter)
+	assert.NoError(t, err)
+	obj := &TestProtoMessage{Str: "111", Int: 111, Nested: &NestedMessage{Str: "a"}}
+	allocs := testing.AllocsPerRun(100, func() {
+		if _, err := cf.Match(obj); err != nil {
+			t.Fatal(err)
+		}
+	})
+	assert.Equal(t, 0.0, allocs)
+}
+
+// cancelingMatcher cancels the context when it is matched.
+type cancelingMatcher struct {
+	cancel context.CancelFunc
+}
+
+func (m cancelingMatcher) Match(*Filtering) (bool, error) {
+	m.cancel()
+	return true, nil
+}
+
+func TestFilterContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	res, err := FilterContext(ctx, &TestProtoMessage{Str: "abc"}, "str == 'ABC'", CaseInsensitive())
+	assert.NoError(t, err)
+	assert.True(t, res)
+
+	cancel()
+	res, err = FilterContext(ctx, &TestProtoMessage{Str: "abc"}, "str == 'abc'")
+	assert.Equal(t, context.Canceled, err)
+	assert.False(t, res)
+}
+
+func TestFilterSlice(t *testing.T) {
+	objs := []interface{}{
+		&TestProtoMessage{Str: "a", Int: 1},
+		&TestProtoMessage{Str: "b", Int: 2},
+		&TestProtoMessage{Str: "c", Int: 3},
+	}
+	res, err := FilterSlice(context.Background(), objs, "str ~ '[ac]' or int > 2")
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{objs[0], objs[2]}, res)
+
+	res, err = FilterSlice(context.Background(), objs, "str ~ '[a'")
+	assert.IsType(t, &syntax.Error{}, err)
+	assert.Nil(t, res)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	objs = make([]interface{}, 3*filterSliceCheckInterval)
+	for i := range objs {
+		objs[i] = &TestProtoMessage{Int: int32(i)}
+	}
+	objs[filterSliceCheckInterval/2] = cancelingMatcher{cancel}
+	res, err = FilterSlice(ctx, objs, "int >= 0")
+	assert.Equal(t, context.Canceled, err)
+	assert.Nil(t, res)
+}
+
+const benchmarkFilter = "str ~ '^1+$' and int >= 100 and nested.str in ['a', 'b'] or str !~ '2[0-9]*'"
+
+func BenchmarkFilter(b *testing.B) {
+	obj := &TestProtoMessage{Str: "111", Int: 111, Nested: &NestedMessage{Str: "a"}}
+	for i := 0; i < b.N; i++ {
+		if _, err := Filter(obj, benchmarkFilter); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCompiledFilter(b *testing.B) {
+	obj := &TestProtoMessage{Str: "111", Int: 111, Nested: &NestedMessage{Str: "a"}}
+	cf, err := CompileFilter(benchmarkFilter)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := cf.Match(obj); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// benchmarkObjects returns n objects half of which match benchmarkFilter.
+func benchmarkObjects(n int) []interface{} {
+	objs := make([]interface{}, n)
+	for i := range objs {
+		str := "111"
+		if i%2 == 1 {
+			str = "222"
+		}
+		objs[i] = &TestProtoMessage{Str: str, Int: int32(i), Nested: &NestedMessage{Str: "a"}}
+	}
+	return objs
+}
+
+const benchmarkSliceSize = 100000
+
+func BenchmarkFilterSliceNaive(b *testing.B) {
+	objs := benchmarkObjects(benchmarkSliceSize)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var res []interface{}
+		for _, obj := range objs {
+			ok, err := Filter(obj, benchmarkFilter)
+			if err != nil {
+				b.Fatal(err)
+			}
+			if ok {
+				res = append(res, obj)
+			}
+		}
+	}
+}
+
+func BenchmarkFilterSlice(b *testing.B) {
+	objs := benchmarkObjects(benchmarkSliceSize)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := FilterSlice(context.Background(), objs, benchmarkFilter); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
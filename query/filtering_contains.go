@@ -0,0 +1,158 @@
+package query
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+)
+
+var rawMessageType = reflect.TypeOf(json.RawMessage(nil))
+
+// Filter evaluates contains condition against obj, i.e. checks that the JSON representation
+// of the field value contains the JSON document of the condition as the Postgres jsonb @> operator does:
+//   - an object contains another object if every key of the latter is present in the former
+//     and its value contains the value of the key of the latter;
+//   - an array contains another array if every element of the latter is contained
+//     in some element of the former regardless of order and duplicates;
+//   - scalars contain equal scalars only, numbers are compared by value,
+//     so an array does not contain a scalar unlike the top-level rule of Postgres.
+//
+// Fields of map, slice, struct and proto message types are converted to JSON, json.RawMessage and []byte
+// fields are expected to hold JSON text. A repeated field is compared as a whole, i.e. as a JSON array,
+// while a path going through a repeated message field, e.g. "items.attrs", is evaluated element-wise
+// as other conditions are. Null field values do not satisfy the condition regardless of negation.
+func (c *ContainsCondition) Filter(obj interface{}) (bool, error) {
+	return c.filter(obj, &filterOptions{})
+}
+
+func (c *ContainsCondition) filter(obj interface{}, o *filterOptions) (bool, error) {
+	doc, err := parseJSONObject(c.Value)
+	if err != nil {
+		return false, &InvalidLiteralError{"JSON object", c.Value, err}
+	}
+	if _, rest, ok := repeatedField(obj, c.FieldPath); ok && len(rest) > 0 {
+		res, _, err := filterRepeated(c, obj, c.FieldPath, c.IsNegative, o)
+		return res, err
+	}
+	fv := o.rawFieldByFieldPath(obj, c.FieldPath)
+	if !fv.IsValid() {
+		return false, &UnknownFieldError{c.FieldPath}
+	}
+	v, ok, err := jsonValue(fv)
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		return false, &TypeMismatchError{"JSON", c.FieldPath, quoteString(c.Value)}
+	}
+	if v == nil {
+		return false, nil
+	}
+	return negateIfNeeded(c.IsNegative, jsonContains(v, doc)), nil
+}
+
+// parseJSONObject parses JSON text s that must be an object.
+func parseJSONObject(s string) (map[string]interface{}, error) {
+	var v interface{}
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		return nil, err
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("not an object")
+	}
+	return m, nil
+}
+
+// jsonValue returns generic JSON representation of fv as decoded by encoding/json,
+// nil for null values. Returned ok is false if fv cannot be represented as a JSON document.
+func jsonValue(fv reflect.Value) (interface{}, bool, error) {
+	for fv.Kind() == reflect.Ptr || fv.Kind() == reflect.Interface {
+		if fv.IsNil() {
+			return nil, true, nil
+		}
+		if fv.Kind() == reflect.Ptr && fv.Type().Implements(protoMessageType) {
+			break
+		}
+		fv = fv.Elem()
+	}
+	var data []byte
+	switch {
+	case fv.Type() == rawMessageType || fv.Kind() == reflect.Slice && fv.Type().Elem().Kind() == reflect.Uint8:
+		if fv.IsNil() {
+			return nil, true, nil
+		}
+		data = fv.Bytes()
+	case fv.Kind() == reflect.Ptr:
+		var buf bytes.Buffer
+		if err := (&jsonpb.Marshaler{OrigName: true}).Marshal(&buf, fv.Interface().(proto.Message)); err != nil {
+			return nil, false, err
+		}
+		data = buf.Bytes()
+	case fv.Kind() == reflect.Map || fv.Kind() == reflect.Slice:
+		if fv.IsNil() {
+			return nil, true, nil
+		}
+		fallthrough
+	case fv.Kind() == reflect.Array || fv.Kind() == reflect.Struct:
+		if !fv.CanInterface() {
+			return nil, false, nil
+		}
+		var err error
+		if data, err = json.Marshal(fv.Interface()); err != nil {
+			return nil, false, err
+		}
+	default:
+		return nil, false, nil
+	}
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, false, fmt.Errorf("invalid JSON value: %s", err)
+	}
+	return v, true, nil
+}
+
+// jsonContains reports whether JSON value v contains JSON value doc.
+func jsonContains(v, doc interface{}) bool {
+	switch d := doc.(type) {
+	case map[string]interface{}:
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for k, dv := range d {
+			if mv, ok := m[k]; !ok || !jsonContains(mv, dv) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		a, ok := v.([]interface{})
+		if !ok {
+			return false
+		}
+		for _, de := range d {
+			if !jsonArrayContains(a, de) {
+				return false
+			}
+		}
+		return true
+	default:
+		return v == doc
+	}
+}
+
+// jsonArrayContains reports whether any element of a contains doc.
+func jsonArrayContains(a []interface{}, doc interface{}) bool {
+	for _, e := range a {
+		if jsonContains(e, doc) {
+			return true
+		}
+	}
+	return false
+}
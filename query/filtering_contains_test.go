@@ -0,0 +1,85 @@
+package query
+
+import (
+	"encoding/json"
+	"testing"
+
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/stretchr/testify/assert"
+)
+
+type TestAttributesObject struct {
+	Attributes map[string]interface{} `json:"attributes"`
+	Labels     map[string]string      `json:"labels"`
+	Raw        json.RawMessage        `json:"raw"`
+	Meta       *structpb.Struct       `json:"meta"`
+	Name       string                 `json:"name"`
+}
+
+func TestFilteringContains(t *testing.T) {
+	obj := &TestAttributesObject{
+		Attributes: map[string]interface{}{
+			"tier":  "gold",
+			"level": 3,
+			"tags":  []string{"a", "b", "c"},
+			"owner": map[string]interface{}{"name": "John", "groups": []interface{}{map[string]interface{}{"id": 1, "admin": true}}},
+		},
+		Labels: map[string]string{"env": "prod"},
+		Raw:    json.RawMessage(`{"region": "us-east", "zones": [1, 2]}`),
+		Meta: &structpb.Struct{Fields: map[string]*structpb.Value{
+			"size": {Kind: &structpb.Value_NumberValue{NumberValue: 10}},
+		}},
+		Name: "acme",
+	}
+	tests := []struct {
+		obj    interface{}
+		filter string
+		res    bool
+		err    error
+	}{
+		{obj: obj, filter: `attributes contains '{"tier": "gold"}'`, res: true},
+		{obj: obj, filter: `attributes contains '{}'`, res: true},
+		{obj: obj, filter: `attributes contains '{"tier": "silver"}'`, res: false},
+		{obj: obj, filter: `attributes contains '{"tier": "gold", "level": 3.0}'`, res: true},
+		{obj: obj, filter: `attributes contains '{"level": "3"}'`, res: false},
+		{obj: obj, filter: `attributes contains '{"missing": null}'`, res: false},
+		// arrays are contained regardless of order and duplicates
+		{obj: obj, filter: `attributes contains '{"tags": ["c", "a", "a"]}'`, res: true},
+		{obj: obj, filter: `attributes contains '{"tags": []}'`, res: true},
+		{obj: obj, filter: `attributes contains '{"tags": ["d"]}'`, res: false},
+		{obj: obj, filter: `attributes contains '{"tags": "a"}'`, res: false},
+		// nested objects
+		{obj: obj, filter: `attributes contains '{"owner": {"name": "John"}}'`, res: true},
+		{obj: obj, filter: `attributes contains '{"owner": {"groups": [{"admin": true}]}}'`, res: true},
+		{obj: obj, filter: `attributes contains '{"owner": {"groups": [{"admin": false}]}}'`, res: false},
+		{obj: obj, filter: `not attributes contains '{"tier": "silver"}'`, res: true},
+		{obj: obj, filter: `labels contains '{"env": "prod"}' and raw contains '{"zones": [2]}'`, res: true},
+		{obj: obj, filter: `meta contains '{"size": 10}'`, res: true},
+		// null values do not satisfy the condition regardless of negation
+		{obj: &TestAttributesObject{}, filter: `attributes contains '{}' or not attributes contains '{}' or raw contains '{}' or meta contains '{}'`, res: false},
+		{obj: obj, filter: `name contains '{}'`, err: &TypeMismatchError{"JSON", []string{"name"}, `'{}'`}},
+		{obj: obj, filter: `unknown contains '{}'`, err: &UnknownFieldError{[]string{"unknown"}}},
+	}
+	for _, test := range tests {
+		res, err := Filter(test.obj, test.filter)
+		assert.Equal(t, test.err, err, test.filter)
+		assert.Equal(t, test.res, res, test.filter)
+	}
+}
+
+func TestParseFilteringContains(t *testing.T) {
+	f, err := ParseFiltering(`attributes not contains '{"tier": "gold"}'`)
+	assert.NoError(t, err)
+	assert.Equal(t, &ContainsCondition{
+		FieldPath:  []string{"attributes"},
+		Value:      `{"tier": "gold"}`,
+		IsNegative: true,
+	}, f.GetContainsCondition())
+
+	for _, filter := range []string{`attributes contains '[1]'`, `attributes contains '{"a"}'`, `attributes contains 'gold'`} {
+		_, err := ParseFiltering(filter)
+		assert.IsType(t, &InvalidLiteralError{}, err, filter)
+	}
+	_, err = ParseFiltering(`attributes contains 1`)
+	assert.IsType(t, &ParseError{}, err)
+}
@@ -0,0 +1,117 @@
+package query
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// OperatorFunc evaluates a custom filtering operator.
+// fieldVal is a value of the field the condition refers to, literal is a value of the literal
+// which is either a string, a float64, a bool, a []string or a []float64.
+type OperatorFunc func(fieldVal, literal interface{}) (bool, error)
+
+var customOperators = struct {
+	sync.RWMutex
+	m map[string]OperatorFunc
+}{m: make(map[string]OperatorFunc)}
+
+// RegisterOperator registers fn as an implementation of the binary operator symbol,
+// e.g. RegisterOperator("within", fn) makes "location within [1.5, 2.5, 10]" a valid condition
+// that is parsed into CustomCondition and evaluated by Filter with fn.
+// symbol must be a word that is not a reserved word of the filtering syntax, it is matched
+// regardless of case and can no longer be used as a field name.
+// Registering the same symbol again replaces its implementation.
+// RegisterOperator panics if symbol is invalid or fn is nil.
+func RegisterOperator(symbol string, fn OperatorFunc) {
+	if fn == nil {
+		panic("query: RegisterOperator fn is nil")
+	}
+	lexer := newFilteringLexer(symbol)
+	t, err := lexer.NextToken()
+	switch t.(type) {
+	case FieldToken, CustomOperatorToken:
+	default:
+		err = fmt.Errorf("not an operator symbol")
+	}
+	if err != nil || !lexer.eof || strings.ContainsAny(symbol, ".-") {
+		panic(fmt.Sprintf("query: invalid operator symbol %q", symbol))
+	}
+	customOperators.Lock()
+	defer customOperators.Unlock()
+	customOperators.m[strings.ToLower(symbol)] = fn
+}
+
+func lookupOperator(symbol string) (OperatorFunc, bool) {
+	customOperators.RLock()
+	defer customOperators.RUnlock()
+	fn, ok := customOperators.m[strings.ToLower(symbol)]
+	return fn, ok
+}
+
+// UnknownOperatorError represents a custom operator Op that is not registered with RegisterOperator.
+type UnknownOperatorError struct {
+	Op string
+}
+
+func (e *UnknownOperatorError) Error() string {
+	return fmt.Sprintf("operator %s is not registered", e.Op)
+}
+
+// Literal returns a value of the literal of the custom condition as it is passed to OperatorFunc.
+func (c *CustomCondition) Literal() interface{} {
+	switch v := c.GetValue().(type) {
+	case *CustomCondition_StringValue:
+		return v.StringValue
+	case *CustomCondition_NumberValue:
+		return v.NumberValue
+	case *CustomCondition_BoolValue:
+		return v.BoolValue
+	case *CustomCondition_StringArrayValue:
+		return v.StringArrayValue.GetValues()
+	case *CustomCondition_NumberArrayValue:
+		return v.NumberArrayValue.GetValues()
+	default:
+		return nil
+	}
+}
+
+// Filter evaluates custom condition against obj with the function registered for its operator.
+// Pointers to scalar values are dereferenced before they are passed to the function.
+// Null field values do not satisfy the condition regardless of negation.
+func (c *CustomCondition) Filter(obj interface{}) (bool, error) {
+	return c.filter(obj, &filterOptions{})
+}
+
+func (c *CustomCondition) filter(obj interface{}, o *filterOptions) (bool, error) {
+	fn, ok := lookupOperator(c.Operator)
+	if !ok {
+		return false, &UnknownOperatorError{c.Operator}
+	}
+	if res, ok, err := filterRepeated(c, obj, c.FieldPath, c.IsNegative, o); ok {
+		return res, err
+	}
+	if res, ok, err := filterDynamic(c, obj, c.FieldPath, c.IsNegative, o); ok {
+		return res, err
+	}
+	fv := o.fieldByFieldPath(obj, c.FieldPath)
+	if !fv.IsValid() {
+		return false, &UnknownFieldError{c.FieldPath}
+	}
+	if fv.Kind() == reflect.Interface {
+		fv = fv.Elem()
+	}
+	if !fv.IsValid() || isNilValue(fv) {
+		return false, nil
+	}
+	// pointers to messages are passed as is
+	if fv.Kind() == reflect.Ptr && fv.Elem().Kind() != reflect.Struct {
+		fv = fv.Elem()
+	}
+	res, err := fn(fv.Interface(), c.Literal())
+	if err != nil {
+		return false, err
+	}
+	return negateIfNeeded(c.IsNegative, res), nil
+}
@@ -0,0 +1,96 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	RegisterOperator("within", func(fieldVal, literal interface{}) (bool, error) {
+		v, ok := fieldVal.(float64)
+		r, isRange := literal.([]float64)
+		if !ok || !isRange || len(r) != 2 {
+			return false, fmt.Errorf("within requires a number field and a range of two numbers")
+		}
+		return r[0] <= v && v <= r[1], nil
+	})
+	RegisterOperator("hasPrefix", func(fieldVal, literal interface{}) (bool, error) {
+		return strings.HasPrefix(fmt.Sprint(fieldVal), fmt.Sprint(literal)), nil
+	})
+}
+
+func TestFilteringCustomOperator(t *testing.T) {
+	obj := &TestProtoMessage{
+		Str:    "hello",
+		Tags:   []string{"abc", "xyz"},
+		Nested: &NestedMessage{Str: "nested"},
+	}
+	num := &TestObject{Float: 4.5}
+
+	tests := []struct {
+		obj    interface{}
+		filter string
+		res    bool
+	}{
+		{num, "float within [1, 5]", true},
+		{num, "float WITHIN [5, 10]", false},
+		{num, "float not within [5, 10]", true},
+		{num, "not float within [1, 5] or str == ''", true},
+		{obj, "str hasprefix 'he'", true},
+		{obj, "str hasPrefix 'lo'", false},
+		{obj, "str hasprefix 'he' and nested.str hasprefix 'ne'", true},
+		{obj, "tags hasprefix 'xy'", true},
+		{obj, "tags not hasprefix 'q'", true},
+		{obj, "str hasprefix true", false},
+		{&TestProtoMessage{}, "nested.str hasprefix ''", false},
+		{&TestProtoMessage{}, "nested.str not hasprefix ''", false},
+	}
+
+	for _, test := range tests {
+		res, err := Filter(test.obj, test.filter)
+		assert.NoError(t, err, test.filter)
+		assert.Equal(t, test.res, res, test.filter)
+	}
+
+	_, err := Filter(num, "float within [1, 2, 3]")
+	assert.EqualError(t, err, "within requires a number field and a range of two numbers")
+
+	_, err = Filter(num, "missing within [1, 2]")
+	assert.IsType(t, &UnknownFieldError{}, err)
+
+	c := &CustomCondition{FieldPath: []string{"float"}, Operator: "unregistered", Value: &CustomCondition_NumberValue{1}}
+	_, err = c.Filter(num)
+	assert.IsType(t, &UnknownOperatorError{}, err)
+}
+
+func TestFilteringParserCustomOperator(t *testing.T) {
+	f, err := ParseFiltering("location within [1.5, 2.5] and name not hasprefix 'x'")
+	assert.NoError(t, err)
+	assert.Equal(t, "(location within [1.5, 2.5] and not name hasprefix 'x')", f.GoString())
+	assert.Equal(t, "LogicalOperator AND\n  CustomCondition within location [1.5, 2.5]\n  CustomCondition NOT hasprefix name 'x'", f.Dump())
+
+	c := f.GetOperator().GetLeftCustomCondition()
+	assert.Equal(t, []float64{1.5, 2.5}, c.Literal())
+
+	for _, filter := range []string{"location within", "location within null", "within == 1", "location within [1] within [2]"} {
+		_, err := ParseFiltering(filter)
+		assert.IsType(t, &ParseError{}, err, filter)
+	}
+
+	_, err = ParseFiltering("name == 'a' and within")
+	assert.EqualError(t, err, `invalid filter at position 16: unexpected operator "within"`)
+
+	_, _, err = ToSQL(f, WithSQLFieldMapping(map[string]string{"location": "location", "name": "name"}))
+	assert.Error(t, err)
+}
+
+func TestRegisterOperatorInvalid(t *testing.T) {
+	fn := func(fieldVal, literal interface{}) (bool, error) { return true, nil }
+	for _, symbol := range []string{"", "and", "like", "true", "a.b", "a-b", "==", "1x", "two words"} {
+		assert.Panics(t, func() { RegisterOperator(symbol, fn) }, symbol)
+	}
+	assert.Panics(t, func() { RegisterOperator("nilfunc", nil) })
+}
@@ -0,0 +1,130 @@
+package query
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// UpperCaseEnumNames makes enum names of string literals to be uppercased before they are looked up
+// in the enum value map, so that lowercase names like 'active' match values generated as ACTIVE.
+// Without the option names are looked up exactly unless CaseInsensitive is set.
+func UpperCaseEnumNames() FilterOption {
+	return func(o *filterOptions) {
+		o.upperEnumNames = true
+	}
+}
+
+// filterEnum evaluates string condition against enum value fv by its symbolic name.
+// If the enum is registered in proto registry the name is resolved via its value map,
+// so that an unknown name results in InvalidLiteralError.
+func (c *StringCondition) filterEnum(obj interface{}, fv reflect.Value, o *filterOptions) (bool, error) {
+	if c.Type != StringCondition_EQ && c.Type != StringCondition_IEQ {
+		return false, &UnsupportedOperatorError{"enum", c.Type.String()}
+	}
+	fold := o.caseInsensitive || c.Type == StringCondition_IEQ
+	lit := c.Value
+	if o.upperEnumNames {
+		lit = strings.ToUpper(lit)
+	}
+	if enum, values := enumValueMap(obj, c.FieldPath); values != nil {
+		value, ok := enumValue(values, lit, fold)
+		if !ok {
+			return false, &InvalidLiteralError{"enum", c.Value, fmt.Errorf("unknown %s value, valid values are %s", enum, strings.Join(enumNames(values), ", "))}
+		}
+		if isNilValue(fv) {
+			return false, nil
+		}
+		return negateIfNeeded(int32(dereferenceValue(fv).Int()) == value, c.IsNegative), nil
+	}
+	if isNilValue(fv) {
+		return false, nil
+	}
+	name, _ := enumName(dereferenceValue(fv))
+	if fold {
+		return negateIfNeeded(strings.EqualFold(name, lit), c.IsNegative), nil
+	}
+	return negateIfNeeded(name == lit, c.IsNegative), nil
+}
+
+// enumNames returns names of enum values map ordered by their values.
+func enumNames(values map[string]int32) []string {
+	names := make([]string, 0, len(values))
+	for n := range values {
+		names = append(names, n)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if values[names[i]] != values[names[j]] {
+			return values[names[i]] < values[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
+// upperStrings returns a copy of ss with all of the strings uppercased.
+func upperStrings(ss []string) []string {
+	res := make([]string, len(ss))
+	for i, s := range ss {
+		res[i] = strings.ToUpper(s)
+	}
+	return res
+}
+
+// enumValue looks up name in enum values map.
+func enumValue(values map[string]int32, name string, fold bool) (int32, bool) {
+	if v, ok := values[name]; ok {
+		return v, true
+	}
+	if fold {
+		for n, v := range values {
+			if strings.EqualFold(n, name) {
+				return v, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// isEnumValue reports whether v holds or points to an enum value.
+func isEnumValue(v reflect.Value) bool {
+	if isNilValue(v) {
+		v = reflect.New(v.Type().Elem())
+	}
+	_, ok := enumName(dereferenceValue(v))
+	return ok
+}
+
+// enumValueMap returns the name of the enum referenced by fieldPath of proto message obj
+// along with its registered value map, or nil map if there is no such enum.
+func enumValueMap(obj interface{}, fieldPath []string) (string, map[string]int32) {
+	t := reflect.TypeOf(obj)
+	var prop *proto.Properties
+	for _, name := range fieldPath {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct || !isProtoMessage(t) {
+			return "", nil
+		}
+		prop = nil
+		for _, p := range proto.GetProperties(t).Prop {
+			if p.OrigName == name || p.JSONName == name {
+				prop = p
+				break
+			}
+		}
+		if prop == nil {
+			return "", nil
+		}
+		sf, _ := t.FieldByName(prop.Name)
+		t = sf.Type
+	}
+	if prop == nil || prop.Enum == "" {
+		return "", nil
+	}
+	return prop.Enum, proto.EnumValueMap(prop.Enum)
+}
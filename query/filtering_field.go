@@ -0,0 +1,121 @@
+package query
+
+import (
+	"reflect"
+	"time"
+)
+
+// Filter evaluates field condition against obj, i.e. compares two fields of obj.
+// Fields are compared if both of them hold values of the same type: strings, numbers, bools,
+// timestamps or durations, numbers of different Go types are compared as float64.
+// If either of the fields is null the condition is false regardless of negation.
+// If the first field is repeated or goes through a repeated message field, e.g. "items.qty",
+// the condition is satisfied if it is satisfied by any of the elements as other conditions are,
+// the second field must not be repeated.
+func (c *FieldCondition) Filter(obj interface{}) (bool, error) {
+	return c.filter(obj, &filterOptions{})
+}
+
+func (c *FieldCondition) filter(obj interface{}, o *filterOptions) (bool, error) {
+	r, err := fieldConditionValue(obj, c.ValueFieldPath, o)
+	if err != nil {
+		return false, err
+	}
+	slice, rest, ok := repeatedField(obj, c.FieldPath)
+	if !ok {
+		l, err := fieldConditionValue(obj, c.FieldPath, o)
+		if err != nil {
+			return false, err
+		}
+		if l == nil || r == nil {
+			return false, nil
+		}
+		res, err := c.compare(l, r)
+		if err != nil {
+			return false, err
+		}
+		return negateIfNeeded(c.IsNegative, res), nil
+	}
+	if r == nil {
+		return false, nil
+	}
+	for i := 0; i < slice.Len(); i++ {
+		l, err := fieldConditionValue(slice.Index(i).Interface(), rest, o)
+		if err != nil {
+			return false, withFieldPath(err, c.FieldPath)
+		}
+		if l == nil {
+			continue
+		}
+		res, err := c.compare(l, r)
+		if err != nil {
+			return false, err
+		}
+		if res {
+			return negateIfNeeded(c.IsNegative, true), nil
+		}
+	}
+	return negateIfNeeded(c.IsNegative, false), nil
+}
+
+// compare reports whether non-null values l and r of the fields satisfy the condition
+// regardless of its negation.
+func (c *FieldCondition) compare(l, r interface{}) (bool, error) {
+	if reflect.TypeOf(l) != reflect.TypeOf(r) {
+		return false, &TypeMismatchError{comparableTypeName(l), c.ValueFieldPath, ""}
+	}
+	cmp := compareValues(l, r)
+	switch c.Type {
+	case FieldCondition_EQ:
+		return cmp == 0, nil
+	case FieldCondition_GT:
+		return cmp > 0, nil
+	case FieldCondition_GE:
+		return cmp >= 0, nil
+	case FieldCondition_LT:
+		return cmp < 0, nil
+	case FieldCondition_LE:
+		return cmp <= 0, nil
+	}
+	return false, nil
+}
+
+// fieldConditionValue returns the value of fieldPath of obj to be compared by field condition
+// or nil if it is null. Numbers are converted to float64.
+func fieldConditionValue(obj interface{}, fieldPath []string, o *filterOptions) (interface{}, error) {
+	fv := o.fieldByFieldPath(obj, fieldPath)
+	if !fv.IsValid() {
+		return nil, &TypeMismatchError{"comparable", fieldPath, ""}
+	}
+	v, ok, err := comparableValue(fv)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, &TypeMismatchError{"comparable", fieldPath, ""}
+	}
+	switch n := v.(type) {
+	case int64:
+		return float64(n), nil
+	case uint64:
+		return float64(n), nil
+	}
+	return v, nil
+}
+
+// comparableTypeName returns the name of the type of value v returned by fieldConditionValue
+// as it is used in filtering expressions.
+func comparableTypeName(v interface{}) string {
+	switch v.(type) {
+	case float64:
+		return "number"
+	case bool:
+		return "bool"
+	case time.Time:
+		return "timestamp"
+	case time.Duration:
+		return "duration"
+	default:
+		return "string"
+	}
+}
@@ -0,0 +1,136 @@
+package query
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// UnknownFieldError describes a field path that is not allowed or cannot be found.
+type UnknownFieldError struct {
+	FieldPath []string
+}
+
+func (e *UnknownFieldError) Error() string {
+	return fmt.Sprintf("%s field is not allowed", strings.Join(e.FieldPath, "."))
+}
+
+// Fields returns sorted distinct list of dot-separated field paths referenced by m.
+func (m *Filtering) Fields() []string {
+	if m == nil {
+		return nil
+	}
+	set := make(map[string]struct{})
+	walkNode(m.Root, func(node interface{}) error {
+		for _, fp := range conditionFieldPaths(node) {
+			set[strings.Join(fp, ".")] = struct{}{}
+		}
+		return nil
+	})
+	fields := make([]string, 0, len(set))
+	for f := range set {
+		fields = append(fields, f)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// ValidateFilteringFields checks that all fields referenced by f are in the allowed list of
+// dot-separated field paths and returns UnknownFieldError for the first field that is not.
+// If pb is provided then both field paths and allowed list are resolved against pb,
+// so fields can be referred to either by their proto or JSON names.
+func ValidateFilteringFields(f *Filtering, allowed []string, pb ...proto.Message) error {
+	if f == nil {
+		return nil
+	}
+	var t reflect.Type
+	if len(pb) > 0 && pb[0] != nil {
+		t = reflect.TypeOf(pb[0])
+	}
+	allowedSet := make(map[string]struct{}, len(allowed))
+	for _, a := range allowed {
+		allowedSet[strings.Join(protoFieldPath(strings.Split(a, "."), t), ".")] = struct{}{}
+	}
+	return walkNode(f.Root, func(node interface{}) error {
+		for _, fp := range conditionFieldPaths(node) {
+			if _, ok := allowedSet[strings.Join(protoFieldPath(fp, t), ".")]; !ok {
+				return &UnknownFieldError{fp}
+			}
+		}
+		return nil
+	})
+}
+
+// conditionFieldPath returns a field path of a condition node or nil if node is not a condition.
+func conditionFieldPath(node interface{}) []string {
+	switch n := node.(type) {
+	case *StringCondition:
+		return n.FieldPath
+	case *NumberCondition:
+		return n.FieldPath
+	case *NullCondition:
+		return n.FieldPath
+	case *BoolCondition:
+		return n.FieldPath
+	case *StringArrayCondition:
+		return n.FieldPath
+	case *NumberArrayCondition:
+		return n.FieldPath
+	case *CustomCondition:
+		return n.FieldPath
+	case *ExistsCondition:
+		return n.FieldPath
+	case *FieldCondition:
+		return n.FieldPath
+	case *ContainsCondition:
+		return n.FieldPath
+	default:
+		return nil
+	}
+}
+
+// conditionFieldPaths returns all field paths referenced by a condition node,
+// that is the field path of the condition and the compared field path of a field condition.
+func conditionFieldPaths(node interface{}) [][]string {
+	fp := conditionFieldPath(node)
+	if fp == nil {
+		return nil
+	}
+	if fc, ok := node.(*FieldCondition); ok {
+		return [][]string{fp, fc.ValueFieldPath}
+	}
+	return [][]string{fp}
+}
+
+// protoFieldPath translates JSON names in fieldPath to the original proto names
+// according to proto message type t. Parts that cannot be resolved are left untouched.
+func protoFieldPath(fieldPath []string, t reflect.Type) []string {
+	if t == nil {
+		return fieldPath
+	}
+	res := make([]string, len(fieldPath))
+	copy(res, fieldPath)
+	for i, name := range fieldPath {
+		for t != nil && (t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice) {
+			t = t.Elem()
+		}
+		if t == nil || t.Kind() != reflect.Struct || !isProtoMessage(t) {
+			break
+		}
+		var next reflect.Type
+		for _, p := range proto.GetProperties(t).Prop {
+			if p.OrigName == name || p.JSONName == name {
+				res[i] = p.OrigName
+				if sf, ok := t.FieldByName(p.Name); ok {
+					next = sf.Type
+				}
+				break
+			}
+		}
+		t = next
+	}
+	return res
+}
@@ -0,0 +1,97 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateFilteringFields(t *testing.T) {
+	tests := []struct {
+		filter  string
+		allowed []string
+		err     error
+	}{
+		{
+			filter:  "",
+			allowed: nil,
+			err:     nil,
+		},
+		{
+			filter:  "str == 'a' and not (int > 1 or nested.str == null)",
+			allowed: []string{"str", "int", "nested.str"},
+			err:     nil,
+		},
+		{
+			filter:  "str == 'a' and not (int > 1 or nested.str == null)",
+			allowed: []string{"str", "nested.str"},
+			err:     &UnknownFieldError{FieldPath: []string{"int"}},
+		},
+		{
+			filter:  "str == 'a' or bool == true",
+			allowed: []string{"str", "int"},
+			err:     &UnknownFieldError{FieldPath: []string{"bool"}},
+		},
+		{
+			filter:  "str == 'a' and int < nested.str",
+			allowed: []string{"str", "int"},
+			err:     &UnknownFieldError{FieldPath: []string{"nested", "str"}},
+		},
+		{
+			filter:  "nestedJSON.str == 'a'",
+			allowed: []string{"nested.str"},
+			err:     &UnknownFieldError{FieldPath: []string{"nestedJSON", "str"}},
+		},
+	}
+
+	for _, test := range tests {
+		f, err := ParseFiltering(test.filter)
+		assert.NoError(t, err)
+		assert.Equal(t, test.err, ValidateFilteringFields(f, test.allowed), test.filter)
+	}
+}
+
+func TestValidateFilteringFieldsProto(t *testing.T) {
+	f, err := ParseFiltering("nestedJSON.str == 'a' and nested.str != 'b'")
+	assert.NoError(t, err)
+	assert.NoError(t, ValidateFilteringFields(f, []string{"nested.str"}, &TestProtoMessage{}))
+	assert.NoError(t, ValidateFilteringFields(f, []string{"nestedJSON.str"}, &TestProtoMessage{}))
+	assert.Equal(t, &UnknownFieldError{FieldPath: []string{"nestedJSON", "str"}}, ValidateFilteringFields(f, []string{"str"}, &TestProtoMessage{}))
+}
+
+func TestFilteringFields(t *testing.T) {
+	tests := []struct {
+		filter string
+		fields []string
+	}{
+		{
+			filter: "",
+			fields: nil,
+		},
+		{
+			filter: "str == 'a'",
+			fields: []string{"str"},
+		},
+		{
+			filter: "str == 'a' and not (int > 1 or nested.str == null) and str ~ 'b' or bool == true",
+			fields: []string{"bool", "int", "nested.str", "str"},
+		},
+		{
+			filter: "nested.str in ['a', 'b'] and int not in [1, 2] and int == 3",
+			fields: []string{"int", "nested.str"},
+		},
+		{
+			filter: "int < nested.int or str != bool",
+			fields: []string{"bool", "int", "nested.int", "str"},
+		},
+	}
+
+	for _, test := range tests {
+		f, err := ParseFiltering(test.filter)
+		assert.Nil(t, err)
+		assert.Equal(t, test.fields, f.Fields(), test.filter)
+	}
+
+	var f *Filtering
+	assert.Nil(t, f.Fields())
+}
@@ -0,0 +1,49 @@
+package query
+
+import (
+	"testing"
+)
+
+func FuzzParseFiltering(f *testing.F) {
+	for _, seed := range []string{
+		"",
+		"a == 1",
+		"not(name == 'abc' or age > 3 and active != true)",
+		"name in ['a', 'b'] and age not in [1, 2]",
+		"age between 1 and 10",
+		"name like 'a%' or name := 'AbC'",
+		"created_at > '2023-01-01T00:00:00Z' and tags exists",
+		"a == -1.5e3 and b == null",
+		"(a == 1",
+		"a == 1)",
+		"[1, 2",
+		"and",
+		"a == 1 and",
+		"not",
+		"'abc",
+		"a ~ 'x\\u00'",
+		"A.<0",
+		"A. == 1",
+		"A..b == 1",
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, text string) {
+		fl, err := ParseFiltering(text)
+		if err != nil {
+			return
+		}
+		// a parsed expression is printed back to the same one
+		s := fl.GoString()
+		pfl, err := ParseFiltering(s)
+		if err != nil {
+			t.Fatalf("failed to parse %q printed from %q: %s", s, text, err)
+		}
+		if ps := pfl.GoString(); ps != s {
+			t.Errorf("%q is printed as %q after parsing, expected: %q", text, ps, s)
+		}
+		// consumers of the parsed expression return errors instead of panics as well
+		Filter(&TestProtoMessage{Str: "abc", Int: 1, Tags: []string{"a"}}, text)
+		ToSQL(fl)
+	})
+}
@@ -0,0 +1,112 @@
+package query
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"reflect"
+)
+
+var (
+	ipType    = reflect.TypeOf(net.IP(nil))
+	ipNetType = reflect.TypeOf(net.IPNet{})
+
+	errInvalidIP = errors.New("invalid IP address")
+)
+
+// isIPValue reports whether v is a net.IP field.
+func isIPValue(v reflect.Value) bool {
+	return v.IsValid() && indirectType(v.Type()) == ipType
+}
+
+// isIPNetValue reports whether v is a net.IPNet field.
+func isIPNetValue(v reflect.Value) bool {
+	return v.IsValid() && indirectType(v.Type()) == ipNetType
+}
+
+// filterIP evaluates string condition against net.IP value fv.
+// Equality and ordering operators compare fv with the IP address literal,
+// in_cidr checks that fv belongs to the CIDR block literal.
+// IPv4 addresses are compared as IPv4-mapped IPv6 ones.
+func (c *StringCondition) filterIP(fv reflect.Value) (bool, error) {
+	if c.Type == StringCondition_IN_CIDR {
+		_, lit, err := net.ParseCIDR(c.Value)
+		if err != nil {
+			return false, &InvalidLiteralError{"CIDR", c.Value, err}
+		}
+		ip, ok := ipValue(fv)
+		if !ok {
+			return false, nil
+		}
+		return negateIfNeeded(lit.Contains(ip), c.IsNegative), nil
+	}
+	if !c.isComparison() {
+		return false, &UnsupportedOperatorError{"IP", c.Type.String()}
+	}
+	lit := net.ParseIP(c.Value)
+	if lit == nil {
+		return false, &InvalidLiteralError{"IP", c.Value, errInvalidIP}
+	}
+	ip, ok := ipValue(fv)
+	if !ok {
+		return false, nil
+	}
+	return c.compare(bytes.Compare(ip.To16(), lit.To16()), "IP")
+}
+
+// filterIPNet evaluates string condition against net.IPNet value fv.
+// Equality compares fv with the CIDR block literal, host bits of fv are ignored,
+// in_cidr checks that fv is a subnet of the CIDR block literal.
+func (c *StringCondition) filterIPNet(fv reflect.Value) (bool, error) {
+	if c.Type != StringCondition_EQ && c.Type != StringCondition_IN_CIDR {
+		return false, &UnsupportedOperatorError{"CIDR", c.Type.String()}
+	}
+	_, lit, err := net.ParseCIDR(c.Value)
+	if err != nil {
+		return false, &InvalidLiteralError{"CIDR", c.Value, err}
+	}
+	if isNilValue(fv) {
+		return false, nil
+	}
+	n := dereferenceValue(fv).Interface().(net.IPNet)
+	if n.IP == nil {
+		return false, nil
+	}
+	n.IP = n.IP.Mask(n.Mask)
+	if c.Type == StringCondition_IN_CIDR {
+		return negateIfNeeded(cidrContains(lit, &n), c.IsNegative), nil
+	}
+	return negateIfNeeded(n.String() == lit.String(), c.IsNegative), nil
+}
+
+// filterIPString evaluates in_cidr condition against string s holding either
+// an IP address or a CIDR block. Strings of other formats do not belong to any block.
+func (c *StringCondition) filterIPString(s string) (bool, error) {
+	_, lit, err := net.ParseCIDR(c.Value)
+	if err != nil {
+		return false, &InvalidLiteralError{"CIDR", c.Value, err}
+	}
+	var res bool
+	if ip := net.ParseIP(s); ip != nil {
+		res = lit.Contains(ip)
+	} else if _, n, err := net.ParseCIDR(s); err == nil {
+		res = cidrContains(lit, n)
+	}
+	return negateIfNeeded(res, c.IsNegative), nil
+}
+
+// ipValue returns IP address held by fv, false is returned for nil or empty address.
+func ipValue(fv reflect.Value) (net.IP, bool) {
+	if isNilValue(fv) {
+		return nil, false
+	}
+	ip := dereferenceValue(fv).Interface().(net.IP)
+	return ip, len(ip) > 0
+}
+
+// cidrContains reports whether network n is a subnet of network outer.
+func cidrContains(outer, n *net.IPNet) bool {
+	outerOnes, outerBits := outer.Mask.Size()
+	ones, bits := n.Mask.Size()
+	return outerBits == bits && outerOnes <= ones && outer.Contains(n.IP)
+}
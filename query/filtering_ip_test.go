@@ -0,0 +1,84 @@
+package query
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TestHostObject struct {
+	IP      net.IP     `json:"ip"`
+	Gateway *net.IP    `json:"gateway"`
+	Subnet  *net.IPNet `json:"subnet"`
+	Aliases []net.IP   `json:"aliases"`
+	Address string     `json:"address"`
+}
+
+func TestFilteringIP(t *testing.T) {
+	gw := net.ParseIP("10.0.0.1")
+	_, subnet, _ := net.ParseCIDR("10.1.0.0/16")
+	obj := &TestHostObject{
+		IP:      net.ParseIP("10.1.2.3"),
+		Gateway: &gw,
+		Subnet:  subnet,
+		Aliases: []net.IP{net.ParseIP("192.168.0.1"), net.ParseIP("2001:db8::1")},
+		Address: "172.16.0.5",
+	}
+	tests := []struct {
+		obj    interface{}
+		filter string
+		res    bool
+		err    error
+	}{
+		{obj: obj, filter: `ip == '10.1.2.3'`, res: true},
+		{obj: obj, filter: `ip == '::ffff:10.1.2.3'`, res: true},
+		{obj: obj, filter: `ip != '10.1.2.4'`, res: true},
+		{obj: obj, filter: `ip > '10.1.2.2' and ip < '10.1.3.0'`, res: true},
+		{obj: obj, filter: `gateway == '10.0.0.1'`, res: true},
+		{obj: obj, filter: `ip in_cidr '10.0.0.0/8'`, res: true},
+		{obj: obj, filter: `ip in_cidr '10.2.0.0/16'`, res: false},
+		{obj: obj, filter: `ip not in_cidr '10.2.0.0/16'`, res: true},
+		// address families never match
+		{obj: obj, filter: `ip in_cidr '::/0'`, res: false},
+		{obj: obj, filter: `aliases in_cidr '2001:db8::/32' and aliases == '192.168.0.1'`, res: true},
+		{obj: obj, filter: `subnet == '10.1.0.0/16'`, res: true},
+		{obj: obj, filter: `subnet == '10.1.5.0/16'`, res: true},
+		{obj: obj, filter: `subnet == '10.1.0.0/24'`, res: false},
+		{obj: obj, filter: `subnet in_cidr '10.0.0.0/8'`, res: true},
+		{obj: obj, filter: `subnet in_cidr '10.1.0.0/24'`, res: false},
+		{obj: obj, filter: `address in_cidr '172.16.0.0/12'`, res: true},
+		{obj: obj, filter: `address not in_cidr '172.16.0.0/12'`, res: false},
+		// null values do not satisfy the condition regardless of negation
+		{obj: &TestHostObject{}, filter: `ip == '10.1.2.3' or ip not in_cidr '10.0.0.0/8' or gateway != '10.0.0.1' or subnet in_cidr '10.0.0.0/8'`, res: false},
+		{obj: obj, filter: `ip == '10.1.2'`, err: &InvalidLiteralError{"IP", "10.1.2", errInvalidIP}},
+		{obj: obj, filter: `ip ~ '10.*'`, err: &UnsupportedOperatorError{"IP", "MATCH"}},
+		{obj: obj, filter: `subnet > '10.0.0.0/8'`, err: &UnsupportedOperatorError{"CIDR", "GT"}},
+	}
+	for _, test := range tests {
+		res, err := Filter(test.obj, test.filter)
+		assert.Equal(t, test.err, err, test.filter)
+		assert.Equal(t, test.res, res, test.filter)
+	}
+
+	_, err := Filter(obj, `subnet == '10.1.0.0'`)
+	assert.IsType(t, &InvalidLiteralError{}, err)
+}
+
+func TestParseFilteringInCIDR(t *testing.T) {
+	f, err := ParseFiltering(`ip not in_cidr '10.0.0.0/8'`)
+	assert.NoError(t, err)
+	assert.Equal(t, &StringCondition{
+		FieldPath:  []string{"ip"},
+		Value:      "10.0.0.0/8",
+		Type:       StringCondition_IN_CIDR,
+		IsNegative: true,
+	}, f.GetStringCondition())
+
+	for _, filter := range []string{`ip in_cidr '10.0.0.1'`, `ip in_cidr '10.0.0.0/33'`, `ip in_cidr 'net'`} {
+		_, err := ParseFiltering(filter)
+		assert.IsType(t, &InvalidLiteralError{}, err, filter)
+	}
+	_, err = ParseFiltering(`ip in_cidr 10`)
+	assert.IsType(t, &ParseError{}, err)
+}
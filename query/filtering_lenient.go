@@ -0,0 +1,79 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Warning describes a part of a filtering expression dropped by ParseFilteringLenient.
+type Warning struct {
+	// Pos is a zero-based position of the dropped part in the expression.
+	Pos int
+	// Text is the dropped part starting with the logical operator that joins it to the kept one.
+	Text string
+	// Err is the error the part is dropped for, e.g. ParseError.
+	Err error
+}
+
+func (w Warning) String() string {
+	return fmt.Sprintf("dropped %q at position %d: %s", w.Text, w.Pos, w.Err)
+}
+
+// ParseFilteringLenient is the same as ParseFiltering but if the expression is invalid it keeps
+// the longest valid prefix that ends before a top-level logical operator, e.g. "a == 1 and b == 2 and c ~ "
+// is parsed to "a == 1 and b == 2", and reports the dropped part with a warning. The error is returned
+// only if there is no such prefix, e.g. for "a ~ 1 and b == 2". Note that dropping an operand of a top-level
+// AND operator makes the expression to match more objects, so the result should not be used for authorization.
+func ParseFilteringLenient(text string) (*Filtering, []Warning, error) {
+	f, err := ParseFiltering(text)
+	if err == nil {
+		return f, nil, nil
+	}
+	errPos := -1
+	if pe, ok := err.(*ParseError); ok {
+		errPos = pe.Pos
+	}
+	cuts := logicalOperatorPositions(text)
+	runes := []rune(text)
+	for i := len(cuts) - 1; i >= 0; i-- {
+		if errPos >= 0 && cuts[i] > errPos {
+			continue
+		}
+		prefix, perr := ParseFiltering(string(runes[:cuts[i]]))
+		if perr != nil || prefix == nil {
+			continue
+		}
+		w := Warning{Pos: cuts[i], Text: strings.TrimSpace(string(runes[cuts[i]:])), Err: err}
+		return prefix, []Warning{w}, nil
+	}
+	return nil, nil, err
+}
+
+// logicalOperatorPositions returns positions of logical operators of text which are not enclosed in parentheses.
+// Tokens are read until the end of text or the first lexical error.
+func logicalOperatorPositions(text string) []int {
+	lexer := newFilteringLexer(text)
+	var positions []int
+	depth := 0
+	var prev Token
+	for {
+		lexer.valuePosition = isValueOperator(prev)
+		token, err := lexer.NextToken()
+		if err != nil {
+			return positions
+		}
+		prev = token
+		switch token.(type) {
+		case EOFToken:
+			return positions
+		case LparenToken:
+			depth++
+		case RparenToken:
+			depth--
+		case AndToken, OrToken:
+			if depth == 0 {
+				positions = append(positions, lexer.tokenPos)
+			}
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFilteringLenient(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+		warning  Warning
+	}{
+		{
+			text:     "a == 1 and b == 2",
+			expected: "(a == 1 and b == 2)",
+		},
+		{
+			text:     "a == 1 and b == 2 and c ~ ",
+			expected: "(a == 1 and b == 2)",
+			warning:  Warning{Pos: 18, Text: "and c ~"},
+		},
+		{
+			text:     "a == 1 or (b == 2 and c === 3) or d == 4",
+			expected: "a == 1",
+			warning:  Warning{Pos: 7, Text: "or (b == 2 and c === 3) or d == 4"},
+		},
+		{
+			text:     "a == 1 and",
+			expected: "a == 1",
+			warning:  Warning{Pos: 7, Text: "and"},
+		},
+		{
+			text:     "a == 1 or name == 'unterminated",
+			expected: "a == 1",
+			warning:  Warning{Pos: 7, Text: "or name == 'unterminated"},
+		},
+		{
+			text:     "t > now-1w+12h and now == 1 and c ~",
+			expected: "(t > now-1w+12h and `now` == 1)",
+			warning:  Warning{Pos: 28, Text: "and c ~"},
+		},
+		{
+			text:     "a == 1 and b between 2 and 1",
+			expected: "a == 1",
+			warning:  Warning{Pos: 7, Text: "and b between 2 and 1"},
+		},
+	}
+
+	for _, test := range tests {
+		f, warnings, err := ParseFilteringLenient(test.text)
+		assert.NoError(t, err, test.text)
+		assert.Equal(t, test.expected, f.GoString(), test.text)
+		if test.warning.Text == "" {
+			assert.Empty(t, warnings, test.text)
+			continue
+		}
+		if assert.Len(t, warnings, 1, test.text) {
+			assert.Equal(t, test.warning.Pos, warnings[0].Pos, test.text)
+			assert.Equal(t, test.warning.Text, warnings[0].Text, test.text)
+			assert.Error(t, warnings[0].Err, test.text)
+		}
+	}
+
+	// the warning carries the error of the whole expression
+	_, warnings, _ := ParseFilteringLenient("a == 1 and == 5")
+	if assert.Len(t, warnings, 1) {
+		assert.IsType(t, &ParseError{}, warnings[0].Err)
+		assert.Equal(t, `dropped "and == 5" at position 7: invalid filter at position 11: unexpected operator "=="`, warnings[0].String())
+	}
+
+	for _, text := range []string{"a ~ 1 and b == 2", "== 1", "(a == 1 and b ==", "'abc"} {
+		f, warnings, err := ParseFilteringLenient(text)
+		assert.Error(t, err, text)
+		assert.Nil(t, f, text)
+		assert.Empty(t, warnings, text)
+	}
+
+	f, warnings, err := ParseFilteringLenient("")
+	assert.NoError(t, err)
+	assert.Nil(t, f)
+	assert.Empty(t, warnings)
+}
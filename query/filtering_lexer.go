@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 )
 
 // FilteringLexer is impemented by lexical analyzers that are used by filtering expression parsers.
@@ -15,14 +16,18 @@ type FilteringLexer interface {
 // NewFilteringLexer returns a default FilteringLexer implementation.
 // text is a filtering expression to analyze.
 func NewFilteringLexer(text string) FilteringLexer {
+	return newFilteringLexer(text)
+}
+
+func newFilteringLexer(text string) *filteringLexer {
 	var runes []rune
 	for _, r := range text {
 		runes = append(runes, r)
 	}
 	if len(runes) > 0 {
-		return &filteringLexer{runes, 0, runes[0], false}
+		return &filteringLexer{text: runes, curChar: runes[0]}
 	}
-	return &filteringLexer{runes, 0, 0, true}
+	return &filteringLexer{text: runes, eof: true}
 }
 
 // UnexpectedSymbolError describes symbol S in position Pos that was not appropriate according to REST API Syntax Specification.
@@ -35,6 +40,34 @@ func (e *UnexpectedSymbolError) Error() string {
 	return fmt.Sprintf("Unexpected symbol %c in %d position", e.S, e.Pos)
 }
 
+// UnterminatedStringError describes a string literal starting in position Pos that is not terminated.
+type UnterminatedStringError struct {
+	Pos int
+}
+
+func (e *UnterminatedStringError) Error() string {
+	return fmt.Sprintf("Unterminated string literal starting in %d position", e.Pos)
+}
+
+// UnterminatedFieldError describes a backtick-quoted field name starting in position Pos that is not terminated.
+type UnterminatedFieldError struct {
+	Pos int
+}
+
+func (e *UnterminatedFieldError) Error() string {
+	return fmt.Sprintf("Unterminated quoted field name starting in %d position", e.Pos)
+}
+
+// InvalidRelativeTimeError describes a malformed relative time literal Value starting in position Pos, e.g. now-7x.
+type InvalidRelativeTimeError struct {
+	Value string
+	Pos   int
+}
+
+func (e *InvalidRelativeTimeError) Error() string {
+	return fmt.Sprintf("Invalid relative time literal %q starting in %d position", e.Value, e.Pos)
+}
+
 // Token is impelemented by all supported tokens in a filtering expression.
 type Token interface {
 	Token()
@@ -95,11 +128,33 @@ func (t StringToken) String() string {
 	return fmt.Sprint(t.Value)
 }
 
+// RelativeTimeToken represents a relative time literal, e.g. now or now-7d.
+// Value is the lower-cased literal.
+type RelativeTimeToken struct {
+	TokenBase
+	Value string
+}
+
+func (t RelativeTimeToken) String() string {
+	return t.Value
+}
+
 // FieldToken represents a reference to a value of a resource.
 // Value is a value of the reference.
 type FieldToken struct {
 	TokenBase
 	Value string
+	// Path holds names of a field path with backtick-quoted names, e.g. `user name`,
+	// that may contain dots. If it is nil, the path is Value split by dots.
+	Path []string
+}
+
+// fieldPath returns the field path referenced by t.
+func (t FieldToken) fieldPath() []string {
+	if t.Path != nil {
+		return t.Path
+	}
+	return strings.Split(t.Value, ".")
 }
 
 func (t FieldToken) String() string {
@@ -150,6 +205,15 @@ func (t InsensitiveEqToken) String() string {
 	return ":="
 }
 
+// NullSafeEqToken represents null-safe equals operator.
+type NullSafeEqToken struct {
+	TokenBase
+}
+
+func (t NullSafeEqToken) String() string {
+	return "<=>"
+}
+
 // NeToken represents not equals operator.
 type NeToken struct {
 	TokenBase
@@ -213,6 +277,24 @@ func (t LeToken) String() string {
 	return "<="
 }
 
+// ExistsToken represents a check of field presence.
+type ExistsToken struct {
+	TokenBase
+}
+
+func (t ExistsToken) String() string {
+	return "exists"
+}
+
+// IsToken represents SQL-like null check, e.g. field is not null.
+type IsToken struct {
+	TokenBase
+}
+
+func (t IsToken) String() string {
+	return "is"
+}
+
 // NullToken represents null literal.
 type NullToken struct {
 	TokenBase
@@ -231,6 +313,51 @@ func (t InToken) String() string {
 	return "in"
 }
 
+// BetweenToken represents range operator.
+type BetweenToken struct {
+	TokenBase
+}
+
+func (t BetweenToken) String() string {
+	return "between"
+}
+
+// LikeToken represents SQL LIKE pattern match.
+type LikeToken struct {
+	TokenBase
+}
+
+func (t LikeToken) String() string {
+	return "like"
+}
+
+// ContainsToken represents JSON containment check.
+type ContainsToken struct {
+	TokenBase
+}
+
+func (t ContainsToken) String() string {
+	return "contains"
+}
+
+// InCIDRToken represents IP address membership in a CIDR block.
+type InCIDRToken struct {
+	TokenBase
+}
+
+func (t InCIDRToken) String() string {
+	return "in_cidr"
+}
+
+// ILikeToken represents case-insensitive SQL LIKE pattern match.
+type ILikeToken struct {
+	TokenBase
+}
+
+func (t ILikeToken) String() string {
+	return "ilike"
+}
+
 //NumberArrayToken represent number array e.g. [1,2,5]
 type StringArrayToken struct {
 	TokenBase
@@ -251,6 +378,17 @@ func (t NumberArrayToken) String() string {
 	return fmt.Sprintf("%v", t.Values)
 }
 
+// CustomOperatorToken represents an operator registered with RegisterOperator.
+// Symbol is the lower-cased symbol of the operator.
+type CustomOperatorToken struct {
+	TokenBase
+	Symbol string
+}
+
+func (t CustomOperatorToken) String() string {
+	return t.Symbol
+}
+
 // EOFToken represents end of an expression.
 type EOFToken struct {
 	TokenBase
@@ -265,6 +403,15 @@ type filteringLexer struct {
 	pos     int
 	curChar rune
 	eof     bool
+	// tokenPos is a position where the last token returned by NextToken starts.
+	tokenPos int
+	// nilKeyword makes nil a synonym for null.
+	nilKeyword bool
+	// sizeSuffixes makes numbers to be multiplied by size suffixes, e.g. 5kb.
+	sizeSuffixes bool
+	// valuePosition makes now to be read as a relative time literal, it is set by a parser
+	// for the value following a comparison operator, so that now is a valid field name elsewhere.
+	valuePosition bool
 }
 
 func (lexer *filteringLexer) advance() {
@@ -277,16 +424,53 @@ func (lexer *filteringLexer) advance() {
 	}
 }
 
+// peek returns a character following the current one or 0 if there is no such character.
+func (lexer *filteringLexer) peek(n int) rune {
+	if lexer.pos+n < len(lexer.text) {
+		return lexer.text[lexer.pos+n]
+	}
+	return 0
+}
+
+// isNumberStart reports whether the current character starts a number literal,
+// i.e. it is a digit or a minus sign followed by a digit.
+func (lexer *filteringLexer) isNumberStart() bool {
+	return unicode.IsDigit(lexer.curChar) || lexer.curChar == '-' && unicode.IsDigit(lexer.peek(1))
+}
+
+// number reads a number literal with an optional minus sign, fractional part
+// and exponent, e.g. 1, -12.5, 1e3 or 1.5E-2.
 func (lexer *filteringLexer) number() (Token, error) {
 	number := string(lexer.curChar)
 	metDot := false
+	metExp := false
 	lexer.advance()
 	for !lexer.eof {
 		if unicode.IsDigit(lexer.curChar) {
 			number += string(lexer.curChar)
-		} else if !metDot && lexer.curChar == '.' {
+		} else if !metDot && !metExp && lexer.curChar == '.' {
 			number += string(lexer.curChar)
 			metDot = true
+		} else if lexer.curChar == '_' {
+			// underscores separate digits as in Go, so they must be surrounded by digits
+			if last, _ := utf8.DecodeLastRuneInString(number); !unicode.IsDigit(last) || !unicode.IsDigit(lexer.peek(1)) {
+				return nil, &UnexpectedSymbolError{lexer.curChar, lexer.pos}
+			}
+		} else if !metExp && (lexer.curChar == 'e' || lexer.curChar == 'E') {
+			// the exponent is consumed only if it has digits
+			n := 1
+			if c := lexer.peek(n); c == '-' || c == '+' {
+				n++
+			}
+			if !unicode.IsDigit(lexer.peek(n)) {
+				break
+			}
+			number += string(lexer.curChar)
+			if n > 1 {
+				lexer.advance()
+				number += string(lexer.curChar)
+			}
+			metExp = true
 		} else {
 			break
 		}
@@ -296,29 +480,110 @@ func (lexer *filteringLexer) number() (Token, error) {
 	if err != nil {
 		return nil, err
 	}
+	if lexer.sizeSuffixes {
+		parsed *= lexer.sizeSuffix()
+	}
 	return NumberToken{Value: parsed}, nil
 }
 
+// sizeSuffixes are multipliers of size suffixes enabled by WithSizeSuffixes.
+var sizeSuffixes = map[string]float64{
+	"kb":  1e3,
+	"mb":  1e6,
+	"gb":  1e9,
+	"tb":  1e12,
+	"kib": 1 << 10,
+	"mib": 1 << 20,
+	"gib": 1 << 30,
+	"tib": 1 << 40,
+}
+
+// sizeSuffix consumes a size suffix that immediately follows a number and returns its multiplier,
+// 1 is returned if there is no suffix.
+func (lexer *filteringLexer) sizeSuffix() float64 {
+	n := 0
+	for unicode.IsLetter(lexer.peek(n)) {
+		n++
+	}
+	if c := lexer.peek(n); unicode.IsDigit(c) || c == '.' || c == '-' || c == '_' {
+		return 1
+	}
+	m, ok := sizeSuffixes[strings.ToLower(string(lexer.text[lexer.pos:lexer.pos+n]))]
+	if !ok {
+		return 1
+	}
+	for i := 0; i < n; i++ {
+		lexer.advance()
+	}
+	return m
+}
+
 func (lexer *filteringLexer) string() (Token, error) {
-	// Add quote escaping support
 	term := lexer.curChar
-	s := ""
+	start := lexer.pos
+	var s []rune
 	lexer.advance()
 	for {
 		if lexer.eof {
-			return nil, &UnexpectedSymbolError{lexer.curChar, lexer.pos}
+			return nil, &UnterminatedStringError{start}
 		}
-		if lexer.curChar == term {
+		switch lexer.curChar {
+		case term:
 			lexer.advance()
 			if lexer.curChar != term {
-				break
+				return StringToken{Value: string(s)}, nil
+			}
+			// term is escaped by duplication
+		case '\\':
+			r, err := lexer.escape(start)
+			if err != nil {
+				return nil, err
 			}
-			// term is escaped
+			s = append(s, r...)
+			continue
 		}
-		s += string(lexer.curChar)
+		s = append(s, lexer.curChar)
 		lexer.advance()
 	}
-	return StringToken{Value: s}, nil
+}
+
+// escape reads an escape sequence that starts with a backslash at the current position
+// of a string literal that starts at start position and returns the unescaped runes.
+// Unknown escape sequences are left as is, so that regular expressions like '\d+'
+// do not need to be escaped twice.
+func (lexer *filteringLexer) escape(start int) ([]rune, error) {
+	lexer.advance()
+	if lexer.eof {
+		return nil, &UnterminatedStringError{start}
+	}
+	c := lexer.curChar
+	lexer.advance()
+	switch c {
+	case '\'', '"', '\\':
+		return []rune{c}, nil
+	case 'n':
+		return []rune{'\n'}, nil
+	case 't':
+		return []rune{'\t'}, nil
+	case 'r':
+		return []rune{'\r'}, nil
+	case 'u':
+		var code rune
+		for i := 0; i < 4; i++ {
+			if lexer.eof {
+				return nil, &UnterminatedStringError{start}
+			}
+			d, err := strconv.ParseUint(string(lexer.curChar), 16, 8)
+			if err != nil {
+				return nil, &UnexpectedSymbolError{lexer.curChar, lexer.pos}
+			}
+			code = code<<4 | rune(d)
+			lexer.advance()
+		}
+		return []rune{code}, nil
+	default:
+		return []rune{'\\', c}, nil
+	}
 }
 
 func (lexer *filteringLexer) array() (Token, error) {
@@ -328,7 +593,7 @@ func (lexer *filteringLexer) array() (Token, error) {
 		return nil, &UnexpectedSymbolError{lexer.curChar, lexer.pos}
 	}
 
-	if unicode.IsDigit(lexer.curChar) {
+	if lexer.isNumberStart() {
 		values := make([]float64, 0)
 		for lexer.curChar != term {
 			if unicode.IsSpace(lexer.curChar) || lexer.curChar == ',' {
@@ -336,7 +601,8 @@ func (lexer *filteringLexer) array() (Token, error) {
 				continue
 			}
 
-			if lexer.eof {
+			// mixed literal types are not allowed within a single array
+			if lexer.eof || !lexer.isNumberStart() {
 				return nil, &UnexpectedSymbolError{lexer.curChar, lexer.pos}
 			}
 
@@ -369,7 +635,7 @@ func (lexer *filteringLexer) array() (Token, error) {
 				continue
 			}
 
-			if lexer.eof {
+			if lexer.eof || (lexer.curChar != '\'' && lexer.curChar != '"') {
 				return nil, &UnexpectedSymbolError{lexer.curChar, lexer.pos}
 			}
 
@@ -403,19 +669,37 @@ func (lexer *filteringLexer) array() (Token, error) {
 func (lexer *filteringLexer) fieldOrReserved() (Token, error) {
 	s := string(lexer.curChar)
 	lexer.advance()
+	// dot is a position of the last dot, names of a path must not be empty, e.g. a..b
+	dot := -1
 	for !lexer.eof {
-		if unicode.IsDigit(lexer.curChar) ||
-			unicode.IsLetter(lexer.curChar) ||
-			lexer.curChar == '.' ||
-			lexer.curChar == '-' ||
-			lexer.curChar == '_' {
+		if isFieldNameChar(lexer.curChar) {
+			s += string(lexer.curChar)
+		} else if lexer.curChar == '.' {
+			if strings.HasSuffix(s, ".") {
+				return nil, &UnexpectedSymbolError{lexer.curChar, lexer.pos}
+			}
+			dot = lexer.pos
 			s += string(lexer.curChar)
 		} else {
 			break
 		}
 		lexer.advance()
 	}
-	switch strings.ToLower(s) {
+	if strings.HasSuffix(s, ".") {
+		// the path continues with a quoted name, e.g. address.`zip code`
+		if lexer.curChar == '`' {
+			return lexer.quotedField(strings.Split(strings.TrimSuffix(s, "."), "."))
+		}
+		return nil, &UnexpectedSymbolError{'.', dot}
+	}
+	k := strings.ToLower(s)
+	if k == "nil" && lexer.nilKeyword {
+		return NullToken{}, nil
+	}
+	if lexer.valuePosition && isRelativeTimeWord(k) {
+		return lexer.relativeTime(k)
+	}
+	switch k {
 	case "and":
 		return AndToken{}, nil
 	case "or":
@@ -442,19 +726,108 @@ func (lexer *filteringLexer) fieldOrReserved() (Token, error) {
 		return NmatchToken{}, nil
 	case "in":
 		return InToken{}, nil
+	case "between":
+		return BetweenToken{}, nil
+	case "like":
+		return LikeToken{}, nil
+	case "ilike":
+		return ILikeToken{}, nil
+	case "contains":
+		return ContainsToken{}, nil
+	case "in_cidr":
+		return InCIDRToken{}, nil
 	case "ieq":
 		return InsensitiveEqToken{}, nil
+	case "exists":
+		return ExistsToken{}, nil
+	case "is":
+		return IsToken{}, nil
 	case "true", "false":
-		v, _ := strconv.ParseBool(s)
-		return BoolToken{Value: v}, nil
+		return BoolToken{Value: k == "true"}, nil
 	default:
+		if _, ok := lookupOperator(s); ok {
+			return CustomOperatorToken{Symbol: strings.ToLower(s)}, nil
+		}
 		return FieldToken{Value: s}, nil
 	}
 }
 
+// relativeTime reads the rest of relative time literal which starts with s, e.g. +12h following now-1d,
+// since a plus sign is not a part of a field name. Malformed literals, e.g. now-7x, are reported with InvalidRelativeTimeError.
+func (lexer *filteringLexer) relativeTime(s string) (Token, error) {
+	for lexer.curChar == '+' {
+		s += string(lexer.curChar)
+		lexer.advance()
+		for !lexer.eof && isFieldNameChar(lexer.curChar) {
+			s += string(unicode.ToLower(lexer.curChar))
+			lexer.advance()
+		}
+	}
+	if _, ok := parseRelativeTime(s); !ok {
+		return nil, &InvalidRelativeTimeError{string(lexer.text[lexer.tokenPos:lexer.pos]), lexer.tokenPos}
+	}
+	return RelativeTimeToken{Value: s}, nil
+}
+
+// isRelativeTimeWord reports whether word s is read as a relative time literal in place of a value.
+func isRelativeTimeWord(s string) bool {
+	s = strings.ToLower(s)
+	return s == "now" || strings.HasPrefix(s, "now-")
+}
+
+// quotedField reads the rest of a field path following names of path, where names are separated by dots
+// and may be quoted with backticks, e.g. `user name`.first or address.`zip code`. Quoted names are taken as is,
+// so they may contain spaces, dots, operators and reserved words, a backtick is escaped by duplication.
+func (lexer *filteringLexer) quotedField(path []string) (Token, error) {
+	for {
+		start := lexer.pos
+		var name []rune
+		if lexer.curChar == '`' {
+			lexer.advance()
+			for {
+				if lexer.eof {
+					return nil, &UnterminatedFieldError{start}
+				}
+				if lexer.curChar == '`' {
+					lexer.advance()
+					// backtick is escaped by duplication
+					if lexer.curChar != '`' {
+						break
+					}
+				}
+				name = append(name, lexer.curChar)
+				lexer.advance()
+			}
+			if len(name) == 0 {
+				return nil, &UnexpectedSymbolError{'`', start}
+			}
+		} else {
+			for !lexer.eof && isFieldNameChar(lexer.curChar) {
+				name = append(name, lexer.curChar)
+				lexer.advance()
+			}
+			if len(name) == 0 {
+				return nil, &UnexpectedSymbolError{lexer.curChar, lexer.pos}
+			}
+		}
+		path = append(path, string(name))
+		if lexer.curChar != '.' {
+			break
+		}
+		lexer.advance()
+	}
+	return FieldToken{Value: strings.Join(path, "."), Path: path}, nil
+}
+
+// isFieldNameChar reports whether c may be a part of an unquoted field name.
+func isFieldNameChar(c rune) bool {
+	return unicode.IsDigit(c) || unicode.IsLetter(c) || c == '-' || c == '_'
+}
+
 // NextToken returns the next token from the expression.
 func (lexer *filteringLexer) NextToken() (Token, error) {
 	for !lexer.eof {
+		lexer.tokenPos = lexer.pos
 		switch {
 		case unicode.IsSpace(lexer.curChar):
 			lexer.advance()
@@ -496,6 +869,10 @@ func (lexer *filteringLexer) NextToken() (Token, error) {
 			lexer.advance()
 			if lexer.curChar == '=' {
 				lexer.advance()
+				if lexer.curChar == '>' {
+					lexer.advance()
+					return NullSafeEqToken{}, nil
+				}
 				return LeToken{}, nil
 			}
 			return LtToken{}, nil
@@ -510,13 +887,16 @@ func (lexer *filteringLexer) NextToken() (Token, error) {
 			return lexer.string()
 		case lexer.curChar == '[':
 			return lexer.array()
-		case unicode.IsDigit(lexer.curChar):
+		case lexer.isNumberStart():
 			return lexer.number()
 		case unicode.IsLetter(lexer.curChar):
 			return lexer.fieldOrReserved()
+		case lexer.curChar == '`':
+			return lexer.quotedField(nil)
 		default:
 			return nil, &UnexpectedSymbolError{lexer.curChar, lexer.pos}
 		}
 	}
+	lexer.tokenPos = lexer.pos
 	return EOFToken{}, nil
 }
@@ -7,7 +7,7 @@ import (
 )
 
 func TestFilteringLexer(t *testing.T) {
-	lexer := NewFilteringLexer(`()14 13.23 'abc'"bcd" field1 and or  not == eq ne != match ~ nomatch !~ gt > ge >= lt < le <= null := ieq [1,5, 6] ['Hello','World'] in true false'''""' """''"`)
+	lexer := NewFilteringLexer(`()14 13.23 'abc'"bcd" field1 and or  not == eq ne != match ~ nomatch !~ gt > ge >= lt < le <= <=> null := ieq [1,5, 6] ['Hello','World'] in between like ILIKE in_cidr is true false'''""' """''"`)
 	tests := []Token{
 		LparenToken{},
 		RparenToken{},
@@ -35,12 +35,18 @@ func TestFilteringLexer(t *testing.T) {
 		LtToken{},
 		LeToken{},
 		LeToken{},
+		NullSafeEqToken{},
 		NullToken{},
 		InsensitiveEqToken{},
 		InsensitiveEqToken{},
 		NumberArrayToken{Values: []float64{1, 5, 6}},
 		StringArrayToken{Values: []string{"Hello", "World"}},
 		InToken{},
+		BetweenToken{},
+		LikeToken{},
+		ILikeToken{},
+		InCIDRToken{},
+		IsToken{},
 		BoolToken{Value: true},
 		BoolToken{Value: false},
 		// duplicate terminator to escape
@@ -61,7 +67,6 @@ func TestFilteringLexerNegative(t *testing.T) {
 		"=!",
 		"!!",
 		"%",
-		"'string",
 		"[]",
 		"['Hello', 1, 2]",
 		"[1, 2",
@@ -76,3 +81,178 @@ func TestFilteringLexerNegative(t *testing.T) {
 	}
 
 }
+
+func TestFilteringLexerEscapes(t *testing.T) {
+	tests := []struct {
+		text  string
+		value string
+	}{
+		{`'O\'Brien'`, `O'Brien`},
+		{`"say \"hi\""`, `say "hi"`},
+		{`'back\\slash'`, `back\slash`},
+		{`'line\nbreak\ttab\rreturn'`, "line\nbreak\ttab\rreturn"},
+		{`'a, b'`, `a, b`},
+		{`'\u00e9t\u00C9 \u65e5'`, "\u00e9t\u00c9 \u65e5"},
+		{`'café'`, "caf\u00e9"},
+		{`'\d+\.\w'`, `\d+\.\w`},
+		{`'it''s'`, `it's`},
+	}
+
+	for _, test := range tests {
+		lexer := NewFilteringLexer(test.text)
+		token, err := lexer.NextToken()
+		assert.Nil(t, err, test.text)
+		assert.Equal(t, StringToken{Value: test.value}, token, test.text)
+		token, err = lexer.NextToken()
+		assert.Nil(t, err, test.text)
+		assert.Equal(t, EOFToken{}, token, test.text)
+	}
+
+	lexer := NewFilteringLexer(`['O\'Brien', "a\"b"]`)
+	token, err := lexer.NextToken()
+	assert.Nil(t, err)
+	assert.Equal(t, StringArrayToken{Values: []string{`O'Brien`, `a"b`}}, token)
+}
+
+func TestFilteringLexerUnterminatedString(t *testing.T) {
+	tests := []struct {
+		text string
+		pos  int
+	}{
+		{`'string`, 0},
+		{`  "string`, 2},
+		{`'string\'`, 0},
+		{`'string\`, 0},
+		{`'\u12`, 0},
+	}
+
+	for _, test := range tests {
+		lexer := NewFilteringLexer(test.text)
+		token, err := lexer.NextToken()
+		assert.Nil(t, token)
+		assert.Equal(t, &UnterminatedStringError{Pos: test.pos}, err, test.text)
+	}
+}
+
+func TestFilteringLexerQuotedFields(t *testing.T) {
+	tests := []struct {
+		text string
+		path []string
+	}{
+		{"`user name`", []string{"user name"}},
+		{"`and`", []string{"and"}},
+		{"`a.b`", []string{"a.b"}},
+		{"`it``s`", []string{"it`s"}},
+		{"address.`zip code`.first", []string{"address", "zip code", "first"}},
+		{"`user name`.`==`.x-1", []string{"user name", "==", "x-1"}},
+	}
+
+	for _, test := range tests {
+		lexer := NewFilteringLexer(test.text + " == 1")
+		token, err := lexer.NextToken()
+		assert.Nil(t, err, test.text)
+		assert.Equal(t, test.path, token.(FieldToken).fieldPath(), test.text)
+		token, err = lexer.NextToken()
+		assert.Nil(t, err, test.text)
+		assert.Equal(t, EqToken{}, token, test.text)
+	}
+
+	for _, text := range []string{"``", "`a`.", "`a`.'b'", "a.``", "a.", "a. ", "a.<0", "a..b", "a.b..`c`", "`a`..b"} {
+		_, err := NewFilteringLexer(text).NextToken()
+		assert.IsType(t, &UnexpectedSymbolError{}, err, text)
+	}
+	for _, text := range []string{"`user name", "a.`b", "`a``"} {
+		_, err := NewFilteringLexer(text).NextToken()
+		assert.IsType(t, &UnterminatedFieldError{}, err, text)
+	}
+}
+
+func TestFilteringLexerNumbers(t *testing.T) {
+	lexer := NewFilteringLexer(`-12.5 1e3 1.5E-2 2e+2 -1e18 [-1, 2.5e1] 1e a-1 3-2`)
+	tests := []Token{
+		NumberToken{Value: -12.5},
+		NumberToken{Value: 1000},
+		NumberToken{Value: 0.015},
+		NumberToken{Value: 200},
+		NumberToken{Value: -1e18},
+		NumberArrayToken{Values: []float64{-1, 25}},
+		// exponent without digits is not a part of the number
+		NumberToken{Value: 1},
+		FieldToken{Value: "e"},
+		FieldToken{Value: "a-1"},
+		NumberToken{Value: 3},
+		NumberToken{Value: -2},
+		EOFToken{},
+	}
+
+	for _, test := range tests {
+		token, err := lexer.NextToken()
+		assert.Equal(t, test, token)
+		assert.Nil(t, err)
+	}
+
+	_, err := NewFilteringLexer("-a").NextToken()
+	assert.IsType(t, &UnexpectedSymbolError{}, err)
+}
+
+func TestFilteringLexerDigitSeparators(t *testing.T) {
+	lexer := NewFilteringLexer(`1_000_000 -1_0.2_5 1e1_0 [1_000, 2]`)
+	tests := []Token{
+		NumberToken{Value: 1000000},
+		NumberToken{Value: -10.25},
+		NumberToken{Value: 1e10},
+		NumberArrayToken{Values: []float64{1000, 2}},
+		EOFToken{},
+	}
+	for _, test := range tests {
+		token, err := lexer.NextToken()
+		assert.Equal(t, test, token)
+		assert.Nil(t, err)
+	}
+
+	for _, text := range []string{"1__0", "1_", "1_.5", "1._5", "1_e5", "1e5_", "-1_ "} {
+		_, err := NewFilteringLexer(text).NextToken()
+		assert.IsType(t, &UnexpectedSymbolError{}, err, text)
+	}
+}
+
+func TestFilteringLexerRelativeTime(t *testing.T) {
+	tests := []struct {
+		text  string
+		token Token
+	}{
+		{"now", RelativeTimeToken{Value: "now"}},
+		{"NOW-7d", RelativeTimeToken{Value: "now-7d"}},
+		{"now+1h", RelativeTimeToken{Value: "now+1h"}},
+		{"now-1d+12h-30m", RelativeTimeToken{Value: "now-1d+12h-30m"}},
+		// words that are not relative time literals are fields
+		{"nowhere", FieldToken{Value: "nowhere"}},
+		{"now.x", FieldToken{Value: "now.x"}},
+		{"`now`", FieldToken{Value: "now", Path: []string{"now"}}},
+	}
+	for _, test := range tests {
+		lexer := newFilteringLexer(test.text)
+		lexer.valuePosition = true
+		token, err := lexer.NextToken()
+		assert.Nil(t, err, test.text)
+		assert.Equal(t, test.token, token, test.text)
+		token, err = lexer.NextToken()
+		assert.Nil(t, err, test.text)
+		assert.Equal(t, EOFToken{}, token, test.text)
+	}
+
+	// now is a field out of value position
+	lexer := NewFilteringLexer("now now-7d")
+	for _, expected := range []Token{FieldToken{Value: "now"}, FieldToken{Value: "now-7d"}, EOFToken{}} {
+		token, err := lexer.NextToken()
+		assert.Nil(t, err)
+		assert.Equal(t, expected, token)
+	}
+
+	for _, text := range []string{"now-", "now-ish", "now-7x", "now-1y", "now+", "now+1", "now+1y", "now-1d+x", "now+99999999999999999999s"} {
+		lexer := newFilteringLexer(text + " ")
+		lexer.valuePosition = true
+		_, err := lexer.NextToken()
+		assert.Equal(t, &InvalidRelativeTimeError{Value: text, Pos: 0}, err, text)
+	}
+}
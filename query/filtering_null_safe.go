@@ -0,0 +1,33 @@
+package query
+
+// filterNullSafe evaluates null-safe equality on fieldPath of obj, where node is the condition
+// without null-safe flag and negation and neg is the negation of the original condition.
+// Null is equal to null only and is not equal to any other literal, so that the condition
+// is never a type mismatch for null fields and its negation is true for them.
+// Fields that cannot hold null, e.g. plain strings, are never null.
+func filterNullSafe(node interface{}, obj interface{}, fieldPath []string, neg bool, o *filterOptions) (bool, error) {
+	null, err := isNullField(obj, fieldPath, o)
+	if err != nil {
+		return false, err
+	}
+	if _, ok := node.(*NullCondition); ok || null {
+		return negateIfNeeded(neg, ok && null), nil
+	}
+	res, err := filterNode(node, obj, o)
+	if err != nil {
+		return false, err
+	}
+	return negateIfNeeded(neg, res), nil
+}
+
+// isNullField reports whether the value of fieldPath of obj is either absent or null,
+// e.g. nil pointer, unset wrapper or JSON null of google.protobuf.Value.
+func isNullField(obj interface{}, fieldPath []string, o *filterOptions) (bool, error) {
+	exists, err := (&ExistsCondition{FieldPath: fieldPath}).filter(obj, o)
+	if err != nil || !exists {
+		return !exists, err
+	}
+	// types that cannot be null are mismatched by null condition
+	null, err := (&NullCondition{FieldPath: fieldPath}).filter(obj, o)
+	return err == nil && null, nil
+}
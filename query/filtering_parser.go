@@ -2,7 +2,8 @@ package query
 
 import (
 	"fmt"
-	"strings"
+	"net"
+	"time"
 )
 
 // ParseFiltering is a shortcut to parse a filtering expression using default FilteringParser implementation
@@ -10,14 +11,132 @@ func ParseFiltering(text string) (*Filtering, error) {
 	return (&filteringParser{}).Parse(text)
 }
 
+// ParseFilteringWithLimits is the same as ParseFiltering but restricts complexity
+// of the filtering expression with limits instead of DefaultFilteringLimits.
+func ParseFilteringWithLimits(text string, limits FilteringLimits) (*Filtering, error) {
+	return (&filteringParser{limits: limits}).Parse(text)
+}
+
+// FilteringLimits restricts complexity of filtering expressions accepted by a parser,
+// so that a client cannot exhaust server resources with a huge expression.
+// Zero fields default to the corresponding fields of DefaultFilteringLimits.
+type FilteringLimits struct {
+	// MaxDepth is a maximum nesting depth of parentheses.
+	MaxDepth int
+	// MaxNodes is a maximum number of conditions and logical operators.
+	MaxNodes int
+}
+
+// DefaultFilteringLimits are used by default FilteringParser implementation if no limits are set.
+var DefaultFilteringLimits = FilteringLimits{MaxDepth: 64, MaxNodes: 1024}
+
+// withDefaults returns a copy of l with zero limits set to the default ones.
+func (l FilteringLimits) withDefaults() FilteringLimits {
+	if l.MaxDepth == 0 {
+		l.MaxDepth = DefaultFilteringLimits.MaxDepth
+	}
+	if l.MaxNodes == 0 {
+		l.MaxNodes = DefaultFilteringLimits.MaxNodes
+	}
+	return l
+}
+
+// Check returns FilteringLimitError if filtering expression f built other than by parsing, e.g. by Cursor.Filtering,
+// exceeds l. Nodes are counted as by a parser and the depth is a nesting depth of logical operators which
+// would be enclosed in parentheses, i.e. negated ones and operands of a logical operator of another type.
+func (l FilteringLimits) Check(f *Filtering) error {
+	return Walk(f, &limitsVisitor{limits: l.withDefaults()})
+}
+
+// limitsVisitor counts nodes and the depth of logical operators, depths holds the depth of each
+// of the logical operators being visited.
+type limitsVisitor struct {
+	limits FilteringLimits
+	nodes  int
+	depths []int
+	lops   []*LogicalOperator
+}
+
+func (v *limitsVisitor) Enter(node interface{}) error {
+	if v.nodes++; v.nodes > v.limits.MaxNodes {
+		return &FilteringLimitError{"number of nodes", v.limits.MaxNodes}
+	}
+	lop, ok := node.(*LogicalOperator)
+	if !ok {
+		return nil
+	}
+	depth := 0
+	n := len(v.lops)
+	if n > 0 {
+		depth = v.depths[n-1]
+	}
+	if lop.IsNegative || n > 0 && v.lops[n-1].Type != lop.Type {
+		depth++
+	}
+	if depth > v.limits.MaxDepth {
+		return &FilteringLimitError{"depth", v.limits.MaxDepth}
+	}
+	v.depths = append(v.depths, depth)
+	v.lops = append(v.lops, lop)
+	return nil
+}
+
+func (v *limitsVisitor) Leave(node interface{}) error {
+	if _, ok := node.(*LogicalOperator); ok {
+		v.depths = v.depths[:len(v.depths)-1]
+		v.lops = v.lops[:len(v.lops)-1]
+	}
+	return nil
+}
+
+// FilteringLimitError describes a filtering expression that exceeds the Max value of Limit.
+type FilteringLimitError struct {
+	Limit string
+	Max   int
+}
+
+func (e *FilteringLimitError) Error() string {
+	return fmt.Sprintf("Filtering expression exceeds maximum %s of %d", e.Limit, e.Max)
+}
+
 // FilteringParser is implemented by parsers of a filtering expression that conforms to REST API Syntax Specification.
 type FilteringParser interface {
 	Parse(string) (*Filtering, error)
 }
 
-// NewFilteringParser returns a default FilteringParser implementation.
-func NewFilteringParser() FilteringParser {
-	return &filteringParser{}
+// FilteringParserOption is a type of function that alters the default FilteringParser implementation.
+type FilteringParserOption func(*filteringParser)
+
+// WithNilKeyword makes the parser accept nil keyword as a synonym for null, e.g. "name != nil",
+// so a field named nil cannot be referred to. It is case-insensitive as other keywords are.
+func WithNilKeyword() FilteringParserOption {
+	return func(p *filteringParser) {
+		p.nilKeyword = true
+	}
+}
+
+// WithSizeSuffixes makes the parser multiply numbers by size suffixes that immediately follow them,
+// e.g. "size > 5kb" is the same as "size > 5000". Suffixes are case-insensitive, decimal ones are
+// kb, mb, gb and tb, binary ones are kib, mib, gib and tib, e.g. 1kib is 1024.
+func WithSizeSuffixes() FilteringParserOption {
+	return func(p *filteringParser) {
+		p.sizeSuffixes = true
+	}
+}
+
+// NewFilteringParser returns a default FilteringParser implementation altered by opts.
+func NewFilteringParser(opts ...FilteringParserOption) FilteringParser {
+	p := &filteringParser{}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// NewFilteringParserWithLimits returns a default FilteringParser implementation
+// that restricts complexity of filtering expressions with limits.
+func NewFilteringParserWithLimits(limits FilteringLimits) FilteringParser {
+	return &filteringParser{limits: limits}
 }
 
 // UnexpectedTokenError describes a token that was not appropriate according to REST API Syntax Specification.
@@ -29,20 +148,118 @@ func (e *UnexpectedTokenError) Error() string {
 	return fmt.Sprintf("Unexpected token %s", e.T)
 }
 
+// ParseError describes a syntax error in a filtering expression.
+// Pos is a zero-based position of the offending Token in the expression and Msg explains the error.
+// Err is the underlying UnexpectedTokenError, UnexpectedSymbolError or UnterminatedStringError.
+type ParseError struct {
+	Pos   int
+	Token string
+	Msg   string
+	Err   error
+}
+
+func (e *ParseError) Error() string {
+	if e.Token == "" {
+		return fmt.Sprintf("invalid filter at position %d: %s", e.Pos, e.Msg)
+	}
+	return fmt.Sprintf("invalid filter at position %d: %s %q", e.Pos, e.Msg, e.Token)
+}
+
+// Unwrap returns the underlying error.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ReversedRangeError describes a range which lower bound is greater than the upper one.
+type ReversedRangeError struct {
+	Low, High Token
+}
+
+func (e *ReversedRangeError) Error() string {
+	return fmt.Sprintf("Lower bound %s is greater than upper bound %s", e.Low, e.High)
+}
+
 // parser implements recursive descent parser of a filtering expression that conforms to REST API Syntax Specification.
 // Some insights into recursive descent: https://en.wikipedia.org/wiki/Recursive_descent_parser .
 type filteringParser struct {
-	lexer    FilteringLexer
+	lexer    *filteringLexer
 	curToken Token
+	limits   FilteringLimits
+	depth    int
+	nodes    int
+	// nilKeyword is set by WithNilKeyword.
+	nilKeyword bool
+	// sizeSuffixes is set by WithSizeSuffixes.
+	sizeSuffixes bool
 }
 
 // Parse builds an AST from an expression in text according to the following grammar:
 // expr      : term (OR term)*
 // term      : factor (AND factor)*
 // factor    : ?NOT (LPAREN expr RPAREN | condition)
-// condition : FIELD ((== | !=) (STRING | NUMBER | NULL | BOOL) | (~ | !~) STRING | (> | >= | < | <=) (NUMBER | STRING).
+// condition : FIELD ((== | != | <=>) (STRING | NUMBER | NULL | BOOL) | (== | != | > | >= | < | <=) FIELD | (~ | !~) STRING | (> | >= | < | <=) (NUMBER | STRING | BOOL) | (== | != | > | >= | < | <=) RELATIVE_TIME | ?NOT IN (STRING_ARRAY | NUMBER_ARRAY) | ?NOT BETWEEN (NUMBER AND NUMBER | (STRING | RELATIVE_TIME) AND (STRING | RELATIVE_TIME)) | ?NOT (LIKE | ILIKE) STRING | ?NOT CONTAINS STRING | ?NOT IN_CIDR STRING | IS ?NOT NULL).
+// Hence NOT binds tighter than AND, AND binds tighter than OR, operators of the same precedence
+// are left-associative and parentheses override precedence, e.g. "a == 1 or b == 2 and c == 3"
+// is the same as "a == 1 or (b == 2 and c == 3)". NOT negates the whole condition following it,
+// e.g. "not a == b and c == d" is the same as "(not (a == b)) and c == d".
+// RELATIVE_TIME is now optionally followed by signed offsets with units s, m, h, d and w, e.g. now-7d or now-1d+12h,
+// it is resolved when the expression is evaluated. It is read only in place of a value, i.e. after a comparison operator
+// or as a bound of BETWEEN, where a field named now has to be quoted as `now`, and now followed by a malformed offset is an error.
+// Syntax errors are reported with ParseError.
 func (p *filteringParser) Parse(text string) (*Filtering, error) {
-	p.lexer = NewFilteringLexer(text)
+	f, err := p.parse(text)
+	if err != nil {
+		return nil, p.parseError(err)
+	}
+	return f, nil
+}
+
+// parseError wraps a syntax error into ParseError with position of the offending token,
+// other errors are returned as is.
+func (p *filteringParser) parseError(err error) error {
+	switch e := err.(type) {
+	case *UnexpectedTokenError:
+		if _, ok := e.T.(EOFToken); ok {
+			return &ParseError{Pos: p.lexer.tokenPos, Msg: "unexpected end of expression", Err: err}
+		}
+		return &ParseError{Pos: p.lexer.tokenPos, Token: fmt.Sprint(e.T), Msg: unexpectedTokenMsg(e.T), Err: err}
+	case *UnexpectedSymbolError:
+		if e.Pos >= len(p.lexer.text) {
+			return &ParseError{Pos: e.Pos, Msg: "unexpected end of expression", Err: err}
+		}
+		return &ParseError{Pos: e.Pos, Token: string(e.S), Msg: "unexpected symbol", Err: err}
+	case *UnterminatedStringError:
+		return &ParseError{Pos: e.Pos, Token: string(p.lexer.text[e.Pos:]), Msg: "unterminated string literal", Err: err}
+	case *UnterminatedFieldError:
+		return &ParseError{Pos: e.Pos, Token: string(p.lexer.text[e.Pos:]), Msg: "unterminated quoted field name", Err: err}
+	case *InvalidRelativeTimeError:
+		return &ParseError{Pos: e.Pos, Token: e.Value, Msg: "invalid relative time literal", Err: err}
+	default:
+		return err
+	}
+}
+
+func unexpectedTokenMsg(t Token) string {
+	switch t.(type) {
+	case EqToken, NeToken, NullSafeEqToken, MatchToken, NmatchToken, InsensitiveEqToken, GtToken, GeToken, LtToken, LeToken, InToken, BetweenToken, LikeToken, ILikeToken, ContainsToken, InCIDRToken, CustomOperatorToken, ExistsToken, IsToken:
+		return "unexpected operator"
+	case AndToken, OrToken, NotToken:
+		return "unexpected logical operator"
+	case LparenToken, RparenToken:
+		return "unexpected parenthesis"
+	case FieldToken:
+		return "unexpected field"
+	default:
+		return "unexpected value"
+	}
+}
+
+func (p *filteringParser) parse(text string) (*Filtering, error) {
+	p.lexer = newFilteringLexer(text)
+	p.lexer.nilKeyword = p.nilKeyword
+	p.lexer.sizeSuffixes = p.sizeSuffixes
+	p.limits = p.limits.withDefaults()
+	p.depth, p.nodes = 0, 0
 	token, err := p.lexer.NextToken()
 	if err != nil {
 		return nil, err
@@ -85,11 +302,32 @@ func (p *filteringParser) negateNode(node FilteringExpression) {
 		v.IsNegative = !v.IsNegative
 	case *BoolCondition:
 		v.IsNegative = !v.IsNegative
+	case *CustomCondition:
+		v.IsNegative = !v.IsNegative
+	case *ExistsCondition:
+		v.IsNegative = !v.IsNegative
+	case *FieldCondition:
+		v.IsNegative = !v.IsNegative
+	case *ContainsCondition:
+		v.IsNegative = !v.IsNegative
 	}
 }
 
+// addNodes accounts n nodes of the expression being built and checks the limit.
+func (p *filteringParser) addNodes(n int) error {
+	p.nodes += n
+	if p.nodes > p.limits.MaxNodes {
+		return &FilteringLimitError{"number of nodes", p.limits.MaxNodes}
+	}
+	return nil
+}
+
 func (p *filteringParser) eatToken() error {
+	if isValueOperator(p.curToken) {
+		p.lexer.valuePosition = true
+	}
 	token, err := p.lexer.NextToken()
+	p.lexer.valuePosition = false
 	if err != nil {
 		return err
 	}
@@ -97,11 +335,22 @@ func (p *filteringParser) eatToken() error {
 	return nil
 }
 
+// isValueOperator reports whether t is an operator followed by a value that may be a relative time literal.
+func isValueOperator(t Token) bool {
+	switch t.(type) {
+	case EqToken, NeToken, GtToken, GeToken, LtToken, LeToken, BetweenToken:
+		return true
+	default:
+		return false
+	}
+}
+
 func (p *filteringParser) expr() (FilteringExpression, error) {
 	node, err := p.term()
 	if err != nil {
 		return nil, err
 	}
+	operands := []FilteringExpression{node}
 	_, isOr := p.curToken.(OrToken)
 	for isOr {
 		if err := p.eatToken(); err != nil {
@@ -111,19 +360,13 @@ func (p *filteringParser) expr() (FilteringExpression, error) {
 		if err != nil {
 			return nil, err
 		}
-		newNode := &LogicalOperator{Type: LogicalOperator_OR}
-		err = newNode.SetLeft(node)
-		if err != nil {
-			return nil, err
-		}
-		err = newNode.SetRight(right)
-		if err != nil {
+		if err := p.addNodes(1); err != nil {
 			return nil, err
 		}
-		node = newNode
+		operands = append(operands, right)
 		_, isOr = p.curToken.(OrToken)
 	}
-	return node, nil
+	return logicalChain(LogicalOperator_OR, operands)
 }
 
 func (p *filteringParser) term() (FilteringExpression, error) {
@@ -131,6 +374,7 @@ func (p *filteringParser) term() (FilteringExpression, error) {
 	if err != nil {
 		return nil, err
 	}
+	operands := []FilteringExpression{node}
 	_, isAnd := p.curToken.(AndToken)
 	for isAnd {
 		if err := p.eatToken(); err != nil {
@@ -140,18 +384,38 @@ func (p *filteringParser) term() (FilteringExpression, error) {
 		if err != nil {
 			return nil, err
 		}
-		newNode := &LogicalOperator{Type: LogicalOperator_AND}
-		err = newNode.SetLeft(node)
-		if err != nil {
+		if err := p.addNodes(1); err != nil {
 			return nil, err
 		}
-		err = newNode.SetRight(right)
-		if err != nil {
-			return nil, err
-		}
-		node = newNode
+		operands = append(operands, right)
 		_, isAnd = p.curToken.(AndToken)
 	}
+	return logicalChain(LogicalOperator_AND, operands)
+}
+
+// logicalChain joins operands of a chain of the same logical operators like "a or b or c" with operators of type t.
+// Operators are arranged in a balanced tree keeping the order of operands, so the depth of a long chain is logarithmic
+// and does not blow up the stack of recursive consumers of the AST.
+func logicalChain(t LogicalOperator_Type, operands []FilteringExpression) (FilteringExpression, error) {
+	if len(operands) == 1 {
+		return operands[0], nil
+	}
+	mid := (len(operands) + 1) / 2
+	left, err := logicalChain(t, operands[:mid])
+	if err != nil {
+		return nil, err
+	}
+	right, err := logicalChain(t, operands[mid:])
+	if err != nil {
+		return nil, err
+	}
+	node := &LogicalOperator{Type: t}
+	if err := node.SetLeft(left); err != nil {
+		return nil, err
+	}
+	if err := node.SetRight(right); err != nil {
+		return nil, err
+	}
 	return node, nil
 }
 
@@ -166,6 +430,9 @@ func (p *filteringParser) factor() (FilteringExpression, error) {
 	}
 	switch p.curToken.(type) {
 	case LparenToken:
+		if p.depth++; p.depth > p.limits.MaxDepth {
+			return nil, &FilteringLimitError{"depth", p.limits.MaxDepth}
+		}
 		if err := p.eatToken(); err != nil {
 			return nil, err
 		}
@@ -173,6 +440,7 @@ func (p *filteringParser) factor() (FilteringExpression, error) {
 		if err != nil {
 			return nil, err
 		}
+		p.depth--
 		switch p.curToken.(type) {
 		case RparenToken:
 			if err := p.eatToken(); err != nil {
@@ -186,6 +454,9 @@ func (p *filteringParser) factor() (FilteringExpression, error) {
 			return nil, &UnexpectedTokenError{p.curToken}
 		}
 	default:
+		if err := p.addNodes(1); err != nil {
+			return nil, err
+		}
 		node, err := p.condition()
 		if err != nil {
 			return nil, err
@@ -197,6 +468,54 @@ func (p *filteringParser) factor() (FilteringExpression, error) {
 	}
 }
 
+// fieldComparison parses comparison of field to another field token, e.g. "start < end".
+func (p *filteringParser) fieldComparison(field, token FieldToken, t FieldCondition_Type, neg bool) (FilteringExpression, error) {
+	if err := p.eatToken(); err != nil {
+		return nil, err
+	}
+	return &FieldCondition{
+		FieldPath:      field.fieldPath(),
+		ValueFieldPath: token.fieldPath(),
+		Type:           t,
+		IsNegative:     neg,
+	}, nil
+}
+
+// relativeTime parses comparison of field to a relative time literal, e.g. "created_at > now-7d".
+func (p *filteringParser) relativeTime(field FieldToken, token RelativeTimeToken, t StringCondition_Type, neg bool) (FilteringExpression, error) {
+	if err := p.eatToken(); err != nil {
+		return nil, err
+	}
+	return &StringCondition{
+		FieldPath:    field.fieldPath(),
+		Value:        token.Value,
+		Type:         t,
+		IsNegative:   neg,
+		RelativeTime: true,
+	}, nil
+}
+
+// nullSafeEquality parses the literal of null-safe equality condition on fieldPath, e.g. "field <=> 'abc'".
+func (p *filteringParser) nullSafeEquality(fieldPath []string) (FilteringExpression, error) {
+	var node FilteringExpression
+	switch token := p.curToken.(type) {
+	case StringToken:
+		node = &StringCondition{FieldPath: fieldPath, Value: token.Value, Type: StringCondition_EQ, NullSafe: true}
+	case NumberToken:
+		node = &NumberCondition{FieldPath: fieldPath, Value: token.Value, Type: NumberCondition_EQ, NullSafe: true}
+	case NullToken:
+		node = &NullCondition{FieldPath: fieldPath, NullSafe: true}
+	case BoolToken:
+		node = &BoolCondition{FieldPath: fieldPath, Value: token.Value, NullSafe: true}
+	default:
+		return nil, &UnexpectedTokenError{p.curToken}
+	}
+	if err := p.eatToken(); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
 func (p *filteringParser) condition() (FilteringExpression, error) {
 	field, ok := p.curToken.(FieldToken)
 	if !ok {
@@ -216,7 +535,7 @@ func (p *filteringParser) condition() (FilteringExpression, error) {
 				return nil, err
 			}
 			return &StringCondition{
-				FieldPath:  strings.Split(field.Value, "."),
+				FieldPath:  field.fieldPath(),
 				Value:      token.Value,
 				Type:       StringCondition_EQ,
 				IsNegative: false,
@@ -226,7 +545,7 @@ func (p *filteringParser) condition() (FilteringExpression, error) {
 				return nil, err
 			}
 			return &NumberCondition{
-				FieldPath:  strings.Split(field.Value, "."),
+				FieldPath:  field.fieldPath(),
 				Value:      token.Value,
 				Type:       NumberCondition_EQ,
 				IsNegative: false,
@@ -236,7 +555,7 @@ func (p *filteringParser) condition() (FilteringExpression, error) {
 				return nil, err
 			}
 			return &NullCondition{
-				FieldPath:  strings.Split(field.Value, "."),
+				FieldPath:  field.fieldPath(),
 				IsNegative: false,
 			}, nil
 		case BoolToken:
@@ -244,13 +563,22 @@ func (p *filteringParser) condition() (FilteringExpression, error) {
 				return nil, err
 			}
 			return &BoolCondition{
-				FieldPath:  strings.Split(field.Value, "."),
+				FieldPath:  field.fieldPath(),
 				IsNegative: false,
 				Value:      token.Value,
 			}, nil
+		case RelativeTimeToken:
+			return p.relativeTime(field, token, StringCondition_EQ, false)
+		case FieldToken:
+			return p.fieldComparison(field, token, FieldCondition_EQ, false)
 		default:
 			return nil, &UnexpectedTokenError{p.curToken}
 		}
+	case NullSafeEqToken:
+		if err := p.eatToken(); err != nil {
+			return nil, err
+		}
+		return p.nullSafeEquality(field.fieldPath())
 	case NeToken:
 		if err := p.eatToken(); err != nil {
 			return nil, err
@@ -261,7 +589,7 @@ func (p *filteringParser) condition() (FilteringExpression, error) {
 				return nil, err
 			}
 			return &StringCondition{
-				FieldPath:  strings.Split(field.Value, "."),
+				FieldPath:  field.fieldPath(),
 				Value:      token.Value,
 				Type:       StringCondition_EQ,
 				IsNegative: true,
@@ -271,7 +599,7 @@ func (p *filteringParser) condition() (FilteringExpression, error) {
 				return nil, err
 			}
 			return &NumberCondition{
-				FieldPath:  strings.Split(field.Value, "."),
+				FieldPath:  field.fieldPath(),
 				Value:      token.Value,
 				Type:       NumberCondition_EQ,
 				IsNegative: true,
@@ -281,7 +609,7 @@ func (p *filteringParser) condition() (FilteringExpression, error) {
 				return nil, err
 			}
 			return &NullCondition{
-				FieldPath:  strings.Split(field.Value, "."),
+				FieldPath:  field.fieldPath(),
 				IsNegative: true,
 			}, nil
 		case BoolToken:
@@ -289,10 +617,14 @@ func (p *filteringParser) condition() (FilteringExpression, error) {
 				return nil, err
 			}
 			return &BoolCondition{
-				FieldPath:  strings.Split(field.Value, "."),
+				FieldPath:  field.fieldPath(),
 				IsNegative: true,
 				Value:      token.Value,
 			}, nil
+		case RelativeTimeToken:
+			return p.relativeTime(field, token, StringCondition_EQ, true)
+		case FieldToken:
+			return p.fieldComparison(field, token, FieldCondition_EQ, true)
 		default:
 			return nil, &UnexpectedTokenError{p.curToken}
 		}
@@ -306,7 +638,7 @@ func (p *filteringParser) condition() (FilteringExpression, error) {
 				return nil, err
 			}
 			return &StringCondition{
-				FieldPath:  strings.Split(field.Value, "."),
+				FieldPath:  field.fieldPath(),
 				Value:      token.Value,
 				Type:       StringCondition_MATCH,
 				IsNegative: false,
@@ -324,7 +656,7 @@ func (p *filteringParser) condition() (FilteringExpression, error) {
 				return nil, err
 			}
 			return &StringCondition{
-				FieldPath:  strings.Split(field.Value, "."),
+				FieldPath:  field.fieldPath(),
 				Value:      token.Value,
 				Type:       StringCondition_MATCH,
 				IsNegative: true,
@@ -342,7 +674,7 @@ func (p *filteringParser) condition() (FilteringExpression, error) {
 				return nil, err
 			}
 			return &StringCondition{
-				FieldPath:  strings.Split(field.Value, "."),
+				FieldPath:  field.fieldPath(),
 				Value:      token.Value,
 				Type:       StringCondition_IEQ,
 				IsNegative: false,
@@ -360,7 +692,7 @@ func (p *filteringParser) condition() (FilteringExpression, error) {
 				return nil, err
 			}
 			return &NumberCondition{
-				FieldPath:  strings.Split(field.Value, "."),
+				FieldPath:  field.fieldPath(),
 				Value:      token.Value,
 				Type:       NumberCondition_GT,
 				IsNegative: false,
@@ -370,11 +702,25 @@ func (p *filteringParser) condition() (FilteringExpression, error) {
 				return nil, err
 			}
 			return &StringCondition{
-				FieldPath:  strings.Split(field.Value, "."),
+				FieldPath:  field.fieldPath(),
 				Value:      token.Value,
 				Type:       StringCondition_GT,
 				IsNegative: false,
 			}, nil
+		case BoolToken:
+			if err := p.eatToken(); err != nil {
+				return nil, err
+			}
+			return &BoolCondition{
+				FieldPath:  field.fieldPath(),
+				Value:      token.Value,
+				Type:       BoolCondition_GT,
+				IsNegative: false,
+			}, nil
+		case RelativeTimeToken:
+			return p.relativeTime(field, token, StringCondition_GT, false)
+		case FieldToken:
+			return p.fieldComparison(field, token, FieldCondition_GT, false)
 		default:
 			return nil, &UnexpectedTokenError{p.curToken}
 		}
@@ -388,7 +734,7 @@ func (p *filteringParser) condition() (FilteringExpression, error) {
 				return nil, err
 			}
 			return &NumberCondition{
-				FieldPath:  strings.Split(field.Value, "."),
+				FieldPath:  field.fieldPath(),
 				Value:      token.Value,
 				Type:       NumberCondition_GE,
 				IsNegative: false,
@@ -398,11 +744,25 @@ func (p *filteringParser) condition() (FilteringExpression, error) {
 				return nil, err
 			}
 			return &StringCondition{
-				FieldPath:  strings.Split(field.Value, "."),
+				FieldPath:  field.fieldPath(),
 				Value:      token.Value,
 				Type:       StringCondition_GE,
 				IsNegative: false,
 			}, nil
+		case BoolToken:
+			if err := p.eatToken(); err != nil {
+				return nil, err
+			}
+			return &BoolCondition{
+				FieldPath:  field.fieldPath(),
+				Value:      token.Value,
+				Type:       BoolCondition_GE,
+				IsNegative: false,
+			}, nil
+		case RelativeTimeToken:
+			return p.relativeTime(field, token, StringCondition_GE, false)
+		case FieldToken:
+			return p.fieldComparison(field, token, FieldCondition_GE, false)
 		default:
 			return nil, &UnexpectedTokenError{p.curToken}
 		}
@@ -416,7 +776,7 @@ func (p *filteringParser) condition() (FilteringExpression, error) {
 				return nil, err
 			}
 			return &NumberCondition{
-				FieldPath:  strings.Split(field.Value, "."),
+				FieldPath:  field.fieldPath(),
 				Value:      token.Value,
 				Type:       NumberCondition_LT,
 				IsNegative: false,
@@ -426,11 +786,25 @@ func (p *filteringParser) condition() (FilteringExpression, error) {
 				return nil, err
 			}
 			return &StringCondition{
-				FieldPath:  strings.Split(field.Value, "."),
+				FieldPath:  field.fieldPath(),
 				Value:      token.Value,
 				Type:       StringCondition_LT,
 				IsNegative: false,
 			}, nil
+		case BoolToken:
+			if err := p.eatToken(); err != nil {
+				return nil, err
+			}
+			return &BoolCondition{
+				FieldPath:  field.fieldPath(),
+				Value:      token.Value,
+				Type:       BoolCondition_LT,
+				IsNegative: false,
+			}, nil
+		case RelativeTimeToken:
+			return p.relativeTime(field, token, StringCondition_LT, false)
+		case FieldToken:
+			return p.fieldComparison(field, token, FieldCondition_LT, false)
 		default:
 			return nil, &UnexpectedTokenError{p.curToken}
 		}
@@ -444,7 +818,7 @@ func (p *filteringParser) condition() (FilteringExpression, error) {
 				return nil, err
 			}
 			return &NumberCondition{
-				FieldPath:  strings.Split(field.Value, "."),
+				FieldPath:  field.fieldPath(),
 				Value:      token.Value,
 				Type:       NumberCondition_LE,
 				IsNegative: false,
@@ -454,48 +828,336 @@ func (p *filteringParser) condition() (FilteringExpression, error) {
 				return nil, err
 			}
 			return &StringCondition{
-				FieldPath:  strings.Split(field.Value, "."),
+				FieldPath:  field.fieldPath(),
 				Value:      token.Value,
 				Type:       StringCondition_LE,
 				IsNegative: false,
 			}, nil
+		case BoolToken:
+			if err := p.eatToken(); err != nil {
+				return nil, err
+			}
+			return &BoolCondition{
+				FieldPath:  field.fieldPath(),
+				Value:      token.Value,
+				Type:       BoolCondition_LE,
+				IsNegative: false,
+			}, nil
+		case RelativeTimeToken:
+			return p.relativeTime(field, token, StringCondition_LE, false)
+		case FieldToken:
+			return p.fieldComparison(field, token, FieldCondition_LE, false)
 		default:
 			return nil, &UnexpectedTokenError{p.curToken}
 		}
+	case NotToken:
+		if err := p.eatToken(); err != nil {
+			return nil, err
+		}
+		var node FilteringExpression
+		var err error
+		switch p.curToken.(type) {
+		case InToken:
+			node, err = p.in(field)
+		case BetweenToken:
+			node, err = p.between(field)
+		case LikeToken, ILikeToken:
+			node, err = p.like(field)
+		case ContainsToken:
+			node, err = p.contains(field)
+		case InCIDRToken:
+			node, err = p.inCIDR(field)
+		case CustomOperatorToken:
+			node, err = p.custom(field)
+		case ExistsToken:
+			node, err = p.exists(field)
+		default:
+			return nil, &UnexpectedTokenError{p.curToken}
+		}
+		if err != nil {
+			return nil, err
+		}
+		p.negateNode(node)
+		return node, nil
 	case InToken:
+		return p.in(field)
+	case BetweenToken:
+		return p.between(field)
+	case LikeToken, ILikeToken:
+		return p.like(field)
+	case ContainsToken:
+		return p.contains(field)
+	case InCIDRToken:
+		return p.inCIDR(field)
+	case CustomOperatorToken:
+		return p.custom(field)
+	case ExistsToken:
+		return p.exists(field)
+	case IsToken:
+		return p.isNull(field)
+	default:
+		return nil, &UnexpectedTokenError{p.curToken}
+	}
+}
+
+func (p *filteringParser) exists(field FieldToken) (FilteringExpression, error) {
+	if err := p.eatToken(); err != nil {
+		return nil, err
+	}
+	return &ExistsCondition{
+		FieldPath:  field.fieldPath(),
+		IsNegative: false,
+	}, nil
+}
+
+// isNull parses "is null" and "is not null" checks that are the same as "== null" and "!= null".
+func (p *filteringParser) isNull(field FieldToken) (FilteringExpression, error) {
+	if err := p.eatToken(); err != nil {
+		return nil, err
+	}
+	_, neg := p.curToken.(NotToken)
+	if neg {
 		if err := p.eatToken(); err != nil {
 			return nil, err
 		}
+	}
+	if _, ok := p.curToken.(NullToken); !ok {
+		return nil, &UnexpectedTokenError{p.curToken}
+	}
+	if err := p.eatToken(); err != nil {
+		return nil, err
+	}
+	return &NullCondition{
+		FieldPath:  field.fieldPath(),
+		IsNegative: neg,
+	}, nil
+}
 
-		switch token := p.curToken.(type) {
-		case StringArrayToken:
-			if err := p.eatToken(); err != nil {
-				return nil, err
-			}
+// custom parses a condition with an operator registered with RegisterOperator.
+func (p *filteringParser) custom(field FieldToken) (FilteringExpression, error) {
+	c := &CustomCondition{
+		FieldPath: field.fieldPath(),
+		Operator:  p.curToken.(CustomOperatorToken).Symbol,
+	}
+	if err := p.eatToken(); err != nil {
+		return nil, err
+	}
+	switch token := p.curToken.(type) {
+	case StringToken:
+		c.Value = &CustomCondition_StringValue{token.Value}
+	case NumberToken:
+		c.Value = &CustomCondition_NumberValue{token.Value}
+	case BoolToken:
+		c.Value = &CustomCondition_BoolValue{token.Value}
+	case StringArrayToken:
+		c.Value = &CustomCondition_StringArrayValue{&CustomCondition_StringArray{Values: token.Values}}
+	case NumberArrayToken:
+		c.Value = &CustomCondition_NumberArrayValue{&CustomCondition_NumberArray{Values: token.Values}}
+	default:
+		return nil, &UnexpectedTokenError{p.curToken}
+	}
+	if err := p.eatToken(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
 
-			return &StringArrayCondition{
-				FieldPath:  strings.Split(field.Value, "."),
-				Values:     token.Values,
-				Type:       StringArrayCondition_IN,
-				IsNegative: false,
-			}, nil
+func (p *filteringParser) like(field FieldToken) (FilteringExpression, error) {
+	t := StringCondition_LIKE
+	if _, ok := p.curToken.(ILikeToken); ok {
+		t = StringCondition_ILIKE
+	}
+	if err := p.eatToken(); err != nil {
+		return nil, err
+	}
+	switch token := p.curToken.(type) {
+	case StringToken:
+		if err := p.eatToken(); err != nil {
+			return nil, err
+		}
+		return &StringCondition{
+			FieldPath:  field.fieldPath(),
+			Value:      token.Value,
+			Type:       t,
+			IsNegative: false,
+		}, nil
+	default:
+		return nil, &UnexpectedTokenError{p.curToken}
+	}
+}
 
-		case NumberArrayToken:
-			if err := p.eatToken(); err != nil {
-				return nil, err
-			}
+// contains parses a JSON containment condition, the literal must be a JSON object.
+func (p *filteringParser) contains(field FieldToken) (FilteringExpression, error) {
+	if err := p.eatToken(); err != nil {
+		return nil, err
+	}
+	token, ok := p.curToken.(StringToken)
+	if !ok {
+		return nil, &UnexpectedTokenError{p.curToken}
+	}
+	if _, err := parseJSONObject(token.Value); err != nil {
+		return nil, &InvalidLiteralError{"JSON object", token.Value, err}
+	}
+	if err := p.eatToken(); err != nil {
+		return nil, err
+	}
+	return &ContainsCondition{
+		FieldPath: field.fieldPath(),
+		Value:     token.Value,
+	}, nil
+}
 
-			return &NumberArrayCondition{
-				FieldPath:  strings.Split(field.Value, "."),
-				Values:     token.Values,
-				Type:       NumberArrayCondition_IN,
-				IsNegative: false,
-			}, nil
+func (p *filteringParser) inCIDR(field FieldToken) (FilteringExpression, error) {
+	if err := p.eatToken(); err != nil {
+		return nil, err
+	}
+	token, ok := p.curToken.(StringToken)
+	if !ok {
+		return nil, &UnexpectedTokenError{p.curToken}
+	}
+	if _, _, err := net.ParseCIDR(token.Value); err != nil {
+		return nil, &InvalidLiteralError{"CIDR", token.Value, err}
+	}
+	if err := p.eatToken(); err != nil {
+		return nil, err
+	}
+	return &StringCondition{
+		FieldPath: field.fieldPath(),
+		Value:     token.Value,
+		Type:      StringCondition_IN_CIDR,
+	}, nil
+}
 
-		default:
-			return nil, &UnexpectedTokenError{p.curToken}
+func (p *filteringParser) in(field FieldToken) (FilteringExpression, error) {
+	if err := p.eatToken(); err != nil {
+		return nil, err
+	}
+
+	switch token := p.curToken.(type) {
+	case StringArrayToken:
+		if err := p.eatToken(); err != nil {
+			return nil, err
 		}
+
+		return &StringArrayCondition{
+			FieldPath:  field.fieldPath(),
+			Values:     token.Values,
+			Type:       StringArrayCondition_IN,
+			IsNegative: false,
+		}, nil
+
+	case NumberArrayToken:
+		if err := p.eatToken(); err != nil {
+			return nil, err
+		}
+
+		return &NumberArrayCondition{
+			FieldPath:  field.fieldPath(),
+			Values:     token.Values,
+			Type:       NumberArrayCondition_IN,
+			IsNegative: false,
+		}, nil
+
 	default:
 		return nil, &UnexpectedTokenError{p.curToken}
 	}
 }
+
+// between parses an inclusive range into a conjunction of >= and <= conditions.
+func (p *filteringParser) between(field FieldToken) (FilteringExpression, error) {
+	fieldPath := field.fieldPath()
+	if err := p.eatToken(); err != nil {
+		return nil, err
+	}
+	low := p.curToken
+	if err := p.eatToken(); err != nil {
+		return nil, err
+	}
+	if _, ok := p.curToken.(AndToken); !ok {
+		return nil, &UnexpectedTokenError{p.curToken}
+	}
+	p.lexer.valuePosition = true
+	if err := p.eatToken(); err != nil {
+		return nil, err
+	}
+	high := p.curToken
+
+	var left, right FilteringExpression
+	switch l := low.(type) {
+	case NumberToken:
+		h, ok := high.(NumberToken)
+		if !ok {
+			return nil, &TypeMismatchError{"number", fieldPath, fmt.Sprint(high)}
+		}
+		if l.Value > h.Value {
+			return nil, &ReversedRangeError{low, high}
+		}
+		left = &NumberCondition{FieldPath: fieldPath, Value: l.Value, Type: NumberCondition_GE}
+		right = &NumberCondition{FieldPath: fieldPath, Value: h.Value, Type: NumberCondition_LE}
+	case StringToken:
+		h, ok := high.(StringToken)
+		if !ok {
+			if h, ok := high.(RelativeTimeToken); ok {
+				left = &StringCondition{FieldPath: fieldPath, Value: l.Value, Type: StringCondition_GE}
+				right = &StringCondition{FieldPath: fieldPath, Value: h.Value, Type: StringCondition_LE, RelativeTime: true}
+				break
+			}
+			return nil, &TypeMismatchError{"string", fieldPath, fmt.Sprint(high)}
+		}
+		if isReversedRange(l.Value, h.Value) {
+			return nil, &ReversedRangeError{low, high}
+		}
+		left = &StringCondition{FieldPath: fieldPath, Value: l.Value, Type: StringCondition_GE}
+		right = &StringCondition{FieldPath: fieldPath, Value: h.Value, Type: StringCondition_LE}
+	case RelativeTimeToken:
+		left = &StringCondition{FieldPath: fieldPath, Value: l.Value, Type: StringCondition_GE, RelativeTime: true}
+		switch h := high.(type) {
+		case RelativeTimeToken:
+			lo, _ := parseRelativeTime(l.Value)
+			hi, _ := parseRelativeTime(h.Value)
+			if lo > hi {
+				return nil, &ReversedRangeError{low, high}
+			}
+			right = &StringCondition{FieldPath: fieldPath, Value: h.Value, Type: StringCondition_LE, RelativeTime: true}
+		case StringToken:
+			right = &StringCondition{FieldPath: fieldPath, Value: h.Value, Type: StringCondition_LE}
+		default:
+			return nil, &TypeMismatchError{"time", fieldPath, fmt.Sprint(high)}
+		}
+	default:
+		return nil, &UnexpectedTokenError{low}
+	}
+	if err := p.eatToken(); err != nil {
+		return nil, err
+	}
+	// range is represented by two conditions joined with a logical operator
+	if err := p.addNodes(2); err != nil {
+		return nil, err
+	}
+
+	node := &LogicalOperator{Type: LogicalOperator_AND}
+	if err := node.SetLeft(left); err != nil {
+		return nil, err
+	}
+	if err := node.SetRight(right); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// isReversedRange reports whether string range bound low is greater than high.
+// Bounds are compared as timestamps or durations if both of them can be parsed as such,
+// otherwise lexically.
+func isReversedRange(low, high string) bool {
+	if l, err := time.Parse(time.RFC3339Nano, low); err == nil {
+		if h, err := time.Parse(time.RFC3339Nano, high); err == nil {
+			return l.After(h)
+		}
+	}
+	if l, err := time.ParseDuration(low); err == nil {
+		if h, err := time.ParseDuration(high); err == nil {
+			return l > h
+		}
+	}
+	return low > high
+}
@@ -1,6 +1,8 @@
 package query
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -484,6 +486,131 @@ func TestFilteringParser(t *testing.T) {
 				},
 			},
 		},
+		{
+			text: "field not in ['Hello', 'World']",
+			exp: &Filtering{
+				&Filtering_StringArrayCondition{
+					&StringArrayCondition{
+						FieldPath:  []string{"field"},
+						Values:     []string{"Hello", "World"},
+						Type:       StringArrayCondition_IN,
+						IsNegative: true,
+					},
+				},
+			},
+		},
+		{
+			text: "field not in [1, 2]",
+			exp: &Filtering{
+				&Filtering_NumberArrayCondition{
+					&NumberArrayCondition{
+						FieldPath:  []string{"field"},
+						Values:     []float64{1, 2},
+						Type:       NumberArrayCondition_IN,
+						IsNegative: true,
+					},
+				},
+			},
+		},
+		{
+			text: "price between 10 and 99.5",
+			exp: &Filtering{
+				&Filtering_Operator{
+					&LogicalOperator{
+						Left: &LogicalOperator_LeftNumberCondition{
+							&NumberCondition{
+								FieldPath: []string{"price"},
+								Value:     10,
+								Type:      NumberCondition_GE,
+							},
+						},
+						Right: &LogicalOperator_RightNumberCondition{
+							&NumberCondition{
+								FieldPath: []string{"price"},
+								Value:     99.5,
+								Type:      NumberCondition_LE,
+							},
+						},
+						Type: LogicalOperator_AND,
+					},
+				},
+			},
+		},
+		{
+			text: "name not between 'a' and 'b'",
+			exp: &Filtering{
+				&Filtering_Operator{
+					&LogicalOperator{
+						Left: &LogicalOperator_LeftStringCondition{
+							&StringCondition{
+								FieldPath: []string{"name"},
+								Value:     "a",
+								Type:      StringCondition_GE,
+							},
+						},
+						Right: &LogicalOperator_RightStringCondition{
+							&StringCondition{
+								FieldPath: []string{"name"},
+								Value:     "b",
+								Type:      StringCondition_LE,
+							},
+						},
+						Type:       LogicalOperator_AND,
+						IsNegative: true,
+					},
+				},
+			},
+		},
+		{
+			text: "name like '%acme%'",
+			exp: &Filtering{
+				&Filtering_StringCondition{
+					&StringCondition{
+						FieldPath: []string{"name"},
+						Value:     "%acme%",
+						Type:      StringCondition_LIKE,
+					},
+				},
+			},
+		},
+		{
+			text: "name not like 'a_c'",
+			exp: &Filtering{
+				&Filtering_StringCondition{
+					&StringCondition{
+						FieldPath:  []string{"name"},
+						Value:      "a_c",
+						Type:       StringCondition_LIKE,
+						IsNegative: true,
+					},
+				},
+			},
+		},
+		{
+			text: "name ilike '%ACME%' or name not ilike 'a_c'",
+			exp: &Filtering{
+				&Filtering_Operator{
+					&LogicalOperator{
+						Left: &LogicalOperator_LeftStringCondition{
+							&StringCondition{
+								FieldPath: []string{"name"},
+								Value:     "%ACME%",
+								Type:      StringCondition_ILIKE,
+							},
+						},
+						Right: &LogicalOperator_RightStringCondition{
+							&StringCondition{
+								FieldPath:  []string{"name"},
+								Value:      "a_c",
+								Type:       StringCondition_ILIKE,
+								IsNegative: true,
+							},
+						},
+						Type: LogicalOperator_OR,
+					},
+				},
+			},
+		},
 		{
 			text: "(not (field in ['Hello' , 'World']) and (field := 'Mike'))",
 			exp: &Filtering{
@@ -538,6 +665,336 @@ func TestFilteringParser(t *testing.T) {
 	}
 }
 
+func TestFilteringParserNotIn(t *testing.T) {
+	tests := [][]string{
+		{"field not in ['a', 'b']", "not (field in ['a', 'b'])", "not field in ['a', 'b']"},
+		{"field not in [1, 2]", "not (field in [1, 2])", "not field in [1, 2]"},
+		{"field in [1, 2]", "not (field not in [1, 2])", "not field not in [1, 2]"},
+		{"field not exists", "not (field exists)", "not field exists"},
+	}
+
+	for _, test := range tests {
+		expected, err := ParseFiltering(test[0])
+		assert.NoError(t, err)
+		for _, text := range test[1:] {
+			f, err := ParseFiltering(text)
+			assert.NoError(t, err, text)
+			assert.Equal(t, expected, f, text)
+		}
+	}
+}
+
+func TestFilteringParserPrecedence(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{
+			text:     "a == 1 or b == 2 and c == 3",
+			expected: "(a == 1 or (b == 2 and c == 3))",
+		},
+		{
+			text:     "a == 1 and b == 2 or c == 3",
+			expected: "((a == 1 and b == 2) or c == 3)",
+		},
+		{
+			text:     "(a == 1 or b == 2) and c == 3",
+			expected: "((a == 1 or b == 2) and c == 3)",
+		},
+		{
+			text:     "a == 1 and b == 2 or c == 3 and d == 4",
+			expected: "((a == 1 and b == 2) or (c == 3 and d == 4))",
+		},
+		{
+			text:     "a == 1 and (b == 2 or c == 3) and d == 4",
+			expected: "(a == 1 and (b == 2 or c == 3) and d == 4)",
+		},
+		{
+			text:     "a == 1 or b == 2 or c == 3",
+			expected: "(a == 1 or b == 2 or c == 3)",
+		},
+		{
+			text:     "((a == 1 or (b == 2)) and (c == 3 or d == 4))",
+			expected: "((a == 1 or b == 2) and (c == 3 or d == 4))",
+		},
+		{
+			text:     "not a == 1 or b == 2 and not (c == 3 or d == 4)",
+			expected: "(a != 1 or (b == 2 and not (c == 3 or d == 4)))",
+		},
+		{
+			text:     "not (a == 1 or b == 2) and c == 3",
+			expected: "(not (a == 1 or b == 2) and c == 3)",
+		},
+		{
+			text:     "not a == b and c == 'd'",
+			expected: "(a != b and c == 'd')",
+		},
+		{
+			text:     "not a == null or b is not null and not c is null",
+			expected: "(a != null or (b != null and c != null))",
+		},
+	}
+
+	for _, test := range tests {
+		f, err := ParseFiltering(test.text)
+		assert.NoError(t, err, test.text)
+		assert.Equal(t, test.expected, f.GoString(), test.text)
+	}
+}
+
+func TestFilteringParserNullSafe(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected FilteringExpression
+		str      string
+	}{
+		{
+			text:     "a <=> 'x'",
+			expected: &StringCondition{FieldPath: []string{"a"}, Value: "x", Type: StringCondition_EQ, NullSafe: true},
+			str:      "a <=> 'x'",
+		},
+		{
+			text:     "a <=> -1.5",
+			expected: &NumberCondition{FieldPath: []string{"a"}, Value: -1.5, Type: NumberCondition_EQ, NullSafe: true},
+			str:      "a <=> -1.5",
+		},
+		{
+			text:     "not a.b <=> null",
+			expected: &NullCondition{FieldPath: []string{"a", "b"}, NullSafe: true, IsNegative: true},
+			str:      "not a.b <=> null",
+		},
+		{
+			text:     "a <=> false",
+			expected: &BoolCondition{FieldPath: []string{"a"}, Value: false, NullSafe: true},
+			str:      "a <=> false",
+		},
+	}
+
+	for _, test := range tests {
+		f, err := ParseFiltering(test.text)
+		assert.NoError(t, err, test.text)
+		var node interface{}
+		switch r := f.Root.(type) {
+		case *Filtering_StringCondition:
+			node = r.StringCondition
+		case *Filtering_NumberCondition:
+			node = r.NumberCondition
+		case *Filtering_NullCondition:
+			node = r.NullCondition
+		case *Filtering_BoolCondition:
+			node = r.BoolCondition
+		}
+		assert.Equal(t, test.expected, node, test.text)
+		assert.Equal(t, test.str, f.GoString(), test.text)
+	}
+}
+
+func TestFilteringParserIsNull(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected *NullCondition
+	}{
+		{
+			text:     "a.b is null",
+			expected: &NullCondition{FieldPath: []string{"a", "b"}},
+		},
+		{
+			text:     "a IS NOT NULL",
+			expected: &NullCondition{FieldPath: []string{"a"}, IsNegative: true},
+		},
+		{
+			text:     "not a is not null",
+			expected: &NullCondition{FieldPath: []string{"a"}},
+		},
+	}
+
+	for _, test := range tests {
+		f, err := ParseFiltering(test.text)
+		assert.NoError(t, err, test.text)
+		assert.Equal(t, test.expected, f.GetNullCondition(), test.text)
+	}
+
+	for _, text := range []string{"a is 'x'", "a is not", "a not is null", "a is null null", "a is not not null"} {
+		_, err := ParseFiltering(text)
+		assert.IsType(t, &ParseError{}, err, text)
+	}
+}
+
+func TestFilteringParserKeywordCase(t *testing.T) {
+	tests := []struct {
+		text string
+		str  string
+	}{
+		{"a == TRUE", "a == true"},
+		{"a != tRuE", "a != true"},
+		{"a == False", "a == false"},
+		{"a == NULL", "a == null"},
+		{"a <=> Null", "a <=> null"},
+	}
+	for _, test := range tests {
+		f, err := ParseFiltering(test.text)
+		assert.NoError(t, err, test.text)
+		assert.Equal(t, test.str, f.GoString(), test.text)
+	}
+}
+
+func TestFilteringParserNilKeyword(t *testing.T) {
+	f, err := NewFilteringParser(WithNilKeyword()).Parse("a == nil and b != NIL")
+	assert.NoError(t, err)
+	assert.Equal(t, "(a == null and b != null)", f.GoString())
+
+	f, err = NewFilteringParser().Parse("nil == 1")
+	assert.NoError(t, err)
+	assert.Equal(t, "nil == 1", f.GoString())
+
+	// without the option nil is a field
+	f, err = NewFilteringParser().Parse("a == nil")
+	assert.NoError(t, err)
+	assert.IsType(t, &Filtering_FieldCondition{}, f.Root)
+}
+
+func TestFilteringParserSizeSuffixes(t *testing.T) {
+	p := NewFilteringParser(WithSizeSuffixes())
+	tests := []struct {
+		text string
+		str  string
+	}{
+		{"size > 5kb", "size > 5000"},
+		{"size <= 1.5MB", "size <= 1500000"},
+		{"size in [1kib, 2Mib]", "size in [1024, 2097152]"},
+		{"size == 1_000gb", "size == 1000000000000"},
+		{"size >= 1tib", "size >= 1099511627776"},
+	}
+	for _, test := range tests {
+		f, err := p.Parse(test.text)
+		assert.NoError(t, err, test.text)
+		assert.Equal(t, test.str, f.GoString(), test.text)
+	}
+
+	for _, text := range []string{"size > 5kb2", "size > 5kbs", "size > 5 kb"} {
+		_, err := p.Parse(text)
+		assert.Error(t, err, text)
+	}
+
+	// suffixes are not recognized by default
+	_, err := NewFilteringParser().Parse("size > 5kb")
+	assert.Error(t, err)
+}
+
+func TestFilteringParserFieldComparison(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected *FieldCondition
+		str      string
+	}{
+		{
+			text:     "a == b.c",
+			expected: &FieldCondition{FieldPath: []string{"a"}, ValueFieldPath: []string{"b", "c"}, Type: FieldCondition_EQ},
+			str:      "a == b.c",
+		},
+		{
+			text:     "a != b",
+			expected: &FieldCondition{FieldPath: []string{"a"}, ValueFieldPath: []string{"b"}, Type: FieldCondition_EQ, IsNegative: true},
+			str:      "a != b",
+		},
+		{
+			text:     "start_date < end_date",
+			expected: &FieldCondition{FieldPath: []string{"start_date"}, ValueFieldPath: []string{"end_date"}, Type: FieldCondition_LT},
+			str:      "start_date < end_date",
+		},
+		{
+			text:     "not a ge b",
+			expected: &FieldCondition{FieldPath: []string{"a"}, ValueFieldPath: []string{"b"}, Type: FieldCondition_GE, IsNegative: true},
+			str:      "not a >= b",
+		},
+	}
+
+	for _, test := range tests {
+		f, err := ParseFiltering(test.text)
+		assert.NoError(t, err, test.text)
+		assert.Equal(t, test.expected, f.GetFieldCondition(), test.text)
+		assert.Equal(t, test.str, f.GoString(), test.text)
+	}
+}
+
+func TestFilteringParserRelativeTime(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected *StringCondition
+		str      string
+	}{
+		{
+			text:     "created_at > now-7d",
+			expected: &StringCondition{FieldPath: []string{"created_at"}, Value: "now-7d", Type: StringCondition_GT, RelativeTime: true},
+			str:      "created_at > now-7d",
+		},
+		{
+			text:     "created_at != NOW",
+			expected: &StringCondition{FieldPath: []string{"created_at"}, Value: "now", Type: StringCondition_EQ, IsNegative: true, RelativeTime: true},
+			str:      "created_at != now",
+		},
+		{
+			text:     "not created_at le now-1d+12h",
+			expected: &StringCondition{FieldPath: []string{"created_at"}, Value: "now-1d+12h", Type: StringCondition_LE, IsNegative: true, RelativeTime: true},
+			str:      "not created_at <= now-1d+12h",
+		},
+	}
+	for _, test := range tests {
+		f, err := ParseFiltering(test.text)
+		assert.NoError(t, err, test.text)
+		assert.Equal(t, test.expected, f.GetStringCondition(), test.text)
+		assert.Equal(t, test.str, f.GoString(), test.text)
+	}
+
+	f, err := ParseFiltering("created_at between '2023-01-01T00:00:00Z' and now")
+	assert.NoError(t, err)
+	assert.Equal(t, "(created_at >= '2023-01-01T00:00:00Z' and created_at <= now)", f.GoString())
+
+	f, err = ParseFiltering("created_at between now-2w and now-1w")
+	assert.NoError(t, err)
+	assert.Equal(t, "(created_at >= now-2w and created_at <= now-1w)", f.GoString())
+
+	_, err = ParseFiltering("created_at between now and now-1d")
+	assert.IsType(t, &ReversedRangeError{}, err)
+
+	for _, text := range []string{"created_at ~ now", "created_at like now", "created_at in [now]", "created_at between now and 1"} {
+		_, err := ParseFiltering(text)
+		assert.Error(t, err, text)
+	}
+
+	// now is a field out of value position and it is quoted when printed
+	f, err = ParseFiltering("now == 1 and now-ish > 2")
+	assert.NoError(t, err)
+	assert.Equal(t, "(`now` == 1 and `now-ish` > 2)", f.GoString())
+	f, err = ParseFiltering("created_at > `now`")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"now"}, f.GetFieldCondition().GetValueFieldPath())
+	assert.Equal(t, "created_at > `now`", f.GoString())
+	f, err = ParseFiltering(f.GoString())
+	assert.NoError(t, err)
+	assert.NotNil(t, f.GetFieldCondition())
+
+	// malformed relative time literals are not fields
+	for _, test := range []struct {
+		text string
+		err  *ParseError
+	}{
+		{"t > now-7x", &ParseError{Pos: 4, Token: "now-7x", Msg: "invalid relative time literal"}},
+		{"t > now-1y", &ParseError{Pos: 4, Token: "now-1y", Msg: "invalid relative time literal"}},
+		{"t <= now-1d+1y and a == 1", &ParseError{Pos: 5, Token: "now-1d+1y", Msg: "invalid relative time literal"}},
+		{"t between now-1x and now", &ParseError{Pos: 10, Token: "now-1x", Msg: "invalid relative time literal"}},
+		{"t between now-1d and now-", &ParseError{Pos: 21, Token: "now-", Msg: "invalid relative time literal"}},
+	} {
+		_, err := ParseFiltering(test.text)
+		if assert.IsType(t, &ParseError{}, err, test.text) {
+			perr := err.(*ParseError)
+			assert.IsType(t, &InvalidRelativeTimeError{}, perr.Err, test.text)
+			perr.Err = nil
+			assert.Equal(t, test.err, perr, test.text)
+		}
+	}
+}
+
 func TestFilteringParserNegative(t *testing.T) {
 	p := NewFilteringParser()
 
@@ -546,32 +1003,272 @@ func TestFilteringParserNegative(t *testing.T) {
 		"((field1 == 'abc)'",
 		"field1 == 'abc')",
 		"null == field1",
-		"field1 == field2",
-		"field1 != field1",
+		"field1 ~ field2",
+		"field1 := field2",
 		"field1 ~ 123",
 		"field1 !~ 123",
 		"field1 < or",
 		"field1 <= null",
 		"field1 or field2",
+		"field1 not == 'abc'",
+		"field1 not in 'abc'",
+		"field1 between 1",
+		"field1 between 1 or 2",
+		"field1 between null and 2",
+		"field1 not between",
+		"field1 like 1",
+		"field1 not like null",
+		"field1 <=> [1, 2]",
+		"field1 <=> field2",
+		"field1 <=>",
+		// lone and trailing operators
+		"and",
+		"not",
+		"==",
+		"field1 ==",
+		"field1 in",
+		"field1 == 1 and",
+		"field1 == 1 or or field2 == 2",
+		"not not",
+		"or field1 == 1",
+		"field1 == 1 and not",
+		// unbalanced parentheses
+		"(",
+		")",
+		"()",
+		"((field1 == 1)",
+		"field1 == 1))",
 	}
 
 	for _, test := range tests {
 		token, err := p.Parse(test)
 		assert.Nil(t, token)
-		assert.IsType(t, &UnexpectedTokenError{}, err)
+		assert.IsType(t, &ParseError{}, err, test)
+		assert.IsType(t, &UnexpectedTokenError{}, errors.Unwrap(err), test)
 	}
 
 	tests = []string{
 		"field1 == 234.23.23",
-		"field1 == 'abc",
 		"field1 =! 'cdf'",
 		"field1 =: 'AbC'",
 		"field1 : = 'AbC'",
+		"field1 in [1, 'abc']",
+		"field1 in ['abc', 1]",
+		"field1 == 'a\\u12g4'",
+		// unbalanced brackets
+		"field1 in [",
+		"field1 in [1, 2",
+		"field1 in ['a', 'b'",
+		"field1 in ]",
+		"field1 in [[1]]",
+		"field1 in [1]]",
+	}
+
+	for _, test := range tests {
+		token, err := p.Parse(test)
+		assert.Nil(t, token)
+		assert.IsType(t, &ParseError{}, err)
+		assert.IsType(t, &UnexpectedSymbolError{}, errors.Unwrap(err))
+	}
+
+	tests = []string{
+		"field1 == 'abc",
+		"field1 == 'abc\\'",
+		"field1 in ['abc', \"def]",
+	}
+
+	for _, test := range tests {
+		token, err := p.Parse(test)
+		assert.Nil(t, token)
+		assert.IsType(t, &ParseError{}, err)
+		assert.IsType(t, &UnterminatedStringError{}, errors.Unwrap(err))
+	}
+
+	tests = []string{
+		"field1 between 2 and 1",
+		"field1 between 'b' and 'a'",
+		"field1 between '2023-01-02T00:00:00Z' and '2023-01-01T00:00:00Z'",
+		"field1 between '1h' and '30m'",
 	}
 
 	for _, test := range tests {
 		token, err := p.Parse(test)
 		assert.Nil(t, token)
-		assert.IsType(t, &UnexpectedSymbolError{}, err)
+		assert.IsType(t, &ReversedRangeError{}, err, test)
+	}
+
+	tests = []string{
+		"field1 between 1 and '2'",
+		"field1 between 'a' and 2",
 	}
+
+	for _, test := range tests {
+		token, err := p.Parse(test)
+		assert.Nil(t, token)
+		assert.IsType(t, &TypeMismatchError{}, err, test)
+	}
+}
+
+func TestFilteringParserErrorPosition(t *testing.T) {
+	tests := []struct {
+		text string
+		err  *ParseError
+	}{
+		{
+			text: "field1 == 'abc' and == 5",
+			err:  &ParseError{Pos: 20, Token: "==", Msg: "unexpected operator"},
+		},
+		{
+			text: "field1 == 'abc' field2",
+			err:  &ParseError{Pos: 16, Token: "field2", Msg: "unexpected field"},
+		},
+		{
+			text: "(field1 == 1",
+			err:  &ParseError{Pos: 12, Msg: "unexpected end of expression"},
+		},
+		{
+			text: "field1 > null",
+			err:  &ParseError{Pos: 9, Token: "null", Msg: "unexpected value"},
+		},
+		{
+			text: "field1 == 1 and or",
+			err:  &ParseError{Pos: 16, Token: "or", Msg: "unexpected logical operator"},
+		},
+		{
+			text: "field1 =! 'cdf'",
+			err:  &ParseError{Pos: 8, Token: "!", Msg: "unexpected symbol"},
+		},
+		{
+			text: "field1 =",
+			err:  &ParseError{Pos: 8, Msg: "unexpected end of expression"},
+		},
+		{
+			text: "field1 == 1 or `field 2 == 2",
+			err:  &ParseError{Pos: 15, Token: "`field 2 == 2", Msg: "unterminated quoted field name"},
+		},
+		{
+			text: "field1 is 5",
+			err:  &ParseError{Pos: 10, Token: "5", Msg: "unexpected value"},
+		},
+		{
+			text: "field1 == 'abc",
+			err:  &ParseError{Pos: 10, Token: "'abc", Msg: "unterminated string literal"},
+		},
+		{
+			text: "a.b. == 1",
+			err:  &ParseError{Pos: 3, Token: ".", Msg: "unexpected symbol"},
+		},
+		{
+			text: "a..b == 1",
+			err:  &ParseError{Pos: 2, Token: ".", Msg: "unexpected symbol"},
+		},
+	}
+
+	for _, test := range tests {
+		_, err := ParseFiltering(test.text)
+		if assert.IsType(t, &ParseError{}, err, test.text) {
+			perr := err.(*ParseError)
+			perr.Err = nil
+			assert.Equal(t, test.err, perr, test.text)
+		}
+	}
+
+	_, err := ParseFiltering("field1 == 'abc' and == 5")
+	assert.EqualError(t, err, `invalid filter at position 20: unexpected operator "=="`)
+	var terr *UnexpectedTokenError
+	assert.True(t, errors.As(err, &terr))
+	assert.Equal(t, EqToken{}, terr.T)
+}
+
+func TestFilteringParserLimits(t *testing.T) {
+	limits := FilteringLimits{MaxDepth: 2, MaxNodes: 5}
+	tests := []struct {
+		text string
+		err  error
+	}{
+		{
+			text: "((a == 1) and not (b == 2 or c == 3))",
+			err:  nil,
+		},
+		{
+			text: "(((a == 1)))",
+			err:  &FilteringLimitError{"depth", 2},
+		},
+		{
+			text: "a == 1 and b == 2 and c == 3",
+			err:  nil,
+		},
+		{
+			text: "a == 1 and b == 2 and c == 3 and d == 4",
+			err:  &FilteringLimitError{"number of nodes", 5},
+		},
+		{
+			text: "a between 1 and 2 and b == 2",
+			err:  nil,
+		},
+		{
+			text: "a between 1 and 2 or b between 1 and 2",
+			err:  &FilteringLimitError{"number of nodes", 5},
+		},
+	}
+
+	p := NewFilteringParserWithLimits(limits)
+	for _, test := range tests {
+		_, err := ParseFilteringWithLimits(test.text, limits)
+		assert.Equal(t, test.err, err, test.text)
+		// parser state is reset between calls
+		_, err = p.Parse(test.text)
+		assert.Equal(t, test.err, err, test.text)
+	}
+
+	deep := strings.Repeat("(", DefaultFilteringLimits.MaxDepth+1) + "a == 1" + strings.Repeat(")", DefaultFilteringLimits.MaxDepth+1)
+	_, err := ParseFiltering(deep)
+	assert.Equal(t, &FilteringLimitError{"depth", DefaultFilteringLimits.MaxDepth}, err)
+	_, err = ParseFilteringWithLimits(deep, FilteringLimits{MaxDepth: DefaultFilteringLimits.MaxDepth + 1})
+	assert.Nil(t, err)
+
+	long := "a == 1" + strings.Repeat(" or a == 1", DefaultFilteringLimits.MaxNodes/2)
+	_, err = ParseFiltering(long)
+	assert.Equal(t, &FilteringLimitError{"number of nodes", DefaultFilteringLimits.MaxNodes}, err)
+}
+
+func TestFilteringLimitsCheck(t *testing.T) {
+	limits := FilteringLimits{MaxDepth: 1, MaxNodes: 7}
+	tests := []struct {
+		text string
+		err  error
+	}{
+		{
+			text: "a == 1 and b == 2 and c == 3 and d == 4",
+			err:  nil,
+		},
+		{
+			text: "a == 1 and b == 2 and c == 3 and d == 4 and e == 5",
+			err:  &FilteringLimitError{"number of nodes", 7},
+		},
+		{
+			text: "a == 1 and (b == 2 or c == 3)",
+			err:  nil,
+		},
+		{
+			text: "not (a == 1 and b == 2)",
+			err:  nil,
+		},
+		{
+			text: "a == 1 and (b == 2 or (c == 3 and d == 4))",
+			err:  &FilteringLimitError{"depth", 1},
+		},
+		{
+			text: "not (a == 1 and not (b == 2 and c == 3))",
+			err:  &FilteringLimitError{"depth", 1},
+		},
+	}
+
+	for _, test := range tests {
+		f, err := ParseFiltering(test.text)
+		assert.Nil(t, err, test.text)
+		assert.Equal(t, test.err, limits.Check(f), test.text)
+	}
+
+	assert.Nil(t, FilteringLimits{}.Check(nil))
 }
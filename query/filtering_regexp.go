@@ -0,0 +1,124 @@
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+)
+
+// RegexpFeature is a feature of regular expression syntax that can be disallowed in match conditions.
+type RegexpFeature int
+
+const (
+	// RegexpAlternation is an alternation of expressions, e.g. "foo|bar".
+	RegexpAlternation RegexpFeature = iota
+	// RegexpCountedRepetition is a repetition with explicit bounds, e.g. "a{2,100}".
+	RegexpCountedRepetition
+	// RegexpUnboundedRepetition is a repetition without upper bound, e.g. "a*" or "a+".
+	RegexpUnboundedRepetition
+	// RegexpCapture is a capturing group, e.g. "(a)".
+	RegexpCapture
+)
+
+func (f RegexpFeature) String() string {
+	switch f {
+	case RegexpAlternation:
+		return "alternation"
+	case RegexpCountedRepetition:
+		return "counted repetition"
+	case RegexpUnboundedRepetition:
+		return "unbounded repetition"
+	case RegexpCapture:
+		return "capturing group"
+	default:
+		return fmt.Sprintf("RegexpFeature(%d)", int(f))
+	}
+}
+
+// MaxRegexpSize rejects regular expressions of match conditions that are compiled into a program
+// of more than n instructions, which protects from patterns that are slow and memory-heavy to evaluate,
+// e.g. very large alternations. LIKE patterns are subject to the limit as well.
+func MaxRegexpSize(n int) FilterOption {
+	return func(o *filterOptions) {
+		o.maxRegexpSize = n
+	}
+}
+
+// DisallowRegexpFeatures rejects regular expressions of match conditions that use any of features.
+// LIKE patterns are not affected.
+func DisallowRegexpFeatures(features ...RegexpFeature) FilterOption {
+	return func(o *filterOptions) {
+		o.disallowedRegexpFeatures = append(o.disallowedRegexpFeatures, features...)
+	}
+}
+
+// RegexpNotAllowedError describes a regular expression Pattern that exceeds limits set by
+// MaxRegexpSize, uses features disallowed by DisallowRegexpFeatures or cannot be translated by RegexpToLike.
+type RegexpNotAllowedError struct {
+	Pattern string
+	Reason  string
+}
+
+func (e *RegexpNotAllowedError) Error() string {
+	return fmt.Sprintf("regular expression %q is not allowed: %s", e.Pattern, e.Reason)
+}
+
+// compileRegexp compiles regular expression expr which is derived from pattern given in
+// a condition, checking it against limits of o. Disallowed features are checked only if features is true.
+func compileRegexp(expr, pattern string, o *filterOptions, features bool) (*regexp.Regexp, error) {
+	if o.caseInsensitive {
+		expr = "(?i)" + expr
+	}
+	if o.maxRegexpSize > 0 || (features && len(o.disallowedRegexpFeatures) > 0) {
+		re, err := syntax.Parse(expr, syntax.Perl)
+		if err != nil {
+			return nil, err
+		}
+		if features {
+			if f, ok := findRegexpFeature(re, o.disallowedRegexpFeatures); ok {
+				return nil, &RegexpNotAllowedError{pattern, f.String() + " is disallowed"}
+			}
+		}
+		if o.maxRegexpSize > 0 {
+			prog, err := syntax.Compile(re.Simplify())
+			if err != nil {
+				return nil, err
+			}
+			if len(prog.Inst) > o.maxRegexpSize {
+				return nil, &RegexpNotAllowedError{pattern, fmt.Sprintf("program size %d exceeds maximum %d", len(prog.Inst), o.maxRegexpSize)}
+			}
+		}
+	}
+	return regexp.Compile(expr)
+}
+
+// findRegexpFeature returns the first of features used by re.
+func findRegexpFeature(re *syntax.Regexp, features []RegexpFeature) (RegexpFeature, bool) {
+	var f RegexpFeature
+	switch re.Op {
+	case syntax.OpAlternate:
+		f = RegexpAlternation
+	case syntax.OpRepeat:
+		f = RegexpCountedRepetition
+		if re.Max == -1 {
+			f = RegexpUnboundedRepetition
+		}
+	case syntax.OpStar, syntax.OpPlus:
+		f = RegexpUnboundedRepetition
+	case syntax.OpCapture:
+		f = RegexpCapture
+	default:
+		f = -1
+	}
+	for _, d := range features {
+		if d == f {
+			return f, true
+		}
+	}
+	for _, sub := range re.Sub {
+		if f, ok := findRegexpFeature(sub, features); ok {
+			return f, true
+		}
+	}
+	return 0, false
+}
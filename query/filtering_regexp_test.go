@@ -0,0 +1,54 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilteringRegexpLimits(t *testing.T) {
+	obj := &TestProtoMessage{Str: "abc"}
+	large := "x[a-z]{1,1000}"
+
+	tests := []struct {
+		filter string
+		opts   []FilterOption
+		res    bool
+		err    string
+	}{
+		{filter: "str ~ '" + large + "'", opts: nil, res: false},
+		{filter: "str ~ '" + large + "'", opts: []FilterOption{MaxRegexpSize(1000)}, err: "program size"},
+		{filter: "str ~ '^a.c$'", opts: []FilterOption{MaxRegexpSize(1000)}, res: true},
+		{filter: "str like '%" + strings.Repeat("_", 2000) + "'", opts: []FilterOption{MaxRegexpSize(1000)}, err: "program size"},
+		{filter: "str ~ 'abc|xyz'", opts: []FilterOption{DisallowRegexpFeatures(RegexpAlternation)}, err: "alternation is disallowed"},
+		{filter: "str ~ '[ab]c'", opts: []FilterOption{DisallowRegexpFeatures(RegexpAlternation)}, res: true},
+		{filter: "str ~ 'a{1,1000}'", opts: []FilterOption{DisallowRegexpFeatures(RegexpCountedRepetition)}, err: "counted repetition is disallowed"},
+		{filter: "str ~ '(b)'", opts: []FilterOption{DisallowRegexpFeatures(RegexpCapture, RegexpAlternation)}, err: "capturing group is disallowed"},
+		{filter: "str ~ '^a.+'", opts: []FilterOption{DisallowRegexpFeatures(RegexpUnboundedRepetition)}, err: "unbounded repetition is disallowed"},
+		{filter: "str ~ 'a{2,}'", opts: []FilterOption{DisallowRegexpFeatures(RegexpUnboundedRepetition)}, err: "unbounded repetition is disallowed"},
+		{filter: "str ~ 'a?b'", opts: []FilterOption{DisallowRegexpFeatures(RegexpUnboundedRepetition, RegexpCapture)}, res: true},
+		// like patterns are not checked for disallowed features
+		{filter: "str like '%c'", opts: []FilterOption{DisallowRegexpFeatures(RegexpUnboundedRepetition)}, res: true},
+		{filter: "str ~ 'ABC|XYZ'", opts: []FilterOption{CaseInsensitive(), DisallowRegexpFeatures(RegexpAlternation)}, err: `regular expression "ABC|XYZ" is not allowed`},
+	}
+
+	for _, test := range tests {
+		res, err := Filter(obj, test.filter, test.opts...)
+		cf, cerr := CompileFilter(test.filter, test.opts...)
+		if test.err != "" {
+			assert.IsType(t, &RegexpNotAllowedError{}, err, test.filter)
+			assert.Contains(t, fmt.Sprint(err), test.err, test.filter)
+			assert.IsType(t, &RegexpNotAllowedError{}, cerr, test.filter)
+			continue
+		}
+		assert.NoError(t, err, test.filter)
+		assert.Equal(t, test.res, res, test.filter)
+		if assert.NoError(t, cerr, test.filter) {
+			res, err = cf.Match(obj)
+			assert.NoError(t, err, test.filter)
+			assert.Equal(t, test.res, res, test.filter)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package query
+
+import (
+	"reflect"
+	"regexp"
+)
+
+// filterRepeated evaluates condition node against obj if fieldPath refers to a repeated field
+// or goes through a repeated message field, e.g. "items.sku".
+// The condition is satisfied if it is satisfied by any of the elements, negation is applied
+// to the overall result, so that negated condition is satisfied if none of the elements match.
+// Returned ok is false if fieldPath does not refer to a repeated field.
+func filterRepeated(node interface{}, obj interface{}, fieldPath []string, neg bool, o *filterOptions) (res bool, ok bool, err error) {
+	slice, rest, ok := repeatedField(obj, fieldPath)
+	if !ok {
+		return false, false, nil
+	}
+	elem := positiveCondition(node, rest)
+	o = elemOptions(node, elem, o)
+	for i := 0; i < slice.Len(); i++ {
+		res, err := filterNode(elem, slice.Index(i).Interface(), o)
+		if err != nil {
+			return false, true, withFieldPath(err, fieldPath)
+		}
+		if res {
+			return negateIfNeeded(neg, true), true, nil
+		}
+	}
+	return negateIfNeeded(neg, false), true, nil
+}
+
+// withFieldPath returns err with the field path of TypeMismatchError and UnknownFieldError
+// replaced by fieldPath, so that errors of conditions evaluated against elements of
+// repeated fields refer to the full path rather than to the path within the element.
+func withFieldPath(err error, fieldPath []string) error {
+	switch e := err.(type) {
+	case *TypeMismatchError:
+		c := *e
+		c.FieldPath = fieldPath
+		return &c
+	case *UnknownFieldError:
+		return &UnknownFieldError{fieldPath}
+	}
+	return err
+}
+
+// elemOptions returns options to evaluate condition elem derived from node with,
+// so that the regular expression precompiled for node is used for elem as well.
+func elemOptions(node, elem interface{}, o *filterOptions) *filterOptions {
+	c, isString := node.(*StringCondition)
+	if !isString {
+		return o
+	}
+	e, isString := elem.(*StringCondition)
+	if !isString {
+		return o
+	}
+	if re, found := o.regexps[c]; found {
+		oc := *o
+		oc.regexps = map[*StringCondition]*regexp.Regexp{e: re}
+		return &oc
+	}
+	return o
+}
+
+// repeatedField resolves fieldPath against obj up to the first repeated field
+// and returns it along with the rest of the path.
+func repeatedField(obj interface{}, fieldPath []string) (reflect.Value, []string, bool) {
+	v := reflect.ValueOf(obj)
+	for i, name := range fieldPath {
+		if v.Kind() == reflect.Ptr && v.IsNil() {
+			if v.Type().Elem().Kind() != reflect.Struct {
+				return reflect.Value{}, nil, false
+			}
+			v = reflect.New(v.Type().Elem())
+		}
+		v = fieldByName(v, name)
+		if !v.IsValid() {
+			return reflect.Value{}, nil, false
+		}
+		if isRepeated(v.Type()) {
+			return v, fieldPath[i+1:], true
+		}
+	}
+	return reflect.Value{}, nil, false
+}
+
+// isRepeated reports whether t is a slice type except for []byte.
+func isRepeated(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8
+}
+
+// positiveCondition returns a non-negated copy of condition node with field path set to fieldPath.
+func positiveCondition(node interface{}, fieldPath []string) interface{} {
+	switch n := node.(type) {
+	case *StringCondition:
+		c := *n
+		c.FieldPath, c.IsNegative = fieldPath, false
+		return &c
+	case *NumberCondition:
+		c := *n
+		c.FieldPath, c.IsNegative = fieldPath, false
+		return &c
+	case *NullCondition:
+		c := *n
+		c.FieldPath, c.IsNegative = fieldPath, false
+		return &c
+	case *BoolCondition:
+		c := *n
+		c.FieldPath, c.IsNegative = fieldPath, false
+		return &c
+	case *StringArrayCondition:
+		c := *n
+		c.FieldPath, c.IsNegative = fieldPath, false
+		return &c
+	case *NumberArrayCondition:
+		c := *n
+		c.FieldPath, c.IsNegative = fieldPath, false
+		return &c
+	case *CustomCondition:
+		c := *n
+		c.FieldPath, c.IsNegative = fieldPath, false
+		return &c
+	case *ExistsCondition:
+		c := *n
+		c.FieldPath, c.IsNegative = fieldPath, false
+		return &c
+	case *ContainsCondition:
+		c := *n
+		c.FieldPath, c.IsNegative = fieldPath, false
+		return &c
+	default:
+		return node
+	}
+}
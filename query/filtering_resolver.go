@@ -0,0 +1,63 @@
+package query
+
+import (
+	"reflect"
+	"strings"
+)
+
+// FieldResolverFunc resolves field of obj that cannot be found by reflection, e.g. a virtual field
+// computed by a method. field is a dot-separated field path of the expression. It returns false
+// if obj has no such field.
+type FieldResolverFunc func(obj interface{}, field string) (value interface{}, ok bool)
+
+// WithFieldResolver makes fields that are not found in an evaluated object to be resolved by fn,
+// e.g. to expose "full_name" computed from the first and last names:
+//
+//	query.Filter(user, "full_name == 'John Doe'", query.WithFieldResolver(func(obj interface{}, field string) (interface{}, bool) {
+//		if u, ok := obj.(*User); ok && field == "full_name" {
+//			return u.FirstName + " " + u.LastName, true
+//		}
+//		return nil, false
+//	}))
+//
+// Resolved values are compared the same way as struct fields of the same type, a typed nil pointer,
+// e.g. (*string)(nil), is null. If fn returns false the field is treated as missing, so the condition
+// fails with TypeMismatchError as it does without a resolver.
+func WithFieldResolver(fn FieldResolverFunc) FilterOption {
+	return func(o *filterOptions) {
+		o.resolver = fn
+	}
+}
+
+// fieldByFieldPath is the same as fieldByFieldPath function but falls back to the resolver set by WithFieldResolver.
+func (o *filterOptions) fieldByFieldPath(obj interface{}, fieldPath []string) reflect.Value {
+	if v := fieldByFieldPath(obj, fieldPath); v.IsValid() {
+		return v
+	}
+	v := o.resolveField(obj, fieldPath)
+	if wv, ok := wrappedValue(v); ok {
+		return wv
+	}
+	return v
+}
+
+// rawFieldByFieldPath is the same as rawFieldByFieldPath function but falls back to the resolver set by WithFieldResolver.
+func (o *filterOptions) rawFieldByFieldPath(obj interface{}, fieldPath []string) reflect.Value {
+	if v := rawFieldByFieldPath(obj, fieldPath); v.IsValid() {
+		return v
+	}
+	return o.resolveField(obj, fieldPath)
+}
+
+// resolveField returns the value of fieldPath of obj resolved by the resolver
+// or invalid value if there is no resolver or it does not know the field.
+func (o *filterOptions) resolveField(obj interface{}, fieldPath []string) reflect.Value {
+	if o.resolver == nil {
+		return reflect.Value{}
+	}
+	v, ok := o.resolver(obj, strings.Join(fieldPath, "."))
+	if !ok {
+		return reflect.Value{}
+	}
+	return collapsePointer(reflect.ValueOf(v))
+}
@@ -0,0 +1,63 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TestResolvedObject struct {
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Age       int32  `json:"age"`
+}
+
+func TestFilteringFieldResolver(t *testing.T) {
+	obj := &TestResolvedObject{FirstName: "John", LastName: "Doe", Age: 42}
+	resolver := WithFieldResolver(func(obj interface{}, field string) (interface{}, bool) {
+		o, ok := obj.(*TestResolvedObject)
+		if !ok {
+			return nil, false
+		}
+		switch field {
+		case "full_name":
+			return o.FirstName + " " + o.LastName, true
+		case "age_in_months":
+			return o.Age * 12, true
+		case "adult":
+			return o.Age >= 18, true
+		case "nickname":
+			return (*string)(nil), true
+		case "first_name":
+			return "shadowed", true
+		}
+		return nil, false
+	})
+
+	tests := []struct {
+		filter string
+		res    bool
+		err    error
+	}{
+		{filter: "full_name == 'John Doe' and full_name ~ '^John' and full_name in ['John Doe']", res: true},
+		{filter: "age_in_months > 500 and age_in_months in [504]", res: true},
+		{filter: "adult == true", res: true},
+		{filter: "nickname == null and not nickname != null", res: true},
+		{filter: "nickname == 'Johnny' or nickname != 'Johnny'", res: false},
+		{filter: "nickname exists", res: false},
+		{filter: "full_name exists", res: true},
+		{filter: "age_in_months > age and full_name > first_name", res: true},
+		// reflection takes precedence over the resolver
+		{filter: "first_name == 'John'", res: true},
+		{filter: "unknown == 'a'", err: &TypeMismatchError{"string", []string{"unknown"}, "'a'"}},
+		{filter: "full_name == 1", err: &TypeMismatchError{"number", []string{"full_name"}, "1"}},
+	}
+	for _, test := range tests {
+		res, err := Filter(obj, test.filter, resolver)
+		assert.Equal(t, test.err, err, test.filter)
+		assert.Equal(t, test.res, res, test.filter)
+	}
+
+	_, err := Filter(obj, "full_name == 'John Doe'")
+	assert.Equal(t, &TypeMismatchError{"string", []string{"full_name"}, "'John Doe'"}, err)
+}
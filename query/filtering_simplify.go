@@ -0,0 +1,116 @@
+package query
+
+import (
+	"github.com/golang/protobuf/proto"
+)
+
+// Simplify returns a copy of m with redundant subexpressions removed, m itself is not modified.
+// Operands repeated in a chain of AND or OR operators are dropped, e.g. "a == 1 and a == 1" is "a == 1",
+// as well as operands which value is determined by the preceding ones, e.g. "a == 1 and (a == 1 or b == 2)"
+// and "a == 1 or (b == 2 and a == 1)" are "a == 1". A negated operator left with a single negated operator
+// is replaced by the latter without negation, e.g. "not (not (a == 1 or b == 2) and not (a == 1 or b == 2))"
+// is "a == 1 or b == 2". Conditions are not compared with their negations, e.g. "a == 1 or not a == 1" is kept,
+// since neither of them is true for a null value, so the result is evaluated the same way both by Filter and
+// by SQL translators. Errors of the dropped subexpressions, e.g. TypeMismatchError, are not reported by the result.
+func (m *Filtering) Simplify() *Filtering {
+	if m.GetRoot() == nil {
+		return m
+	}
+	res := proto.Clone(m).(*Filtering)
+	node, _, _ := simplifyNode(unwrapNode(res.Root), &simplifyFacts{})
+	res.SetRoot(node)
+	return res
+}
+
+// simplifyFacts are subexpressions known to be true or false where a node is evaluated.
+type simplifyFacts struct {
+	truthy, falsy []interface{}
+}
+
+// value returns the value of node if it is known.
+func (f *simplifyFacts) value(node interface{}) (value bool, known bool) {
+	for _, n := range f.truthy {
+		if proto.Equal(n.(proto.Message), node.(proto.Message)) {
+			return true, true
+		}
+	}
+	for _, n := range f.falsy {
+		if proto.Equal(n.(proto.Message), node.(proto.Message)) {
+			return false, true
+		}
+	}
+	return false, false
+}
+
+func (f *simplifyFacts) add(node interface{}, value bool) {
+	if value {
+		f.truthy = append(f.truthy, node)
+	} else {
+		f.falsy = append(f.falsy, node)
+	}
+}
+
+func (f *simplifyFacts) copy() *simplifyFacts {
+	return &simplifyFacts{
+		truthy: append([]interface{}(nil), f.truthy...),
+		falsy:  append([]interface{}(nil), f.falsy...),
+	}
+}
+
+// simplifyNode returns simplified node given facts, nodes of the tree are not modified.
+// The second returned value is true if the value of node, which is the third one, is determined by facts, node is not simplified then.
+func simplifyNode(node interface{}, facts *simplifyFacts) (interface{}, bool, bool) {
+	lop, ok := node.(*LogicalOperator)
+	if !ok {
+		value, known := facts.value(node)
+		return node, known, value
+	}
+	if lop.IsNegative {
+		// facts do not hold under negation, e.g. "a and not (a or b)" is not "a and not (b)" for null a
+		facts = &simplifyFacts{}
+	} else {
+		facts = facts.copy()
+	}
+	// operands of AND are true where the following ones are evaluated, so a true operand is dropped
+	// and a false one makes the whole operator false, and vice versa for OR
+	neutral := lop.Type == LogicalOperator_AND
+	var operands []interface{}
+	for _, op := range lop.operands() {
+		op, known, value := simplifyNode(op, facts)
+		if !known {
+			value, known = facts.value(op)
+		}
+		if known {
+			if value == neutral {
+				continue
+			}
+			return lop, true, negateIfNeeded(lop.IsNegative, value)
+		}
+		operands = append(operands, op)
+		facts.add(op, neutral)
+	}
+	switch len(operands) {
+	case 0:
+		return lop, true, negateIfNeeded(lop.IsNegative, neutral)
+	case 1:
+		if !lop.IsNegative {
+			return operands[0], false, false
+		}
+		if l, ok := operands[0].(*LogicalOperator); ok {
+			return &LogicalOperator{Left: l.Left, Right: l.Right, Type: l.Type, IsNegative: !l.IsNegative}, false, false
+		}
+		// negation of a condition is not the same as negation of its value for null values
+		return lop, false, false
+	}
+	res := &LogicalOperator{Type: lop.Type}
+	res.SetLeft(operands[0])
+	res.SetRight(operands[1])
+	for _, op := range operands[2:] {
+		left := res
+		res = &LogicalOperator{Type: lop.Type}
+		res.SetLeft(left)
+		res.SetRight(op)
+	}
+	res.IsNegative = lop.IsNegative
+	return res, false, false
+}
@@ -0,0 +1,76 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilteringSimplify(t *testing.T) {
+	tests := []struct {
+		filter   string
+		expected string
+	}{
+		{"a == 1", "a == 1"},
+		{"a == 1 and b == 2", "(a == 1 and b == 2)"},
+		{"a == 1 and a == 1", "a == 1"},
+		{"a == 1 or b == 2 or a == 1", "(a == 1 or b == 2)"},
+		{"a == 1 and (b == 2 and a == 1)", "(a == 1 and b == 2)"},
+		{"a == 1 and (a == 1 or b == 2)", "a == 1"},
+		{"a == 1 and (b == 2 or a == 1)", "a == 1"},
+		{"a == 1 or (b == 2 and a == 1)", "a == 1"},
+		{"a == 1 and (b == 2 or (c == 3 and a == 1))", "(a == 1 and (b == 2 or c == 3))"},
+		{"a == 1 or (b == 2 and (a == 1 or c == 3))", "(a == 1 or (b == 2 and c == 3))"},
+		{"(a == 1 or b == 2) and (b == 2 or a == 1)", "((a == 1 or b == 2) and (b == 2 or a == 1))"},
+		{"(a == 1 or b == 2) and c == 3 and (a == 1 or b == 2)", "((a == 1 or b == 2) and c == 3)"},
+		{"not (a == 1 and a == 1 and b == 2)", "not (a == 1 and b == 2)"},
+		{"not (not (a == 1 or b == 2) and not (a == 1 or b == 2))", "(a == 1 or b == 2)"},
+		{"not ((a == 1 or b == 2) or (b == 2 or a == 1))", "not (a == 1 or b == 2)"},
+		// negations of conditions are not complements for null values
+		{"a == 1 or not a == 1", "(a == 1 or a != 1)"},
+		{"a == 1 and not (a == 1 or b == 2)", "(a == 1 and not (a == 1 or b == 2))"},
+		{"not (a == 1 and a == 1)", "not (a == 1 and a == 1)"},
+		{"a == 1 and a == 2", "(a == 1 and a == 2)"},
+	}
+	for _, test := range tests {
+		f, err := ParseFiltering(test.filter)
+		if !assert.NoError(t, err, test.filter) {
+			continue
+		}
+		orig := f.GoString()
+		assert.Equal(t, test.expected, f.Simplify().GoString(), test.filter)
+		assert.Equal(t, orig, f.GoString(), "%s is modified", test.filter)
+	}
+
+	assert.Nil(t, (*Filtering)(nil).Simplify())
+}
+
+func TestFilteringSimplifyEquivalent(t *testing.T) {
+	objs := []*TestObject{
+		{Str: "a", Float: 1},
+		{Str: "b", Float: 2},
+		{Str: "a", Float: 3},
+		{Str: "", Float: 0},
+	}
+	filters := []string{
+		"str == 'a' and (str == 'a' or float > 1)",
+		"str == 'a' or (float > 1 and str == 'a')",
+		"not (not (str == 'a' or float > 1) and not (str == 'a' or float > 1))",
+		"(float == 1 or float == 2) and not (float == 1 and float == 1)",
+		"str == 'a' and (float > 2 or (float < 2 and str == 'a'))",
+	}
+	for _, filter := range filters {
+		f, err := ParseFiltering(filter)
+		if !assert.NoError(t, err, filter) {
+			continue
+		}
+		s := f.Simplify()
+		for _, obj := range objs {
+			expected, err := f.Filter(obj)
+			assert.NoError(t, err, filter)
+			res, err := s.Filter(obj)
+			assert.NoError(t, err, filter)
+			assert.Equal(t, expected, res, "%s (%s) for %+v", filter, s.GoString(), obj)
+		}
+	}
+}
@@ -0,0 +1,472 @@
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SQLOption is a type of function that alters a sqlBuilder used by ToSQL.
+type SQLOption func(*sqlBuilder)
+
+// WithSQLFieldMapping sets mapping from dot-separated field paths of a filtering
+// expression to column names. Fields that are not in the mapping are rejected by ToSQL.
+func WithSQLFieldMapping(mapping map[string]string) SQLOption {
+	return func(b *sqlBuilder) {
+		b.mapping = mapping
+	}
+}
+
+// WithSQLLikeMatching makes ToSQL translate match operators (~ and !~) to LIKE and NOT LIKE
+// instead of Postgres regular expression operators. Patterns are converted by RegexpToLike
+// and ToSQL fails for patterns beyond the subset it supports.
+func WithSQLLikeMatching() SQLOption {
+	return func(b *sqlBuilder) {
+		b.like = true
+	}
+}
+
+// RegexpToLike translates regular expression pattern of a match condition to an equivalent
+// SQL LIKE pattern. Only a subset of the syntax is supported: literal characters, backslash-escaped
+// punctuation, . and .* which become _ and %, and ^ and $ anchors; ends that are not anchored become %.
+// Literal % and _ are escaped with backslash. Other patterns, e.g. "a+" or "[ab]", are reported with
+// RegexpNotAllowedError.
+func RegexpToLike(pattern string) (string, error) {
+	runes := []rune(pattern)
+	var b strings.Builder
+	// wildcard is true if the last written symbol is %, so that .* at the ends doesn't produce %%
+	wildcard := false
+	if len(runes) > 0 && runes[0] == '^' {
+		runes = runes[1:]
+	} else {
+		b.WriteByte('%')
+		wildcard = true
+	}
+	anchored := false
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '\\':
+			if i+1 == len(runes) || !isRegexpPunct(runes[i+1]) {
+				return "", &RegexpNotAllowedError{pattern, "cannot be translated to LIKE pattern"}
+			}
+			i++
+			writeLikeLiteral(&b, runes[i])
+			wildcard = false
+		case r == '.' && i+1 < len(runes) && runes[i+1] == '*':
+			i++
+			if !wildcard {
+				b.WriteByte('%')
+				wildcard = true
+			}
+		case r == '.':
+			b.WriteByte('_')
+			wildcard = false
+		case r == '$' && i+1 == len(runes):
+			anchored = true
+		case strings.ContainsRune("^$*+?()[]{}|", r):
+			return "", &RegexpNotAllowedError{pattern, "cannot be translated to LIKE pattern"}
+		default:
+			writeLikeLiteral(&b, r)
+			wildcard = false
+		}
+	}
+	if !anchored && !wildcard {
+		b.WriteByte('%')
+	}
+	return b.String(), nil
+}
+
+// isRegexpPunct reports whether r escaped with backslash denotes itself in regular expression.
+func isRegexpPunct(r rune) bool {
+	return r < 0x80 && !('0' <= r && r <= '9' || 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z')
+}
+
+func writeLikeLiteral(b *strings.Builder, r rune) {
+	if r == '%' || r == '_' || r == '\\' {
+		b.WriteByte('\\')
+	}
+	b.WriteRune(r)
+}
+
+// WithSQLQuestionPlaceholders makes ToSQL use ? placeholders instead of numbered ones,
+// e.g. to pass the result to GORM which rebinds placeholders on its own.
+func WithSQLQuestionPlaceholders() SQLOption {
+	return func(b *sqlBuilder) {
+		b.question = true
+	}
+}
+
+// WithSQLClock makes ToSQL translate relative time literals, e.g. now-7d, to parameterized absolute times
+// resolved against the time returned by now instead of Postgres expressions like now() - interval '7 days'.
+func WithSQLClock(now func() time.Time) SQLOption {
+	return func(b *sqlBuilder) {
+		b.clock = now
+	}
+}
+
+// ToSQL returns a parameterized SQL WHERE fragment representation of the filtering expression f
+// with Postgres-style placeholders ($1, $2, ...) and the ordered list of arguments.
+// Only fields from a mapping set by WithSQLFieldMapping are allowed.
+func ToSQL(f *Filtering, opts ...SQLOption) (string, []interface{}, error) {
+	if f == nil || f.Root == nil {
+		return "", nil, nil
+	}
+	b := &sqlBuilder{}
+	for _, opt := range opts {
+		opt(b)
+	}
+	str, err := b.build(unwrapNode(f.Root))
+	if err != nil {
+		return "", nil, err
+	}
+	return str, b.args, nil
+}
+
+type sqlBuilder struct {
+	mapping  map[string]string
+	like     bool
+	question bool
+	clock    func() time.Time
+	args     []interface{}
+}
+
+func (b *sqlBuilder) placeholder(v interface{}) string {
+	b.args = append(b.args, v)
+	if b.question {
+		return "?"
+	}
+	return "$" + strconv.Itoa(len(b.args))
+}
+
+func (b *sqlBuilder) column(fieldPath []string) (string, error) {
+	if col, ok := b.mapping[strings.Join(fieldPath, ".")]; ok {
+		return col, nil
+	}
+	return "", &UnknownFieldError{fieldPath}
+}
+
+func (b *sqlBuilder) build(node interface{}) (string, error) {
+	switch n := node.(type) {
+	case *LogicalOperator:
+		return b.logicalOperator(n)
+	case *StringCondition:
+		return b.stringCondition(n)
+	case *NumberCondition:
+		return b.numberCondition(n)
+	case *NullCondition:
+		return b.nullCondition(n)
+	case *ExistsCondition:
+		return b.nullCondition(&NullCondition{FieldPath: n.FieldPath, IsNegative: !n.IsNegative})
+	case *BoolCondition:
+		return b.boolCondition(n)
+	case *FieldCondition:
+		return b.fieldCondition(n)
+	case *ContainsCondition:
+		return b.containsCondition(n)
+	case *StringArrayCondition:
+		values := make([]interface{}, len(n.Values))
+		for i, v := range n.Values {
+			values[i] = v
+		}
+		return b.arrayCondition(n.FieldPath, values, n.IsNegative)
+	case *NumberArrayCondition:
+		values := make([]interface{}, len(n.Values))
+		for i, v := range n.Values {
+			values[i] = v
+		}
+		return b.arrayCondition(n.FieldPath, values, n.IsNegative)
+	default:
+		return "", fmt.Errorf("%T type is not supported in Filtering", n)
+	}
+}
+
+func (b *sqlBuilder) logicalOperator(lop *LogicalOperator) (string, error) {
+	o := " AND "
+	if lop.Type == LogicalOperator_OR {
+		o = " OR "
+	}
+	var l []string
+	for _, node := range lop.operands() {
+		s, err := b.build(node)
+		if err != nil {
+			return "", err
+		}
+		l = append(l, s)
+	}
+	return negateSQL("("+strings.Join(l, o)+")", lop.IsNegative), nil
+}
+
+func (b *sqlBuilder) stringCondition(c *StringCondition) (string, error) {
+	col, err := b.column(c.FieldPath)
+	if err != nil {
+		return "", err
+	}
+	if c.NullSafe {
+		return b.nullSafeCondition(col, c.Value, c.IsNegative), nil
+	}
+	if c.RelativeTime {
+		return b.relativeTimeCondition(col, c)
+	}
+	switch c.Type {
+	case StringCondition_EQ:
+		if c.IsNegative {
+			return fmt.Sprintf("(%s <> %s)", col, b.placeholder(c.Value)), nil
+		}
+		return fmt.Sprintf("(%s = %s)", col, b.placeholder(c.Value)), nil
+	case StringCondition_IEQ:
+		return negateSQL(fmt.Sprintf("(lower(%s) = lower(%s))", col, b.placeholder(c.Value)), c.IsNegative), nil
+	case StringCondition_MATCH:
+		if b.like {
+			pattern, err := RegexpToLike(c.Value)
+			if err != nil {
+				return "", err
+			}
+			if c.IsNegative {
+				return fmt.Sprintf("(%s NOT LIKE %s)", col, b.placeholder(pattern)), nil
+			}
+			return fmt.Sprintf("(%s LIKE %s)", col, b.placeholder(pattern)), nil
+		}
+		if c.IsNegative {
+			return fmt.Sprintf("(%s !~ %s)", col, b.placeholder(c.Value)), nil
+		}
+		return fmt.Sprintf("(%s ~ %s)", col, b.placeholder(c.Value)), nil
+	case StringCondition_LIKE:
+		if c.IsNegative {
+			return fmt.Sprintf("(%s NOT LIKE %s)", col, b.placeholder(c.Value)), nil
+		}
+		return fmt.Sprintf("(%s LIKE %s)", col, b.placeholder(c.Value)), nil
+	case StringCondition_ILIKE:
+		if c.IsNegative {
+			return fmt.Sprintf("(%s NOT ILIKE %s)", col, b.placeholder(c.Value)), nil
+		}
+		return fmt.Sprintf("(%s ILIKE %s)", col, b.placeholder(c.Value)), nil
+	case StringCondition_IN_CIDR:
+		return negateSQL(fmt.Sprintf("(%s <<= %s::inet)", col, b.placeholder(c.Value)), c.IsNegative), nil
+	case StringCondition_GT:
+		return negateSQL(fmt.Sprintf("(%s > %s)", col, b.placeholder(c.Value)), c.IsNegative), nil
+	case StringCondition_GE:
+		return negateSQL(fmt.Sprintf("(%s >= %s)", col, b.placeholder(c.Value)), c.IsNegative), nil
+	case StringCondition_LT:
+		return negateSQL(fmt.Sprintf("(%s < %s)", col, b.placeholder(c.Value)), c.IsNegative), nil
+	case StringCondition_LE:
+		return negateSQL(fmt.Sprintf("(%s <= %s)", col, b.placeholder(c.Value)), c.IsNegative), nil
+	default:
+		return "", &UnsupportedOperatorError{"string", c.Type.String()}
+	}
+}
+
+// relativeTimeCondition translates comparison with a relative time literal either to an expression
+// of Postgres current time or to a parameterized absolute time if a clock is set by WithSQLClock.
+func (b *sqlBuilder) relativeTimeCondition(col string, c *StringCondition) (string, error) {
+	var value string
+	if b.clock != nil {
+		t, err := ResolveRelativeTime(c.Value, b.clock)
+		if err != nil {
+			return "", err
+		}
+		value = b.placeholder(t)
+	} else {
+		var err error
+		if value, err = RelativeTimeSQL(c.Value); err != nil {
+			return "", err
+		}
+	}
+	var o string
+	switch c.Type {
+	case StringCondition_EQ:
+		if c.IsNegative {
+			return fmt.Sprintf("(%s <> %s)", col, value), nil
+		}
+		o = "="
+	case StringCondition_GT:
+		o = ">"
+	case StringCondition_GE:
+		o = ">="
+	case StringCondition_LT:
+		o = "<"
+	case StringCondition_LE:
+		o = "<="
+	default:
+		return "", &UnsupportedOperatorError{"time", c.Type.String()}
+	}
+	return negateSQL(fmt.Sprintf("(%s %s %s)", col, o, value), c.IsNegative), nil
+}
+
+// relativeTimeSQLUnits are Postgres interval units of relative time literal units.
+var relativeTimeSQLUnits = map[byte]string{
+	's': "second",
+	'm': "minute",
+	'h': "hour",
+	'd': "day",
+	'w': "week",
+}
+
+// RelativeTimeSQL returns Postgres expression of relative time literal s, e.g. now() - interval '7 days' for now-7d.
+// Days are calendar days for timestamptz columns unlike evaluation where a day is always 24 hours.
+func RelativeTimeSQL(s string) (string, error) {
+	lit := s
+	s = strings.ToLower(s)
+	// the offset is checked for overflow as it is done by the evaluation
+	if _, ok := parseRelativeTime(s); !ok {
+		return "", &InvalidLiteralError{"relative time", lit, errInvalidRelativeTime}
+	}
+	offsets, _ := relativeTimeOffsets(s)
+	expr := "now()"
+	for _, o := range offsets {
+		sign, n := "+", o.n
+		if n < 0 {
+			sign, n = "-", -n
+		}
+		unit := relativeTimeSQLUnits[o.unit]
+		if n != 1 {
+			unit += "s"
+		}
+		expr += fmt.Sprintf(" %s interval '%d %s'", sign, n, unit)
+	}
+	return expr, nil
+}
+
+func (b *sqlBuilder) numberCondition(c *NumberCondition) (string, error) {
+	col, err := b.column(c.FieldPath)
+	if err != nil {
+		return "", err
+	}
+	if c.NullSafe {
+		return b.nullSafeCondition(col, c.Value, c.IsNegative), nil
+	}
+	var o string
+	switch c.Type {
+	case NumberCondition_EQ:
+		if c.IsNegative {
+			return fmt.Sprintf("(%s <> %s)", col, b.placeholder(c.Value)), nil
+		}
+		o = "="
+	case NumberCondition_GT:
+		o = ">"
+	case NumberCondition_GE:
+		o = ">="
+	case NumberCondition_LT:
+		o = "<"
+	case NumberCondition_LE:
+		o = "<="
+	default:
+		return "", &UnsupportedOperatorError{"number", c.Type.String()}
+	}
+	return negateSQL(fmt.Sprintf("(%s %s %s)", col, o, b.placeholder(c.Value)), c.IsNegative), nil
+}
+
+// fieldCondition returns comparison of two columns, e.g. (start_date < end_date).
+func (b *sqlBuilder) fieldCondition(c *FieldCondition) (string, error) {
+	col, err := b.column(c.FieldPath)
+	if err != nil {
+		return "", err
+	}
+	vcol, err := b.column(c.ValueFieldPath)
+	if err != nil {
+		return "", err
+	}
+	var o string
+	switch c.Type {
+	case FieldCondition_EQ:
+		if c.IsNegative {
+			return fmt.Sprintf("(%s <> %s)", col, vcol), nil
+		}
+		o = "="
+	case FieldCondition_GT:
+		o = ">"
+	case FieldCondition_GE:
+		o = ">="
+	case FieldCondition_LT:
+		o = "<"
+	case FieldCondition_LE:
+		o = "<="
+	default:
+		return "", &UnsupportedOperatorError{"field", c.Type.String()}
+	}
+	return negateSQL(fmt.Sprintf("(%s %s %s)", col, o, vcol), c.IsNegative), nil
+}
+
+// containsCondition returns Postgres jsonb containment check of a column, e.g. (attributes @> $1::jsonb),
+// the JSON text of the condition is passed as an argument.
+func (b *sqlBuilder) containsCondition(c *ContainsCondition) (string, error) {
+	col, err := b.column(c.FieldPath)
+	if err != nil {
+		return "", err
+	}
+	return negateSQL(fmt.Sprintf("(%s @> %s::jsonb)", col, b.placeholder(c.Value)), c.IsNegative), nil
+}
+
+// nullSafeCondition returns null-safe equality of column col to value, which is false if col is null,
+// or inequality if neg is set, which is true if col is null.
+func (b *sqlBuilder) nullSafeCondition(col string, value interface{}, neg bool) string {
+	if neg {
+		return fmt.Sprintf("(%s IS DISTINCT FROM %s)", col, b.placeholder(value))
+	}
+	return fmt.Sprintf("(%s IS NOT DISTINCT FROM %s)", col, b.placeholder(value))
+}
+
+// nullCondition returns null check of c, it is the same for null-safe equality to null.
+func (b *sqlBuilder) nullCondition(c *NullCondition) (string, error) {
+	col, err := b.column(c.FieldPath)
+	if err != nil {
+		return "", err
+	}
+	if c.IsNegative {
+		return fmt.Sprintf("(%s IS NOT NULL)", col), nil
+	}
+	return fmt.Sprintf("(%s IS NULL)", col), nil
+}
+
+func (b *sqlBuilder) boolCondition(c *BoolCondition) (string, error) {
+	col, err := b.column(c.FieldPath)
+	if err != nil {
+		return "", err
+	}
+	if c.NullSafe {
+		return b.nullSafeCondition(col, c.Value, c.IsNegative), nil
+	}
+	var o string
+	switch c.Type {
+	case BoolCondition_EQ:
+		if c.IsNegative {
+			return fmt.Sprintf("(%s <> %s)", col, b.placeholder(c.Value)), nil
+		}
+		o = "="
+	case BoolCondition_GT:
+		o = ">"
+	case BoolCondition_GE:
+		o = ">="
+	case BoolCondition_LT:
+		o = "<"
+	case BoolCondition_LE:
+		o = "<="
+	default:
+		return "", &UnsupportedOperatorError{"bool", c.Type.String()}
+	}
+	return negateSQL(fmt.Sprintf("(%s %s %s)", col, o, b.placeholder(c.Value)), c.IsNegative), nil
+}
+
+func (b *sqlBuilder) arrayCondition(fieldPath []string, values []interface{}, neg bool) (string, error) {
+	col, err := b.column(fieldPath)
+	if err != nil {
+		return "", err
+	}
+	placeholders := make([]string, len(values))
+	for i, v := range values {
+		placeholders[i] = b.placeholder(v)
+	}
+	o := "IN"
+	if neg {
+		o = "NOT IN"
+	}
+	return fmt.Sprintf("(%s %s (%s))", col, o, strings.Join(placeholders, ", ")), nil
+}
+
+func negateSQL(s string, neg bool) string {
+	if neg {
+		return "NOT" + s
+	}
+	return s
+}
@@ -0,0 +1,218 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToSQL(t *testing.T) {
+	mapping := map[string]string{
+		"name":       "name",
+		"age":        "age",
+		"active":     "is_active",
+		"address.id": "address_id",
+		"attributes": "attributes",
+	}
+
+	tests := []struct {
+		filter string
+		like   bool
+		sql    string
+		args   []interface{}
+	}{
+		{
+			filter: "",
+			sql:    "",
+		},
+		{
+			filter: "not(name == 'abc' or age > 3 and active != true)",
+			sql:    "NOT((name = $1) OR ((age > $2) AND (is_active <> $3)))",
+			args:   []interface{}{"abc", 3.0, true},
+		},
+		{
+			filter: "active > false or not active <= true",
+			sql:    "((is_active > $1) OR NOT(is_active <= $2))",
+			args:   []interface{}{false, true},
+		},
+		{
+			filter: "name != 'abc' and not age >= 3",
+			sql:    "((name <> $1) AND NOT(age >= $2))",
+			args:   []interface{}{"abc", 3.0},
+		},
+		{
+			filter: "name ~ 'a.*' or name !~ 'b.*'",
+			sql:    "((name ~ $1) OR (name !~ $2))",
+			args:   []interface{}{"a.*", "b.*"},
+		},
+		{
+			filter: "name ~ '^a' or name !~ 'b.*c$'",
+			like:   true,
+			sql:    "((name LIKE $1) OR (name NOT LIKE $2))",
+			args:   []interface{}{"a%", "%b%c"},
+		},
+		{
+			filter: "name like 'a\\%%' or name not like '_b'",
+			sql:    "((name LIKE $1) OR (name NOT LIKE $2))",
+			args:   []interface{}{"a\\%%", "_b"},
+		},
+		{
+			filter: "name ilike 'a\\%%' and name not ilike '_b'",
+			sql:    "((name ILIKE $1) AND (name NOT ILIKE $2))",
+			args:   []interface{}{"a\\%%", "_b"},
+		},
+		{
+			filter: "name in_cidr '10.0.0.0/8' and name not in_cidr '10.1.0.0/16'",
+			sql:    "((name <<= $1::inet) AND NOT(name <<= $2::inet))",
+			args:   []interface{}{"10.0.0.0/8", "10.1.0.0/16"},
+		},
+		{
+			filter: "name := 'AbC'",
+			sql:    "(lower(name) = lower($1))",
+			args:   []interface{}{"AbC"},
+		},
+		{
+			filter: `attributes contains '{"tier": "gold"}' and not attributes contains '{"tags": ["a"]}'`,
+			sql:    "((attributes @> $1::jsonb) AND NOT(attributes @> $2::jsonb))",
+			args:   []interface{}{`{"tier": "gold"}`, `{"tags": ["a"]}`},
+		},
+		{
+			filter: "address.id exists and name not exists",
+			sql:    "((address_id IS NOT NULL) AND (name IS NULL))",
+		},
+		{
+			filter: "address.id == null or name != null",
+			sql:    "((address_id IS NULL) OR (name IS NOT NULL))",
+		},
+		{
+			filter: "name != address.id or age < active and not name >= address.id",
+			sql:    "((name <> address_id) OR ((age < is_active) AND NOT(name >= address_id)))",
+		},
+		{
+			filter: "name <=> 'abc' and not age <=> 3 or active <=> true or address.id <=> null",
+			sql:    "(((name IS NOT DISTINCT FROM $1) AND (age IS DISTINCT FROM $2)) OR (is_active IS NOT DISTINCT FROM $3) OR (address_id IS NULL))",
+			args:   []interface{}{"abc", 3.0, true},
+		},
+		{
+			filter: "name in ['a', 'b'] and age not in [1, 2]",
+			sql:    "((name IN ($1, $2)) AND (age NOT IN ($3, $4)))",
+			args:   []interface{}{"a", "b", 1.0, 2.0},
+		},
+	}
+
+	for _, test := range tests {
+		f, err := ParseFiltering(test.filter)
+		assert.NoError(t, err)
+		opts := []SQLOption{WithSQLFieldMapping(mapping)}
+		if test.like {
+			opts = append(opts, WithSQLLikeMatching())
+		}
+		sql, args, err := ToSQL(f, opts...)
+		assert.NoError(t, err)
+		assert.Equal(t, test.sql, sql)
+		assert.Equal(t, test.args, args)
+	}
+}
+
+func TestRegexpToLike(t *testing.T) {
+	tests := []struct {
+		pattern string
+		like    string
+	}{
+		{"abc", "%abc%"},
+		{"^abc$", "abc"},
+		{"^a.c", "a_c%"},
+		{".*abc.*", "%abc%"},
+		{"^a.*b.*$", "a%b%"},
+		{"100%_off", `%100\%\_off%`},
+		{`^a\.b\$$`, "a.b$"},
+		{`a\\b`, `%a\\b%`},
+		{"", "%"},
+	}
+	for _, test := range tests {
+		like, err := RegexpToLike(test.pattern)
+		assert.NoError(t, err, test.pattern)
+		assert.Equal(t, test.like, like, test.pattern)
+	}
+
+	for _, pattern := range []string{"a+", "[ab]", "a|b", "(a)", "a?", "a{2}", `\d`, "a$b", "a^", `a\`, "(?i)a"} {
+		_, err := RegexpToLike(pattern)
+		assert.IsType(t, &RegexpNotAllowedError{}, err, pattern)
+	}
+
+	f, err := ParseFiltering("name ~ '[ab]+'")
+	assert.NoError(t, err)
+	_, _, err = ToSQL(f, WithSQLFieldMapping(map[string]string{"name": "name"}), WithSQLLikeMatching())
+	assert.IsType(t, &RegexpNotAllowedError{}, err)
+}
+
+func TestToSQLQuestionPlaceholders(t *testing.T) {
+	f, err := ParseFiltering("name == 'abc' and age in [1, 2]")
+	assert.NoError(t, err)
+	sql, args, err := ToSQL(f, WithSQLFieldMapping(map[string]string{"name": "name", "age": "age"}), WithSQLQuestionPlaceholders())
+	assert.NoError(t, err)
+	assert.Equal(t, "((name = ?) AND (age IN (?, ?)))", sql)
+	assert.Equal(t, []interface{}{"abc", 1.0, 2.0}, args)
+}
+
+func TestToSQLRelativeTime(t *testing.T) {
+	mapping := WithSQLFieldMapping(map[string]string{"created": "created_at"})
+	f, err := ParseFiltering("created > now-7d and created <= now-1d+12h and created != now")
+	assert.NoError(t, err)
+
+	sql, args, err := ToSQL(f, mapping)
+	assert.NoError(t, err)
+	assert.Equal(t, "((created_at > now() - interval '7 days') AND (created_at <= now() - interval '1 day' + interval '12 hours') AND (created_at <> now()))", sql)
+	assert.Empty(t, args)
+
+	now := time.Date(2023, 1, 8, 0, 0, 0, 0, time.UTC)
+	sql, args, err = ToSQL(f, mapping, WithSQLClock(func() time.Time { return now }))
+	assert.NoError(t, err)
+	assert.Equal(t, "((created_at > $1) AND (created_at <= $2) AND (created_at <> $3))", sql)
+	assert.Equal(t, []interface{}{now.AddDate(0, 0, -7), now.Add(-12 * time.Hour), now}, args)
+}
+
+func TestToSQLPrecedence(t *testing.T) {
+	mapping := WithSQLFieldMapping(map[string]string{"a": "a", "b": "b", "c": "c"})
+	tests := map[string]string{
+		"a == 1 or b == 2 and c == 3":       "((a = ?) OR ((b = ?) AND (c = ?)))",
+		"(a == 1 or b == 2) and c == 3":     "(((a = ?) OR (b = ?)) AND (c = ?))",
+		"a == 1 or b == 2 or c == 3":        "((a = ?) OR (b = ?) OR (c = ?))",
+		"a == 1 and (b == 2 and c == 3)":    "((a = ?) AND (b = ?) AND (c = ?))",
+		"a == 1 and not(b == 2 and c == 3)": "((a = ?) AND NOT((b = ?) AND (c = ?)))",
+	}
+	for filter, expected := range tests {
+		f, err := ParseFiltering(filter)
+		assert.NoError(t, err)
+		sql, _, err := ToSQL(f, mapping, WithSQLQuestionPlaceholders())
+		assert.NoError(t, err)
+		assert.Equal(t, expected, sql, filter)
+	}
+}
+
+func TestToSQLLongChain(t *testing.T) {
+	conds := make([]string, 2000)
+	for i := range conds {
+		conds[i] = fmt.Sprintf("id == %d", i)
+	}
+	f, err := ParseFilteringWithLimits(strings.Join(conds, " or "), FilteringLimits{MaxNodes: 4000})
+	assert.NoError(t, err)
+	sql, args, err := ToSQL(f, WithSQLFieldMapping(map[string]string{"id": "id"}), WithSQLQuestionPlaceholders())
+	assert.NoError(t, err)
+	assert.Equal(t, "("+strings.Repeat("(id = ?) OR ", 1999)+"(id = ?))", sql)
+	assert.Len(t, args, 2000)
+	assert.Equal(t, 1999.0, args[1999])
+}
+
+func TestToSQLUnmappedField(t *testing.T) {
+	f, err := ParseFiltering("name == 'abc' or id == 1")
+	assert.NoError(t, err)
+	_, _, err = ToSQL(f, WithSQLFieldMapping(map[string]string{"name": "name"}))
+	assert.Equal(t, &UnknownFieldError{FieldPath: []string{"id"}}, err)
+
+	_, _, err = ToSQL(f)
+	assert.IsType(t, &UnknownFieldError{}, err)
+}
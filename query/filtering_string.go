@@ -0,0 +1,323 @@
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// GoString implements fmt.GoStringer interface and returns a normalized representation
+// of the filtering expression in REST API Syntax where every logical operator is
+// parenthesized, e.g. "(str == '111' and (int > 5 or not bool == true))".
+// The result can be parsed back with ParseFiltering to the same expression.
+// String method of Filtering is generated by protoc and renders the proto message itself.
+func (m *Filtering) GoString() string {
+	if m == nil {
+		return ""
+	}
+	return nodeString(m.Root)
+}
+
+// Dump returns a typed tree of the filtering expression nodes, one node per line
+// indented according to its depth, e.g.
+//
+//	LogicalOperator AND
+//	  StringCondition EQ str "111"
+//	  NumberCondition NOT GT int 5
+func (m *Filtering) Dump() string {
+	if m == nil {
+		return ""
+	}
+	var lines []string
+	dumpNode(m.Root, 0, &lines)
+	return strings.Join(lines, "\n")
+}
+
+func nodeString(node interface{}) string {
+	switch n := unwrapNode(node).(type) {
+	case *LogicalOperator:
+		o := "and"
+		if n.Type == LogicalOperator_OR {
+			o = "or"
+		}
+		var l []string
+		for _, op := range n.operands() {
+			l = append(l, nodeString(op))
+		}
+		return notString("("+strings.Join(l, " "+o+" ")+")", n.IsNegative)
+	case *StringCondition:
+		if n.NullSafe {
+			return nullSafeString(n.FieldPath, quoteString(n.Value), n.IsNegative)
+		}
+		return stringConditionString(n)
+	case *NumberCondition:
+		if n.NullSafe {
+			return nullSafeString(n.FieldPath, numberString(n.Value), n.IsNegative)
+		}
+		var o string
+		switch n.Type {
+		case NumberCondition_EQ:
+			if n.IsNegative {
+				return fmt.Sprintf("%s != %s", fieldPathString(n.FieldPath), numberString(n.Value))
+			}
+			o = "=="
+		case NumberCondition_GT:
+			o = ">"
+		case NumberCondition_GE:
+			o = ">="
+		case NumberCondition_LT:
+			o = "<"
+		case NumberCondition_LE:
+			o = "<="
+		}
+		return notString(fmt.Sprintf("%s %s %s", fieldPathString(n.FieldPath), o, numberString(n.Value)), n.IsNegative)
+	case *NullCondition:
+		if n.NullSafe {
+			return nullSafeString(n.FieldPath, "null", n.IsNegative)
+		}
+		if n.IsNegative {
+			return fmt.Sprintf("%s != null", fieldPathString(n.FieldPath))
+		}
+		return fmt.Sprintf("%s == null", fieldPathString(n.FieldPath))
+	case *BoolCondition:
+		if n.NullSafe {
+			return nullSafeString(n.FieldPath, fmt.Sprint(n.Value), n.IsNegative)
+		}
+		var o string
+		switch n.Type {
+		case BoolCondition_EQ:
+			if n.IsNegative {
+				return fmt.Sprintf("%s != %t", fieldPathString(n.FieldPath), n.Value)
+			}
+			o = "=="
+		case BoolCondition_GT:
+			o = ">"
+		case BoolCondition_GE:
+			o = ">="
+		case BoolCondition_LT:
+			o = "<"
+		case BoolCondition_LE:
+			o = "<="
+		}
+		return notString(fmt.Sprintf("%s %s %t", fieldPathString(n.FieldPath), o, n.Value), n.IsNegative)
+	case *StringArrayCondition:
+		values := make([]string, len(n.Values))
+		for i, v := range n.Values {
+			values[i] = quoteString(v)
+		}
+		return inString(n.FieldPath, values, n.IsNegative)
+	case *NumberArrayCondition:
+		values := make([]string, len(n.Values))
+		for i, v := range n.Values {
+			values[i] = numberString(v)
+		}
+		return inString(n.FieldPath, values, n.IsNegative)
+	case *ExistsCondition:
+		return notString(fieldPathString(n.FieldPath)+" exists", n.IsNegative)
+	case *FieldCondition:
+		var o string
+		switch n.Type {
+		case FieldCondition_EQ:
+			if n.IsNegative {
+				return fmt.Sprintf("%s != %s", fieldPathString(n.FieldPath), fieldPathString(n.ValueFieldPath))
+			}
+			o = "=="
+		case FieldCondition_GT:
+			o = ">"
+		case FieldCondition_GE:
+			o = ">="
+		case FieldCondition_LT:
+			o = "<"
+		case FieldCondition_LE:
+			o = "<="
+		}
+		return notString(fmt.Sprintf("%s %s %s", fieldPathString(n.FieldPath), o, fieldPathString(n.ValueFieldPath)), n.IsNegative)
+	case *CustomCondition:
+		return notString(fmt.Sprintf("%s %s %s", fieldPathString(n.FieldPath), n.Operator, literalString(n.Literal())), n.IsNegative)
+	case *ContainsCondition:
+		return notString(fmt.Sprintf("%s contains %s", fieldPathString(n.FieldPath), quoteString(n.Value)), n.IsNegative)
+	default:
+		return ""
+	}
+}
+
+// nullSafeString returns a representation of null-safe equality of fieldPath to the literal value.
+func nullSafeString(fieldPath []string, value string, neg bool) string {
+	return notString(fmt.Sprintf("%s <=> %s", fieldPathString(fieldPath), value), neg)
+}
+
+// literalString returns a representation of the literal of a custom condition.
+func literalString(v interface{}) string {
+	switch l := v.(type) {
+	case string:
+		return quoteString(l)
+	case float64:
+		return numberString(l)
+	case []string:
+		values := make([]string, len(l))
+		for i, s := range l {
+			values[i] = quoteString(s)
+		}
+		return "[" + strings.Join(values, ", ") + "]"
+	case []float64:
+		values := make([]string, len(l))
+		for i, f := range l {
+			values[i] = numberString(f)
+		}
+		return "[" + strings.Join(values, ", ") + "]"
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func stringConditionString(c *StringCondition) string {
+	field, value := fieldPathString(c.FieldPath), quoteString(c.Value)
+	if c.RelativeTime {
+		// relative time literals are not quoted, otherwise they are parsed as strings
+		value = c.Value
+	}
+	var o string
+	switch c.Type {
+	case StringCondition_EQ:
+		if c.IsNegative {
+			return fmt.Sprintf("%s != %s", field, value)
+		}
+		o = "=="
+	case StringCondition_MATCH:
+		if c.IsNegative {
+			return fmt.Sprintf("%s !~ %s", field, value)
+		}
+		o = "~"
+	case StringCondition_LIKE:
+		if c.IsNegative {
+			return fmt.Sprintf("%s not like %s", field, value)
+		}
+		o = "like"
+	case StringCondition_ILIKE:
+		if c.IsNegative {
+			return fmt.Sprintf("%s not ilike %s", field, value)
+		}
+		o = "ilike"
+	case StringCondition_IN_CIDR:
+		if c.IsNegative {
+			return fmt.Sprintf("%s not in_cidr %s", field, value)
+		}
+		o = "in_cidr"
+	case StringCondition_IEQ:
+		o = ":="
+	case StringCondition_GT:
+		o = ">"
+	case StringCondition_GE:
+		o = ">="
+	case StringCondition_LT:
+		o = "<"
+	case StringCondition_LE:
+		o = "<="
+	}
+	return notString(fmt.Sprintf("%s %s %s", field, o, value), c.IsNegative)
+}
+
+func inString(fieldPath []string, values []string, isNegative bool) string {
+	o := "in"
+	if isNegative {
+		o = "not in"
+	}
+	return fmt.Sprintf("%s %s [%s]", fieldPathString(fieldPath), o, strings.Join(values, ", "))
+}
+
+func notString(s string, isNegative bool) string {
+	if isNegative {
+		return "not " + s
+	}
+	return s
+}
+
+// fieldPathString returns dot-notated field path, names that cannot be lexed as is,
+// e.g. ones with spaces or reserved words, are quoted with backticks. Paths starting with now
+// are quoted as well, since they are read as relative time literals in place of a value.
+func fieldPathString(fieldPath []string) string {
+	names := make([]string, len(fieldPath))
+	for i, name := range fieldPath {
+		names[i] = name
+		if !isFieldName(name, i == 0) || len(fieldPath) == 1 && !isFieldToken(name) || i == 0 && isRelativeTimeWord(name) {
+			names[i] = "`" + strings.Replace(name, "`", "``", -1) + "`"
+		}
+	}
+	return strings.Join(names, ".")
+}
+
+// isFieldName reports whether name can be a part of an unquoted field path,
+// the first name of which must start with a letter.
+func isFieldName(name string, first bool) bool {
+	for i, c := range name {
+		if !isFieldNameChar(c) || first && i == 0 && !unicode.IsLetter(c) {
+			return false
+		}
+	}
+	return name != ""
+}
+
+// isFieldToken reports whether s is lexed as a field rather than a reserved word or a custom operator.
+func isFieldToken(s string) bool {
+	t, err := newFilteringLexer(s).NextToken()
+	ft, ok := t.(FieldToken)
+	return err == nil && ok && ft.Value == s
+}
+
+func numberString(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// quoteString returns single-quoted string literal with backslash escapes
+// that are recognized by the filtering lexer.
+func quoteString(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `'`, `\'`, "\n", `\n`, "\t", `\t`, "\r", `\r`)
+	return "'" + r.Replace(s) + "'"
+}
+
+func dumpNode(node interface{}, depth int, lines *[]string) {
+	indent := strings.Repeat("  ", depth)
+	var line string
+	switch n := unwrapNode(node).(type) {
+	case *LogicalOperator:
+		*lines = append(*lines, indent+"LogicalOperator "+notDump(n.IsNegative)+n.Type.String())
+		dumpNode(n.Left, depth+1, lines)
+		dumpNode(n.Right, depth+1, lines)
+		return
+	case *StringCondition:
+		if n.RelativeTime {
+			line = fmt.Sprintf("StringCondition %s%s %s %s", notDump(n.IsNegative), n.Type, fieldPathString(n.FieldPath), n.Value)
+			break
+		}
+		line = fmt.Sprintf("StringCondition %s%s %s %q", notDump(n.IsNegative), n.Type, fieldPathString(n.FieldPath), n.Value)
+	case *NumberCondition:
+		line = fmt.Sprintf("NumberCondition %s%s %s %s", notDump(n.IsNegative), n.Type, fieldPathString(n.FieldPath), numberString(n.Value))
+	case *NullCondition:
+		line = fmt.Sprintf("NullCondition %s%s", notDump(n.IsNegative), fieldPathString(n.FieldPath))
+	case *BoolCondition:
+		line = fmt.Sprintf("BoolCondition %s%s %s %t", notDump(n.IsNegative), n.Type, fieldPathString(n.FieldPath), n.Value)
+	case *StringArrayCondition:
+		line = fmt.Sprintf("StringArrayCondition %s%s %s %q", notDump(n.IsNegative), n.Type, fieldPathString(n.FieldPath), n.Values)
+	case *NumberArrayCondition:
+		line = fmt.Sprintf("NumberArrayCondition %s%s %s %v", notDump(n.IsNegative), n.Type, fieldPathString(n.FieldPath), n.Values)
+	case *ExistsCondition:
+		line = fmt.Sprintf("ExistsCondition %s%s", notDump(n.IsNegative), fieldPathString(n.FieldPath))
+	case *FieldCondition:
+		line = fmt.Sprintf("FieldCondition %s%s %s %s", notDump(n.IsNegative), n.Type, fieldPathString(n.FieldPath), fieldPathString(n.ValueFieldPath))
+	case *CustomCondition:
+		line = fmt.Sprintf("CustomCondition %s%s %s %s", notDump(n.IsNegative), n.Operator, fieldPathString(n.FieldPath), literalString(n.Literal()))
+	case *ContainsCondition:
+		line = fmt.Sprintf("ContainsCondition %s%s %s", notDump(n.IsNegative), fieldPathString(n.FieldPath), n.Value)
+	default:
+		return
+	}
+	*lines = append(*lines, indent+line)
+}
+
+func notDump(isNegative bool) string {
+	if isNegative {
+		return "NOT "
+	}
+	return ""
+}
@@ -0,0 +1,110 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilteringGoString(t *testing.T) {
+	tests := []struct {
+		filter string
+		str    string
+	}{
+		{
+			filter: "str == '111' and int > 5",
+			str:    "(str == '111' and int > 5)",
+		},
+		{
+			filter: "a == 1 or b != 2 and not (c < 3 or d >= 4.5)",
+			str:    "(a == 1 or (b != 2 and not (c < 3 or d >= 4.5)))",
+		},
+		{
+			filter: "not str ~ 'a.*' and str !~ 'b' and str like '%c_' and str not like 'd'",
+			str:    "(str !~ 'a.*' and str !~ 'b' and str like '%c_' and str not like 'd')",
+		},
+		{
+			filter: "str ilike 'A%' and not str ilike '%b'",
+			str:    "(str ilike 'A%' and str not ilike '%b')",
+		},
+		{
+			filter: "str in_cidr '10.0.0.0/8' and not str in_cidr '::/0'",
+			str:    "(str in_cidr '10.0.0.0/8' and str not in_cidr '::/0')",
+		},
+		{
+			filter: "str := 'AbC' or str >= 'a' or not str < 'z'",
+			str:    "(str := 'AbC' or str >= 'a' or not str < 'z')",
+		},
+		{
+			filter: "nested.str == null and ptr != null and bool == true and not bool != false",
+			str:    "(nested.str == null and ptr != null and bool == true and bool == false)",
+		},
+		{
+			filter: "bool > false or not bool <= true",
+			str:    "(bool > false or not bool <= true)",
+		},
+		{
+			filter: "ptr exists and not nested exists and str not exists",
+			str:    "(ptr exists and not nested exists and not str exists)",
+		},
+		{
+			filter: `attrs contains '{"tier": "gold"}' and not attrs contains '{"a": [1]}'`,
+			str:    `(attrs contains '{"tier": "gold"}' and not attrs contains '{"a": [1]}')`,
+		},
+		{
+			filter: "str in ['a', 'b'] and int not in [1, 2.5]",
+			str:    "(str in ['a', 'b'] and int not in [1, 2.5])",
+		},
+		{
+			filter: "price between 10 and 99.5",
+			str:    "(price >= 10 and price <= 99.5)",
+		},
+		{
+			filter: `str == 'O\'Brien \\ "x"' or str == "line\nbreak"`,
+			str:    `(str == 'O\'Brien \\ "x"' or str == 'line\nbreak')`,
+		},
+		{
+			filter: "`user name` == 'x' and `and` != null and nested.`a.b`.c > 1 and `it``s` == true",
+			str:    "(`user name` == 'x' and `and` != null and nested.`a.b`.c > 1 and `it``s` == true)",
+		},
+		{
+			filter: "`str` == 'x' and `nested`.str == `_id` and nested.`0` == 1",
+			str:    "(str == 'x' and nested.str == `_id` and nested.0 == 1)",
+		},
+	}
+
+	for _, test := range tests {
+		f, err := ParseFiltering(test.filter)
+		assert.Nil(t, err, test.filter)
+		assert.Equal(t, test.str, f.GoString(), test.filter)
+
+		// normalized representation is parsed back to the same expression
+		parsed, err := ParseFiltering(f.GoString())
+		assert.Nil(t, err, test.filter)
+		assert.Equal(t, f, parsed, test.filter)
+	}
+
+	var f *Filtering
+	assert.Equal(t, "", f.GoString())
+}
+
+func TestFilteringDump(t *testing.T) {
+	f, err := ParseFiltering("str == '111' and not (int > 5 or nested.str == null) or str in ['a', 'b']")
+	assert.Nil(t, err)
+	expected := `LogicalOperator OR
+  LogicalOperator AND
+    StringCondition EQ str "111"
+    LogicalOperator NOT OR
+      NumberCondition GT int 5
+      NullCondition nested.str
+  StringArrayCondition IN str ["a" "b"]`
+	assert.Equal(t, expected, f.Dump())
+
+	// relative time literals are not quoted
+	f, err = ParseFiltering("created > now-7d")
+	assert.Nil(t, err)
+	assert.Equal(t, "StringCondition GT created now-7d", f.Dump())
+
+	f = nil
+	assert.Equal(t, "", f.Dump())
+}
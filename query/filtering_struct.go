@@ -0,0 +1,264 @@
+package query
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+
+	structpb "github.com/golang/protobuf/ptypes/struct"
+)
+
+var (
+	structType  = reflect.TypeOf((*structpb.Struct)(nil))
+	valueType   = reflect.TypeOf((*structpb.Value)(nil))
+	jsonMapType = reflect.TypeOf(map[string]interface{}(nil))
+)
+
+// dynamicHolder holds a value of google.protobuf.Value converted to a Go value,
+// so that conditions can be evaluated against it as against any other field.
+type dynamicHolder struct {
+	Value interface{} `json:"value"`
+}
+
+var dynamicFieldPath = []string{"value"}
+
+// filterDynamic evaluates condition node against obj if fieldPath goes through
+// a google.protobuf.Struct or google.protobuf.Value field, e.g. "metadata.region",
+// or through a map[string]interface{} holding decoded JSON, including obj itself.
+// The rest of the path is resolved dynamically by keys of the struct, a missing key is null.
+// Returned ok is false if fieldPath does not refer to a dynamic value.
+func filterDynamic(node interface{}, obj interface{}, fieldPath []string, neg bool, o *filterOptions) (res bool, ok bool, err error) {
+	dv, ok := dynamicField(obj, fieldPath)
+	if !ok {
+		return false, false, nil
+	}
+	res, err = filterDynamicValue(node, dv, neg, o)
+	return res, true, err
+}
+
+// filterDynamicValue evaluates condition node against dv which is nil for a missing key.
+// Conditions on a list value are satisfied if any of the elements satisfies them.
+func filterDynamicValue(node interface{}, dv *structpb.Value, neg bool, o *filterOptions) (bool, error) {
+	switch node.(type) {
+	case *NullCondition:
+		_, isNull := dv.GetKind().(*structpb.Value_NullValue)
+		return negateIfNeeded(neg, dv == nil || isNull), nil
+	case *ExistsCondition:
+		return negateIfNeeded(neg, dv != nil), nil
+	}
+	if l, ok := dv.GetKind().(*structpb.Value_ListValue); ok {
+		for _, e := range l.ListValue.GetValues() {
+			res, err := filterDynamicValue(node, e, false, o)
+			if err != nil {
+				return false, err
+			}
+			if res {
+				return negateIfNeeded(neg, true), nil
+			}
+		}
+		return negateIfNeeded(neg, false), nil
+	}
+	elem, v, ok := dynamicCondition(node, dv)
+	if !ok {
+		// null values and values of other kinds than the literal do not satisfy the condition
+		return false, nil
+	}
+	res, err := filterNode(elem, &dynamicHolder{v}, elemOptions(node, elem, o))
+	if err != nil {
+		return false, err
+	}
+	return negateIfNeeded(neg, res), nil
+}
+
+// dynamicCondition returns a non-negated copy of condition node to be evaluated against
+// dynamicHolder along with the Go value of dv: a string, float64, bool or *structpb.Struct.
+// String literals are coerced to numbers and booleans if dv holds a number or a bool.
+// Returned ok is false if dv is null or the condition cannot be applied to a value of its kind.
+func dynamicCondition(node interface{}, dv *structpb.Value) (interface{}, interface{}, bool) {
+	elem := positiveCondition(node, dynamicFieldPath)
+	if _, ok := elem.(*CustomCondition); ok {
+		switch k := dv.GetKind().(type) {
+		case *structpb.Value_StringValue:
+			return elem, k.StringValue, true
+		case *structpb.Value_NumberValue:
+			return elem, k.NumberValue, true
+		case *structpb.Value_BoolValue:
+			return elem, k.BoolValue, true
+		case *structpb.Value_StructValue:
+			return elem, k.StructValue, true
+		default:
+			return nil, nil, false
+		}
+	}
+	switch k := dv.GetKind().(type) {
+	case *structpb.Value_StringValue:
+		switch elem.(type) {
+		case *StringCondition, *StringArrayCondition:
+			return elem, k.StringValue, true
+		}
+	case *structpb.Value_NumberValue:
+		switch c := elem.(type) {
+		case *NumberCondition, *NumberArrayCondition:
+			return elem, k.NumberValue, true
+		case *StringCondition:
+			if nc, ok := c.numberCondition(); ok {
+				return nc, k.NumberValue, true
+			}
+		case *StringArrayCondition:
+			nc := &NumberArrayCondition{FieldPath: c.FieldPath, Type: NumberArrayCondition_IN}
+			for _, s := range c.Values {
+				if f, err := strconv.ParseFloat(s, 64); err == nil {
+					nc.Values = append(nc.Values, f)
+				}
+			}
+			return nc, k.NumberValue, true
+		}
+	case *structpb.Value_BoolValue:
+		switch c := elem.(type) {
+		case *BoolCondition:
+			return elem, k.BoolValue, true
+		case *StringCondition:
+			if b, err := strconv.ParseBool(c.Value); err == nil && c.Type == StringCondition_EQ {
+				return &BoolCondition{FieldPath: c.FieldPath, Value: b}, k.BoolValue, true
+			}
+		}
+	}
+	return nil, nil, false
+}
+
+// numberCondition converts comparison string condition to the number one
+// if its literal is a number.
+func (c *StringCondition) numberCondition() (*NumberCondition, bool) {
+	if !c.isComparison() {
+		return nil, false
+	}
+	f, err := strconv.ParseFloat(c.Value, 64)
+	if err != nil {
+		return nil, false
+	}
+	nc := &NumberCondition{FieldPath: c.FieldPath, Value: f}
+	switch c.Type {
+	case StringCondition_EQ:
+		nc.Type = NumberCondition_EQ
+	case StringCondition_GT:
+		nc.Type = NumberCondition_GT
+	case StringCondition_GE:
+		nc.Type = NumberCondition_GE
+	case StringCondition_LT:
+		nc.Type = NumberCondition_LT
+	case StringCondition_LE:
+		nc.Type = NumberCondition_LE
+	}
+	return nc, true
+}
+
+// dynamicField resolves fieldPath against obj up to the first google.protobuf.Struct,
+// google.protobuf.Value or map[string]interface{} field and resolves the rest of the path
+// by keys of nested structs or maps. Returned value is nil if a key is missing or any
+// of the intermediate values is not a struct. Returned ok is false if fieldPath does not go
+// through a dynamic value or a map value of the path is not a JSON value, e.g. time.Time.
+func dynamicField(obj interface{}, fieldPath []string) (*structpb.Value, bool) {
+	v := reflect.ValueOf(obj)
+	for i := 0; ; i++ {
+		if v.IsValid() {
+			switch v.Type() {
+			case structType:
+				s := v.Interface().(*structpb.Struct)
+				if s == nil {
+					return nil, true
+				}
+				return dynamicValueByPath(&structpb.Value{Kind: &structpb.Value_StructValue{StructValue: s}}, fieldPath[i:]), true
+			case valueType:
+				return dynamicValueByPath(v.Interface().(*structpb.Value), fieldPath[i:]), true
+			}
+			if m := dereferenceValue(v); m.IsValid() && m.Type() == jsonMapType {
+				if m.IsNil() {
+					return nil, true
+				}
+				return jsonValueByPath(m.Interface().(map[string]interface{}), fieldPath[i:])
+			}
+		}
+		if i == len(fieldPath) {
+			return nil, false
+		}
+		if v.Kind() == reflect.Ptr && v.IsNil() {
+			if v.Type().Elem().Kind() != reflect.Struct {
+				return nil, false
+			}
+			v = reflect.New(v.Type().Elem())
+		}
+		v = fieldByName(v, fieldPath[i])
+		if !v.IsValid() {
+			return nil, false
+		}
+	}
+}
+
+func dynamicValueByPath(v *structpb.Value, fieldPath []string) *structpb.Value {
+	for _, name := range fieldPath {
+		v = v.GetStructValue().GetFields()[name]
+	}
+	return v
+}
+
+// jsonValueByPath resolves fieldPath by keys of m and its nested maps and returns
+// the found value converted to google.protobuf.Value, nil is returned for a missing key
+// or if any of the intermediate values is not a map. Returned ok is false if the found value
+// is not a JSON value.
+func jsonValueByPath(m map[string]interface{}, fieldPath []string) (*structpb.Value, bool) {
+	var x interface{} = m
+	for _, name := range fieldPath {
+		m, ok := x.(map[string]interface{})
+		if !ok {
+			return nil, true
+		}
+		if x, ok = m[name]; !ok {
+			return nil, true
+		}
+	}
+	return toDynamicValue(x)
+}
+
+// toDynamicValue converts decoded JSON value x to google.protobuf.Value.
+// Numbers of any Go type are converted to float64, returned ok is false
+// if x or any of its elements is not a JSON value.
+func toDynamicValue(x interface{}) (*structpb.Value, bool) {
+	switch v := x.(type) {
+	case nil:
+		return &structpb.Value{Kind: &structpb.Value_NullValue{}}, true
+	case string:
+		return &structpb.Value{Kind: &structpb.Value_StringValue{StringValue: v}}, true
+	case bool:
+		return &structpb.Value{Kind: &structpb.Value_BoolValue{BoolValue: v}}, true
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return nil, false
+		}
+		return &structpb.Value{Kind: &structpb.Value_NumberValue{NumberValue: f}}, true
+	case map[string]interface{}:
+		s := &structpb.Struct{Fields: make(map[string]*structpb.Value, len(v))}
+		for k, e := range v {
+			ev, ok := toDynamicValue(e)
+			if !ok {
+				return nil, false
+			}
+			s.Fields[k] = ev
+		}
+		return &structpb.Value{Kind: &structpb.Value_StructValue{StructValue: s}}, true
+	case []interface{}:
+		l := &structpb.ListValue{Values: make([]*structpb.Value, len(v))}
+		for i, e := range v {
+			ev, ok := toDynamicValue(e)
+			if !ok {
+				return nil, false
+			}
+			l.Values[i] = ev
+		}
+		return &structpb.Value{Kind: &structpb.Value_ListValue{ListValue: l}}, true
+	}
+	if f, ok := numberValue(reflect.ValueOf(x)); ok {
+		return &structpb.Value{Kind: &structpb.Value_NumberValue{NumberValue: f}}, true
+	}
+	return nil, false
+}
@@ -0,0 +1,178 @@
+package query
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/stretchr/testify/assert"
+)
+
+type TestStructMessage struct {
+	Name     string           `protobuf:"bytes,1,opt,name=name"`
+	Metadata *structpb.Struct `protobuf:"bytes,2,opt,name=metadata"`
+	Extra    *structpb.Value  `protobuf:"bytes,3,opt,name=extra"`
+}
+
+func (m *TestStructMessage) Reset()         { *m = TestStructMessage{} }
+func (m *TestStructMessage) String() string { return proto.CompactTextString(m) }
+func (*TestStructMessage) ProtoMessage()    {}
+
+func structString(s string) *structpb.Value {
+	return &structpb.Value{Kind: &structpb.Value_StringValue{StringValue: s}}
+}
+
+func structNumber(f float64) *structpb.Value {
+	return &structpb.Value{Kind: &structpb.Value_NumberValue{NumberValue: f}}
+}
+
+func structBool(b bool) *structpb.Value {
+	return &structpb.Value{Kind: &structpb.Value_BoolValue{BoolValue: b}}
+}
+
+func TestFilteringStruct(t *testing.T) {
+	obj := &TestStructMessage{
+		Name: "a",
+		Metadata: &structpb.Struct{Fields: map[string]*structpb.Value{
+			"region":  structString("us-east"),
+			"size":    structNumber(10),
+			"enabled": structBool(true),
+			"nothing": {Kind: &structpb.Value_NullValue{}},
+			"labels": {Kind: &structpb.Value_ListValue{ListValue: &structpb.ListValue{
+				Values: []*structpb.Value{structString("x"), structString("y")},
+			}}},
+			"owner": {Kind: &structpb.Value_StructValue{StructValue: &structpb.Struct{
+				Fields: map[string]*structpb.Value{"name": structString("bob")},
+			}}},
+		}},
+		Extra: structNumber(5),
+	}
+
+	tests := []struct {
+		filter string
+		res    bool
+	}{
+		{"metadata.region == 'us-east'", true},
+		{"metadata.region != 'us-east'", false},
+		{"metadata.region ~ '^us-'", true},
+		{"metadata.region in ['eu', 'us-east']", true},
+		{"metadata.region == 5", false},
+		{"metadata.size == 10", true},
+		{"metadata.size > 5 and metadata.size <= 10", true},
+		{"metadata.size in [1, 10]", true},
+		{"metadata.size == '10'", true},
+		{"metadata.size > '20'", false},
+		{"metadata.size in ['1', '10']", true},
+		{"metadata.size == 'ten'", false},
+		{"metadata.enabled == true", true},
+		{"metadata.enabled == 'true'", true},
+		{"metadata.enabled == false", false},
+		{"metadata.nothing == null", true},
+		{"metadata.nothing == 'x'", false},
+		{"metadata.nothing != 'x'", false},
+		{"metadata.missing == null", true},
+		{"metadata.missing != null", false},
+		{"metadata.missing == 'x'", false},
+		{"metadata.missing != 'x'", false},
+		{"metadata.region != null", true},
+		{"metadata.region exists", true},
+		{"metadata.nothing exists", true},
+		{"metadata.missing exists", false},
+		{"metadata.labels == 'y'", true},
+		{"metadata.labels != 'z'", true},
+		{"metadata.labels != 'x'", false},
+		{"metadata.owner.name == 'bob'", true},
+		{"metadata.owner.name.first == null", true},
+		{"metadata.region.name == null", true},
+		{"metadata == null", false},
+		{"extra == 5", true},
+		{"extra < 5", false},
+		{"name == 'a' and metadata.region == 'us-east'", true},
+	}
+
+	for _, test := range tests {
+		res, err := Filter(obj, test.filter)
+		assert.NoError(t, err, test.filter)
+		assert.Equal(t, test.res, res, test.filter)
+		cf, err := CompileFilter(test.filter)
+		if assert.NoError(t, err, test.filter) {
+			res, err = cf.Match(obj)
+			assert.NoError(t, err, test.filter)
+			assert.Equal(t, test.res, res, test.filter)
+		}
+	}
+
+	empty := &TestStructMessage{}
+	for _, test := range []struct {
+		filter string
+		res    bool
+	}{
+		{"metadata.region == 'us-east'", false},
+		{"metadata.region == null", true},
+		{"metadata == null", true},
+		{"metadata exists", false},
+		{"extra == null", true},
+	} {
+		res, err := Filter(empty, test.filter)
+		assert.NoError(t, err, test.filter)
+		assert.Equal(t, test.res, res, test.filter)
+	}
+}
+
+func TestFilteringJSONMap(t *testing.T) {
+	var obj map[string]interface{}
+	data := `{"name": "a", "size": 10, "enabled": true, "nick": null,
+		"tags": ["x", "y"], "owner": {"name": "bob"}}`
+	if !assert.NoError(t, json.Unmarshal([]byte(data), &obj)) {
+		return
+	}
+	obj["count"] = int64(3)
+
+	tests := []struct {
+		filter string
+		res    bool
+	}{
+		{"name == 'a'", true},
+		{"name ~ '^a'", true},
+		{"name == 5", false},
+		{"size == 10 and size > '5'", true},
+		{"size == 'ten'", false},
+		{"count == 3", true},
+		{"count in ['1', '3']", true},
+		{"enabled == 'true'", true},
+		{"nick == null", true},
+		{"nick <=> null", true},
+		{"nick == 'x'", false},
+		{"nick exists", true},
+		{"missing == null", true},
+		{"missing exists", false},
+		{"missing == 'x'", false},
+		{"not missing == 'x'", false},
+		{"tags == 'y'", true},
+		{"tags == 'z'", false},
+		{"owner.name == 'bob'", true},
+		{"owner.missing == null", true},
+		{"name.missing == null", true},
+	}
+	for _, test := range tests {
+		f, err := ParseFiltering(test.filter)
+		if !assert.NoError(t, err, test.filter) {
+			continue
+		}
+		res, err := f.Filter(obj)
+		assert.NoError(t, err, test.filter)
+		assert.Equal(t, test.res, res, test.filter)
+
+		res, err = f.Filter(&obj)
+		assert.NoError(t, err, test.filter)
+		assert.Equal(t, test.res, res, test.filter)
+	}
+
+	type wrapper struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	res, err := Filter(&wrapper{Data: obj}, "data.owner.name == 'bob' and data.tags == 'x'")
+	assert.NoError(t, err)
+	assert.True(t, res)
+}
@@ -1,12 +1,23 @@
 package query
 
 import (
+	"fmt"
+	"reflect"
 	"regexp/syntax"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/duration"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type TestObject struct {
@@ -14,6 +25,7 @@ type TestObject struct {
 	Float float64 `json:"float"`
 	Uint  uint    `json:"uint"`
 	Ptr   *struct{}
+	Enum  Enum `json:"enum"`
 }
 
 type TestProtoMessage struct {
@@ -24,12 +36,43 @@ type TestProtoMessage struct {
 	Bool        bool                  `protobuf:"bytes,3,opt,name=bool,proto3" json:"id,omitempty"`
 	Nested      *NestedMessage        `protobuf:"bytes,3,opt,name=nested,json=nestedJSON"`
 	Enum        Enum                  `protobuf:"varint,6,opt,name=enum,proto3,enum=query.Enum" json:"enum,omitempty"`
+	CreatedAt   *timestamp.Timestamp  `protobuf:"bytes,7,opt,name=created_at,json=createdAt"`
+	Timeout     *duration.Duration    `protobuf:"bytes,8,opt,name=timeout"`
+	Tags        []string              `protobuf:"bytes,9,rep,name=tags"`
+	Items       []*NestedMessage      `protobuf:"bytes,10,rep,name=items"`
 }
 
 func (m *TestProtoMessage) Reset()         { *m = TestProtoMessage{} }
 func (m *TestProtoMessage) String() string { return proto.CompactTextString(m) }
 func (*TestProtoMessage) ProtoMessage()    {}
 
+type TestWrappersMessage struct {
+	Bool   *wrappers.BoolValue   `protobuf:"bytes,1,opt,name=bool"`
+	Double *wrappers.DoubleValue `protobuf:"bytes,2,opt,name=double"`
+	Float  *wrappers.FloatValue  `protobuf:"bytes,3,opt,name=float"`
+	Int32  *wrappers.Int32Value  `protobuf:"bytes,4,opt,name=int32"`
+	Int64  *wrappers.Int64Value  `protobuf:"bytes,5,opt,name=int64"`
+	Uint32 *wrappers.UInt32Value `protobuf:"bytes,6,opt,name=uint32"`
+	Uint64 *wrappers.UInt64Value `protobuf:"bytes,7,opt,name=uint64"`
+	Str    *wrappers.StringValue `protobuf:"bytes,8,opt,name=string"`
+}
+
+func (m *TestWrappersMessage) Reset()         { *m = TestWrappersMessage{} }
+func (m *TestWrappersMessage) String() string { return proto.CompactTextString(m) }
+func (*TestWrappersMessage) ProtoMessage()    {}
+
+type TestMapMessage struct {
+	Labels map[string]string         `protobuf:"bytes,1,rep,name=labels" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Codes  map[int32]string          `protobuf:"bytes,2,rep,name=codes" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Counts map[uint64]int64          `protobuf:"bytes,3,rep,name=counts" protobuf_key:"varint,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	Nested map[string]*NestedMessage `protobuf:"bytes,4,rep,name=nested" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Flags  map[bool]bool             `protobuf:"bytes,5,rep,name=flags" protobuf_key:"varint,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+}
+
+func (m *TestMapMessage) Reset()         { *m = TestMapMessage{} }
+func (m *TestMapMessage) String() string { return proto.CompactTextString(m) }
+func (*TestMapMessage) ProtoMessage()    {}
+
 type NestedMessage struct {
 	Str string `protobuf:"bytes,1,opt,name=str"`
 }
@@ -59,6 +102,10 @@ func (x Enum) String() string {
 	return proto.EnumName(Enum_name, int32(x))
 }
 
+func init() {
+	proto.RegisterEnum("query.Enum", Enum_name, Enum_value)
+}
+
 func TestFiltering(t *testing.T) {
 
 	tests := []struct {
@@ -202,6 +249,16 @@ func TestFiltering(t *testing.T) {
 			filter: "nestedJSON == null",
 			res:    false,
 		},
+		{
+			obj:    &TestProtoMessage{},
+			filter: "nestedJSON is null and not nestedJSON is not null",
+			res:    true,
+		},
+		{
+			obj:    &TestProtoMessage{Nested: &NestedMessage{}},
+			filter: "nestedJSON is not null",
+			res:    true,
+		},
 		{
 			obj: &TestProtoMessage{
 				Bool: true,
@@ -223,6 +280,96 @@ func TestFiltering(t *testing.T) {
 			filter: "bool != true",
 			res:    true,
 		},
+		{
+			obj:    &TestProtoMessage{Bool: true},
+			filter: "bool > false",
+			res:    true,
+		},
+		{
+			obj:    &TestProtoMessage{Bool: false},
+			filter: "bool > false",
+			res:    false,
+		},
+		{
+			obj:    &TestProtoMessage{Bool: true},
+			filter: "bool > true",
+			res:    false,
+		},
+		{
+			obj:    &TestProtoMessage{Bool: false},
+			filter: "bool >= false",
+			res:    true,
+		},
+		{
+			obj:    &TestProtoMessage{Bool: false},
+			filter: "bool < true",
+			res:    true,
+		},
+		{
+			obj:    &TestProtoMessage{Bool: false},
+			filter: "bool < false",
+			res:    false,
+		},
+		{
+			obj:    &TestProtoMessage{Bool: true},
+			filter: "bool <= false",
+			res:    false,
+		},
+		{
+			obj:    &TestProtoMessage{Bool: true},
+			filter: "not bool <= false",
+			res:    true,
+		},
+		{
+			obj:    &TestProtoMessage{Str: "111"},
+			filter: "str in ['111', '222']",
+			res:    true,
+		},
+		{
+			obj:    &TestProtoMessage{Str: "111"},
+			filter: "str not in ['111', '222']",
+			res:    false,
+		},
+		{
+			obj:    &TestProtoMessage{Int: 3},
+			filter: "int in [1, 2]",
+			res:    false,
+		},
+		{
+			obj:    &TestProtoMessage{Int: 3},
+			filter: "int not in [1, 2]",
+			res:    true,
+		},
+		{
+			obj:    &TestProtoMessage{Enum: ENUM_TwO},
+			filter: "enum in ['TW0'] and enum in [1] and enum not in ['ONE']",
+			res:    true,
+		},
+		{
+			obj:    &TestProtoMessage{Nested: &NestedMessage{Str: "foo"}},
+			filter: "nested.str == 'foo' and nestedJSON.str == 'foo'",
+			res:    true,
+		},
+		{
+			obj:    &TestProtoMessage{Nested: &NestedMessage{Str: "foo"}},
+			filter: "nested.str in ['bar']",
+			res:    false,
+		},
+		{
+			obj:    &TestProtoMessage{},
+			filter: "nested.str == 'foo'",
+			res:    false,
+		},
+		{
+			obj:    &TestProtoMessage{},
+			filter: "nested.str != 'foo'",
+			res:    false,
+		},
+		{
+			obj:    &TestProtoMessage{},
+			filter: "nested.str == null",
+			res:    true,
+		},
 		{
 			obj:    &TestProtoMessage{},
 			filter: "",
@@ -236,49 +383,1594 @@ func TestFiltering(t *testing.T) {
 	}
 }
 
-func TestFilteringNegative(t *testing.T) {
+func TestFilteringWrappers(t *testing.T) {
+	full := &TestWrappersMessage{
+		Bool:   &wrappers.BoolValue{Value: true},
+		Double: &wrappers.DoubleValue{Value: 11.11},
+		Float:  &wrappers.FloatValue{Value: 11.11},
+		Int32:  &wrappers.Int32Value{Value: -32},
+		Int64:  &wrappers.Int64Value{Value: 64},
+		Uint32: &wrappers.UInt32Value{Value: 32},
+		Uint64: &wrappers.UInt64Value{Value: 64},
+		Str:    &wrappers.StringValue{Value: "str"},
+	}
+	zero := &TestWrappersMessage{
+		Bool:   &wrappers.BoolValue{},
+		Double: &wrappers.DoubleValue{},
+		Float:  &wrappers.FloatValue{},
+		Int32:  &wrappers.Int32Value{},
+		Int64:  &wrappers.Int64Value{},
+		Uint32: &wrappers.UInt32Value{},
+		Uint64: &wrappers.UInt64Value{},
+		Str:    &wrappers.StringValue{},
+	}
 
 	tests := []struct {
 		obj    interface{}
 		filter string
-		err    error
+		res    bool
 	}{
 		{
-			obj:    &TestObject{Str: "111"},
-			filter: "str == 111",
-			err:    &TypeMismatchError{},
+			obj:    full,
+			filter: "bool == true and double == 11.11 and float == 11.11 and int32 < 0 and int64 == 64 and uint32 == 32 and uint64 == 64 and string == 'str'",
+			res:    true,
 		},
 		{
-			obj:    &TestObject{Float: 11.11},
-			filter: "float == '11.11'",
-			err:    &TypeMismatchError{},
+			obj:    full,
+			filter: "double > 11 and float < 11.2 and int32 <= 0 and int64 >= 64 and uint32 in [1, 32] and uint64 not in [1, 2] and float in [11.11]",
+			res:    true,
 		},
 		{
-			obj:    &TestObject{},
-			filter: "float == null",
-			err:    &TypeMismatchError{},
+			obj:    full,
+			filter: "bool == false or double == 11.1 or float == 11.1 or int32 == 32 or uint64 < 64",
+			res:    false,
 		},
 		{
-			obj:    &TestObject{},
-			filter: "missingField == 11.11",
-			err:    &TypeMismatchError{},
+			obj:    full,
+			filter: "bool != null and double != null and float != null and int32 != null and int64 != null and uint32 != null and uint64 != null and string != null",
+			res:    true,
+		},
+		{
+			obj:    zero,
+			filter: "bool == false and double == 0 and float == 0 and int32 == 0 and int64 == 0 and uint32 == 0 and uint64 == 0 and string == ''",
+			res:    true,
+		},
+		{
+			obj:    zero,
+			filter: "bool == null or double == null or float == null or int32 == null or int64 == null or uint32 == null or uint64 == null or string == null",
+			res:    false,
+		},
+		{
+			obj:    &TestWrappersMessage{},
+			filter: "bool == null and double == null and float == null and int32 == null and int64 == null and uint32 == null and uint64 == null and string == null",
+			res:    true,
+		},
+		{
+			obj:    &TestWrappersMessage{},
+			filter: "bool == true or bool == false or double == 0 or float == 0 or int32 == 0 or int64 == 0 or uint32 == 0 or uint64 == 0 or string == ''",
+			res:    false,
+		},
+		{
+			obj:    &TestWrappersMessage{},
+			filter: "not bool == true or not double == 0 or not uint32 in [0]",
+			res:    false,
+		},
+	}
+
+	for _, test := range tests {
+		res, err := Filter(test.obj, test.filter)
+		assert.Equal(t, test.res, res, test.filter)
+		assert.Nil(t, err, test.filter)
+	}
+
+	for _, filter := range []string{"bool == 1", "double == 'str'", "uint32 == true", "string == 1"} {
+		_, err := Filter(full, filter)
+		assert.IsType(t, &TypeMismatchError{}, err, filter)
+	}
+}
+
+func TestFilteringNullSafe(t *testing.T) {
+	full := &TestWrappersMessage{
+		Bool:   &wrappers.BoolValue{Value: true},
+		Double: &wrappers.DoubleValue{Value: 11.11},
+		Int32:  &wrappers.Int32Value{Value: -32},
+		Str:    &wrappers.StringValue{Value: "str"},
+	}
+
+	tests := []struct {
+		obj    interface{}
+		filter string
+		res    bool
+	}{
+		{
+			obj:    full,
+			filter: "bool <=> true and double <=> 11.11 and int32 <=> -32 and string <=> 'str'",
+			res:    true,
+		},
+		{
+			obj:    full,
+			filter: "bool <=> null or double <=> null or string <=> 'abc' or not int32 <=> -32",
+			res:    false,
+		},
+		{
+			obj:    &TestWrappersMessage{},
+			filter: "bool <=> true or double <=> 11.11 or int32 <=> 0 or string <=> ''",
+			res:    false,
+		},
+		{
+			obj:    &TestWrappersMessage{},
+			filter: "bool <=> null and not double <=> 11.11 and not int32 <=> 0 and not string <=> 'str'",
+			res:    true,
+		},
+		{
+			obj:    &TestWrappersMessage{},
+			filter: "not string <=> null",
+			res:    false,
+		},
+		{
+			obj:    &TestObject{Str: "abc"},
+			filter: "str <=> 'abc' and not str <=> null",
+			res:    true,
+		},
+	}
+
+	for _, test := range tests {
+		res, err := Filter(test.obj, test.filter)
+		assert.Equal(t, test.res, res, test.filter)
+		assert.Nil(t, err, test.filter)
+	}
+
+	// non-null values are still type checked
+	_, err := Filter(full, "string <=> 1")
+	assert.IsType(t, &TypeMismatchError{}, err)
+}
+
+func TestFilteringBetween(t *testing.T) {
+	tests := []struct {
+		obj    interface{}
+		filter string
+		res    bool
+	}{
+		{
+			obj:    &TestObject{Float: 10},
+			filter: "float between 10 and 99.5",
+			res:    true,
+		},
+		{
+			obj:    &TestObject{Float: 99.5},
+			filter: "float between 10 and 99.5",
+			res:    true,
+		},
+		{
+			obj:    &TestObject{Float: 99.6},
+			filter: "float between 10 and 99.5",
+			res:    false,
+		},
+		{
+			obj:    &TestObject{Float: 99.6},
+			filter: "float not between 10 and 99.5",
+			res:    true,
+		},
+		{
+			obj:    &TestObject{Uint: 5},
+			filter: "uint between 5 and 5 and str between '' and 'a'",
+			res:    true,
+		},
+		{
+			obj:    &TestProtoMessage{Str: "bcd"},
+			filter: "str between 'abc' and 'bce'",
+			res:    true,
+		},
+		{
+			obj:    &TestProtoMessage{CreatedAt: &timestamp.Timestamp{Seconds: 1672531200}},
+			filter: "created_at between '2023-01-01T00:00:00Z' and '2023-01-02T00:00:00Z'",
+			res:    true,
+		},
+		{
+			obj:    &TestProtoMessage{Timeout: &duration.Duration{Seconds: 3600}},
+			filter: "timeout between '30m' and '1h'",
+			res:    true,
+		},
+		{
+			obj:    &TestProtoMessage{Timeout: &duration.Duration{Seconds: 3601}},
+			filter: "timeout between '30m' and '1h'",
+			res:    false,
 		},
 		{
 			obj:    &TestProtoMessage{},
-			filter: "missingField == 11.11",
-			err:    &TypeMismatchError{},
+			filter: "timeout between '30m' and '1h'",
+			res:    false,
+		},
+	}
+
+	for _, test := range tests {
+		res, err := Filter(test.obj, test.filter)
+		assert.Equal(t, test.res, res, test.filter)
+		assert.Nil(t, err, test.filter)
+	}
+
+	_, err := Filter(&TestProtoMessage{}, "str between 1 and 2")
+	assert.IsType(t, &TypeMismatchError{}, err)
+
+	_, err = Filter(&TestProtoMessage{}, "int between 'a' and 'b'")
+	assert.IsType(t, &TypeMismatchError{}, err)
+}
+
+func TestFilteringEscapes(t *testing.T) {
+	tests := []struct {
+		obj    interface{}
+		filter string
+		res    bool
+	}{
+		{
+			obj:    &TestObject{Str: "O'Brien"},
+			filter: `str == 'O\'Brien'`,
+			res:    true,
 		},
 		{
-			obj:    &TestObject{Str: "111"},
-			filter: "str ~ '11[1'",
-			err:    &syntax.Error{},
+			obj:    &TestObject{Str: "O'Brien, \"Jr\""},
+			filter: `str == "O'Brien, \"Jr\""`,
+			res:    true,
+		},
+		{
+			obj:    &TestObject{Str: "line1\nline2"},
+			filter: `str == 'line1\nline2' and str ~ '^line\d\n'`,
+			res:    true,
+		},
+		{
+			obj:    &TestObject{Str: "caf\u00e9"},
+			filter: `str in ['cafe', 'caf\u00e9']`,
+			res:    true,
 		},
 	}
 
 	for _, test := range tests {
 		res, err := Filter(test.obj, test.filter)
-		assert.False(t, res)
-		assert.IsType(t, test.err, err)
+		assert.Equal(t, test.res, res, test.filter)
+		assert.Nil(t, err, test.filter)
+	}
+}
+
+func TestFilteringLike(t *testing.T) {
+	tests := []struct {
+		obj    interface{}
+		filter string
+		res    bool
+	}{
+		{&TestObject{Str: "Acme Corp"}, "str like '%Corp'", true},
+		{&TestObject{Str: "Acme Corp"}, "str like 'Acme'", false},
+		{&TestObject{Str: "Acme Corp"}, "str like '%me%'", true},
+		{&TestObject{Str: "Acme Corp"}, "str like 'Acm_ C_rp'", true},
+		{&TestObject{Str: "Acme Corp"}, "str like 'Acm_'", false},
+		{&TestObject{Str: "Acme Corp"}, "str not like '%acme%'", true},
+		{&TestObject{Str: "a.c"}, "str like 'a.c' and str not like 'a.c.'", true},
+		{&TestObject{Str: "abc"}, "str like 'a.c'", false},
+		{&TestObject{Str: "50% off"}, "str like '50\\% %'", true},
+		{&TestObject{Str: "50 % off"}, "str like '50\\%%'", false},
+		{&TestObject{Str: "a_b"}, "str like 'a\\_b'", true},
+		{&TestObject{Str: "axb"}, "str like 'a\\_b'", false},
+		{&TestObject{Str: "line1\nline2"}, "str like 'line1%'", true},
+		{&TestProtoMessage{Items: []*NestedMessage{{Str: "foo"}, {Str: "bar"}}}, "items.str like 'b%'", true},
+	}
+
+	for _, test := range tests {
+		res, err := Filter(test.obj, test.filter)
+		assert.Equal(t, test.res, res, test.filter)
+		assert.Nil(t, err, test.filter)
+	}
+
+	res, err := Filter(&TestObject{Str: "Acme Corp"}, "str like '%ACME%'", CaseInsensitive())
+	assert.Nil(t, err)
+	assert.True(t, res)
+
+	cf, err := CompileFilter("str like '%me_C%'")
+	assert.Nil(t, err)
+	res, err = cf.Match(&TestObject{Str: "Acme Corp"})
+	assert.Nil(t, err)
+	assert.True(t, res)
+
+	_, err = Filter(&TestObject{}, "float like '1%'")
+	assert.IsType(t, &TypeMismatchError{}, err)
+}
+
+func TestFilteringILike(t *testing.T) {
+	tests := []struct {
+		obj    interface{}
+		filter string
+		res    bool
+	}{
+		{&TestObject{Str: "Acme Corp"}, "str ilike '%corp'", true},
+		{&TestObject{Str: "Acme Corp"}, "str ilike 'ACM_ C_RP'", true},
+		{&TestObject{Str: "Acme Corp"}, "str ilike 'acme'", false},
+		{&TestObject{Str: "Acme Corp"}, "str not ilike '%ACME%'", false},
+		{&TestObject{Str: "Acme Corp"}, "str like '%acme%' or not str ilike '%acme%'", false},
+		{&TestObject{Str: "50% OFF"}, "str ilike '50\\% off'", true},
+		{&TestObject{Str: "ÉCOLE"}, "str ilike 'é%'", true},
+		{&TestProtoMessage{Items: []*NestedMessage{{Str: "foo"}, {Str: "BAR"}}}, "items.str ilike 'b%'", true},
 	}
 
+	for _, test := range tests {
+		res, err := Filter(test.obj, test.filter)
+		assert.Equal(t, test.res, res, test.filter)
+		assert.Nil(t, err, test.filter)
+	}
+
+	cf, err := CompileFilter("str ilike '%ME_c%'")
+	assert.Nil(t, err)
+	assert.Len(t, cf.options.regexps, 1)
+	res, err := cf.Match(&TestObject{Str: "Acme Corp"})
+	assert.Nil(t, err)
+	assert.True(t, res)
+
+	assert.Equal(t, `(?i)(?s)^a.c.*$`, ILikeToRegexp("a_c%"))
+
+	_, err = Filter(&TestObject{}, "float ilike '1%'")
+	assert.IsType(t, &TypeMismatchError{}, err)
+}
+
+func TestFilteringEnum(t *testing.T) {
+	tests := []struct {
+		obj    interface{}
+		filter string
+		res    bool
+	}{
+		{
+			obj:    &TestProtoMessage{Enum: ENUM_TwO},
+			filter: "enum == 'TW0' and enum != 'ONE' and enum == 1 and enum != 0",
+			res:    true,
+		},
+		{
+			obj:    &TestProtoMessage{Enum: ENUM_ONE},
+			filter: "enum == 'TW0' or not enum != 'TW0'",
+			res:    false,
+		},
+		{
+			obj:    &TestProtoMessage{Enum: ENUM_TwO},
+			filter: "enum := 'tw0'",
+			res:    true,
+		},
+		{
+			obj:    &TestObject{Enum: ENUM_TwO},
+			filter: "enum == 'TW0' and enum != 'ONE' and enum == 1",
+			res:    true,
+		},
+		{
+			obj:    &TestObject{Enum: ENUM_TwO},
+			filter: "enum == 'THREE'",
+			res:    false,
+		},
+	}
+
+	for _, test := range tests {
+		res, err := Filter(test.obj, test.filter)
+		assert.Equal(t, test.res, res, test.filter)
+		assert.Nil(t, err, test.filter)
+	}
+
+	_, err := Filter(&TestProtoMessage{}, "enum == 'THREE'")
+	assert.IsType(t, &InvalidLiteralError{}, err)
+
+	_, err = Filter(&TestProtoMessage{}, "enum != 'tw0'")
+	assert.IsType(t, &InvalidLiteralError{}, err)
+
+	_, err = Filter(&TestProtoMessage{}, "enum > 'ONE'")
+	assert.IsType(t, &UnsupportedOperatorError{}, err)
+}
+
+func TestFilteringUpperCaseEnumNames(t *testing.T) {
+	tests := []struct {
+		obj    interface{}
+		filter string
+		res    bool
+	}{
+		{
+			obj:    &TestProtoMessage{Enum: ENUM_TwO},
+			filter: "enum == 'tw0' and enum != 'one' and enum == 'TW0' and enum in ['tw0']",
+			res:    true,
+		},
+		{
+			obj:    &TestProtoMessage{Enum: ENUM_ONE},
+			filter: "enum == 'tw0' or enum not in ['one']",
+			res:    false,
+		},
+		{
+			obj:    &TestObject{Enum: ENUM_TwO},
+			filter: "enum == 'tw0' and enum != 'one'",
+			res:    true,
+		},
+	}
+
+	for _, test := range tests {
+		res, err := Filter(test.obj, test.filter, UpperCaseEnumNames())
+		assert.Equal(t, test.res, res, test.filter)
+		assert.Nil(t, err, test.filter)
+	}
+
+	// lookup is exact without the option
+	_, err := Filter(&TestProtoMessage{}, "enum == 'tw0'")
+	assert.Equal(t, &InvalidLiteralError{"enum", "tw0", fmt.Errorf("unknown query.Enum value, valid values are ONE, TW0")}, err)
+
+	_, err = Filter(&TestProtoMessage{}, "enum == 'three'", UpperCaseEnumNames())
+	assert.EqualError(t, err, `"three" is not a valid enum literal: unknown query.Enum value, valid values are ONE, TW0`)
+}
+
+func TestFilteringRepeated(t *testing.T) {
+	obj := &TestProtoMessage{
+		Tags:  []string{"urgent", "bug"},
+		Items: []*NestedMessage{{Str: "abc"}, nil, {Str: "bcd"}},
+	}
+
+	tests := []struct {
+		obj    interface{}
+		filter string
+		res    bool
+	}{
+		{
+			obj:    obj,
+			filter: "tags == 'urgent' and tags == 'bug' and tags ~ '^ur' and tags in ['bug', 'feature']",
+			res:    true,
+		},
+		{
+			obj:    obj,
+			filter: "tags == 'feature' or tags in ['feature'] or tags > 'z'",
+			res:    false,
+		},
+		{
+			obj:    obj,
+			filter: "not tags == 'urgent' or tags != 'bug' or tags not in ['bug']",
+			res:    false,
+		},
+		{
+			obj:    obj,
+			filter: "tags != 'feature' and not tags == 'feature' and tags not in ['feature', 'docs']",
+			res:    true,
+		},
+		{
+			obj:    obj,
+			filter: "items.str == 'abc' and items.str == 'bcd' and items.str != 'cde'",
+			res:    true,
+		},
+		{
+			obj:    &TestProtoMessage{},
+			filter: "tags == 'urgent' or items.str == 'abc'",
+			res:    false,
+		},
+		{
+			obj:    &TestProtoMessage{},
+			filter: "tags != 'urgent' and items.str != 'abc'",
+			res:    true,
+		},
+	}
+
+	for _, test := range tests {
+		res, err := Filter(test.obj, test.filter)
+		assert.Equal(t, test.res, res, test.filter)
+		assert.Nil(t, err, test.filter)
+	}
+
+	cf, err := CompileFilter("tags ~ '^bu'")
+	assert.Nil(t, err)
+	res, err := cf.Match(obj)
+	assert.True(t, res)
+	assert.Nil(t, err)
+
+	// errors refer to the full field path
+	for filter, msg := range map[string]string{
+		"tags == 1":               "tags is not a number type, cannot compare with 1",
+		"tags == null":            "tags is not a nullable type, cannot compare with null",
+		"items.str == null":       "items.str is not a nullable type, cannot compare with null",
+		"items.int == str":        "items.int is not a comparable type",
+		"str == tags":             "tags is not a comparable type",
+		"items.str contains '{}'": "items.str is not a JSON type, cannot compare with '{}'",
+	} {
+		_, err = Filter(obj, filter)
+		assert.EqualError(t, err, msg, filter)
+	}
+}
+
+func TestFilteringRepeatedFieldAndContains(t *testing.T) {
+	obj := &TestProtoMessage{
+		Str:   "bcd",
+		Tags:  []string{"abc", "cde"},
+		Items: []*NestedMessage{{Str: "abc"}, nil, {Str: "cde"}},
+	}
+
+	tests := []struct {
+		obj    interface{}
+		filter string
+		res    bool
+	}{
+		{obj, "items.str < str and items.str > str and tags < str", true},
+		{obj, "not items.str < str or items.str != str or tags != str", true},
+		{obj, "items.str == str or tags >= 'z' or not tags < str", false},
+		{&TestProtoMessage{Str: "abc"}, "items.str == str or not (not items.str == str)", false},
+		{&TestProtoMessage{Str: "abc"}, "not items.str == str and not items.str < str", true},
+	}
+	for _, test := range tests {
+		res, err := Filter(test.obj, test.filter)
+		assert.Equal(t, test.res, res, test.filter)
+		assert.Nil(t, err, test.filter)
+	}
+
+	type elem struct {
+		Attrs map[string]interface{} `json:"attrs"`
+	}
+	type collection struct {
+		Items []elem `json:"items"`
+	}
+	c := &collection{Items: []elem{{Attrs: map[string]interface{}{"color": "red"}}, {}, {Attrs: map[string]interface{}{"color": "blue"}}}}
+	for filter, expected := range map[string]bool{
+		`items.attrs contains '{"color": "red"}'`:       true,
+		`items.attrs contains '{"color": "green"}'`:     false,
+		`not items.attrs contains '{"color": "blue"}'`:  false,
+		`not items.attrs contains '{"color": "green"}'`: true,
+		`items contains '{"attrs": {"color": "red"}}'`:  false,
+	} {
+		res, err := Filter(c, filter)
+		assert.Equal(t, expected, res, filter)
+		assert.Nil(t, err, filter)
+	}
+}
+
+func TestFilteringCaseInsensitive(t *testing.T) {
+
+	tests := []struct {
+		obj    interface{}
+		filter string
+		res    bool
+	}{
+		{
+			obj:    &TestProtoMessage{Str: "Acme"},
+			filter: "str == 'acme' and str != 'ACME2' and str in ['ACME']",
+			res:    true,
+		},
+		{
+			obj:    &TestProtoMessage{Str: "Acme"},
+			filter: "str > 'ac' and str >= 'ACME' and str < 'AD' and str <= 'acme'",
+			res:    true,
+		},
+		{
+			obj:    &TestProtoMessage{Str: "Acme"},
+			filter: "str ~ '^ac' and str !~ 'ACMEE'",
+			res:    true,
+		},
+		{
+			obj:    &TestProtoMessage{Str: "Acme", Int: 1},
+			filter: "str == 'acme' and int == 1",
+			res:    true,
+		},
+	}
+	for _, test := range tests {
+		res, err := Filter(test.obj, test.filter, CaseInsensitive())
+		assert.Equal(t, test.res, res, test.filter)
+		assert.Nil(t, err)
+
+		// default behavior is case-sensitive
+		res, err = Filter(test.obj, test.filter)
+		assert.False(t, res, test.filter)
+		assert.Nil(t, err)
+	}
+}
+
+func TestFilteringCaseFold(t *testing.T) {
+	tests := []struct {
+		str    string
+		filter string
+		opts   []FilterOption
+		res    bool
+	}{
+		{str: "Straße", filter: "str == 'STRASSE'", res: true},
+		{str: "ΣΊΣΥΦΟΣ", filter: "str in ['σίσυφος', 'x']", res: true},
+		{str: "Straße", filter: "str := 'strasse'", res: true},
+		// language-neutral folding maps I to i
+		{str: "KIRMIZI", filter: "str == 'kirmizi'", res: true},
+		{str: "KIRMIZI", filter: "str == 'kırmızı'", res: false},
+		{str: "KIRMIZI", filter: "str == 'kırmızı'", opts: []FilterOption{CaseFoldLanguage(language.Turkish)}, res: true},
+		{str: "İstanbul", filter: "str in ['istanbul']", opts: []FilterOption{CaseFoldLanguage(language.Turkish)}, res: true},
+		{str: "KIRMIZI", filter: "str == 'kirmizi'", opts: []FilterOption{CaseFoldLanguage(language.Turkish)}, res: false},
+	}
+	for _, test := range tests {
+		res, err := Filter(&TestProtoMessage{Str: test.str}, test.filter, append(test.opts, CaseInsensitive())...)
+		assert.NoError(t, err, test.filter)
+		assert.Equal(t, test.res, res, "%s: %s", test.str, test.filter)
+	}
+}
+
+func TestFilteringTimestamp(t *testing.T) {
+	// 2023-01-01T00:00:00Z
+	ts := &timestamp.Timestamp{Seconds: 1672531200}
+
+	tests := []struct {
+		obj    interface{}
+		filter string
+		res    bool
+	}{
+		{
+			obj:    &TestProtoMessage{CreatedAt: ts},
+			filter: "created_at == '2023-01-01T00:00:00Z' and createdAt == '2023-01-01T01:00:00+01:00'",
+			res:    true,
+		},
+		{
+			obj:    &TestProtoMessage{CreatedAt: ts},
+			filter: "created_at > '2022-12-31T23:59:59.999Z' and created_at >= '2023-01-01T00:00:00Z'",
+			res:    true,
+		},
+		{
+			obj:    &TestProtoMessage{CreatedAt: ts},
+			filter: "created_at < '2023-01-01T00:00:01Z' and created_at <= '2023-01-01T00:00:00Z'",
+			res:    true,
+		},
+		{
+			obj:    &TestProtoMessage{CreatedAt: ts},
+			filter: "created_at != '2023-01-01T00:00:00Z'",
+			res:    false,
+		},
+		{
+			obj:    &TestProtoMessage{CreatedAt: ts},
+			filter: "created_at == null",
+			res:    false,
+		},
+		{
+			obj:    &TestProtoMessage{},
+			filter: "created_at == null",
+			res:    true,
+		},
+		{
+			obj:    &TestProtoMessage{},
+			filter: "created_at > '2023-01-01T00:00:00Z'",
+			res:    false,
+		},
+	}
+	for _, test := range tests {
+		res, err := Filter(test.obj, test.filter)
+		assert.Equal(t, test.res, res, test.filter)
+		assert.Nil(t, err)
+	}
+
+	_, err := Filter(&TestProtoMessage{CreatedAt: ts}, "created_at > '2023-01-01'")
+	assert.IsType(t, &InvalidLiteralError{}, err)
+
+	_, err = Filter(&TestProtoMessage{CreatedAt: ts}, "created_at ~ '2023'")
+	assert.IsType(t, &UnsupportedOperatorError{}, err)
+}
+
+func TestFilteringTime(t *testing.T) {
+	type event struct {
+		Created time.Time  `json:"created"`
+		Deleted *time.Time `json:"deleted"`
+	}
+	created := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		obj    interface{}
+		filter string
+		res    bool
+	}{
+		{
+			obj:    &event{Created: created},
+			filter: "created == '2023-01-01T00:00:00Z' and created == '2023-01-01T01:00:00+01:00' and created != '2023-01-02T00:00:00Z'",
+			res:    true,
+		},
+		{
+			obj:    &event{Created: created, Deleted: &created},
+			filter: "created > '2022-12-31T23:59:59Z' and created <= '2023-01-01T00:00:00Z' and deleted < '2023-01-01T00:00:01Z'",
+			res:    true,
+		},
+		{
+			obj:    &event{Created: created},
+			filter: "created != null and deleted == null",
+			res:    true,
+		},
+		{
+			obj:    &event{},
+			filter: "created == null and not deleted != null",
+			res:    true,
+		},
+		{
+			obj:    &event{},
+			filter: "created < '2023-01-01T00:00:00Z' or created != '2023-01-01T00:00:00Z' or deleted > '2000-01-01T00:00:00Z'",
+			res:    false,
+		},
+	}
+	for _, test := range tests {
+		res, err := Filter(test.obj, test.filter)
+		assert.Equal(t, test.res, res, test.filter)
+		assert.Nil(t, err, test.filter)
+	}
+
+	_, err := Filter(&event{Created: created}, "created > '2023-01-01'")
+	assert.IsType(t, &InvalidLiteralError{}, err)
+
+	_, err = Filter(&event{Created: created}, "created ~ '2023'")
+	assert.IsType(t, &UnsupportedOperatorError{}, err)
+
+	// alternative layouts
+	res, err := Filter(&event{Created: created}, "created == '2023-01-01' and created < '2023-01-01 00:00:01'", TimeLayouts("2006-01-02", "2006-01-02 15:04:05"))
+	assert.Nil(t, err)
+	assert.True(t, res)
+
+	res, err = Filter(&TestProtoMessage{CreatedAt: &timestamp.Timestamp{Seconds: 1672531200}}, "created_at == '2023-01-01'", TimeLayouts("2006-01-02"))
+	assert.Nil(t, err)
+	assert.True(t, res)
+}
+
+func TestFilteringRelativeTime(t *testing.T) {
+	type event struct {
+		Name    string     `json:"name"`
+		Created time.Time  `json:"created"`
+		Deleted *time.Time `json:"deleted"`
+	}
+	now := time.Date(2023, 1, 8, 12, 0, 0, 0, time.UTC)
+	clock := WithClock(func() time.Time { return now })
+	obj := &event{Created: time.Date(2023, 1, 3, 0, 0, 0, 0, time.UTC)}
+
+	tests := []struct {
+		filter string
+		res    bool
+	}{
+		{"created > now-7d and created < now-5d", true},
+		{"created > now-5d+12h", false},
+		{"created == now-5d-12h and created != now", true},
+		{"created >= now-132h and created <= now-7920m and created < now-475199s", true},
+		{"created between now-6d and now", true},
+		{"created < NOW", true},
+		{"deleted < now or deleted > now", false},
+		// null does not satisfy negated conditions as with absolute times
+		{"not deleted == now", false},
+	}
+	for _, test := range tests {
+		res, err := Filter(obj, test.filter, clock)
+		assert.Equal(t, test.res, res, test.filter)
+		assert.Nil(t, err, test.filter)
+	}
+
+	res, err := Filter(&TestProtoMessage{CreatedAt: &timestamp.Timestamp{Seconds: now.Unix() - 60}}, "created_at > now-1h and created_at < now", clock)
+	assert.Nil(t, err)
+	assert.True(t, res)
+
+	// time.Now is used by default
+	res, err = Filter(&event{Created: time.Now().Add(-time.Hour)}, "created > now-1d and created < now")
+	assert.Nil(t, err)
+	assert.True(t, res)
+
+	_, err = Filter(&event{Name: "now"}, "name == now", clock)
+	assert.IsType(t, &TypeMismatchError{}, err)
+
+	// a field named now has to be quoted
+	res, err = Filter(map[string]interface{}{"now": 1}, "`now` == 1")
+	assert.Nil(t, err)
+	assert.True(t, res)
+}
+
+func TestFilteringDuration(t *testing.T) {
+	tests := []struct {
+		obj    interface{}
+		filter string
+		res    bool
+	}{
+		{
+			obj:    &TestProtoMessage{Timeout: &duration.Duration{Seconds: 30}},
+			filter: "timeout == '30s' and timeout == '0.5m' and timeout != '30.5s'",
+			res:    true,
+		},
+		{
+			obj:    &TestProtoMessage{Timeout: &duration.Duration{Seconds: 5400}},
+			filter: "timeout > '1h' and timeout >= '1h30m' and timeout < '2h' and timeout <= '5400s'",
+			res:    true,
+		},
+		{
+			obj:    &TestProtoMessage{Timeout: &duration.Duration{Nanos: 500000000}},
+			filter: "timeout == '500ms'",
+			res:    true,
+		},
+		{
+			obj:    &TestProtoMessage{Timeout: &duration.Duration{Seconds: -90}},
+			filter: "timeout == '-1m30s' and timeout < '0s' and timeout > '-2m'",
+			res:    true,
+		},
+		{
+			obj:    &TestProtoMessage{},
+			filter: "timeout == null",
+			res:    true,
+		},
+		{
+			obj:    &TestProtoMessage{Timeout: &duration.Duration{}},
+			filter: "timeout == null",
+			res:    false,
+		},
+		{
+			obj:    &TestProtoMessage{},
+			filter: "timeout < '1s'",
+			res:    false,
+		},
+	}
+	for _, test := range tests {
+		res, err := Filter(test.obj, test.filter)
+		assert.Equal(t, test.res, res, test.filter)
+		assert.Nil(t, err)
+	}
+
+	_, err := Filter(&TestProtoMessage{Timeout: &duration.Duration{}}, "timeout > '30 seconds'")
+	assert.IsType(t, &InvalidLiteralError{}, err)
+
+	_, err = Filter(&TestProtoMessage{Timeout: &duration.Duration{}}, "timeout := '1s'")
+	assert.IsType(t, &UnsupportedOperatorError{}, err)
+}
+
+type TestBytesMessage struct {
+	Hash    []byte               `protobuf:"bytes,1,opt,name=hash,proto3"`
+	Wrapped *wrappers.BytesValue `protobuf:"bytes,2,opt,name=wrapped"`
+	Chunks  [][]byte             `protobuf:"bytes,3,rep,name=chunks,proto3"`
+}
+
+func (m *TestBytesMessage) Reset()         { *m = TestBytesMessage{} }
+func (m *TestBytesMessage) String() string { return proto.CompactTextString(m) }
+func (*TestBytesMessage) ProtoMessage()    {}
+
+func TestFilteringBytes(t *testing.T) {
+	obj := &TestBytesMessage{
+		Hash:    []byte{0xde, 0xad, 0xbe, 0xef},
+		Wrapped: &wrappers.BytesValue{Value: []byte("abc")},
+		Chunks:  [][]byte{{0x01}, {0x02, 0x03}},
+	}
+	tests := []struct {
+		obj    *TestBytesMessage
+		filter string
+		res    bool
+	}{
+		{obj, "hash == 'deadbeef'", true},
+		{obj, "hash == 'DEADBEEF'", true},
+		{obj, "hash != 'deadbeef'", false},
+		{obj, "hash > 'dead'", true},
+		{obj, "hash < 'df'", true},
+		{obj, "hash <= 'deadbeef' and hash >= 'deadbeef'", true},
+		{obj, "hash != null", true},
+		{obj, "wrapped == '616263'", true},
+		{obj, "chunks == '0203'", true},
+		{obj, "chunks != '04'", true},
+		{&TestBytesMessage{}, "hash == null", true},
+		{&TestBytesMessage{}, "hash == ''", false},
+		{&TestBytesMessage{}, "hash != 'deadbeef'", false},
+		{&TestBytesMessage{}, "wrapped == null", true},
+		{&TestBytesMessage{}, "wrapped == '616263'", false},
+		{&TestBytesMessage{Hash: []byte{}}, "hash == ''", true},
+	}
+
+	for _, test := range tests {
+		res, err := Filter(test.obj, test.filter)
+		assert.NoError(t, err, test.filter)
+		assert.Equal(t, test.res, res, test.filter)
+	}
+
+	res, err := Filter(obj, "hash == '3q2+7w==' and wrapped == 'YWJj'", Base64Bytes())
+	assert.NoError(t, err)
+	assert.True(t, res)
+
+	_, err = Filter(obj, "hash == 'xyz'")
+	assert.IsType(t, &InvalidLiteralError{}, err)
+
+	_, err = Filter(obj, "hash ~ 'de'")
+	assert.IsType(t, &UnsupportedOperatorError{}, err)
+}
+
+func TestFilteringExists(t *testing.T) {
+	obj := &TestProtoMessage{
+		StringValue: &wrappers.StringValue{},
+		Nested:      &NestedMessage{},
+		Tags:        []string{},
+	}
+	maps := &TestMapMessage{Labels: map[string]string{"env": ""}, Nested: map[string]*NestedMessage{"a": {}}}
+	tests := []struct {
+		obj    interface{}
+		filter string
+		res    bool
+	}{
+		// set to zero values
+		{obj, "string_value exists", true},
+		{obj, "nested exists and nested.str exists", true},
+		{obj, "tags exists", false},
+		{&TestProtoMessage{Tags: []string{""}}, "tags exists", true},
+		{obj, "str exists and int exists and not bool not exists", true},
+		// unset
+		{&TestProtoMessage{}, "string_value exists", false},
+		{&TestProtoMessage{}, "int_value not exists", true},
+		{&TestProtoMessage{}, "nested exists or nested.str exists", false},
+		{&TestProtoMessage{}, "tags not exists", true},
+		{&TestProtoMessage{}, "created_at exists", false},
+		{&TestProtoMessage{Items: []*NestedMessage{{}}}, "items.str exists", true},
+		{&TestProtoMessage{Items: []*NestedMessage{}}, "items.str exists", false},
+		// map keys
+		{maps, "labels.env exists and labels.missing not exists", true},
+		{maps, "nested.a exists and nested.b not exists", true},
+		{&TestMapMessage{}, "labels exists", false},
+	}
+
+	for _, test := range tests {
+		res, err := Filter(test.obj, test.filter)
+		assert.NoError(t, err, test.filter)
+		assert.Equal(t, test.res, res, test.filter)
+	}
+
+	_, err := Filter(obj, "missing exists")
+	assert.IsType(t, &UnknownFieldError{}, err)
+}
+
+func TestFilteringMap(t *testing.T) {
+	obj := &TestMapMessage{
+		Labels: map[string]string{"env": "prod", "team": "core"},
+		Codes:  map[int32]string{404: "not found", -1: "unknown"},
+		Counts: map[uint64]int64{7: 42},
+		Nested: map[string]*NestedMessage{"a": {Str: "aaa"}},
+		Flags:  map[bool]bool{true: false},
+	}
+	tests := []struct {
+		filter string
+		res    bool
+	}{
+		{"labels.env == 'prod'", true},
+		{"labels.env != 'prod'", false},
+		{"labels.env ~ '^pr' and labels.team in ['core', 'infra']", true},
+		{"labels.env == null", false},
+		{"labels.env != null", true},
+		{"labels.missing == null", true},
+		{"labels.missing != null", false},
+		{"labels.missing == 'prod'", false},
+		{"labels.missing != 'prod'", false},
+		{"codes.404 == 'not found'", true},
+		{"codes.500 == null", true},
+		{"codes.abc == null", true},
+		{"counts.7 >= 42", true},
+		{"counts.8 == null", true},
+		{"counts.8 > 0", false},
+		{"nested.a.str == 'aaa'", true},
+		{"nested.a == null", false},
+		{"nested.b == null", true},
+		{"nested.b.str == 'aaa'", false},
+		{"flags.true == false", true},
+		{"flags.false == null", true},
+	}
+
+	for _, test := range tests {
+		res, err := Filter(obj, test.filter)
+		assert.NoError(t, err, test.filter)
+		assert.Equal(t, test.res, res, test.filter)
+	}
+
+	res, err := Filter(&TestMapMessage{}, "labels.env == null and nested.a == null")
+	assert.NoError(t, err)
+	assert.True(t, res)
+}
+
+func TestFilteringPrecedence(t *testing.T) {
+	// a == 1 or b == 2 and c == 3 is true for obj since "and" binds tighter than "or"
+	// while (a == 1 or b == 2) and c == 3 is false
+	obj := &TestProtoMessage{Str: "a", Int: 2, Bool: false}
+	tests := []struct {
+		filter string
+		res    bool
+	}{
+		{"str == 'a' or int == 2 and bool == true", true},
+		{"(str == 'a' or int == 2) and bool == true", false},
+		{"bool == true and int == 2 or str == 'a'", true},
+		{"bool == true and (int == 2 or str == 'a')", false},
+		{"not str == 'a' or int == 2 and not bool == true", true},
+		{"not (str == 'a' or int == 2) and not bool == true", false},
+	}
+
+	for _, test := range tests {
+		res, err := Filter(obj, test.filter)
+		assert.NoError(t, err, test.filter)
+		assert.Equal(t, test.res, res, test.filter)
+	}
+}
+
+func TestFilteringNegative(t *testing.T) {
+
+	tests := []struct {
+		obj    interface{}
+		filter string
+		err    error
+	}{
+		{
+			obj:    &TestObject{Str: "111"},
+			filter: "str == 111",
+			err:    &TypeMismatchError{},
+		},
+		{
+			obj:    &TestObject{Float: 11.11},
+			filter: "float == '11.11'",
+			err:    &TypeMismatchError{},
+		},
+		{
+			obj:    &TestObject{},
+			filter: "float == null",
+			err:    &TypeMismatchError{},
+		},
+		{
+			obj:    &TestObject{},
+			filter: "missingField == 11.11",
+			err:    &TypeMismatchError{},
+		},
+		{
+			obj:    &TestProtoMessage{},
+			filter: "missingField == 11.11",
+			err:    &TypeMismatchError{},
+		},
+		{
+			obj:    &TestProtoMessage{Nested: &NestedMessage{}},
+			filter: "nested.missingField == 'foo'",
+			err:    &TypeMismatchError{},
+		},
+		{
+			obj:    &TestProtoMessage{},
+			filter: "nested.missingField == 'foo'",
+			err:    &TypeMismatchError{},
+		},
+		{
+			obj:    &TestObject{Str: "111"},
+			filter: "str.missingField == 'foo'",
+			err:    &TypeMismatchError{},
+		},
+		{
+			obj:    &TestObject{Str: "111"},
+			filter: "str ~ '11[1'",
+			err:    &syntax.Error{},
+		},
+		{
+			obj:    &TestObject{Str: "111"},
+			filter: "str in [1, 2]",
+			err:    &TypeMismatchError{},
+		},
+		{
+			obj:    &TestObject{Float: 1},
+			filter: "float not in ['1']",
+			err:    &TypeMismatchError{},
+		},
+	}
+
+	for _, test := range tests {
+		res, err := Filter(test.obj, test.filter)
+		assert.False(t, res)
+		assert.IsType(t, test.err, err)
+	}
+
+}
+
+func TestCombineFiltering(t *testing.T) {
+	l, err := ParseFiltering("str == 'a'")
+	assert.Nil(t, err)
+	r, err := ParseFiltering("int > 1 or bool == true")
+	assert.Nil(t, err)
+	exp, err := ParseFiltering("str == 'a' and (int > 1 or bool == true)")
+	assert.Nil(t, err)
+
+	assert.Equal(t, exp, combineFiltering(LogicalOperator_AND, l, r))
+	assert.Equal(t, r, combineFiltering(LogicalOperator_AND, nil, r))
+	assert.Equal(t, l, combineFiltering(LogicalOperator_AND, l, &Filtering{}))
+
+	// every kind of root is combined, including hand-built ones
+	_, _, _, wrappers := (*Filtering)(nil).XXX_OneofFuncs()
+	for _, w := range wrappers {
+		root := reflect.New(reflect.TypeOf(w).Elem())
+		root.Elem().Field(0).Set(reflect.New(root.Elem().Field(0).Type().Elem()))
+		f := &Filtering{Root: root.Interface().(isFiltering_Root)}
+		node := unwrapNode(f.Root)
+
+		res := combineFiltering(LogicalOperator_OR, f, f)
+		assert.Equal(t, LogicalOperator_OR, res.GetOperator().GetType(), "%T", w)
+		assert.True(t, unwrapNode(res.GetOperator().GetLeft()) == node, "%T", w)
+		assert.True(t, unwrapNode(res.GetOperator().GetRight()) == node, "%T", w)
+	}
+}
+
+func TestAndOrFilterings(t *testing.T) {
+	l, err := ParseFiltering("str == 'a'")
+	assert.Nil(t, err)
+	r, err := ParseFiltering("int > 1 and bool == true")
+	assert.Nil(t, err)
+
+	exp, err := ParseFiltering("str == 'a' or (int > 1 and bool == true)")
+	assert.Nil(t, err)
+	f := OrFilterings(l, r)
+	assert.Equal(t, exp, f)
+
+	res, err := f.Filter(&TestProtoMessage{Str: "b", Int: 2, Bool: true})
+	assert.Nil(t, err)
+	assert.True(t, res)
+
+	exp, err = ParseFiltering("str == 'a' and (int > 1 and bool == true)")
+	assert.Nil(t, err)
+	f = AndFilterings(l, r)
+	assert.Equal(t, exp, f)
+
+	// the operands do not share nodes with the result
+	f.GetOperator().GetLeftStringCondition().Value = "b"
+	f.GetOperator().GetRightOperator().GetLeftNumberCondition().Value = 2
+	assert.Equal(t, "a", l.GetStringCondition().GetValue())
+	assert.Equal(t, 1.0, r.GetOperator().GetLeftNumberCondition().GetValue())
+
+	f = OrFilterings(l, nil)
+	assert.Equal(t, l, f)
+	assert.False(t, f == l)
+
+	assert.Nil(t, AndFilterings(nil, nil))
+	assert.Nil(t, OrFilterings(&Filtering{}, nil))
+}
+
+// benchmarkNotIn evaluates "not in" condition with 1000 values against obj.
+func benchmarkNotIn(b *testing.B, obj *TestProtoMessage) {
+	values := make([]string, 1000)
+	for i := range values {
+		values[i] = strconv.Itoa(i)
+	}
+	cf, err := CompileFilter(fmt.Sprintf("str not in ['%s'] and int not in [%s]", strings.Join(values, "', '"), strings.Join(values, ", ")))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := cf.Match(obj); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkNotInFirst stops on the first value of the list.
+func BenchmarkNotInFirst(b *testing.B) {
+	benchmarkNotIn(b, &TestProtoMessage{Str: "0", Int: 0})
+}
+
+// BenchmarkNotInMissing looks through the whole list.
+func BenchmarkNotInMissing(b *testing.B) {
+	benchmarkNotIn(b, &TestProtoMessage{Str: "blocked", Int: -1})
+}
+
+type TestWideMessage struct {
+	Field01 string  `protobuf:"bytes,1,opt,name=field_01,json=field01"`
+	Field02 string  `protobuf:"bytes,2,opt,name=field_02,json=field02"`
+	Field03 string  `protobuf:"bytes,3,opt,name=field_03,json=field03"`
+	Field04 string  `protobuf:"bytes,4,opt,name=field_04,json=field04"`
+	Field05 string  `protobuf:"bytes,5,opt,name=field_05,json=field05"`
+	Field06 string  `protobuf:"bytes,6,opt,name=field_06,json=field06"`
+	Field07 string  `protobuf:"bytes,7,opt,name=field_07,json=field07"`
+	Field08 string  `protobuf:"bytes,8,opt,name=field_08,json=field08"`
+	Field09 string  `protobuf:"bytes,9,opt,name=field_09,json=field09"`
+	Field10 string  `protobuf:"bytes,10,opt,name=field_10,json=field10"`
+	Field11 string  `protobuf:"bytes,11,opt,name=field_11,json=field11"`
+	Field12 string  `protobuf:"bytes,12,opt,name=field_12,json=field12"`
+	Field13 string  `protobuf:"bytes,13,opt,name=field_13,json=field13"`
+	Field14 string  `protobuf:"bytes,14,opt,name=field_14,json=field14"`
+	Field15 string  `protobuf:"bytes,15,opt,name=field_15,json=field15"`
+	Field16 string  `protobuf:"bytes,16,opt,name=field_16,json=field16"`
+	Field17 string  `protobuf:"bytes,17,opt,name=field_17,json=field17"`
+	Field18 string  `protobuf:"bytes,18,opt,name=field_18,json=field18"`
+	Field19 string  `protobuf:"bytes,19,opt,name=field_19,json=field19"`
+	Field20 string  `protobuf:"bytes,20,opt,name=field_20,json=field20"`
+	Field21 string  `protobuf:"bytes,21,opt,name=field_21,json=field21"`
+	Field22 string  `protobuf:"bytes,22,opt,name=field_22,json=field22"`
+	Field23 string  `protobuf:"bytes,23,opt,name=field_23,json=field23"`
+	Field24 string  `protobuf:"bytes,24,opt,name=field_24,json=field24"`
+	Field25 string  `protobuf:"bytes,25,opt,name=field_25,json=field25"`
+	Field26 string  `protobuf:"bytes,26,opt,name=field_26,json=field26"`
+	Field27 string  `protobuf:"bytes,27,opt,name=field_27,json=field27"`
+	Field28 string  `protobuf:"bytes,28,opt,name=field_28,json=field28"`
+	Field29 string  `protobuf:"bytes,29,opt,name=field_29,json=field29"`
+	Field30 string  `protobuf:"bytes,30,opt,name=field_30,json=field30"`
+	Field31 string  `protobuf:"bytes,31,opt,name=field_31,json=field31"`
+	Value   float64 `protobuf:"fixed64,32,opt,name=value"`
+}
+
+func (m *TestWideMessage) Reset()         { *m = TestWideMessage{} }
+func (m *TestWideMessage) String() string { return proto.CompactTextString(m) }
+func (*TestWideMessage) ProtoMessage()    {}
+
+func TestFilteringWideMessage(t *testing.T) {
+	obj := &TestWideMessage{Field01: "a", Field31: "z", Value: 42}
+	for _, filter := range []string{"field_01 == 'a'", "field01 == 'a'", "field31 == 'z' and value > 40", "field_31 ~ '^z'"} {
+		res, err := Filter(obj, filter)
+		assert.NoError(t, err, filter)
+		assert.True(t, res, filter)
+	}
+}
+
+// benchmarkWideMessage evaluates a condition on the last field of a message with many fields.
+func benchmarkWideMessage(b *testing.B, cached bool) {
+	obj := &TestWideMessage{Value: 42}
+	cf, err := CompileFilter("value > 40")
+	if err != nil {
+		b.Fatal(err)
+	}
+	t := reflect.TypeOf(*obj)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if !cached {
+			fieldIndexCache.Delete(t)
+		}
+		if _, err := cf.Match(obj); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkWideMessageCached resolves field names with the per-type cache.
+func BenchmarkWideMessageCached(b *testing.B) {
+	benchmarkWideMessage(b, true)
+}
+
+// BenchmarkWideMessageUncached resolves field names of the message type on every evaluation.
+func BenchmarkWideMessageUncached(b *testing.B) {
+	benchmarkWideMessage(b, false)
+}
+
+type TestJSONTagObject struct {
+	Name     string `json:"display_name,omitempty"`
+	Label    string `json:"Name"`
+	Count    int    `json:",omitempty"`
+	Internal string `json:"-"`
+	Plain    bool
+}
+
+func TestFilteringJSONTags(t *testing.T) {
+	obj := &TestJSONTagObject{Name: "n", Label: "l", Count: 3, Internal: "i", Plain: true}
+	tobj := &TestObject{Str: "a", Float: 1.5, Uint: 2}
+	tests := []struct {
+		obj    interface{}
+		filter string
+		res    bool
+		err    error
+	}{
+		{obj: obj, filter: "display_name == 'n'", res: true},
+		// JSON name of Label takes precedence over Go name of Name
+		{obj: obj, filter: "Name == 'l'", res: true},
+		{obj: obj, filter: "Label == 'l'", res: true},
+		{obj: obj, filter: "Count == 3", res: true},
+		{obj: obj, filter: "Plain == true", res: true},
+		{obj: obj, filter: "Internal == 'i'", err: &TypeMismatchError{"string", []string{"Internal"}, "'i'"}},
+		{obj: tobj, filter: "str == 'a'", res: true},
+		{obj: tobj, filter: "Str == 'a'", res: true},
+		{obj: tobj, filter: "float > 1 and Float > 1", res: true},
+		{obj: tobj, filter: "uint == 2 and Uint == 2", res: true},
+	}
+	for _, test := range tests {
+		res, err := Filter(test.obj, test.filter)
+		assert.Equal(t, test.err, err, test.filter)
+		assert.Equal(t, test.res, res, test.filter)
+	}
+}
+
+type TestEmbeddedBase struct {
+	ID   string `json:"id"`
+	Note string `json:"note"`
+}
+
+type TestEmbeddedOther struct {
+	Note string `json:"note"`
+}
+
+type TestEmbeddingObject struct {
+	TestEmbeddedBase
+	*TestEmbeddedOther
+	*TestProtoMessage
+	Name string `json:"name"`
+	// hides TestProtoMessage.Int
+	Int string `json:"int"`
+}
+
+func TestFilteringEmbedded(t *testing.T) {
+	obj := &TestEmbeddingObject{
+		TestEmbeddedBase:  TestEmbeddedBase{ID: "a1", Note: "base"},
+		TestEmbeddedOther: &TestEmbeddedOther{Note: "other"},
+		TestProtoMessage:  &TestProtoMessage{Str: "s", Int: 5, Nested: &NestedMessage{Str: "n"}},
+		Name:              "name",
+		Int:               "int",
+	}
+	empty := &TestEmbeddingObject{}
+	tests := []struct {
+		obj    interface{}
+		filter string
+		res    bool
+		err    error
+	}{
+		{obj: obj, filter: "id == 'a1' and ID == 'a1' and name == 'name'", res: true},
+		{obj: obj, filter: "TestEmbeddedBase.id == 'a1' and TestEmbeddedOther.note == 'other'", res: true},
+		// proto and JSON names of the embedded proto message are promoted
+		{obj: obj, filter: "str == 's' and nested.str == 'n' and nestedJSON.str == 'n'", res: true},
+		{obj: obj, filter: "int == 'int'", res: true},
+		{obj: obj, filter: "int == 5", err: &TypeMismatchError{"number", []string{"int"}, "5"}},
+		// note is ambiguous at the same depth
+		{obj: obj, filter: "note == 'base'", err: &TypeMismatchError{"string", []string{"note"}, "'base'"}},
+		// fields of nil embedded pointers are null
+		{obj: empty, filter: "str == null and nested.str == null and TestEmbeddedOther.note == null", res: true},
+		{obj: empty, filter: "str == 's' or nested.str == 'n'", res: false},
+		{obj: empty, filter: "id == '' and ID == ''", res: true},
+	}
+	for _, test := range tests {
+		res, err := Filter(test.obj, test.filter)
+		assert.Equal(t, test.err, err, test.filter)
+		assert.Equal(t, test.res, res, test.filter)
+	}
+}
+
+type TestFieldComparisonObject struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	Min   int32     `json:"min"`
+	Max   float64   `json:"max"`
+	Name  string    `json:"name"`
+	Label *string   `json:"label"`
+	Tags  []string  `json:"tags"`
+}
+
+func TestFilteringFieldComparison(t *testing.T) {
+	label := "b"
+	obj := &TestFieldComparisonObject{
+		Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC),
+		Min:   1,
+		Max:   2.5,
+		Name:  "a",
+		Label: &label,
+	}
+	tests := []struct {
+		obj    interface{}
+		filter string
+		res    bool
+		err    error
+	}{
+		{obj: obj, filter: "start < end and start <= end and end > start and end >= start", res: true},
+		{obj: obj, filter: "start == end or start > end or not start < end", res: false},
+		{obj: obj, filter: "start != end and start == start", res: true},
+		// numbers of different types are comparable
+		{obj: obj, filter: "min < max and max >= min and min != max", res: true},
+		{obj: obj, filter: "name < label and label > name", res: true},
+		// null fields do not satisfy the condition regardless of negation
+		{obj: &TestFieldComparisonObject{Name: "a"}, filter: "name == label or name != label or label == label", res: false},
+		{obj: &TestFieldComparisonObject{}, filter: "start < end or start != end", res: false},
+		{obj: obj, filter: "min < name", err: &TypeMismatchError{"number", []string{"name"}, ""}},
+		{obj: obj, filter: "start > name", err: &TypeMismatchError{"timestamp", []string{"name"}, ""}},
+		{obj: obj, filter: "name == tags", err: &TypeMismatchError{"comparable", []string{"tags"}, ""}},
+		{obj: obj, filter: "name == unknown", err: &TypeMismatchError{"comparable", []string{"unknown"}, ""}},
+	}
+	for _, test := range tests {
+		res, err := Filter(test.obj, test.filter)
+		assert.Equal(t, test.err, err, test.filter)
+		assert.Equal(t, test.res, res, test.filter)
+	}
+}
+
+func TestTypeMismatchErrorStatus(t *testing.T) {
+	_, err := Filter(&TestObject{}, "float == 'abc'")
+	assert.Equal(t, &TypeMismatchError{"string", []string{"float"}, "'abc'"}, err)
+
+	s := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, s.Code())
+	assert.Equal(t, "float is not a string type, cannot compare with 'abc'", s.Message())
+	assert.Equal(t, []interface{}{
+		&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "float", Description: "float is not a string type, cannot compare with 'abc'"},
+			},
+		},
+	}, s.Details())
+
+	_, err = Filter(&TestObject{}, "str in [1, 2]")
+	assert.EqualError(t, err, "str is not a number type, cannot compare with [1, 2]")
+	_, err = Filter(&TestObject{Str: "a", Float: 1}, "str == float")
+	assert.EqualError(t, err, "float is not a string type")
+}
+
+func TestFilteringLongChain(t *testing.T) {
+	conds := make([]string, 2000)
+	for i := range conds {
+		conds[i] = fmt.Sprintf("float == %d", i)
+	}
+	f, err := ParseFilteringWithLimits(strings.Join(conds, " or "), FilteringLimits{MaxNodes: 4000})
+	assert.NoError(t, err)
+
+	// operators are balanced instead of being nested one into another
+	var depth func(node interface{}) int
+	depth = func(node interface{}) int {
+		lop, ok := unwrapNode(node).(*LogicalOperator)
+		if !ok {
+			return 0
+		}
+		l, r := depth(lop.Left), depth(lop.Right)
+		if l < r {
+			l = r
+		}
+		return l + 1
+	}
+	assert.Equal(t, 11, depth(f.Root))
+
+	for _, test := range []struct {
+		obj      *TestObject
+		expected bool
+	}{
+		{&TestObject{Float: 0}, true},
+		{&TestObject{Float: 1999}, true},
+		{&TestObject{Float: 2000}, false},
+	} {
+		res, err := f.Filter(test.obj)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, res, test.obj.Float)
+	}
+}
+
+func TestFilteringNumberLiterals(t *testing.T) {
+	tests := []struct {
+		obj    interface{}
+		filter string
+		res    bool
+	}{
+		{
+			obj:    &TestProtoMessage{Int: -12},
+			filter: "int == -12 and int > -13 and int < -1.1e1 and int in [-12, 1]",
+			res:    true,
+		},
+		{
+			obj:    &TestProtoMessage{Int: 12},
+			filter: "int == -12 or int < -1",
+			res:    false,
+		},
+		{
+			obj:    &TestObject{Float: -12.5},
+			filter: "float == -12.5 and float >= -1.25E1 and float < -1.5e-2",
+			res:    true,
+		},
+		{
+			obj:    &TestObject{Float: 1500},
+			filter: "float > 1e3 and float == 1.5e3 and float <= 15E2",
+			res:    true,
+		},
+		{
+			obj:    &TestWrappersMessage{Int64: &wrappers.Int64Value{Value: 1000000000000000000}},
+			filter: "int64 == 1e18 and int64 > -1e18 and int64 < 1.1e18",
+			res:    true,
+		},
+		{
+			obj:    &TestWrappersMessage{Int32: &wrappers.Int32Value{Value: -2147483648}},
+			filter: "int32 == -2147483648 and int32 < -2e9",
+			res:    true,
+		},
+	}
+
+	for _, test := range tests {
+		res, err := Filter(test.obj, test.filter)
+		assert.Equal(t, test.res, res, test.filter)
+		assert.Nil(t, err, test.filter)
+	}
+}
+
+type TestPointerObject struct {
+	Str     *string  `json:"str"`
+	Float   *float64 `json:"float"`
+	Float32 *float32 `json:"float32"`
+	Uint    *uint    `json:"uint"`
+	Count   *int     `json:"count"`
+	Bool    *bool    `json:"bool"`
+	Enum    *Enum    `json:"enum"`
+	PtrPtr  **int    `json:"ptr_ptr"`
+}
+
+func TestFilteringPointerFields(t *testing.T) {
+	str, float, float32, uint, count, boolean, enum := "abc", 11.11, float32(1.1), uint(11), 5, true, ENUM_ONE
+	pcount := &count
+	obj := &TestPointerObject{
+		Str:     &str,
+		Float:   &float,
+		Float32: &float32,
+		Uint:    &uint,
+		Count:   &count,
+		Bool:    &boolean,
+		Enum:    &enum,
+		PtrPtr:  &pcount,
+	}
+	tests := []struct {
+		obj    interface{}
+		filter string
+		res    bool
+		err    error
+	}{
+		{obj: obj, filter: "count == 5", res: true},
+		{obj: obj, filter: "count > 4 and count <= 5 and count in [1, 5]", res: true},
+		{obj: obj, filter: "count != 5", res: false},
+		{obj: obj, filter: "count == null", res: false},
+		{obj: obj, filter: "count != null and str != null and bool != null", res: true},
+		{obj: obj, filter: "str == 'abc' and str ~ 'b' and str in ['abc'] and str > 'abb'", res: true},
+		{obj: obj, filter: "float == 11.11 and float32 == 1.1 and uint == 11", res: true},
+		{obj: obj, filter: "bool == true and bool > false", res: true},
+		{obj: obj, filter: "enum == 'ONE' and enum in ['ONE', 'TW0']", res: true},
+		{obj: obj, filter: "ptr_ptr == 5 and ptr_ptr != null", res: true},
+		{obj: obj, filter: "count == float32", res: false},
+		{obj: obj, filter: "count == ptr_ptr and uint > count", res: true},
+		{obj: &TestPointerObject{PtrPtr: new(*int)}, filter: "ptr_ptr == null", res: true},
+		{obj: &TestPointerObject{PtrPtr: new(*int)}, filter: "ptr_ptr == 5 or ptr_ptr != 5", res: false},
+		// nil pointers are null
+		{obj: &TestPointerObject{}, filter: "count == null and str == null and bool == null and ptr_ptr == null", res: true},
+		{obj: &TestPointerObject{}, filter: "count == 5 or count != 5 or str == 'abc' or str != 'abc' or bool == true or bool != true", res: false},
+		{obj: &TestPointerObject{}, filter: "count in [5] or str in ['abc'] or not count in [5]", res: false},
+		// type coercion applies to the pointed-to type
+		{obj: obj, filter: "count == 'abc'", err: &TypeMismatchError{"string", []string{"count"}, "'abc'"}},
+		{obj: &TestPointerObject{}, filter: "str == 5", err: &TypeMismatchError{"number", []string{"str"}, "5"}},
+		{obj: &TestPointerObject{}, filter: "count == true", err: &TypeMismatchError{"bool", []string{"count"}, "true"}},
+	}
+	for _, test := range tests {
+		res, err := Filter(test.obj, test.filter)
+		assert.Equal(t, test.err, err, test.filter)
+		assert.Equal(t, test.res, res, test.filter)
+	}
+}
+
+type TestQuotedFieldsObject struct {
+	UserName string            `json:"user name"`
+	And      int               `json:"and"`
+	Dotted   *NestedMessage    `json:"a.b"`
+	Labels   map[string]string `json:"labels"`
+}
+
+func TestFilteringQuotedFields(t *testing.T) {
+	obj := &TestQuotedFieldsObject{
+		UserName: "John",
+		And:      1,
+		Dotted:   &NestedMessage{Str: "foo"},
+		Labels:   map[string]string{"app name": "acme"},
+	}
+	tests := []struct {
+		filter string
+		res    bool
+	}{
+		{"`user name` == 'John'", true},
+		{"`user name` != 'John' or `and` > 1", false},
+		{"`a.b`.str == 'foo' and labels.`app name` == 'acme'", true},
+		{"not `and` == 1", false},
+	}
+	for _, test := range tests {
+		res, err := Filter(obj, test.filter)
+		assert.NoError(t, err, test.filter)
+		assert.Equal(t, test.res, res, test.filter)
+	}
+
+	res, err := Filter(obj, "`display name` == 'John'", WithFieldAliases(map[string]string{"display name": "user name"}))
+	assert.NoError(t, err)
+	assert.True(t, res)
+}
+
+type TestFloatObject struct {
+	Price  float64                `json:"price"`
+	Weight float32                `json:"weight"`
+	Ptr    *float64               `json:"ptr"`
+	Count  int                    `json:"count"`
+	Extra  map[string]interface{} `json:"extra"`
+}
+
+func TestFilteringFloatTolerance(t *testing.T) {
+	ptr := 2.0000001
+	obj := &TestFloatObject{Price: 11.109999999, Weight: 0.3000001, Ptr: &ptr, Count: 3, Extra: map[string]interface{}{"rate": 0.30000000000000004}}
+	tests := []struct {
+		filter string
+		exact  bool
+		eps    bool
+	}{
+		{"price == 11.11", false, true},
+		{"price != 11.11", true, false},
+		{"price == 11.12", false, false},
+		{"price <=> 11.11", false, true},
+		{"price > 11.11", false, false},
+		{"price <= 11.11", true, true},
+		{"price in [11.11]", false, false},
+		{"weight == 0.3", false, true},
+		{"ptr == 2", false, true},
+		{"count == 3.0000001", false, false},
+		{"extra.rate == 0.3", false, true},
+	}
+	for _, test := range tests {
+		res, err := Filter(obj, test.filter)
+		assert.NoError(t, err, test.filter)
+		assert.Equal(t, test.exact, res, test.filter)
+
+		res, err = Filter(obj, test.filter, WithFloatTolerance(1e-6))
+		assert.NoError(t, err, test.filter)
+		assert.Equal(t, test.eps, res, "%s with tolerance", test.filter)
+	}
 }
@@ -0,0 +1,277 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/duration"
+	"github.com/golang/protobuf/ptypes/timestamp"
+)
+
+var (
+	timestampType = reflect.TypeOf((*timestamp.Timestamp)(nil))
+	durationType  = reflect.TypeOf((*duration.Duration)(nil))
+	timeType      = reflect.TypeOf(time.Time{})
+)
+
+// TimeLayouts makes string literals that are compared with timestamp and time.Time fields
+// to be parsed with layouts in the given order if they are not in RFC3339 format,
+// e.g. TimeLayouts("2006-01-02", "2006-01-02 15:04:05").
+func TimeLayouts(layouts ...string) FilterOption {
+	return func(o *filterOptions) {
+		o.timeLayouts = append(o.timeLayouts, layouts...)
+	}
+}
+
+// InvalidLiteralError describes a literal that cannot be interpreted as a value of the field type.
+type InvalidLiteralError struct {
+	Type  string
+	Value string
+	Err   error
+}
+
+func (e *InvalidLiteralError) Error() string {
+	return fmt.Sprintf("%q is not a valid %s literal: %s", e.Value, e.Type, e.Err)
+}
+
+// filterTimestamp evaluates string condition against google.protobuf.Timestamp value fv.
+// The string literal is expected to be in RFC3339 format.
+func (c *StringCondition) filterTimestamp(fv reflect.Value, o *filterOptions) (bool, error) {
+	if !c.isComparison() {
+		return false, &UnsupportedOperatorError{"timestamp", c.Type.String()}
+	}
+	lit, err := c.timeLiteral(o)
+	if err != nil {
+		return false, &InvalidLiteralError{"timestamp", c.Value, err}
+	}
+	if fv.IsNil() {
+		return false, nil
+	}
+	t, err := ptypes.Timestamp(fv.Interface().(*timestamp.Timestamp))
+	if err != nil {
+		return false, err
+	}
+	return c.compare(compareTime(t, lit), "timestamp")
+}
+
+// filterTime evaluates string condition against time.Time or *time.Time value fv.
+// Zero time is treated as null, so it does not satisfy the condition as a nil pointer does.
+func (c *StringCondition) filterTime(fv reflect.Value, o *filterOptions) (bool, error) {
+	if !c.isComparison() {
+		return false, &UnsupportedOperatorError{"time", c.Type.String()}
+	}
+	lit, err := c.timeLiteral(o)
+	if err != nil {
+		return false, &InvalidLiteralError{"time", c.Value, err}
+	}
+	if isNilValue(fv) {
+		return false, nil
+	}
+	t := dereferenceValue(fv).Interface().(time.Time)
+	if t.IsZero() {
+		return false, nil
+	}
+	return c.compare(compareTime(t, lit), "time")
+}
+
+// isTimeValue reports whether v is either time.Time or *time.Time value.
+func isTimeValue(v reflect.Value) bool {
+	return v.IsValid() && (v.Type() == timeType || v.Type() == reflect.PtrTo(timeType))
+}
+
+// parseTime parses literal s in RFC3339 format or in one of the layouts set by TimeLayouts option.
+func parseTime(s string, o *filterOptions) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err == nil {
+		return t, nil
+	}
+	for _, layout := range o.timeLayouts {
+		if t, lerr := time.Parse(layout, s); lerr == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+// relativeTimeUnits are units of offsets of relative time literals.
+var relativeTimeUnits = map[byte]time.Duration{
+	's': time.Second,
+	'm': time.Minute,
+	'h': time.Hour,
+	'd': 24 * time.Hour,
+	'w': 7 * 24 * time.Hour,
+}
+
+var errInvalidRelativeTime = errors.New("expected now optionally followed by offsets like -7d or +12h")
+
+// relativeTimeOffset is a signed offset of a relative time literal, e.g. -7d.
+type relativeTimeOffset struct {
+	n    int64
+	unit byte
+}
+
+// relativeTimeOffsets splits relative time literal s, that is now followed by any number of signed offsets
+// with a unit, e.g. now, now-7d or now-1d+12h, into the offsets.
+// The second returned value is false if s is not a relative time literal.
+func relativeTimeOffsets(s string) ([]relativeTimeOffset, bool) {
+	if !strings.HasPrefix(s, "now") {
+		return nil, false
+	}
+	var offsets []relativeTimeOffset
+	for s = s[len("now"):]; s != ""; {
+		if s[0] != '+' && s[0] != '-' {
+			return nil, false
+		}
+		i := 1
+		for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+			i++
+		}
+		if i == 1 || i == len(s) {
+			return nil, false
+		}
+		if _, ok := relativeTimeUnits[s[i]]; !ok {
+			return nil, false
+		}
+		n, err := strconv.ParseInt(s[:i], 10, 64)
+		if err != nil {
+			return nil, false
+		}
+		offsets = append(offsets, relativeTimeOffset{n, s[i]})
+		s = s[i+1:]
+	}
+	return offsets, true
+}
+
+// parseRelativeTime returns the offset of relative time literal s from the current time.
+// The second returned value is false if s is not a relative time literal or the offset overflows time.Duration.
+func parseRelativeTime(s string) (time.Duration, bool) {
+	offsets, ok := relativeTimeOffsets(s)
+	if !ok {
+		return 0, false
+	}
+	var sum time.Duration
+	for _, o := range offsets {
+		unit := relativeTimeUnits[o.unit]
+		if o.n > int64(math.MaxInt64/unit) || o.n < int64(math.MinInt64/unit) {
+			return 0, false
+		}
+		d := time.Duration(o.n) * unit
+		if d > 0 && sum > math.MaxInt64-d || d < 0 && sum < math.MinInt64-d {
+			return 0, false
+		}
+		sum += d
+	}
+	return sum, true
+}
+
+// WithClock makes relative time literals, e.g. now-7d, to be resolved against the time returned by now
+// instead of time.Now, e.g. to evaluate them consistently across many objects or in tests.
+func WithClock(now func() time.Time) FilterOption {
+	return func(o *filterOptions) {
+		o.clock = now
+	}
+}
+
+// timeLiteral returns the time that is the literal of condition c, a relative time literal is resolved
+// against the clock set by WithClock.
+func (c *StringCondition) timeLiteral(o *filterOptions) (time.Time, error) {
+	if !c.RelativeTime {
+		return parseTime(c.Value, o)
+	}
+	return resolveRelativeTime(c.Value, o.clock)
+}
+
+// ResolveRelativeTime returns the time denoted by relative time literal s, e.g. now-7d, given the current time
+// returned by now, time.Now is used if now is nil. It is used by translators of conditions with RelativeTime set.
+func ResolveRelativeTime(s string, now func() time.Time) (time.Time, error) {
+	t, err := resolveRelativeTime(s, now)
+	if err != nil {
+		return time.Time{}, &InvalidLiteralError{"relative time", s, err}
+	}
+	return t, nil
+}
+
+func resolveRelativeTime(s string, now func() time.Time) (time.Time, error) {
+	offset, ok := parseRelativeTime(strings.ToLower(s))
+	if !ok {
+		return time.Time{}, errInvalidRelativeTime
+	}
+	if now == nil {
+		now = time.Now
+	}
+	return now().Add(offset), nil
+}
+
+// compareTime returns -1, 0 or +1 as t is before, equal to or after u.
+func compareTime(t, u time.Time) int {
+	switch {
+	case t.Before(u):
+		return -1
+	case t.After(u):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// filterDuration evaluates string condition against google.protobuf.Duration value fv.
+// The string literal is expected to be in a format accepted by time.ParseDuration, e.g. "1h30m".
+func (c *StringCondition) filterDuration(fv reflect.Value) (bool, error) {
+	if !c.isComparison() {
+		return false, &UnsupportedOperatorError{"duration", c.Type.String()}
+	}
+	lit, err := time.ParseDuration(c.Value)
+	if err != nil {
+		return false, &InvalidLiteralError{"duration", c.Value, err}
+	}
+	if fv.IsNil() {
+		return false, nil
+	}
+	d, err := ptypes.Duration(fv.Interface().(*duration.Duration))
+	if err != nil {
+		return false, err
+	}
+	var cmp int
+	switch {
+	case d < lit:
+		cmp = -1
+	case d > lit:
+		cmp = 1
+	}
+	return c.compare(cmp, "duration")
+}
+
+// isComparison reports whether the condition is either equality or ordering comparison.
+func (c *StringCondition) isComparison() bool {
+	switch c.Type {
+	case StringCondition_EQ, StringCondition_GT, StringCondition_GE, StringCondition_LT, StringCondition_LE:
+		return true
+	default:
+		return false
+	}
+}
+
+// compare evaluates the condition given the result of comparison of a field value
+// and the literal, which is -1, 0 or +1 as the value is less, equal or greater than the literal.
+func (c *StringCondition) compare(cmp int, typ string) (bool, error) {
+	switch c.Type {
+	case StringCondition_EQ:
+		return negateIfNeeded(cmp == 0, c.IsNegative), nil
+	case StringCondition_GT:
+		return negateIfNeeded(cmp > 0, c.IsNegative), nil
+	case StringCondition_GE:
+		return negateIfNeeded(cmp >= 0, c.IsNegative), nil
+	case StringCondition_LT:
+		return negateIfNeeded(cmp < 0, c.IsNegative), nil
+	case StringCondition_LE:
+		return negateIfNeeded(cmp <= 0, c.IsNegative), nil
+	default:
+		return false, &UnsupportedOperatorError{typ, c.Type.String()}
+	}
+}
@@ -0,0 +1,100 @@
+package query
+
+import (
+	"reflect"
+	"strings"
+)
+
+// ValidateFilterTypes checks that literals of f are compatible with kinds of the fields they are compared with
+// according to schema, that maps dot-separated field paths to kinds of their values, e.g. to validate a client
+// filter at the API boundary without an instance of the filtered type. Kinds are checked as follows:
+//   - string literals are compatible with reflect.String and with kinds of the other types compared
+//     with strings: reflect.Int32 (enums), reflect.Struct (times, timestamps, networks) and reflect.Slice (bytes, IPs);
+//   - number literals are compatible with integer and floating-point kinds;
+//   - bool literals are compatible with reflect.Bool;
+//   - JSON object literals of contains are compatible with reflect.Map, reflect.Slice, reflect.Array and reflect.Struct;
+//   - fields compared with each other must be of the same kind, any numeric kinds are compatible;
+//   - null, exists and custom conditions as well as fields of reflect.Interface kind are not checked.
+//
+// Pointer fields are described by the kinds of their elements. TypeMismatchError is returned for the first
+// incompatible comparison and UnknownFieldError for the first field missing from schema.
+func ValidateFilterTypes(f *Filtering, schema map[string]reflect.Kind) error {
+	if f == nil {
+		return nil
+	}
+	return walkNode(f.Root, func(node interface{}) error {
+		fp := conditionFieldPath(node)
+		if fp == nil {
+			return nil
+		}
+		kind, ok := schema[strings.Join(fp, ".")]
+		if !ok {
+			return &UnknownFieldError{fp}
+		}
+		if kind == reflect.Interface {
+			return nil
+		}
+		switch n := node.(type) {
+		case *StringCondition:
+			if !isStringLiteralKind(kind) {
+				return &TypeMismatchError{"string", fp, literalString(n.Value)}
+			}
+		case *StringArrayCondition:
+			if !isStringLiteralKind(kind) {
+				return &TypeMismatchError{"string", fp, literalString(n.Values)}
+			}
+		case *NumberCondition:
+			if !isNumberKind(kind) {
+				return &TypeMismatchError{"number", fp, literalString(n.Value)}
+			}
+		case *NumberArrayCondition:
+			if !isNumberKind(kind) {
+				return &TypeMismatchError{"number", fp, literalString(n.Values)}
+			}
+		case *BoolCondition:
+			if kind != reflect.Bool {
+				return &TypeMismatchError{"bool", fp, literalString(n.Value)}
+			}
+		case *ContainsCondition:
+			switch kind {
+			case reflect.Map, reflect.Slice, reflect.Array, reflect.Struct:
+			default:
+				return &TypeMismatchError{"JSON", fp, quoteString(n.Value)}
+			}
+		case *FieldCondition:
+			vkind, ok := schema[strings.Join(n.ValueFieldPath, ".")]
+			if !ok {
+				return &UnknownFieldError{n.ValueFieldPath}
+			}
+			if vkind == reflect.Interface || vkind == kind || isNumberKind(vkind) && isNumberKind(kind) {
+				return nil
+			}
+			return &TypeMismatchError{kindTypeName(kind), n.ValueFieldPath, ""}
+		}
+		return nil
+	})
+}
+
+// isStringLiteralKind reports whether fields of kind k can be compared with string literals.
+func isStringLiteralKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.String, reflect.Int32, reflect.Struct, reflect.Slice:
+		return true
+	default:
+		return false
+	}
+}
+
+// kindTypeName returns the name of the type of kind k as reported by TypeMismatchError.
+func kindTypeName(k reflect.Kind) string {
+	switch {
+	case isNumberKind(k):
+		return "number"
+	case k == reflect.Bool:
+		return "bool"
+	case k == reflect.String:
+		return "string"
+	default:
+		return k.String()
+	}
+}
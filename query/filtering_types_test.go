@@ -0,0 +1,53 @@
+package query
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateFilterTypes(t *testing.T) {
+	schema := map[string]reflect.Kind{
+		"name":       reflect.String,
+		"age":        reflect.Int64,
+		"score":      reflect.Float32,
+		"active":     reflect.Bool,
+		"status":     reflect.Int32,
+		"created_at": reflect.Struct,
+		"labels":     reflect.Map,
+		"extra":      reflect.Interface,
+		"address.id": reflect.Uint32,
+	}
+	tests := []struct {
+		filter string
+		err    error
+	}{
+		{filter: "", err: nil},
+		{filter: "name == 'a' and not (age > 1 or score < 1.5) and active == true", err: nil},
+		{filter: "name in ['a', 'b'] and age in [1, 2] and address.id between 1 and 5", err: nil},
+		{filter: "status == 'ACTIVE' or status == 1 or created_at > '2023-01-01T00:00:00Z'", err: nil},
+		{filter: "name == null and age is not null and name exists", err: nil},
+		{filter: "extra == 'a' and extra == 1 and extra == true", err: nil},
+		{filter: "labels contains '{\"env\": \"prod\"}'", err: nil},
+		{filter: "age > score and address.id == age and name == extra", err: nil},
+		{filter: "name == 'a' and age == '1'", err: &TypeMismatchError{"string", []string{"age"}, "'1'"}},
+		{filter: "age in ['1']", err: &TypeMismatchError{"string", []string{"age"}, "['1']"}},
+		{filter: "name > 1", err: &TypeMismatchError{"number", []string{"name"}, "1"}},
+		{filter: "not name in [1, 2]", err: &TypeMismatchError{"number", []string{"name"}, "[1, 2]"}},
+		{filter: "age == true", err: &TypeMismatchError{"bool", []string{"age"}, "true"}},
+		{filter: "name contains '{}'", err: &TypeMismatchError{"JSON", []string{"name"}, "'{}'"}},
+		{filter: "name == age", err: &TypeMismatchError{"string", []string{"age"}, ""}},
+		{filter: "active != name", err: &TypeMismatchError{"bool", []string{"name"}, ""}},
+		{filter: "nickname == 'a'", err: &UnknownFieldError{[]string{"nickname"}}},
+		{filter: "name == nickname", err: &UnknownFieldError{[]string{"nickname"}}},
+	}
+	for _, test := range tests {
+		f, err := ParseFiltering(test.filter)
+		if !assert.NoError(t, err, test.filter) {
+			continue
+		}
+		assert.Equal(t, test.err, ValidateFilterTypes(f, schema), test.filter)
+	}
+	assert.NoError(t, ValidateFilterTypes(nil, schema))
+}
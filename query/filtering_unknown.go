@@ -0,0 +1,61 @@
+package query
+
+import (
+	"errors"
+)
+
+// UnknownFieldPolicy defines how conditions on fields that cannot be found in an evaluated object are handled.
+type UnknownFieldPolicy int
+
+const (
+	// RejectUnknownField makes evaluation fail with TypeMismatchError or UnknownFieldError.
+	RejectUnknownField UnknownFieldPolicy = iota
+	// MismatchUnknownField makes a condition on an unknown field to be false regardless of its negation,
+	// e.g. both "missing == 1" and "missing != 1" are false.
+	MismatchUnknownField
+	// SkipUnknownField makes a condition on an unknown field to be dropped from the expression,
+	// e.g. "missing == 1 and a == 2" is the same as "a == 2", an expression that consists
+	// of such conditions only is true.
+	SkipUnknownField
+)
+
+// WithUnknownFieldPolicy makes conditions on fields that cannot be found in an evaluated object
+// to be handled according to policy instead of failing the evaluation, e.g. to keep filters saved
+// by clients working after a field is removed. The policy is applied to conditions nested in logical
+// operators and to fields of repeated messages as well. Map keys are never unknown, missing ones are null.
+func WithUnknownFieldPolicy(policy UnknownFieldPolicy) FilterOption {
+	return func(o *filterOptions) {
+		o.unknownFields = policy
+	}
+}
+
+// errUnknownField is returned by filterNode for a condition on an unknown field if the policy
+// is not RejectUnknownField, it is resolved by the enclosing logical operator or by filterRoot.
+var errUnknownField = errors.New("unknown field")
+
+// isUnknownField reports whether any of the field paths of condition node cannot be resolved against obj.
+// Paths going through repeated fields are resolved against the elements.
+func (o *filterOptions) isUnknownField(node interface{}, obj interface{}) bool {
+	for _, fp := range conditionFieldPaths(node) {
+		if _, _, ok := repeatedField(obj, fp); ok {
+			continue
+		}
+		if _, ok := dynamicField(obj, fp); ok {
+			continue
+		}
+		if !o.rawFieldByFieldPath(obj, fp).IsValid() {
+			return true
+		}
+	}
+	return false
+}
+
+// filterRoot evaluates root node of a filtering expression against obj,
+// the whole expression is a condition on an unknown field if errUnknownField is returned.
+func filterRoot(root interface{}, obj interface{}, o *filterOptions) (bool, error) {
+	res, err := filterNode(root, obj, o)
+	if err == errUnknownField {
+		return o.unknownFields == SkipUnknownField, nil
+	}
+	return res, err
+}
@@ -0,0 +1,75 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TestUnknownItem struct {
+	Sku string `json:"sku"`
+}
+
+type TestUnknownObject struct {
+	Str    string            `json:"str"`
+	Num    int               `json:"num"`
+	Items  []TestUnknownItem `json:"items"`
+	Labels map[string]string `json:"labels"`
+}
+
+func TestFilteringUnknownFieldPolicy(t *testing.T) {
+	obj := &TestUnknownObject{Str: "a", Num: 1, Items: []TestUnknownItem{{Sku: "x"}}}
+	tests := []struct {
+		filter   string
+		reject   bool
+		mismatch bool
+		skip     bool
+	}{
+		{"missing == 'a'", true, false, true},
+		{"missing != 'a'", true, false, true},
+		{"not missing in [1, 2]", true, false, true},
+		{"missing exists", true, false, true},
+		{"missing == null", true, false, true},
+		{"str.missing == 'a'", true, false, true},
+		{"str == 'a' and missing == 'a'", true, false, true},
+		{"str == 'b' and missing == 'a'", false, false, false},
+		{"str == 'b' or missing == 'a'", true, false, false},
+		{"str == 'a' or missing == 'a'", false, true, true},
+		{"not (missing == 'a' and str == 'b')", true, true, true},
+		{"not (missing == 'a' or str == 'a')", true, false, false},
+		{"not (missing == 'a' or other > 1) and num == 1", true, true, true},
+		{"str == missing", true, false, true},
+		{"missing == num", true, false, true},
+		{"items.missing == 'x'", true, false, true},
+		{"not items.missing == 'x'", true, false, true},
+		{"items.sku == 'x' and items.missing == 'x'", true, false, true},
+		{"missing == 'a' or (other == 'b' and num == 1)", true, false, true},
+		{"missing.nested contains '{}'", true, false, true},
+		// missing keys of maps are null, not unknown
+		{"labels.missing == null", false, true, true},
+		{"labels.missing == 'a' or num == 1", false, true, true},
+	}
+	for _, test := range tests {
+		_, err := Filter(obj, test.filter)
+		assert.Equal(t, test.reject, err != nil, "%s: %v", test.filter, err)
+
+		res, err := Filter(obj, test.filter, WithUnknownFieldPolicy(MismatchUnknownField))
+		assert.NoError(t, err, test.filter)
+		assert.Equal(t, test.mismatch, res, "%s with MismatchUnknownField", test.filter)
+
+		res, err = Filter(obj, test.filter, WithUnknownFieldPolicy(SkipUnknownField))
+		assert.NoError(t, err, test.filter)
+		assert.Equal(t, test.skip, res, "%s with SkipUnknownField", test.filter)
+
+		cf, err := CompileFilter(test.filter, WithUnknownFieldPolicy(SkipUnknownField))
+		if assert.NoError(t, err, test.filter) {
+			res, err = cf.Match(obj)
+			assert.NoError(t, err, test.filter)
+			assert.Equal(t, test.skip, res, "compiled %s with SkipUnknownField", test.filter)
+		}
+	}
+
+	// other errors are reported regardless of the policy
+	_, err := Filter(obj, "str > 1 or missing == 'a'", WithUnknownFieldPolicy(SkipUnknownField))
+	assert.IsType(t, &TypeMismatchError{}, err)
+}
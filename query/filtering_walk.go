@@ -0,0 +1,58 @@
+package query
+
+import (
+	"errors"
+)
+
+// Visitor is implemented by translators of filtering expressions, e.g. to other query languages,
+// so that they do not need to traverse the AST themselves. Walk calls Enter for every node of an expression
+// before its operands and Leave after them, so a translator can push translations of operands onto a stack
+// in Leave and combine them when it leaves a logical operator.
+//
+// A node is either *LogicalOperator with Type AND or OR and Left and Right operands,
+// or one of the conditions: *StringCondition, *NumberCondition, *NullCondition, *BoolCondition,
+// *StringArrayCondition, *NumberArrayCondition, *ExistsCondition, *FieldCondition, *ContainsCondition
+// and *CustomCondition. There is no separate node for NOT, every node has IsNegative flag instead,
+// e.g. "not (a == 1 or b == 2)" is a negated *LogicalOperator and "a != 1" is a negated *StringCondition.
+type Visitor interface {
+	// Enter is called for node before its operands. If it returns SkipNode,
+	// operands of node are not visited and Leave is not called for it.
+	Enter(node interface{}) error
+	// Leave is called for node after its operands.
+	Leave(node interface{}) error
+}
+
+// SkipNode is returned by Visitor.Enter to skip operands of a logical operator, it is not returned by Walk.
+var SkipNode = errors.New("skip this node")
+
+// Walk traverses the AST of f in depth-first order from left to right calling Enter and Leave
+// methods of v for every node. Walk stops at the first error returned by v and returns it.
+// Nothing is visited for nil or empty f.
+func Walk(f *Filtering, v Visitor) error {
+	if f == nil {
+		return nil
+	}
+	return walkVisitor(f.Root, v)
+}
+
+func walkVisitor(node interface{}, v Visitor) error {
+	node = unwrapNode(node)
+	if node == nil {
+		return nil
+	}
+	if err := v.Enter(node); err != nil {
+		if err == SkipNode {
+			return nil
+		}
+		return err
+	}
+	if lop, ok := node.(*LogicalOperator); ok {
+		if err := walkVisitor(lop.Left, v); err != nil {
+			return err
+		}
+		if err := walkVisitor(lop.Right, v); err != nil {
+			return err
+		}
+	}
+	return v.Leave(node)
+}
@@ -0,0 +1,118 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// traceVisitor records Enter and Leave calls.
+type traceVisitor struct {
+	trace []string
+	skip  bool
+	err   error
+}
+
+func (v *traceVisitor) Enter(node interface{}) error {
+	v.trace = append(v.trace, "enter "+nodeString(node))
+	if _, ok := node.(*LogicalOperator); ok && v.skip && len(v.trace) > 1 {
+		return SkipNode
+	}
+	if _, ok := node.(*NumberCondition); ok && v.err != nil {
+		return v.err
+	}
+	return nil
+}
+
+func (v *traceVisitor) Leave(node interface{}) error {
+	v.trace = append(v.trace, "leave "+nodeString(node))
+	return nil
+}
+
+// luceneVisitor translates an expression to a Lucene-like query to test a stack-based translator.
+type luceneVisitor struct {
+	stack []string
+}
+
+func (v *luceneVisitor) Enter(node interface{}) error {
+	return nil
+}
+
+func (v *luceneVisitor) Leave(node interface{}) error {
+	var s string
+	switch n := node.(type) {
+	case *LogicalOperator:
+		l, r := v.stack[len(v.stack)-2], v.stack[len(v.stack)-1]
+		v.stack = v.stack[:len(v.stack)-2]
+		s = fmt.Sprintf("(%s %s %s)", l, n.Type, r)
+		if n.IsNegative {
+			s = "NOT " + s
+		}
+	case *StringCondition:
+		s = fmt.Sprintf("%s:%q", strings.Join(n.FieldPath, "."), n.Value)
+		if n.IsNegative {
+			s = "NOT " + s
+		}
+	case *NumberCondition:
+		s = fmt.Sprintf("%s:%v", strings.Join(n.FieldPath, "."), n.Value)
+		if n.IsNegative {
+			s = "NOT " + s
+		}
+	default:
+		return fmt.Errorf("%T is not supported", node)
+	}
+	v.stack = append(v.stack, s)
+	return nil
+}
+
+func TestWalkFiltering(t *testing.T) {
+	f, err := ParseFiltering("a == 'x' and not (b == 1 or c != 'y')")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	v := &luceneVisitor{}
+	assert.NoError(t, Walk(f, v))
+	assert.Equal(t, []string{`(a:"x" AND NOT (b:1 OR NOT c:"y"))`}, v.stack)
+
+	tv := &traceVisitor{}
+	assert.NoError(t, Walk(f, tv))
+	assert.Equal(t, []string{
+		"enter (a == 'x' and not (b == 1 or c != 'y'))",
+		"enter a == 'x'",
+		"leave a == 'x'",
+		"enter not (b == 1 or c != 'y')",
+		"enter b == 1",
+		"leave b == 1",
+		"enter c != 'y'",
+		"leave c != 'y'",
+		"leave not (b == 1 or c != 'y')",
+		"leave (a == 'x' and not (b == 1 or c != 'y'))",
+	}, tv.trace)
+
+	tv = &traceVisitor{skip: true}
+	assert.NoError(t, Walk(f, tv))
+	assert.Equal(t, []string{
+		"enter (a == 'x' and not (b == 1 or c != 'y'))",
+		"enter a == 'x'",
+		"leave a == 'x'",
+		"enter not (b == 1 or c != 'y')",
+		"leave (a == 'x' and not (b == 1 or c != 'y'))",
+	}, tv.trace)
+
+	errStop := errors.New("stop")
+	tv = &traceVisitor{err: errStop}
+	assert.Equal(t, errStop, Walk(f, tv))
+	assert.Equal(t, "enter b == 1", tv.trace[len(tv.trace)-1])
+
+	f, err = ParseFiltering("a exists")
+	if assert.NoError(t, err) {
+		assert.EqualError(t, Walk(f, &luceneVisitor{}), "*query.ExistsCondition is not supported")
+	}
+
+	assert.NoError(t, Walk(nil, &luceneVisitor{}))
+	assert.NoError(t, Walk(&Filtering{}, &luceneVisitor{}))
+}
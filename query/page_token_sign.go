@@ -0,0 +1,98 @@
+package query
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"io"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/partitio/atlas-app-toolkit/errors"
+)
+
+// SignPageToken signs page token pt with HMAC-SHA256 using key.
+// The signed token has the following format: <pt>.<base64url(signature)>.
+func SignPageToken(pt string, key []byte) string {
+	return pt + "." + base64.RawURLEncoding.EncodeToString(pageTokenMAC(pt, key))
+}
+
+// VerifyPageToken verifies signature of page token signed by SignPageToken
+// and returns the original page token.
+// To support key rotation the signature is checked against key and then against each of oldKeys.
+func VerifyPageToken(token string, key []byte, oldKeys ...[]byte) (string, error) {
+	errC := errors.InitContainer()
+	i := strings.LastIndex(token, ".")
+	if i < 0 {
+		return "", errC.New(codes.InvalidArgument, "Page token is not signed.")
+	}
+	pt := token[:i]
+	sig, err := base64.RawURLEncoding.DecodeString(token[i+1:])
+	if err != nil {
+		return "", errC.New(codes.InvalidArgument, "Invalid page token signature %q.", err)
+	}
+	for _, k := range append([][]byte{key}, oldKeys...) {
+		if hmac.Equal(sig, pageTokenMAC(pt, k)) {
+			return pt, nil
+		}
+	}
+	return "", errC.New(codes.InvalidArgument, "Page token signature mismatch.")
+}
+
+func pageTokenMAC(pt string, key []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(pt))
+	return mac.Sum(nil)
+}
+
+// EncryptPageToken encrypts page token pt with AES-GCM using key, which is
+// either 16, 24, or 32 bytes to select AES-128, AES-192, or AES-256.
+// The encrypted token is base64url encoding of nonce followed by the sealed page token,
+// it is both confidential and tamper-proof.
+func EncryptPageToken(pt string, key []byte) (string, error) {
+	gcm, err := pageTokenGCM(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(gcm.Seal(nonce, nonce, []byte(pt), nil)), nil
+}
+
+// DecryptPageToken decrypts page token encrypted by EncryptPageToken and returns the original page token.
+// To support key rotation the token is decrypted with key and then with each of oldKeys.
+func DecryptPageToken(token string, key []byte, oldKeys ...[]byte) (string, error) {
+	errC := errors.InitContainer()
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", errC.New(codes.InvalidArgument, "Invalid page token %q.", err)
+	}
+	for _, k := range append([][]byte{key}, oldKeys...) {
+		gcm, err := pageTokenGCM(k)
+		if err != nil {
+			return "", err
+		}
+		if len(data) < gcm.NonceSize() {
+			return "", errC.New(codes.InvalidArgument, "Malformed page token.")
+		}
+		nonce, sealed := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+		if pt, err := gcm.Open(nil, nonce, sealed, nil); err == nil {
+			return string(pt), nil
+		}
+	}
+	return "", errC.New(codes.InvalidArgument, "Page token cannot be decrypted.")
+}
+
+func pageTokenGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,110 @@
+package query
+
+import (
+	"testing"
+)
+
+func TestSignPageToken(t *testing.T) {
+	key, oldKey := []byte("key"), []byte("old key")
+	pt := EncodePageToken(10, 20)
+
+	token := SignPageToken(pt, key)
+	if token == pt {
+		t.Fatalf("page token is not signed: %s", token)
+	}
+
+	for _, keys := range [][][]byte{{key}, {[]byte("new key"), key}, {[]byte("new key"), oldKey, key}} {
+		res, err := VerifyPageToken(token, keys[0], keys[1:]...)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if res != pt {
+			t.Errorf("invalid page token: %s - expected: %s", res, pt)
+		}
+	}
+
+	tcases := []struct {
+		Token         string
+		ExpectedError string
+	}{
+		{
+			Token:         pt,
+			ExpectedError: "Page token is not signed.",
+		},
+		{
+			Token:         SignPageToken(pt, oldKey),
+			ExpectedError: "Page token signature mismatch.",
+		},
+		{
+			Token:         EncodePageToken(0, 1000) + token[len(pt):],
+			ExpectedError: "Page token signature mismatch.",
+		},
+		{
+			Token:         pt + ".!",
+			ExpectedError: "Invalid page token signature \"illegal base64 data at input byte 0\".",
+		},
+	}
+
+	for n, tc := range tcases {
+		_, err := VerifyPageToken(tc.Token, key)
+		if err == nil || err.Error() != tc.ExpectedError {
+			t.Errorf("tc %d: invalid error %v, expected %q", n, err, tc.ExpectedError)
+		}
+	}
+}
+
+func TestEncryptPageToken(t *testing.T) {
+	key, oldKey := []byte("0123456789abcdef"), []byte("0123456789ABCDEF0123456789ABCDEF")
+	pt := EncodePageToken(10, 20)
+
+	token, err := EncryptPageToken(pt, oldKey)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	res, err := DecryptPageToken(token, key, oldKey)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if res != pt {
+		t.Errorf("invalid page token: %s - expected: %s", res, pt)
+	}
+
+	if _, err := DecryptPageToken(token, key); err == nil || err.Error() != "Page token cannot be decrypted." {
+		t.Errorf("invalid error %v, expected %q", err, "Page token cannot be decrypted.")
+	}
+	if _, err := DecryptPageToken(token[:len(token)-2]+"AA", oldKey); err == nil {
+		t.Error("unexpected nil error for tampered page token")
+	}
+	if _, err := DecryptPageToken("YQ", key); err == nil || err.Error() != "Malformed page token." {
+		t.Errorf("invalid error %v, expected %q", err, "Malformed page token.")
+	}
+	if _, err := EncryptPageToken(pt, []byte("short")); err == nil {
+		t.Error("unexpected nil error for invalid key size")
+	}
+}
+
+func TestParsePaginationSignedPageToken(t *testing.T) {
+	key := []byte("key")
+	pt := EncodePageToken(10, 20)
+
+	p, err := ParsePagination("", "", SignPageToken(pt, key), RequireSignedPageToken([]byte("new key"), key))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if p.GetPageToken() != pt {
+		t.Errorf("invalid page token: %s - expected: %s", p.GetPageToken(), pt)
+	}
+
+	p, err = ParsePagination("", "", "null", RequireSignedPageToken(key))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if p.GetPageToken() != "null" {
+		t.Errorf("invalid page token: %s - expected: null", p.GetPageToken())
+	}
+
+	_, err = ParsePagination("", "", pt, RequireSignedPageToken(key))
+	if err == nil || err.Error() != "pagination: page token - Page token is not signed." {
+		t.Errorf("invalid error: %v - expected: pagination: page token - Page token is not signed.", err)
+	}
+}
@@ -3,6 +3,10 @@ package query
 import (
 	"fmt"
 	"strconv"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -12,10 +16,34 @@ const (
 	lastOffset = int32(1 << 30)
 )
 
+// PaginationOption is a type of function that alters options of ParsePagination.
+type PaginationOption func(*paginationOptions)
+
+type paginationOptions struct {
+	signKeys [][]byte
+}
+
+// RequireSignedPageToken makes ParsePagination verify signature of the page token
+// made by SignPageToken using key or any of oldKeys. The page token of the returned
+// Pagination is the original unsigned one. Page token "null" is not required to be signed.
+func RequireSignedPageToken(key []byte, oldKeys ...[]byte) PaginationOption {
+	return func(o *paginationOptions) {
+		o.signKeys = append([][]byte{key}, oldKeys...)
+	}
+}
+
 // Pagination parses string representation of pagination limit, offset.
 // Returns error if limit or offset has invalid syntax or out of range.
-func ParsePagination(limit, offset, ptoken string) (*Pagination, error) {
+// Offset and page token are mutually exclusive, so it is an error to specify both of them
+// unless one of them is "null".
+// HasLimit of the returned Pagination reports whether limit is specified, so that
+// zero limit, i.e. no resources but page info only, is distinguished from omitted limit.
+func ParsePagination(limit, offset, ptoken string, opts ...PaginationOption) (*Pagination, error) {
 	p := new(Pagination)
+	o := &paginationOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
 
 	if limit != "" {
 		if u, err := strconv.ParseInt(limit, 10, 32); err != nil {
@@ -24,6 +52,7 @@ func ParsePagination(limit, offset, ptoken string) (*Pagination, error) {
 			return nil, fmt.Errorf("pagination: limit - negative value")
 		} else {
 			p.Limit = int32(u)
+			p.HasLimit = true
 		}
 	}
 
@@ -39,6 +68,18 @@ func ParsePagination(limit, offset, ptoken string) (*Pagination, error) {
 		}
 	}
 
+	if offset != "" && offset != "null" && ptoken != "" && ptoken != "null" {
+		return nil, fmt.Errorf("pagination: offset and page token are mutually exclusive")
+	}
+
+	if ptoken != "" && ptoken != "null" && len(o.signKeys) > 0 {
+		pt, err := VerifyPageToken(ptoken, o.signKeys[0], o.signKeys[1:]...)
+		if err != nil {
+			return nil, fmt.Errorf("pagination: page token - %s", err)
+		}
+		ptoken = pt
+	}
+
 	if ptoken != "" {
 		p.PageToken = ptoken
 	}
@@ -46,6 +87,81 @@ func ParsePagination(limit, offset, ptoken string) (*Pagination, error) {
 	return p, nil
 }
 
+// ParsePageTokenPagination is the same as ParsePagination but offset is ignored if page token
+// is specified, e.g. to continue pagination with the page token returned in PageInfo and a new limit.
+func ParsePageTokenPagination(limit, offset, ptoken string, opts ...PaginationOption) (*Pagination, error) {
+	if ptoken != "" && ptoken != "null" {
+		offset = ""
+	}
+	return ParsePagination(limit, offset, ptoken, opts...)
+}
+
+// LimitPolicy defines how ParsePaginationWithLimits handles a limit that exceeds the maximum.
+type LimitPolicy int
+
+const (
+	// RejectExceedingLimit makes ParsePaginationWithLimits return InvalidArgument error.
+	RejectExceedingLimit LimitPolicy = iota
+	// ClampExceedingLimit makes ParsePaginationWithLimits silently use the maximum limit instead.
+	ClampExceedingLimit
+)
+
+// ParsePaginationWithLimits is the same as ParsePagination but it also applies default limit def
+// if limit is not specified (explicit zero limit is kept) and enforces maximum limit max according to policy
+// (RejectExceedingLimit if not specified). Non-positive max means there is no maximum.
+// The effective limit is recorded in the returned Pagination.
+// All errors have InvalidArgument code.
+func ParsePaginationWithLimits(limit, offset, ptoken string, def, max int32, policy ...LimitPolicy) (*Pagination, error) {
+	p, err := ParsePagination(limit, offset, ptoken)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if !p.HasLimit {
+		p.Limit = def
+	}
+	if max > 0 && p.Limit > max {
+		if limit != "" && (len(policy) == 0 || policy[0] == RejectExceedingLimit) {
+			return nil, status.Errorf(codes.InvalidArgument, "pagination: limit - exceeds maximum value %d", max)
+		}
+		p.Limit = max
+	}
+	return p, nil
+}
+
+// InvalidPaginationError describes a violated invariant of pagination,
+// Field is the violating one, i.e. "limit", "offset" or "page_token".
+// It is converted to InvalidArgument gRPC status.
+type InvalidPaginationError struct {
+	Field string
+	Msg   string
+}
+
+func (e *InvalidPaginationError) Error() string {
+	return fmt.Sprintf("pagination: %s - %s", e.Field, e.Msg)
+}
+
+// GRPCStatus returns InvalidArgument status describing the violation.
+func (e *InvalidPaginationError) GRPCStatus() *status.Status {
+	return status.New(codes.InvalidArgument, e.Error())
+}
+
+// Validate checks that limit and offset of p are not negative, offset and page token
+// are not specified together and limit does not exceed max unless max is not positive.
+// InvalidPaginationError describing the first violation is returned. Nil pagination is valid.
+func (p *Pagination) Validate(max int32) error {
+	switch {
+	case p.GetLimit() < 0:
+		return &InvalidPaginationError{"limit", "negative value"}
+	case p.GetOffset() < 0:
+		return &InvalidPaginationError{"offset", "negative value"}
+	case p.GetOffset() != 0 && p.GetPageToken() != "" && p.GetPageToken() != "null":
+		return &InvalidPaginationError{"page_token", "offset and page token are mutually exclusive"}
+	case max > 0 && p.GetLimit() > max:
+		return &InvalidPaginationError{"limit", fmt.Sprintf("exceeds maximum value %d", max)}
+	}
+	return nil
+}
+
 // FirstPage returns true if requested first page
 func (p *Pagination) FirstPage() bool {
 	if p.GetPageToken() == "null" || p.GetOffset() == 0 {
@@ -66,6 +182,42 @@ func (p *Pagination) DefaultLimit(dl ...int32) int32 {
 	return DefaultLimit
 }
 
+// ZeroLimit returns true if zero limit is explicitly requested,
+// i.e. no resources are to be returned but page info only.
+// Note that DefaultLimit does not distinguish it from omitted limit.
+func (p *Pagination) ZeroLimit() bool {
+	return p.GetHasLimit() && p.GetLimit() == 0
+}
+
+// NewOffsetPageInfo returns page info of a page of returned resources requested
+// with offset and limit, where zero limit means the page is not limited.
+// Negative total means the total number of resources matching the request is unknown.
+// Size of the page info is returned and offset of the next page is offset + returned.
+// There are no more pages, i.e. NoMore reports true, if:
+//   - total is known and offset + returned >= total;
+//   - total is unknown and returned < limit or limit is zero,
+//     so a full page (returned == limit) is assumed to be followed by another one;
+//   - nothing is returned.
+func NewOffsetPageInfo(offset, limit, returned, total int32) *PageInfo {
+	p := &PageInfo{Size: returned}
+	if total >= 0 {
+		p.SetTotal(total)
+	}
+	next := offset + returned
+	more := returned > 0
+	if total >= 0 {
+		more = more && next < total
+	} else {
+		more = more && limit > 0 && returned >= limit
+	}
+	if more {
+		p.Offset = next
+	} else {
+		p.SetLastOffset()
+	}
+	return p
+}
+
 // SetLastToken sets page info to indicate no more pages are available
 func (p *PageInfo) SetLastToken() {
 	p.PageToken = "null"
@@ -83,3 +235,43 @@ func (p *PageInfo) NoMore() bool {
 	}
 	return false
 }
+
+// SetTotal sets the total number of resources matching the request.
+// Zero total is preserved and is distinguishable from the unknown one.
+func (p *PageInfo) SetTotal(total int32) {
+	p.TotalSize = &wrappers.Int32Value{Value: total}
+}
+
+// Total returns the total number of resources matching the request
+// and reports whether it is known.
+func (p *PageInfo) Total() (int32, bool) {
+	if p.GetTotalSize() == nil {
+		return 0, false
+	}
+	return p.GetTotalSize().GetValue(), true
+}
+
+// TotalPages returns the number of pages of limit resources needed to return the total number
+// of resources matching the request, i.e. ceil(total / limit), so that frontends can render page numbers.
+// Zero is returned if the total is unknown or zero. Zero or negative limit means the page
+// is not limited, so all resources fit in a single page.
+func (p *PageInfo) TotalPages(limit int32) int32 {
+	total, ok := p.Total()
+	if !ok || total <= 0 {
+		return 0
+	}
+	if limit <= 0 {
+		return 1
+	}
+	return int32((int64(total) + int64(limit) - 1) / int64(limit))
+}
+
+// CurrentPage returns the 1-based number of the page requested with offset and limit,
+// i.e. offset / limit + 1. Zero or negative limit means the page is not limited,
+// so the first page is returned.
+func (p *PageInfo) CurrentPage(offset, limit int32) int32 {
+	if limit <= 0 || offset <= 0 {
+		return 1
+	}
+	return offset/limit + 1
+}
@@ -0,0 +1,146 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PaginationToSQL returns SQL clause that selects the page requested by p of resources sorted
+// according to s and the ordered list of its arguments. Placeholders and columns are the same
+// as in ToSQL, so only fields from a mapping set by WithSQLFieldMapping are allowed.
+//
+// In offset mode the clause is "[ORDER BY ...] [LIMIT $1] [OFFSET $2]", zero limit and offset are omitted.
+//
+// If page token of p is a cursor made by NewCursor the clause selects resources following it
+// (keyset pagination), e.g. "WHERE (name, id) > ($1, $2) ORDER BY name ASC, id ASC LIMIT $3".
+// Keys of the cursor must start with the sort criterias of s in the same order, so that the sorting
+// is stable, otherwise InvalidPaginationError is returned. The rest of the keys are tiebreakers
+// which are appended to ORDER BY. If the keys are sorted in different directions the predicate is
+// expanded in the same way as by Cursor.Filtering, e.g. "WHERE ((name < $1) OR ((name = $2) AND (id > $3)))".
+// To combine keyset pagination with a filtering expression apply Cursor.Filtering with AndFilterings instead.
+func PaginationToSQL(p *Pagination, s *Sorting, opts ...SQLOption) (string, []interface{}, error) {
+	b := &sqlBuilder{}
+	for _, opt := range opts {
+		opt(b)
+	}
+	var (
+		where, order string
+		err          error
+	)
+	if pt := p.GetPageToken(); pt != "" && pt != "null" {
+		c, derr := DecodeCursor(pt)
+		if derr != nil {
+			return "", nil, &InvalidPaginationError{"page_token", "not a cursor"}
+		}
+		if err := c.MatchSorting(s); err != nil {
+			return "", nil, err
+		}
+		if where, err = b.keyset(c); err != nil {
+			return "", nil, err
+		}
+		if order, err = b.cursorOrder(c); err != nil {
+			return "", nil, err
+		}
+	} else if order, err = SortingToSQLWithMapping(s, b.mapping); err != nil {
+		return "", nil, err
+	}
+
+	var l []string
+	if where != "" {
+		l = append(l, "WHERE "+where)
+	}
+	if order != "" {
+		l = append(l, "ORDER BY "+order)
+	}
+	if p.GetLimit() > 0 || p.ZeroLimit() {
+		l = append(l, "LIMIT "+b.placeholder(p.GetLimit()))
+	}
+	if where == "" && p.GetOffset() > 0 {
+		l = append(l, "OFFSET "+b.placeholder(p.GetOffset()))
+	}
+	return strings.Join(l, " "), b.args, nil
+}
+
+// MatchSorting checks that keys of c start with the sort criterias of s in the same order and
+// directions, so that the cursor points to a position in the collection sorted according to s.
+// InvalidPaginationError is returned otherwise.
+func (c *Cursor) MatchSorting(s *Sorting) error {
+	cs := s.GetCriterias()
+	if len(cs) == 0 {
+		return &InvalidPaginationError{"page_token", "cursor requires sorting"}
+	}
+	if len(c.Keys) < len(cs) {
+		return &InvalidPaginationError{"page_token", "cursor does not match sorting"}
+	}
+	for i, cr := range cs {
+		if k := c.Keys[i]; k.Field != cr.GetTag() || k.Desc != cr.IsDesc() {
+			return &InvalidPaginationError{"page_token", "cursor does not match sorting"}
+		}
+	}
+	return nil
+}
+
+// keyset returns predicate selecting rows following cursor c, row comparison is used
+// if all the keys are sorted in the same direction.
+func (b *sqlBuilder) keyset(c *Cursor) (string, error) {
+	for _, k := range c.Keys[1:] {
+		if k.Desc != c.Keys[0].Desc {
+			f, err := c.Filtering()
+			if err != nil {
+				return "", err
+			}
+			return b.build(unwrapNode(f.Root))
+		}
+	}
+	cols := make([]string, len(c.Keys))
+	values := make([]string, len(c.Keys))
+	for i, k := range c.Keys {
+		col, err := b.column(strings.Split(k.Field, "."))
+		if err != nil {
+			return "", err
+		}
+		v, err := k.sqlValue()
+		if err != nil {
+			return "", err
+		}
+		cols[i], values[i] = col, b.placeholder(v)
+	}
+	o := ">"
+	if c.Keys[0].Desc {
+		o = "<"
+	}
+	return fmt.Sprintf("(%s) %s (%s)", strings.Join(cols, ", "), o, strings.Join(values, ", ")), nil
+}
+
+// cursorOrder returns ORDER BY clause (without the keywords) of the keys of c.
+func (b *sqlBuilder) cursorOrder(c *Cursor) (string, error) {
+	l := make([]string, len(c.Keys))
+	for i, k := range c.Keys {
+		col, err := b.column(strings.Split(k.Field, "."))
+		if err != nil {
+			return "", err
+		}
+		l[i] = col + " ASC"
+		if k.Desc {
+			l[i] = col + " DESC"
+		}
+	}
+	return strings.Join(l, ", "), nil
+}
+
+// sqlValue returns the value of k to be passed as an SQL argument, integers are converted
+// to int64 and other numbers to float64.
+func (k CursorKey) sqlValue() (interface{}, error) {
+	switch v := k.Value.(type) {
+	case string, bool:
+		return v, nil
+	}
+	f, err := k.number()
+	if err != nil {
+		return nil, err
+	}
+	if i, isInt, _ := cursorInteger(k.Value); isInt {
+		return i, nil
+	}
+	return f, nil
+}
@@ -0,0 +1,147 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaginationToSQL(t *testing.T) {
+	mapping := map[string]string{
+		"name": "name",
+		"age":  "age",
+		"id":   "user_id",
+	}
+	cursor := func(keys ...CursorKey) string {
+		pt, err := EncodeCursor(&Cursor{Keys: keys})
+		if err != nil {
+			t.Fatalf("failed to encode cursor: %s", err)
+		}
+		return pt
+	}
+
+	tests := []struct {
+		pagination *Pagination
+		sorting    string
+		sql        string
+		args       []interface{}
+		err        string
+	}{
+		{
+			sql: "",
+		},
+		{
+			pagination: &Pagination{Limit: 10, Offset: 20},
+			sql:        "LIMIT $1 OFFSET $2",
+			args:       []interface{}{int32(10), int32(20)},
+		},
+		{
+			pagination: &Pagination{HasLimit: true, Offset: 20},
+			sql:        "LIMIT $1 OFFSET $2",
+			args:       []interface{}{int32(0), int32(20)},
+		},
+		{
+			pagination: &Pagination{Offset: 20},
+			sorting:    "name desc, id",
+			sql:        "ORDER BY name DESC, user_id ASC OFFSET $1",
+			args:       []interface{}{int32(20)},
+		},
+		{
+			pagination: &Pagination{Limit: 10, PageToken: "null"},
+			sorting:    "age",
+			sql:        "ORDER BY age ASC LIMIT $1",
+			args:       []interface{}{int32(10)},
+		},
+		{
+			pagination: &Pagination{Limit: 10, PageToken: cursor(
+				CursorKey{Field: "name", Value: "John"},
+				CursorKey{Field: "id", Value: 42},
+			)},
+			sorting: "name",
+			sql:     "WHERE (name, user_id) > ($1, $2) ORDER BY name ASC, user_id ASC LIMIT $3",
+			args:    []interface{}{"John", int64(42), int32(10)},
+		},
+		{
+			pagination: &Pagination{PageToken: cursor(
+				CursorKey{Field: "age", Value: 30, Desc: true},
+				CursorKey{Field: "id", Value: 42, Desc: true},
+			)},
+			sorting: "age desc, id desc",
+			sql:     "WHERE (age, user_id) < ($1, $2) ORDER BY age DESC, user_id DESC",
+			args:    []interface{}{int64(30), int64(42)},
+		},
+		{
+			pagination: &Pagination{Limit: 5, PageToken: cursor(
+				CursorKey{Field: "name", Value: "John", Desc: true},
+				CursorKey{Field: "id", Value: 42},
+			)},
+			sorting: "name desc",
+			sql:     "WHERE ((name < $1) OR ((name = $2) AND (user_id > $3))) ORDER BY name DESC, user_id ASC LIMIT $4",
+			args:    []interface{}{"John", "John", 42.0, int32(5)},
+		},
+		{
+			pagination: &Pagination{PageToken: cursor(CursorKey{Field: "id", Value: 42})},
+			err:        "pagination: page_token - cursor requires sorting",
+		},
+		{
+			pagination: &Pagination{PageToken: cursor(CursorKey{Field: "id", Value: 42})},
+			sorting:    "id desc",
+			err:        "pagination: page_token - cursor does not match sorting",
+		},
+		{
+			pagination: &Pagination{PageToken: cursor(CursorKey{Field: "id", Value: 42})},
+			sorting:    "name, id",
+			err:        "pagination: page_token - cursor does not match sorting",
+		},
+		{
+			pagination: &Pagination{PageToken: EncodePageToken(10, 5)},
+			sorting:    "id",
+			err:        "pagination: page_token - not a cursor",
+		},
+		{
+			pagination: &Pagination{PageToken: cursor(
+				CursorKey{Field: "id", Value: 42},
+				CursorKey{Field: "password", Value: "x"},
+			)},
+			sorting: "id",
+			err:     "password field is not allowed",
+		},
+		{
+			pagination: &Pagination{Limit: 10},
+			sorting:    "password",
+			err:        "password field is not allowed",
+		},
+	}
+
+	for _, test := range tests {
+		var s *Sorting
+		if test.sorting != "" {
+			var err error
+			s, err = ParseSorting(test.sorting)
+			assert.NoError(t, err)
+		}
+		sql, args, err := PaginationToSQL(test.pagination, s, WithSQLFieldMapping(mapping))
+		if test.err != "" {
+			assert.EqualError(t, err, test.err)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, test.sql, sql)
+		assert.Equal(t, test.args, args)
+	}
+}
+
+func TestPaginationToSQLNewCursor(t *testing.T) {
+	s, err := ParseSorting("str")
+	assert.NoError(t, err)
+	c, err := NewCursor(&TestProtoMessage{Str: "John", Int: 42}, s, "int")
+	assert.NoError(t, err)
+	pt, err := EncodeCursor(c)
+	assert.NoError(t, err)
+
+	sql, args, err := PaginationToSQL(&Pagination{Limit: 2, PageToken: pt}, s,
+		WithSQLFieldMapping(map[string]string{"str": "name", "int": "id"}), WithSQLQuestionPlaceholders())
+	assert.NoError(t, err)
+	assert.Equal(t, "WHERE (name, id) > (?, ?) ORDER BY name ASC, id ASC LIMIT ?", sql)
+	assert.Equal(t, []interface{}{"John", int64(42), int32(2)}, args)
+}
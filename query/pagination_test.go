@@ -1,7 +1,12 @@
 package query
 
 import (
+	"math"
+	"reflect"
 	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestParsePagination(t *testing.T) {
@@ -51,7 +56,7 @@ func TestParsePagination(t *testing.T) {
 	}
 
 	// first page
-	p, err = ParsePagination("0", "0", "ptoken")
+	p, err = ParsePagination("0", "0", "")
 	if err != nil {
 		t.Errorf("unexpected error: %s", err)
 	}
@@ -72,7 +77,7 @@ func TestParsePagination(t *testing.T) {
 	}
 
 	// valid pagination
-	p, err = ParsePagination("1000", "100", "ptoken")
+	p, err = ParsePagination("1000", "100", "")
 	if err != nil {
 		t.Errorf("unexpected error: %s", err)
 	}
@@ -82,9 +87,80 @@ func TestParsePagination(t *testing.T) {
 	if p.GetOffset() != 100 {
 		t.Errorf("invalid offset: %d - expected: 100", p.GetOffset())
 	}
+	p, err = ParsePagination("1000", "null", "ptoken")
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
 	if p.GetPageToken() != "ptoken" {
 		t.Errorf("invalid page token: %q - expected: ptoken", p.GetPageToken())
 	}
+
+	// both offset and page token
+	_, err = ParsePagination("10", "0", "ptoken")
+	if err == nil {
+		t.Fatal("unexpected nil error - expected: pagination: offset and page token are mutually exclusive")
+	}
+	if err.Error() != "pagination: offset and page token are mutually exclusive" {
+		t.Errorf("invalid error: %s - expected: pagination: offset and page token are mutually exclusive", err)
+	}
+}
+
+func TestParsePaginationHasLimit(t *testing.T) {
+	tests := []struct {
+		limit    string
+		exp      int32
+		hasLimit bool
+		zero     bool
+	}{
+		{limit: "", exp: 0, hasLimit: false, zero: false},
+		{limit: "0", exp: 0, hasLimit: true, zero: true},
+		{limit: "10", exp: 10, hasLimit: true, zero: false},
+	}
+
+	for _, test := range tests {
+		p, err := ParsePagination(test.limit, "", "")
+		if err != nil {
+			t.Errorf("unexpected error for limit %q: %s", test.limit, err)
+			continue
+		}
+		if p.GetLimit() != test.exp {
+			t.Errorf("invalid limit for %q: %d - expected: %d", test.limit, p.GetLimit(), test.exp)
+		}
+		if p.GetHasLimit() != test.hasLimit {
+			t.Errorf("invalid has limit for %q: %v - expected: %v", test.limit, p.GetHasLimit(), test.hasLimit)
+		}
+		if p.ZeroLimit() != test.zero {
+			t.Errorf("invalid zero limit for %q: %v - expected: %v", test.limit, p.ZeroLimit(), test.zero)
+		}
+	}
+
+	if (*Pagination)(nil).ZeroLimit() {
+		t.Error("invalid zero limit of nil pagination: true - expected: false")
+	}
+}
+
+func TestParsePageTokenPagination(t *testing.T) {
+	p, err := ParsePageTokenPagination("10", "20", "ptoken")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if p.GetLimit() != 10 || p.GetOffset() != 0 || p.GetPageToken() != "ptoken" {
+		t.Errorf("invalid pagination: %v - expected: limit 10, page token ptoken", p)
+	}
+
+	// offset is used if there is no page token
+	p, err = ParsePageTokenPagination("10", "20", "null")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if p.GetLimit() != 10 || p.GetOffset() != 20 || p.GetPageToken() != "null" {
+		t.Errorf("invalid pagination: %v - expected: limit 10, offset 20, page token null", p)
+	}
+
+	// offset is not validated if it is ignored
+	if _, err := ParsePageTokenPagination("10", "-1", "ptoken"); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
 }
 
 func TestPageInfo(t *testing.T) {
@@ -106,3 +182,159 @@ func TestPageInfo(t *testing.T) {
 		t.Errorf("invalid value of NoMore: %v - expected: true", p.NoMore())
 	}
 }
+
+func TestPageInfoTotal(t *testing.T) {
+	p := new(PageInfo)
+	if total, ok := p.Total(); ok {
+		t.Errorf("invalid total: %d - expected: unknown", total)
+	}
+	p.SetTotal(0)
+	if total, ok := p.Total(); !ok || total != 0 {
+		t.Errorf("invalid total: %d, %v - expected: 0, true", total, ok)
+	}
+	p.SetTotal(42)
+	if total, ok := p.Total(); !ok || total != 42 {
+		t.Errorf("invalid total: %d, %v - expected: 42, true", total, ok)
+	}
+}
+
+func TestPageInfoPages(t *testing.T) {
+	tests := []struct {
+		total, offset, limit int32
+		totalPages, current  int32
+	}{
+		{-1, 0, 10, 0, 1},
+		{0, 0, 10, 0, 1},
+		{1, 0, 10, 1, 1},
+		{10, 0, 10, 1, 1},
+		{11, 10, 10, 2, 2},
+		{25, 20, 10, 3, 3},
+		{25, 15, 10, 3, 2},
+		{25, 0, 0, 1, 1},
+		{25, 10, -1, 1, 1},
+		{math.MaxInt32, math.MaxInt32 - 1, math.MaxInt32, 1, 1},
+		{math.MaxInt32, 0, 2, math.MaxInt32/2 + 1, 1},
+	}
+	for _, test := range tests {
+		p := new(PageInfo)
+		if test.total >= 0 {
+			p.SetTotal(test.total)
+		}
+		if n := p.TotalPages(test.limit); n != test.totalPages {
+			t.Errorf("invalid total pages for %+v: %d - expected: %d", test, n, test.totalPages)
+		}
+		if n := p.CurrentPage(test.offset, test.limit); n != test.current {
+			t.Errorf("invalid current page for %+v: %d - expected: %d", test, n, test.current)
+		}
+	}
+}
+
+func TestNewOffsetPageInfo(t *testing.T) {
+	tests := []struct {
+		offset, limit, returned, total int32
+		noMore                         bool
+		nextOffset                     int32
+	}{
+		// unknown total
+		{0, 10, 10, -1, false, 10},
+		{10, 10, 5, -1, true, 0},
+		{20, 10, 0, -1, true, 0},
+		{0, 0, 42, -1, true, 0},
+		// known total
+		{0, 10, 10, 25, false, 10},
+		{20, 10, 5, 25, true, 0},
+		{10, 10, 10, 20, true, 0},
+		{0, 10, 0, 0, true, 0},
+		{0, 0, 10, 25, false, 10},
+	}
+	for _, test := range tests {
+		p := NewOffsetPageInfo(test.offset, test.limit, test.returned, test.total)
+		if p.GetSize() != test.returned {
+			t.Errorf("invalid size for %+v: %d - expected: %d", test, p.GetSize(), test.returned)
+		}
+		if p.NoMore() != test.noMore {
+			t.Errorf("invalid value of NoMore for %+v: %v - expected: %v", test, p.NoMore(), test.noMore)
+		}
+		if !test.noMore && p.GetOffset() != test.nextOffset {
+			t.Errorf("invalid offset for %+v: %d - expected: %d", test, p.GetOffset(), test.nextOffset)
+		}
+		if total, ok := p.Total(); ok != (test.total >= 0) || (ok && total != test.total) {
+			t.Errorf("invalid total for %+v: %d, %v", test, total, ok)
+		}
+	}
+}
+
+func TestParsePaginationWithLimits(t *testing.T) {
+	tests := []struct {
+		limit  string
+		def    int32
+		max    int32
+		policy []LimitPolicy
+		exp    int32
+		err    string
+	}{
+		{limit: "", def: 20, max: 100, exp: 20},
+		{limit: "0", def: 20, max: 100, exp: 0},
+		{limit: "50", def: 20, max: 100, exp: 50},
+		{limit: "100", def: 20, max: 100, exp: 100},
+		{limit: "101", def: 20, max: 100, err: "rpc error: code = InvalidArgument desc = pagination: limit - exceeds maximum value 100"},
+		{limit: "101", def: 20, max: 100, policy: []LimitPolicy{RejectExceedingLimit}, err: "rpc error: code = InvalidArgument desc = pagination: limit - exceeds maximum value 100"},
+		{limit: "1000000", def: 20, max: 100, policy: []LimitPolicy{ClampExceedingLimit}, exp: 100},
+		{limit: "", def: 200, max: 100, exp: 100},
+		{limit: "1000000", def: 20, max: 0, exp: 1000000},
+		{limit: "", def: 0, max: 0, exp: 0},
+		{limit: "-1", def: 20, max: 100, err: "rpc error: code = InvalidArgument desc = pagination: limit - negative value"},
+	}
+
+	for _, test := range tests {
+		p, err := ParsePaginationWithLimits(test.limit, "10", "", test.def, test.max, test.policy...)
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("invalid error for limit %q: %v - expected: %s", test.limit, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error for limit %q: %s", test.limit, err)
+			continue
+		}
+		if p.GetLimit() != test.exp {
+			t.Errorf("invalid limit for %q: %d - expected: %d", test.limit, p.GetLimit(), test.exp)
+		}
+		if p.GetOffset() != 10 {
+			t.Errorf("invalid offset: %d - expected: 10", p.GetOffset())
+		}
+	}
+}
+
+func TestPaginationValidate(t *testing.T) {
+	tests := []struct {
+		p   *Pagination
+		max int32
+		err error
+	}{
+		{p: nil, max: 10},
+		{p: &Pagination{}, max: 10},
+		{p: &Pagination{Limit: 10, Offset: 20}, max: 10},
+		{p: &Pagination{Limit: 1000}, max: 0},
+		{p: &Pagination{Offset: 5, PageToken: "null"}, max: 10},
+		{p: &Pagination{Limit: -1}, err: &InvalidPaginationError{"limit", "negative value"}},
+		{p: &Pagination{Offset: -1}, err: &InvalidPaginationError{"offset", "negative value"}},
+		{p: &Pagination{Offset: 1, PageToken: "abc"}, err: &InvalidPaginationError{"page_token", "offset and page token are mutually exclusive"}},
+		{p: &Pagination{Limit: 11}, max: 10, err: &InvalidPaginationError{"limit", "exceeds maximum value 10"}},
+		// the first violation is reported
+		{p: &Pagination{Limit: 11, Offset: -1}, max: 10, err: &InvalidPaginationError{"offset", "negative value"}},
+	}
+
+	for _, test := range tests {
+		err := test.p.Validate(test.max)
+		if !reflect.DeepEqual(err, test.err) {
+			t.Errorf("invalid error for %v: %v - expected: %v", test.p, err, test.err)
+		}
+	}
+
+	err := (&Pagination{Limit: -1}).Validate(0)
+	if s := status.Convert(err); s.Code() != codes.InvalidArgument || s.Message() != "pagination: limit - negative value" {
+		t.Errorf("invalid status: %v - expected: %s", s, codes.InvalidArgument)
+	}
+}
@@ -0,0 +1,109 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SelectionLimits restricts the number of fields a client can select with a field selection
+// or sort by with a sorting, so that a request cannot exhaust server resources.
+// Zero maximums mean there is no maximum.
+type SelectionLimits struct {
+	// MaxFields is a maximum number of selected fields.
+	MaxFields int
+	// MaxSortCriterias is a maximum number of sort criterias.
+	MaxSortCriterias int
+	// RejectDuplicates makes a field listed more than once to be rejected with DuplicateFieldError,
+	// otherwise duplicates are counted once.
+	RejectDuplicates bool
+}
+
+// SelectionLimitError describes a field selection or a sorting that exceeds the Max value of Limit.
+type SelectionLimitError struct {
+	Limit string
+	Max   int
+}
+
+func (e *SelectionLimitError) Error() string {
+	return fmt.Sprintf("%s exceeds maximum of %d", e.Limit, e.Max)
+}
+
+// GRPCStatus returns InvalidArgument status describing the violation.
+func (e *SelectionLimitError) GRPCStatus() *status.Status {
+	return status.New(codes.InvalidArgument, e.Error())
+}
+
+// DuplicateFieldError describes a field listed more than once in a field selection or a sorting.
+type DuplicateFieldError struct {
+	Field string
+}
+
+func (e *DuplicateFieldError) Error() string {
+	return fmt.Sprintf("%s field is listed more than once", e.Field)
+}
+
+// GRPCStatus returns InvalidArgument status describing the violation.
+func (e *DuplicateFieldError) GRPCStatus() *status.Status {
+	return status.New(codes.InvalidArgument, e.Error())
+}
+
+// ParseSortingWithLimits is the same as ParseSorting but returns SelectionLimitError if the number
+// of distinct tags exceeds limits.MaxSortCriterias and DuplicateFieldError for a tag listed more than once
+// if limits.RejectDuplicates is set, regardless of the sort order, e.g. "name asc, name desc".
+func ParseSortingWithLimits(s string, limits SelectionLimits) (*Sorting, error) {
+	sorting, err := ParseSorting(s)
+	if err != nil {
+		return nil, err
+	}
+	tags := make([]string, len(sorting.GetCriterias()))
+	for i, c := range sorting.GetCriterias() {
+		tags[i] = c.GetTag()
+	}
+	if err := checkSelectionLimits("number of sort criterias", tags, limits.MaxSortCriterias, limits.RejectDuplicates); err != nil {
+		return nil, err
+	}
+	return sorting, nil
+}
+
+// ParseFieldSelectionWithLimits is the same as ParseFieldSelectionStrict but returns SelectionLimitError
+// if the number of distinct fields listed in input exceeds limits.MaxFields and DuplicateFieldError for a field
+// listed more than once if limits.RejectDuplicates is set. Every listed field counts regardless of nesting,
+// e.g. "owner,owner.name" lists two fields.
+func ParseFieldSelectionWithLimits(input string, limits SelectionLimits, delimiter ...string) (*FieldSelection, error) {
+	fs, err := ParseFieldSelectionStrict(input, delimiter...)
+	if err != nil || fs == nil {
+		return fs, err
+	}
+	var fields []string
+	for _, field := range strings.Split(input, opCommonDelimiter) {
+		if field = strings.TrimPrefix(field, opExcludePrefix); field != "" {
+			fields = append(fields, field)
+		}
+	}
+	if err := checkSelectionLimits("number of selected fields", fields, limits.MaxFields, limits.RejectDuplicates); err != nil {
+		return nil, err
+	}
+	return fs, nil
+}
+
+// checkSelectionLimits checks that the number of distinct names does not exceed max
+// and there are no duplicates if rejectDuplicates is set.
+func checkSelectionLimits(limit string, names []string, max int, rejectDuplicates bool) error {
+	seen := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		if _, ok := seen[name]; ok {
+			if rejectDuplicates {
+				return &DuplicateFieldError{name}
+			}
+			continue
+		}
+		seen[name] = struct{}{}
+		if max > 0 && len(seen) > max {
+			return &SelectionLimitError{limit, max}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSortingWithLimits(t *testing.T) {
+	tests := []struct {
+		sorting string
+		limits  SelectionLimits
+		err     error
+	}{
+		{"name, -age, created_at desc", SelectionLimits{}, nil},
+		{"name, -age", SelectionLimits{MaxSortCriterias: 2}, nil},
+		{"name, -age, created_at desc", SelectionLimits{MaxSortCriterias: 2}, &SelectionLimitError{"number of sort criterias", 2}},
+		{"name, name desc, age", SelectionLimits{MaxSortCriterias: 2}, nil},
+		{"name, name desc", SelectionLimits{RejectDuplicates: true}, &DuplicateFieldError{"name"}},
+		{"name, age", SelectionLimits{MaxSortCriterias: 2, RejectDuplicates: true}, nil},
+	}
+	for _, test := range tests {
+		s, err := ParseSortingWithLimits(test.sorting, test.limits)
+		assert.Equal(t, test.err, err, test.sorting)
+		if test.err == nil {
+			expected, _ := ParseSorting(test.sorting)
+			assert.Equal(t, expected, s, test.sorting)
+		} else {
+			assert.Nil(t, s, test.sorting)
+		}
+	}
+
+	_, err := ParseSortingWithLimits("name asc desc", SelectionLimits{MaxSortCriterias: 2})
+	assert.Error(t, err)
+}
+
+func TestParseFieldSelectionWithLimits(t *testing.T) {
+	tests := []struct {
+		fields string
+		limits SelectionLimits
+		err    error
+	}{
+		{"", SelectionLimits{MaxFields: 1}, nil},
+		{"name,age,owner.name", SelectionLimits{}, nil},
+		{"name,age", SelectionLimits{MaxFields: 2}, nil},
+		{"owner,owner.name,age", SelectionLimits{MaxFields: 2}, &SelectionLimitError{"number of selected fields", 2}},
+		{"-name,-age,-raw", SelectionLimits{MaxFields: 2}, &SelectionLimitError{"number of selected fields", 2}},
+		{"name,age,name", SelectionLimits{MaxFields: 2}, nil},
+		{"name,age,name", SelectionLimits{RejectDuplicates: true}, &DuplicateFieldError{"name"}},
+		{"-raw,-raw", SelectionLimits{RejectDuplicates: true}, &DuplicateFieldError{"raw"}},
+	}
+	for _, test := range tests {
+		fs, err := ParseFieldSelectionWithLimits(test.fields, test.limits)
+		assert.Equal(t, test.err, err, test.fields)
+		if test.err == nil {
+			assert.Equal(t, ParseFieldSelection(test.fields), fs, test.fields)
+		} else {
+			assert.Nil(t, fs, test.fields)
+		}
+	}
+
+	_, err := ParseFieldSelectionWithLimits("name,-age", SelectionLimits{MaxFields: 1})
+	assert.IsType(t, &MixedFieldSelectionError{}, err)
+}
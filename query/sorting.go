@@ -2,7 +2,11 @@ package query
 
 import (
 	"fmt"
+	"reflect"
+	"regexp"
 	"strings"
+
+	"github.com/golang/protobuf/proto"
 )
 
 // IsAsc returns true if sort criteria has ascending sort order, otherwise false.
@@ -15,17 +19,48 @@ func (c SortCriteria) IsDesc() bool {
 	return c.Order == SortCriteria_DESC
 }
 
+// IsNullsFirst returns true if sort criteria places nulls before non-null values, otherwise false.
+func (c SortCriteria) IsNullsFirst() bool {
+	return c.Nulls == SortCriteria_NULLS_FIRST
+}
+
+// IsNullsLast returns true if sort criteria places nulls after non-null values, otherwise false.
+func (c SortCriteria) IsNullsLast() bool {
+	return c.Nulls == SortCriteria_NULLS_LAST
+}
+
 // GoString implements fmt.GoStringer interface
 // return string representation of a sort criteria in next form:
-// "<tag_name> (ASC|DESC)".
+// "<tag_name> (ASC|DESC) [NULLS (FIRST|LAST)]".
 func (c SortCriteria) GoString() string {
+	if n := c.nullsSQL(); n != "" {
+		return fmt.Sprintf("%s %s %s", c.Tag, c.Order, n)
+	}
 	return fmt.Sprintf("%s %s", c.Tag, c.Order)
 }
 
+// nullsSQL returns SQL clause for the nulls ordering of c
+// or an empty string if the default one is used.
+func (c SortCriteria) nullsSQL() string {
+	switch c.Nulls {
+	case SortCriteria_NULLS_FIRST:
+		return "NULLS FIRST"
+	case SortCriteria_NULLS_LAST:
+		return "NULLS LAST"
+	default:
+		return ""
+	}
+}
+
 // ParseSorting parses raw string that represent sort criteria into a Sorting
 // data structure.
 // Provided string is supposed to be in accordance with the sorting collection
 // operator from REST API Syntax.
+// The sort order may be specified either by "asc" or "desc" keyword following the tag
+// or by "+" or "-" sign preceding it, e.g. "-created_at, name desc". Both forms may be
+// mixed in one list, but a sign and a keyword of the same criteria must not conflict.
+// Each sort criteria may be followed by "nulls first" or "nulls last" suffix
+// to control ordering of null values, e.g. "name desc nulls last".
 // See: https://github.com/partitio/atlas-app-toolkit#sorting
 func ParseSorting(s string) (*Sorting, error) {
 	var sorting Sorting
@@ -33,13 +68,40 @@ func ParseSorting(s string) (*Sorting, error) {
 	for _, craw := range strings.Split(s, ",") {
 		v := strings.Fields(craw)
 
+		// sign prefix of the tag, e.g. "-created_at"
+		var signed bool
+		var sign SortCriteria_Order
+		if len(v) > 0 && (v[0][0] == '-' || v[0][0] == '+') {
+			signed, sign = true, SortCriteria_ASC
+			if v[0][0] == '-' {
+				sign = SortCriteria_DESC
+			}
+			if v[0] = v[0][1:]; v[0] == "" || v[0][0] == '-' || v[0][0] == '+' {
+				return nil, fmt.Errorf("invalid sort criteria: %s", craw)
+			}
+		}
+
 		var c SortCriteria
+		if l := len(v); l > 2 && strings.ToUpper(v[l-2]) == "NULLS" {
+			if n, ok := SortCriteria_Nulls_value["NULLS_"+strings.ToUpper(v[l-1])]; !ok || n == int32(SortCriteria_NULLS_DEFAULT) {
+				return nil, fmt.Errorf("invalid nulls order - %q in %q", v[l-1], craw)
+			} else {
+				c.Nulls = SortCriteria_Nulls(n)
+			}
+			v = v[:l-2]
+		}
+
 		switch len(v) {
 		case 1:
 			c.Tag, c.Order = v[0], SortCriteria_ASC
+			if signed {
+				c.Order = sign
+			}
 		case 2:
 			if o, ok := SortCriteria_Order_value[strings.ToUpper(v[1])]; !ok {
 				return nil, fmt.Errorf("invalid sort order - %q in %q", v[1], craw)
+			} else if signed && SortCriteria_Order(o) != sign {
+				return nil, fmt.Errorf("conflicting sort order - %q in %q", v[1], craw)
 			} else {
 				c.Tag, c.Order = v[0], SortCriteria_Order(o)
 			}
@@ -53,9 +115,102 @@ func ParseSorting(s string) (*Sorting, error) {
 	return &sorting, nil
 }
 
+// sqlColumnRegexp matches tags that are safe to use as column names as is, optionally qualified with a table name.
+var sqlColumnRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.]*$`)
+
+// SortingToSQL returns SQL ORDER BY clause (without the keywords) representation of s
+// in the form "<tag_name> (ASC|DESC) [NULLS (FIRST|LAST)] [, ...]".
+// Tags are used as column names as is, so an error is returned for the first tag
+// that is not an identifier matching [A-Za-z_][A-Za-z0-9_.]*. Prefer SortingToSQLWithMapping
+// for sorting supplied by clients. NULLS clause is emitted only for
+// sort criteria that explicitly specify ordering of nulls.
+func SortingToSQL(s *Sorting) (string, error) {
+	for _, c := range s.GetCriterias() {
+		if !sqlColumnRegexp.MatchString(c.GetTag()) {
+			return "", fmt.Errorf("invalid sort tag %q: not an SQL identifier", c.GetTag())
+		}
+	}
+	if s == nil {
+		return "", nil
+	}
+	return s.GoString(), nil
+}
+
+// SortingToSQLWithMapping is the same as SortingToSQL but translates tags to column names
+// according to fieldMap from dot-separated field paths to columns and returns UnknownFieldError
+// for the first tag that is not in the map, so that arbitrary SQL cannot be injected via sorting.
+// If several criterias refer to the same column, the first one wins and the rest are omitted,
+// since they cannot affect the order. An empty string is returned for nil or empty s.
+func SortingToSQLWithMapping(s *Sorting, fieldMap map[string]string) (string, error) {
+	var l []string
+	seen := make(map[string]struct{})
+	for _, c := range s.GetCriterias() {
+		col, ok := fieldMap[c.GetTag()]
+		if !ok {
+			return "", &UnknownFieldError{strings.Split(c.GetTag(), ".")}
+		}
+		if _, ok := seen[col]; ok {
+			continue
+		}
+		seen[col] = struct{}{}
+		mc := *c
+		mc.Tag = col
+		l = append(l, mc.GoString())
+	}
+	return strings.Join(l, ", "), nil
+}
+
+// MergeSorting returns s with criterias of defaults appended as tiebreakers, so that the order
+// is deterministic even if s sorts by non-unique fields, e.g. "name desc" merged with "id" is "name desc, id asc".
+// Criterias of defaults on tags that s already sorts by are omitted. If s is nil or empty the result sorts by defaults,
+// if defaults is nil or empty s is returned as is. Neither s nor defaults is modified.
+func MergeSorting(s, defaults *Sorting) *Sorting {
+	if len(defaults.GetCriterias()) == 0 {
+		return s
+	}
+	res := &Sorting{}
+	seen := make(map[string]struct{})
+	add := func(criterias []*SortCriteria) {
+		for _, c := range criterias {
+			if _, ok := seen[c.GetTag()]; ok {
+				continue
+			}
+			seen[c.GetTag()] = struct{}{}
+			mc := *c
+			res.Criterias = append(res.Criterias, &mc)
+		}
+	}
+	add(s.GetCriterias())
+	add(defaults.GetCriterias())
+	return res
+}
+
+// ValidateSortingFields checks that all sort criteria of s refer to fields that are
+// in the allowed list of dot-separated field paths regardless of their sort order
+// and returns UnknownFieldError for the first field that is not.
+// If pb is provided then both tags and allowed list are resolved against pb,
+// so fields can be referred to either by their proto or JSON names.
+func ValidateSortingFields(s *Sorting, allowed []string, pb ...proto.Message) error {
+	var t reflect.Type
+	if len(pb) > 0 && pb[0] != nil {
+		t = reflect.TypeOf(pb[0])
+	}
+	allowedSet := make(map[string]struct{}, len(allowed))
+	for _, a := range allowed {
+		allowedSet[strings.Join(protoFieldPath(strings.Split(a, "."), t), ".")] = struct{}{}
+	}
+	for _, c := range s.GetCriterias() {
+		fp := strings.Split(c.GetTag(), ".")
+		if _, ok := allowedSet[strings.Join(protoFieldPath(fp, t), ".")]; !ok {
+			return &UnknownFieldError{fp}
+		}
+	}
+	return nil
+}
+
 // GoString implements fmt.GoStringer interface
 // Returns string representation of sorting in next form:
-// "<name> (ASC|DESC) [, <tag_name> (ASC|DESC)]"
+// "<name> (ASC|DESC) [NULLS (FIRST|LAST)] [, <tag_name> (ASC|DESC) [NULLS (FIRST|LAST)]]"
 func (s Sorting) GoString() string {
 	var l []string
 
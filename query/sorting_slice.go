@@ -0,0 +1,229 @@
+package query
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/duration"
+	"github.com/golang/protobuf/ptypes/timestamp"
+)
+
+// SortOption is a type of function that alters in-memory sorting of SortSlice.
+type SortOption func(*sortOptions)
+
+type sortOptions struct {
+	nulls SortCriteria_Nulls
+}
+
+// DefaultNullsOrder sets ordering of null values for sort criterias that do not specify it.
+// By default nulls are ordered as Postgres does, i.e. they are greater than any non-null value,
+// so they are placed last in ascending order and first in descending one.
+func DefaultNullsOrder(nulls SortCriteria_Nulls) SortOption {
+	return func(o *sortOptions) {
+		o.nulls = nulls
+	}
+}
+
+// SortSlice sorts objs that is a slice of structs, proto messages or pointers to them
+// in place according to s. The sort is stable, so objects with equal sort keys keep their order.
+// Fields are resolved in the same way as in filtering, so dot-separated paths and both proto
+// and JSON names of proto message fields are supported.
+// Null values are nil pointers, unset wrappers, timestamps and durations and zero time.Time,
+// they are ordered according to nulls ordering of a sort criteria or DefaultNullsOrder.
+// If a field cannot be sorted by objs are left unchanged and an error is returned.
+func SortSlice(objs interface{}, s *Sorting, opts ...SortOption) error {
+	v := reflect.ValueOf(objs)
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Slice {
+		return fmt.Errorf("sort: %T is not a slice", objs)
+	}
+	o := &sortOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	crs := s.GetCriterias()
+	if len(crs) == 0 || v.Len() < 2 {
+		return nil
+	}
+
+	keys := make([][]interface{}, v.Len())
+	// types of non-null keys of each criteria that must be the same to be compared
+	types := make([]reflect.Type, len(crs))
+	for i := range keys {
+		keys[i] = make([]interface{}, len(crs))
+		for j, cr := range crs {
+			k, err := sortValue(v.Index(i).Interface(), cr.GetTag())
+			if err != nil {
+				return err
+			}
+			if k != nil {
+				if types[j] == nil {
+					types[j] = reflect.TypeOf(k)
+				} else if types[j] != reflect.TypeOf(k) {
+					return &TypeMismatchError{"sortable", strings.Split(cr.GetTag(), "."), ""}
+				}
+			}
+			keys[i][j] = k
+		}
+	}
+
+	idx := make([]int, v.Len())
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(a, b int) bool {
+		ka, kb := keys[idx[a]], keys[idx[b]]
+		for j, cr := range crs {
+			if c := compareSortKeys(ka[j], kb[j], cr, o); c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+
+	sorted := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+	for i, k := range idx {
+		sorted.Index(i).Set(v.Index(k))
+	}
+	reflect.Copy(v, sorted)
+	return nil
+}
+
+// compareSortKeys returns -1, 0 or +1 as a is ordered before, together with or after b
+// according to sort criteria cr.
+func compareSortKeys(a, b interface{}, cr *SortCriteria, o *sortOptions) int {
+	if a == nil || b == nil {
+		if a == nil && b == nil {
+			return 0
+		}
+		nulls := cr.GetNulls()
+		if nulls == SortCriteria_NULLS_DEFAULT {
+			nulls = o.nulls
+		}
+		// nulls are greater than any value by default
+		if nulls == SortCriteria_NULLS_DEFAULT {
+			nulls = SortCriteria_NULLS_LAST
+			if cr.IsDesc() {
+				nulls = SortCriteria_NULLS_FIRST
+			}
+		}
+		if (a == nil) == (nulls == SortCriteria_NULLS_FIRST) {
+			return -1
+		}
+		return 1
+	}
+	c := compareValues(a, b)
+	if cr.IsDesc() {
+		return -c
+	}
+	return c
+}
+
+// compareValues returns -1, 0 or +1 as non-null value a is less than, equal to or greater than b
+// of the same type, values are the ones returned by comparableValue.
+func compareValues(a, b interface{}) int {
+	switch av := a.(type) {
+	case string:
+		return strings.Compare(av, b.(string))
+	case bool:
+		if av == b.(bool) {
+			return 0
+		}
+		if av {
+			return 1
+		}
+		return -1
+	case int64:
+		return compareOrdered(av < b.(int64), av > b.(int64))
+	case uint64:
+		return compareOrdered(av < b.(uint64), av > b.(uint64))
+	case float64:
+		return compareOrdered(av < b.(float64), av > b.(float64))
+	case time.Time:
+		return compareTime(av, b.(time.Time))
+	case time.Duration:
+		return compareOrdered(av < b.(time.Duration), av > b.(time.Duration))
+	}
+	return 0
+}
+
+func compareOrdered(less, greater bool) int {
+	switch {
+	case less:
+		return -1
+	case greater:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// sortValue returns the value of field of obj to be compared by SortSlice
+// or nil if the value is null.
+func sortValue(obj interface{}, field string) (interface{}, error) {
+	fp := strings.Split(field, ".")
+	fv := fieldByFieldPath(obj, fp)
+	if !fv.IsValid() {
+		return nil, &UnknownFieldError{fp}
+	}
+	v, ok, err := comparableValue(fv)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, &TypeMismatchError{"sortable", fp, ""}
+	}
+	return v, nil
+}
+
+// comparableValue returns field value fv converted to a value that can be compared by compareValues
+// or nil if the value is null. Returned ok is false if values of fv type cannot be compared.
+func comparableValue(fv reflect.Value) (v interface{}, ok bool, err error) {
+	switch fv.Type() {
+	case timestampType:
+		if fv.IsNil() {
+			return nil, true, nil
+		}
+		t, err := ptypes.Timestamp(fv.Interface().(*timestamp.Timestamp))
+		return t, err == nil, err
+	case durationType:
+		if fv.IsNil() {
+			return nil, true, nil
+		}
+		d, err := ptypes.Duration(fv.Interface().(*duration.Duration))
+		return d, err == nil, err
+	}
+	if isNilValue(fv) {
+		return nil, true, nil
+	}
+	fv = dereferenceValue(fv)
+	if !fv.IsValid() {
+		return nil, true, nil
+	}
+	if fv.Type() == timeType {
+		if t := fv.Interface().(time.Time); !t.IsZero() {
+			return t, true, nil
+		}
+		return nil, true, nil
+	}
+	switch fv.Kind() {
+	case reflect.String:
+		return fv.String(), true, nil
+	case reflect.Bool:
+		return fv.Bool(), true, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return fv.Int(), true, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return fv.Uint(), true, nil
+	case reflect.Float32, reflect.Float64:
+		return fv.Float(), true, nil
+	default:
+		return nil, false, nil
+	}
+}
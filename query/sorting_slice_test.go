@@ -0,0 +1,104 @@
+package query
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/golang/protobuf/ptypes/wrappers"
+)
+
+func TestSortSlice(t *testing.T) {
+	type person struct {
+		Name string               `json:"name"`
+		Age  *wrappers.Int32Value `json:"age"`
+		ID   int                  `json:"id"`
+	}
+	age := func(v int32) *wrappers.Int32Value { return &wrappers.Int32Value{Value: v} }
+	people := []*person{
+		{Name: "b", Age: age(30), ID: 1},
+		{Name: "a", ID: 2},
+		{Name: "c", Age: age(20), ID: 3},
+		{Name: "a", Age: age(30), ID: 4},
+		{Name: "b", Age: age(20), ID: 5},
+	}
+
+	tests := []struct {
+		sorting  string
+		opts     []SortOption
+		expected []int
+	}{
+		{sorting: "name", expected: []int{2, 4, 1, 5, 3}},
+		{sorting: "name desc", expected: []int{3, 1, 5, 2, 4}},
+		// nulls are greater than any value by default
+		{sorting: "age, name", expected: []int{5, 3, 4, 1, 2}},
+		{sorting: "age desc, name", expected: []int{2, 4, 1, 5, 3}},
+		{sorting: "age nulls first, name desc", expected: []int{2, 3, 5, 1, 4}},
+		{sorting: "age desc nulls last", expected: []int{1, 4, 3, 5, 2}},
+		{sorting: "age", opts: []SortOption{DefaultNullsOrder(SortCriteria_NULLS_FIRST)}, expected: []int{2, 3, 5, 1, 4}},
+		// the sort is stable
+		{sorting: "age", expected: []int{3, 5, 1, 4, 2}},
+	}
+	for _, test := range tests {
+		s, err := ParseSorting(test.sorting)
+		if err != nil {
+			t.Fatalf("failed to parse sorting: %s", err)
+		}
+		objs := append([]*person(nil), people...)
+		if err := SortSlice(objs, s, test.opts...); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		var ids []int
+		for _, p := range objs {
+			ids = append(ids, p.ID)
+		}
+		if !reflect.DeepEqual(ids, test.expected) {
+			t.Errorf("invalid order by %q: %v - expected: %v", test.sorting, ids, test.expected)
+		}
+	}
+}
+
+func TestSortSliceProto(t *testing.T) {
+	objs := []TestProtoMessage{
+		{Str: "a", Nested: &NestedMessage{Str: "2"}, CreatedAt: &timestamp.Timestamp{Seconds: 2}},
+		{Str: "b", Nested: &NestedMessage{Str: "1"}},
+		{Str: "c", CreatedAt: &timestamp.Timestamp{Seconds: 1}},
+	}
+	s, _ := ParseSorting("nestedJSON.str")
+	if err := SortSlice(&objs, s); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if objs[0].Str != "b" || objs[1].Str != "a" || objs[2].Str != "c" {
+		t.Errorf("invalid order by nested field: %v", objs)
+	}
+
+	s, _ = ParseSorting("created_at desc nulls last")
+	if err := SortSlice(objs, s); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if objs[0].Str != "a" || objs[1].Str != "c" || objs[2].Str != "b" {
+		t.Errorf("invalid order by timestamp: %v", objs)
+	}
+}
+
+func TestSortSliceErrors(t *testing.T) {
+	objs := []*TestObject{{Str: "b"}, {Str: "a"}}
+
+	s, _ := ParseSorting("str, unknown")
+	if err := SortSlice(objs, s); !reflect.DeepEqual(err, &UnknownFieldError{[]string{"unknown"}}) {
+		t.Errorf("invalid error: %v - expected: unknown field", err)
+	}
+	if objs[0].Str != "b" {
+		t.Errorf("objects are sorted in spite of the error")
+	}
+
+	s, _ = ParseSorting("Ptr")
+	if err := SortSlice(objs, s); err != nil {
+		t.Errorf("unexpected error for null values: %s", err)
+	}
+
+	s, _ = ParseSorting("str")
+	if err := SortSlice(TestObject{}, s); err == nil {
+		t.Errorf("expected error for non-slice")
+	}
+}
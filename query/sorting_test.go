@@ -1,11 +1,12 @@
 package query
 
 import (
+	"reflect"
 	"testing"
 )
 
 func TestSortCriteria(t *testing.T) {
-	c := SortCriteria{"name", SortCriteria_ASC}
+	c := SortCriteria{Tag: "name", Order: SortCriteria_ASC}
 	if !c.IsAsc() {
 		t.Errorf("invalid sort order: IsAsc = %v - expected: %v", c.IsAsc(), true)
 	}
@@ -13,7 +14,7 @@ func TestSortCriteria(t *testing.T) {
 		t.Errorf("invalid string representation: %v - expected: %s", c, "name ASC")
 	}
 
-	c = SortCriteria{"age", SortCriteria_DESC}
+	c = SortCriteria{Tag: "age", Order: SortCriteria_DESC}
 	if !c.IsDesc() {
 		t.Errorf("invalid sort order: IsDesc = %v - expected: %v", c.IsDesc(), true)
 	}
@@ -31,7 +32,7 @@ func TestParseSorting(t *testing.T) {
 		t.Fatalf("invalid number of sort criterias: %d - expected: %d", len(s.GetCriterias()), 1)
 	}
 	if c := s.GetCriterias()[0]; !c.IsAsc() || c.Tag != "name" {
-		t.Errorf("invalid sort criteria: %v - expected: %v", c, SortCriteria{"name", SortCriteria_ASC})
+		t.Errorf("invalid sort criteria: %v - expected: %v", c, SortCriteria{Tag: "name", Order: SortCriteria_ASC})
 	}
 
 	s, err = ParseSorting("name desc, age")
@@ -42,10 +43,10 @@ func TestParseSorting(t *testing.T) {
 		t.Fatalf("invalid number of sort criterias: %d - expected: %d", len(s.GetCriterias()), 2)
 	}
 	if c := s.GetCriterias()[0]; !c.IsDesc() || c.Tag != "name" {
-		t.Errorf("invalid sort criteria: %v - expected: %v", c, SortCriteria{"name", SortCriteria_DESC})
+		t.Errorf("invalid sort criteria: %v - expected: %v", c, SortCriteria{Tag: "name", Order: SortCriteria_DESC})
 	}
 	if c := s.GetCriterias()[1]; !c.IsAsc() || c.Tag != "age" {
-		t.Errorf("invalid sort criteria: %v - expected: %v", c, SortCriteria{"age", SortCriteria_ASC})
+		t.Errorf("invalid sort criteria: %v - expected: %v", c, SortCriteria{Tag: "age", Order: SortCriteria_ASC})
 	}
 	if s.GoString() != "name DESC, age ASC" {
 		t.Errorf("invalid sorting: %v - expected: %s", s, "name DESC, age ASC")
@@ -59,3 +60,207 @@ func TestParseSorting(t *testing.T) {
 		t.Errorf("invalid error message: %s - expected: %s", err, "invalid sort order - \"dask\" in \"name dask\"")
 	}
 }
+
+func TestParseSortingNulls(t *testing.T) {
+	s, err := ParseSorting("name desc nulls last, age NULLS FIRST, id")
+	if err != nil {
+		t.Fatalf("failed to parse sort parameters: %s", err)
+	}
+	if len(s.GetCriterias()) != 3 {
+		t.Fatalf("invalid number of sort criterias: %d - expected: %d", len(s.GetCriterias()), 3)
+	}
+	if c := s.GetCriterias()[0]; !c.IsDesc() || !c.IsNullsLast() || c.Tag != "name" {
+		t.Errorf("invalid sort criteria: %v - expected: %v", c, SortCriteria{Tag: "name", Order: SortCriteria_DESC, Nulls: SortCriteria_NULLS_LAST})
+	}
+	if c := s.GetCriterias()[1]; !c.IsAsc() || !c.IsNullsFirst() || c.Tag != "age" {
+		t.Errorf("invalid sort criteria: %v - expected: %v", c, SortCriteria{Tag: "age", Order: SortCriteria_ASC, Nulls: SortCriteria_NULLS_FIRST})
+	}
+	if c := s.GetCriterias()[2]; !c.IsAsc() || c.GetNulls() != SortCriteria_NULLS_DEFAULT || c.Tag != "id" {
+		t.Errorf("invalid sort criteria: %v - expected: %v", c, SortCriteria{Tag: "id", Order: SortCriteria_ASC})
+	}
+	if sql, err := SortingToSQL(s); err != nil || sql != "name DESC NULLS LAST, age ASC NULLS FIRST, id ASC" {
+		t.Errorf("invalid sql: %s (%v) - expected: %s", sql, err, "name DESC NULLS LAST, age ASC NULLS FIRST, id ASC")
+	}
+	if sql, err := SortingToSQL(nil); err != nil || sql != "" {
+		t.Errorf("invalid sql: %s (%v) - expected: empty string", sql, err)
+	}
+	for _, tag := range []string{"id;DELETE/**/FROM/**/users", "1id", "name)", "a-b", "\"\""} {
+		s := &Sorting{Criterias: []*SortCriteria{{Tag: "u.name"}, {Tag: tag}}}
+		if sql, err := SortingToSQL(s); err == nil {
+			t.Errorf("expected error for %q - got %s", tag, sql)
+		}
+	}
+
+	for _, raw := range []string{"name nulls", "name desc nulls middle", "name nulls default", "name desc asc nulls last"} {
+		if _, err := ParseSorting(raw); err == nil {
+			t.Errorf("expected error for %q - got nil", raw)
+		}
+	}
+}
+
+func TestParseSortingSigns(t *testing.T) {
+	s, err := ParseSorting("-created_at, +name, age DESC, -id desc nulls last, email Asc, +rank asc")
+	if err != nil {
+		t.Fatalf("failed to parse sort parameters: %s", err)
+	}
+	if expected := "created_at DESC, name ASC, age DESC, id DESC NULLS LAST, email ASC, rank ASC"; s.GoString() != expected {
+		t.Errorf("invalid sorting: %v - expected: %s", s.GoString(), expected)
+	}
+
+	for raw, msg := range map[string]string{
+		"-name asc":        "conflicting sort order - \"asc\" in \"-name asc\"",
+		"name, +age DESC":  "conflicting sort order - \"DESC\" in \" +age DESC\"",
+		"-":                "invalid sort criteria: -",
+		"--name":           "invalid sort criteria: --name",
+		"+-name":           "invalid sort criteria: +-name",
+		"- name":           "invalid sort criteria: - name",
+		"-name desc extra": "invalid sort criteria: -name desc extra",
+	} {
+		_, err := ParseSorting(raw)
+		if err == nil {
+			t.Errorf("expected error for %q - got nil", raw)
+			continue
+		}
+		if err.Error() != msg {
+			t.Errorf("invalid error message: %s - expected: %s", err, msg)
+		}
+	}
+}
+
+func TestSortingToSQLWithMapping(t *testing.T) {
+	fieldMap := map[string]string{"name": "u.name", "age": "u.age", "address.city": "a.city", "city": "a.city"}
+
+	s, err := ParseSorting("name desc nulls last, address.city, age asc")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	sql, err := SortingToSQLWithMapping(s, fieldMap)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if expected := "u.name DESC NULLS LAST, a.city ASC, u.age ASC"; sql != expected {
+		t.Errorf("invalid sql: %s - expected: %s", sql, expected)
+	}
+	if s.GetCriterias()[0].GetTag() != "name" {
+		t.Errorf("sorting is modified: %v", s)
+	}
+
+	// the first of duplicate fields wins
+	s, _ = ParseSorting("age desc, name, age asc, city desc, address.city asc")
+	sql, err = SortingToSQLWithMapping(s, fieldMap)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if expected := "u.age DESC, u.name ASC, a.city DESC"; sql != expected {
+		t.Errorf("invalid sql: %s - expected: %s", sql, expected)
+	}
+
+	for _, raw := range []string{"id", "name;drop", "name, u.name"} {
+		s, _ := ParseSorting(raw)
+		sql, err := SortingToSQLWithMapping(s, fieldMap)
+		if _, ok := err.(*UnknownFieldError); !ok || sql != "" {
+			t.Errorf("invalid result for %q: %q, %v - expected: UnknownFieldError", raw, sql, err)
+		}
+	}
+
+	for _, s := range []*Sorting{nil, {}} {
+		if sql, err := SortingToSQLWithMapping(s, fieldMap); err != nil || sql != "" {
+			t.Errorf("invalid result: %q, %v - expected: empty string", sql, err)
+		}
+	}
+}
+
+func TestMergeSorting(t *testing.T) {
+	defaults, _ := ParseSorting("id, created_at desc")
+	tests := []struct {
+		sorting  string
+		expected string
+	}{
+		{"name desc", "name DESC, id ASC, created_at DESC"},
+		{"created_at asc nulls first, name", "created_at ASC NULLS FIRST, name ASC, id ASC"},
+		{"id desc, name, id asc", "id DESC, name ASC, created_at DESC"},
+	}
+	for _, test := range tests {
+		s, _ := ParseSorting(test.sorting)
+		orig := s.GoString()
+		if res := MergeSorting(s, defaults).GoString(); res != test.expected {
+			t.Errorf("invalid sorting for %q: %s - expected: %s", test.sorting, res, test.expected)
+		}
+		if s.GoString() != orig || defaults.GoString() != "id ASC, created_at DESC" {
+			t.Errorf("sorting is modified: %v, %v", s, defaults)
+		}
+	}
+
+	for _, s := range []*Sorting{nil, {}} {
+		if res := MergeSorting(s, defaults); res.GoString() != defaults.GoString() || res == defaults {
+			t.Errorf("invalid sorting: %v - expected a copy of: %v", res, defaults)
+		}
+	}
+
+	s, _ := ParseSorting("name")
+	for _, d := range []*Sorting{nil, {}} {
+		if res := MergeSorting(s, d); res != s {
+			t.Errorf("invalid sorting: %v - expected: %v", res, s)
+		}
+	}
+}
+
+func TestValidateSortingFields(t *testing.T) {
+	tests := []struct {
+		sort    string
+		allowed []string
+		err     error
+	}{
+		{
+			sort:    "str, int desc, nested.str asc",
+			allowed: []string{"str", "int", "nested.str"},
+			err:     nil,
+		},
+		{
+			sort:    "str, int desc, nested.str asc",
+			allowed: []string{"str", "nested.str"},
+			err:     &UnknownFieldError{FieldPath: []string{"int"}},
+		},
+		{
+			sort:    "str asc, nested.int desc",
+			allowed: []string{"str", "nested.str"},
+			err:     &UnknownFieldError{FieldPath: []string{"nested", "int"}},
+		},
+		{
+			sort:    "nestedJSON.str desc",
+			allowed: []string{"nested.str"},
+			err:     &UnknownFieldError{FieldPath: []string{"nestedJSON", "str"}},
+		},
+	}
+
+	for _, test := range tests {
+		s, err := ParseSorting(test.sort)
+		if err != nil {
+			t.Fatalf("failed to parse sort parameters: %s", err)
+		}
+		if err := ValidateSortingFields(s, test.allowed); !reflect.DeepEqual(err, test.err) {
+			t.Errorf("invalid error for %q: %v - expected: %v", test.sort, err, test.err)
+		}
+	}
+
+	if err := ValidateSortingFields(nil, nil); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestValidateSortingFieldsProto(t *testing.T) {
+	s, err := ParseSorting("nestedJSON.str desc, nested.str")
+	if err != nil {
+		t.Fatalf("failed to parse sort parameters: %s", err)
+	}
+	if err := ValidateSortingFields(s, []string{"nested.str"}, &TestProtoMessage{}); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if err := ValidateSortingFields(s, []string{"nestedJSON.str"}, &TestProtoMessage{}); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	expected := &UnknownFieldError{FieldPath: []string{"nestedJSON", "str"}}
+	if err := ValidateSortingFields(s, []string{"str"}, &TestProtoMessage{}); !reflect.DeepEqual(err, expected) {
+		t.Errorf("invalid error: %v - expected: %v", err, expected)
+	}
+}